@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func createSendEmailTool() aigentic.AgentTool {
+	type SendEmailInput struct {
+		To      string `json:"to" description:"Email recipient address"`
+		Subject string `json:"subject" description:"Email subject line"`
+		Body    string `json:"body" description:"Email body content"`
+	}
+
+	emailTool := aigentic.NewTool(
+		"send_email",
+		"Sends an email to a recipient with subject and body. Requires approval before sending.",
+		func(run *aigentic.AgentRun, input SendEmailInput) (string, error) {
+			time.Sleep(500 * time.Millisecond)
+			return fmt.Sprintf("Email successfully sent to %s with subject '%s'", input.To, input.Subject), nil
+		},
+	)
+	emailTool.RequireApproval = true
+	return emailTool
+}
+
+// AllowRule auto-approves calls to ToolName whose arguments equal every value in Match.
+// Arguments not in Match can be anything.
+type AllowRule struct {
+	ToolName string
+	Match    map[string]interface{}
+}
+
+func (r AllowRule) matches(toolName string, args map[string]interface{}) bool {
+	if r.ToolName != toolName {
+		return false
+	}
+	for key, want := range r.Match {
+		got, ok := args[key]
+		if !ok || fmt.Sprint(got) != fmt.Sprint(want) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r AllowRule) String() string {
+	var conditions []string
+	for key, value := range r.Match {
+		conditions = append(conditions, fmt.Sprintf("%s=%v", key, value))
+	}
+	sort.Strings(conditions)
+	return fmt.Sprintf("%s(%s)", r.ToolName, strings.Join(conditions, ", "))
+}
+
+// SessionAllowlist remembers the operator's "always allow" decisions for one session.
+// It is only kept in memory, so the decisions end with the session.
+type SessionAllowlist struct {
+	mu    sync.Mutex
+	rules []AllowRule
+}
+
+func (a *SessionAllowlist) Remember(rule AllowRule) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.rules = append(a.rules, rule)
+}
+
+// Allowed returns the rule that pre-approves the call, if any
+func (a *SessionAllowlist) Allowed(toolName string, args map[string]interface{}) (AllowRule, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, rule := range a.rules {
+		if rule.matches(toolName, args) {
+			return rule, true
+		}
+	}
+	return AllowRule{}, false
+}
+
+// promptDecision asks for y/n, or to approve and remember the decision for the session
+func promptDecision(e *aigentic.ApprovalEvent, args map[string]interface{}, reader *bufio.Reader) (bool, *AllowRule) {
+	keys := make([]string, 0, len(args))
+	for key := range args {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Println("\n" + strings.Repeat("=", 70))
+	fmt.Printf("APPROVAL REQUIRED: %s (%s)\n", e.ToolName, e.ApprovalID)
+	for _, key := range keys {
+		fmt.Printf("  %s: %v\n", key, args[key])
+	}
+	fmt.Println(strings.Repeat("=", 70))
+
+	for {
+		fmt.Println("y = approve once, n = reject")
+		fmt.Println("s = approve and always allow these exact arguments this session")
+		fmt.Println("k = approve and always allow calls matching chosen arguments this session")
+		fmt.Print("Decision: ")
+		response, _ := reader.ReadString('\n')
+
+		switch strings.TrimSpace(strings.ToLower(response)) {
+		case "y", "yes":
+			return true, nil
+		case "n", "no", "":
+			return false, nil
+		case "s":
+			return true, &AllowRule{ToolName: e.ToolName, Match: args}
+		case "k":
+			fmt.Printf("Arguments to match (comma separated, from %s): ", strings.Join(keys, ", "))
+			input, _ := reader.ReadString('\n')
+
+			match := make(map[string]interface{})
+			for _, key := range strings.Split(input, ",") {
+				key = strings.TrimSpace(key)
+				if value, ok := args[key]; ok {
+					match[key] = value
+				}
+			}
+			if len(match) == 0 {
+				fmt.Println("No known arguments given; an empty match would allow every call.")
+				continue
+			}
+			return true, &AllowRule{ToolName: e.ToolName, Match: match}
+		}
+	}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	fmt.Println("Session Pre-Approval Allowlist Example")
+	fmt.Println("======================================")
+	fmt.Println("Answer 'k' with 'to' to stop being asked about emails to the same recipient.")
+	fmt.Println()
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:        model,
+		Name:         "EmailAgent",
+		Description:  "An agent that can send emails with approval",
+		Instructions: "You can send emails using the send_email tool. Always use the tool when asked to send an email. Send one email per tool call.",
+		AgentTools:   []aigentic.AgentTool{createSendEmailTool()},
+		Session:      aigentic.NewSession(context.Background()),
+	}
+
+	// The allowlist lives as long as the session: both requests share it
+	allowlist := &SessionAllowlist{}
+	reader := bufio.NewReader(os.Stdin)
+
+	requests := []string{
+		"Email john@example.com with subject 'Standup' and body 'Standup moves to 10am today.'",
+		"Email john@example.com with subject 'Lunch' and body 'Lunch is on me.', then email jane@example.com with subject 'Lunch' and body 'Join us for lunch?'",
+	}
+
+	for i, request := range requests {
+		fmt.Printf("\n▶️  Request %d: %s\n", i+1, request)
+
+		run, err := agent.Start(request)
+		if err != nil {
+			log.Fatalf("Failed to start agent: %v", err)
+		}
+
+		var fullResponse string
+		for event := range run.Next() {
+			switch e := event.(type) {
+			case *aigentic.ContentEvent:
+				fullResponse += e.Content
+			case *aigentic.ApprovalEvent:
+				args, _ := e.ValidationResult.Values.(map[string]interface{})
+
+				if rule, ok := allowlist.Allowed(e.ToolName, args); ok {
+					fmt.Printf("\n✓ %s auto-approved by session rule %s\n", e.ToolName, rule)
+					run.Approve(e.ApprovalID, true)
+					continue
+				}
+
+				approved, rule := promptDecision(e, args, reader)
+				if rule != nil {
+					allowlist.Remember(*rule)
+					fmt.Printf("✓ Action APPROVED; remembering %s for this session\n", rule)
+				} else if approved {
+					fmt.Println("✓ Action APPROVED")
+				} else {
+					fmt.Println("✗ Action REJECTED")
+				}
+				run.Approve(e.ApprovalID, approved)
+			case *aigentic.ToolEvent:
+				fmt.Printf("[Tool executed: %s]\n", e.ToolName)
+			case *aigentic.ErrorEvent:
+				log.Printf("Error: %v", e.Err)
+			}
+		}
+
+		fmt.Printf("\nResponse: %s\n", fullResponse)
+	}
+
+	fmt.Println("\n✅ Example completed successfully!")
+}
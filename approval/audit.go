@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+)
+
+// AuditRecord is one line of the audit log. Every approval event produces exactly one
+// record, written after the decision has been made.
+type AuditRecord struct {
+	Timestamp         time.Time   `json:"timestamp"`
+	RunID             string      `json:"run_id"`
+	ApprovalID        string      `json:"approval_id"`
+	ToolName          string      `json:"tool_name"`
+	Arguments         interface{} `json:"arguments"`
+	ValidationMessage string      `json:"validation_message,omitempty"`
+	ValidationErrors  []string    `json:"validation_errors,omitempty"`
+	Decision          string      `json:"decision"`
+	Operator          string      `json:"operator"`
+}
+
+// AuditLog appends approval decisions to a JSONL file. The file is opened in append-only
+// mode and synced after every record so a crash cannot lose a decision that was acted on.
+type AuditLog struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func OpenAuditLog(path string) (*AuditLog, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+	return &AuditLog{file: file}, nil
+}
+
+// Record writes the decision an operator made on an approval event
+func (a *AuditLog) Record(e *aigentic.ApprovalEvent, approved bool, operator string) error {
+	record := AuditRecord{
+		Timestamp:         time.Now().UTC(),
+		RunID:             e.RunID,
+		ApprovalID:        e.ApprovalID,
+		ToolName:          e.ToolName,
+		Arguments:         e.ValidationResult.Values,
+		ValidationMessage: e.ValidationResult.Message,
+		Decision:          "rejected",
+		Operator:          operator,
+	}
+	if approved {
+		record.Decision = "approved"
+	}
+	for _, validationErr := range e.ValidationResult.ValidationErrors {
+		record.ValidationErrors = append(record.ValidationErrors, validationErr.Error())
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encoding audit record: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("writing audit record: %w", err)
+	}
+	return a.file.Sync()
+}
+
+func (a *AuditLog) Close() error {
+	return a.file.Close()
+}
+
+// currentOperator identifies who is answering the approval prompts, falling back to the
+// OS user when no operator is given
+func currentOperator(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return "unknown"
+}
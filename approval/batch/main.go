@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func createTransferTool() aigentic.AgentTool {
+	type TransferInput struct {
+		Account string  `json:"account" description:"Destination account number"`
+		Amount  float64 `json:"amount" description:"Amount to transfer in USD"`
+	}
+
+	transferTool := aigentic.NewTool(
+		"transfer_money",
+		"Transfers money to an account. Requires approval before the transfer is made.",
+		func(run *aigentic.AgentRun, input TransferInput) (string, error) {
+			return fmt.Sprintf("Transferred $%.2f to account %s", input.Amount, input.Account), nil
+		},
+	)
+	transferTool.RequireApproval = true
+	return transferTool
+}
+
+// QueuedApproval is an approval waiting in the shared queue. It keeps the run it came from
+// so the decision can be sent back to the right AgentRun.
+type QueuedApproval struct {
+	AgentName  string
+	RunID      string
+	ApprovalID string
+	ToolName   string
+	Parameters map[string]interface{}
+
+	run *aigentic.AgentRun
+}
+
+// watch forwards the run's approvals to the queue and prints its final response
+func watch(agentName string, run *aigentic.AgentRun, queue chan<- QueuedApproval) {
+	var response string
+	for event := range run.Next() {
+		switch e := event.(type) {
+		case *aigentic.ContentEvent:
+			response += e.Content
+		case *aigentic.ApprovalEvent:
+			params, _ := e.ValidationResult.Values.(map[string]interface{})
+			queue <- QueuedApproval{
+				AgentName:  agentName,
+				RunID:      e.RunID,
+				ApprovalID: e.ApprovalID,
+				ToolName:   e.ToolName,
+				Parameters: params,
+				run:        run,
+			}
+		case *aigentic.ErrorEvent:
+			log.Printf("[%s] Error: %v", agentName, e.Err)
+		}
+	}
+	fmt.Printf("[%s] %s\n", agentName, response)
+}
+
+// collectBatch waits for the first approval, then keeps collecting until the queue has been
+// quiet for the window. It returns nil once every run has finished.
+func collectBatch(queue <-chan QueuedApproval, done <-chan struct{}, window time.Duration) []QueuedApproval {
+	var batch []QueuedApproval
+	select {
+	case approval := <-queue:
+		batch = append(batch, approval)
+	case <-done:
+		return nil
+	}
+
+	for {
+		select {
+		case approval := <-queue:
+			batch = append(batch, approval)
+		case <-time.After(window):
+			return batch
+		}
+	}
+}
+
+// parseSelection turns "1,3" into the set of selected batch indexes
+func parseSelection(input string, size int) (map[int]bool, error) {
+	selected := make(map[int]bool)
+	for _, field := range strings.Split(input, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n < 1 || n > size {
+			return nil, fmt.Errorf("invalid selection %q", field)
+		}
+		selected[n-1] = true
+	}
+	return selected, nil
+}
+
+// reviewBatch shows the batch and asks for a single decision covering all of it
+func reviewBatch(batch []QueuedApproval, reader *bufio.Reader) map[int]bool {
+	fmt.Println("\n" + strings.Repeat("=", 70))
+	fmt.Printf("%d APPROVALS PENDING\n", len(batch))
+	fmt.Println(strings.Repeat("=", 70))
+	for i, approval := range batch {
+		var params []string
+		for key, value := range approval.Parameters {
+			params = append(params, fmt.Sprintf("%s=%v", key, value))
+		}
+		sort.Strings(params)
+		fmt.Printf("%2d. [%s run %s] %s %s\n", i+1, approval.AgentName, approval.RunID, approval.ToolName, strings.Join(params, " "))
+	}
+
+	for {
+		fmt.Print("\nApprove (a)ll, (r)eject all, or list numbers to approve (e.g. 1,3): ")
+		input, _ := reader.ReadString('\n')
+		input = strings.TrimSpace(strings.ToLower(input))
+
+		approved := make(map[int]bool)
+		switch input {
+		case "a", "all":
+			for i := range batch {
+				approved[i] = true
+			}
+			return approved
+		case "r", "none", "":
+			return approved
+		}
+
+		selected, err := parseSelection(input, len(batch))
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		return selected
+	}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	window := flag.Duration("window", 3*time.Second, "How long the queue must be quiet before a batch is shown")
+	flag.Parse()
+
+	fmt.Println("Batch Approval Example")
+	fmt.Println("======================")
+	fmt.Println()
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	requests := map[string]string{
+		"PayrollAgent":  "Transfer $2500 to account 111-222 and $2600 to account 333-444 for this month's salaries.",
+		"VendorAgent":   "Transfer $780.50 to account 555-666 to pay the office supplies invoice.",
+		"RefundAgent":   "Transfer $45 to account 777-888 to refund order #1234.",
+		"TreasuryAgent": "Transfer $50000 to account 999-000 to move funds to the savings account.",
+	}
+
+	queue := make(chan QueuedApproval)
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for name, request := range requests {
+		agent := aigentic.Agent{
+			Model:        model,
+			Name:         name,
+			Description:  "An agent that makes bank transfers with approval",
+			Instructions: "Use the transfer_money tool for every transfer requested. Make each transfer with a separate tool call.",
+			AgentTools:   []aigentic.AgentTool{createTransferTool()},
+		}
+
+		run, err := agent.Start(request)
+		if err != nil {
+			log.Fatalf("Failed to start %s: %v", name, err)
+		}
+
+		wg.Add(1)
+		go func(name string, run *aigentic.AgentRun) {
+			defer wg.Done()
+			watch(name, run, queue)
+		}(name, run)
+	}
+
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// Single operator loop reviewing whatever has queued up from all agents
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		batch := collectBatch(queue, done, *window)
+		if batch == nil {
+			break
+		}
+
+		approved := reviewBatch(batch, reader)
+		for i, approval := range batch {
+			approval.run.Approve(approval.ApprovalID, approved[i])
+
+			decision := "✗ REJECTED"
+			if approved[i] {
+				decision = "✓ APPROVED"
+			}
+			fmt.Printf("%s %s for %s (%s)\n", decision, approval.ToolName, approval.AgentName, approval.ApprovalID)
+		}
+	}
+
+	fmt.Println("\n✅ Example completed successfully!")
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic/ai"
+)
+
+// PlannedCall is an approval-gated tool call the agent made during a dry run
+type PlannedCall struct {
+	AgentName string
+	ToolName  string
+	Arguments map[string]interface{}
+}
+
+// DryRunPlan collects the calls that would have needed approval
+type DryRunPlan struct {
+	mu    sync.Mutex
+	calls []PlannedCall
+}
+
+// Stub returns a copy of agent and its sub-agents with every approval-gated tool replaced
+// by a stub that records the call instead of running it. Tools without approval still run.
+func (p *DryRunPlan) Stub(agent aigentic.Agent) aigentic.Agent {
+	tools := make([]aigentic.AgentTool, len(agent.AgentTools))
+	for i, tool := range agent.AgentTools {
+		if tool.RequireApproval {
+			tool = p.stubTool(agent.Name, tool)
+		}
+		tools[i] = tool
+	}
+	agent.AgentTools = tools
+
+	agents := make([]aigentic.Agent, len(agent.Agents))
+	for i, sub := range agent.Agents {
+		agents[i] = p.Stub(sub)
+	}
+	agent.Agents = agents
+	return agent
+}
+
+func (p *DryRunPlan) stubTool(agentName string, tool aigentic.AgentTool) aigentic.AgentTool {
+	tool.RequireApproval = false
+	tool.Execute = func(run *aigentic.AgentRun, args map[string]interface{}) (*ai.ToolResult, error) {
+		p.mu.Lock()
+		p.calls = append(p.calls, PlannedCall{AgentName: agentName, ToolName: tool.Name, Arguments: args})
+		p.mu.Unlock()
+
+		// Let the model carry on with its plan as if the call had succeeded
+		return &ai.ToolResult{
+			Content: []ai.ToolContent{{
+				Type:    "text",
+				Content: fmt.Sprintf("DRY RUN: %s was not executed. It requires approval; assume it succeeded and continue.", tool.Name),
+			}},
+		}, nil
+	}
+	return tool
+}
+
+// Report lists the calls that would have required approval, in the order they were made
+func (p *DryRunPlan) Report() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var b strings.Builder
+	b.WriteString("\n📋 Dry Run: Approvals Required\n")
+	b.WriteString("==============================\n")
+	if len(p.calls) == 0 {
+		b.WriteString("No approval-gated tools would have been called.\n")
+		return b.String()
+	}
+
+	for i, call := range p.calls {
+		b.WriteString(fmt.Sprintf("%d. %s (agent %s)\n", i+1, call.ToolName, call.AgentName))
+
+		keys := make([]string, 0, len(call.Arguments))
+		for key := range call.Arguments {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			b.WriteString(fmt.Sprintf("   %s: %v\n", key, call.Arguments[key]))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func createDeleteRecordsTool() aigentic.AgentTool {
+	type DeleteRecordsInput struct {
+		Table  string `json:"table" description:"Database table to delete from"`
+		Filter string `json:"filter" description:"Condition selecting the records to delete"`
+	}
+
+	deleteTool := aigentic.NewTool(
+		"delete_records",
+		"Deletes records matching a filter from a database table. Requires approval before deleting.",
+		func(run *aigentic.AgentRun, input DeleteRecordsInput) (string, error) {
+			return fmt.Sprintf("Deleted records from %s where %s", input.Table, input.Filter), nil
+		},
+	)
+	deleteTool.RequireApproval = true
+	return deleteTool
+}
+
+// Decision is a reviewer's answer. NoDecision means the reviewer did not answer in time.
+type Decision int
+
+const (
+	NoDecision Decision = iota
+	Approved
+	Rejected
+)
+
+func (d Decision) String() string {
+	switch d {
+	case Approved:
+		return "approved"
+	case Rejected:
+		return "rejected"
+	}
+	return "no decision"
+}
+
+// Reviewer is one tier of the escalation chain. Each tier can use a different channel.
+type Reviewer interface {
+	Name() string
+	Review(ctx context.Context, e *aigentic.ApprovalEvent) Decision
+}
+
+// EscalationChain routes the same ApprovalID through the reviewers in order. An approval
+// ends the chain; a rejection or timeout escalates to the next tier. The last tier's
+// rejection is final, and if nobody answers the call is rejected.
+type EscalationChain struct {
+	Reviewers []Reviewer
+	Timeout   time.Duration // per tier
+}
+
+func (c EscalationChain) Decide(e *aigentic.ApprovalEvent) (bool, string) {
+	for i, reviewer := range c.Reviewers {
+		ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+		decision := reviewer.Review(ctx, e)
+		cancel()
+
+		log.Printf("approval=%s tier=%d reviewer=%s decision=%s", e.ApprovalID, i+1, reviewer.Name(), decision)
+		if decision == Approved {
+			return true, reviewer.Name()
+		}
+		if i == len(c.Reviewers)-1 {
+			break
+		}
+		fmt.Printf("⬆️  Escalating %s to %s (%s)\n", e.ApprovalID, c.Reviewers[i+1].Name(), decision)
+	}
+	return false, "escalation chain"
+}
+
+// ConsoleReviewer is the first tier: an operator at the terminal
+type ConsoleReviewer struct {
+	lines chan string
+}
+
+func NewConsoleReviewer() *ConsoleReviewer {
+	r := &ConsoleReviewer{lines: make(chan string)}
+
+	// A single reader goroutine so an unanswered prompt does not leave a reader behind
+	go func() {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			r.lines <- scanner.Text()
+		}
+		close(r.lines)
+	}()
+	return r
+}
+
+func (r *ConsoleReviewer) Name() string { return "operator" }
+
+// discardStale drops lines typed after an earlier prompt timed out, so a late answer
+// meant for that prompt is not taken as the answer to the next one
+func (r *ConsoleReviewer) discardStale() {
+	for {
+		select {
+		case line, ok := <-r.lines:
+			if !ok {
+				return
+			}
+			fmt.Printf("(ignoring %q, typed after the previous prompt timed out)\n", line)
+		default:
+			return
+		}
+	}
+}
+
+func (r *ConsoleReviewer) Review(ctx context.Context, e *aigentic.ApprovalEvent) Decision {
+	r.discardStale()
+	fmt.Println("\n" + strings.Repeat("=", 70))
+	fmt.Printf("APPROVAL REQUIRED: %s (%s)\n", e.ToolName, e.ApprovalID)
+	if args, ok := e.ValidationResult.Values.(map[string]interface{}); ok {
+		for key, value := range args {
+			fmt.Printf("  %s: %v\n", key, value)
+		}
+	}
+	deadline, _ := ctx.Deadline()
+	fmt.Printf("Approve this action? (y/n) — escalates if rejected or unanswered in %v: ", time.Until(deadline).Round(time.Second))
+
+	select {
+	case line, ok := <-r.lines:
+		if !ok {
+			return NoDecision
+		}
+		response := strings.TrimSpace(strings.ToLower(line))
+		if response == "y" || response == "yes" {
+			return Approved
+		}
+		return Rejected
+	case <-ctx.Done():
+		fmt.Println()
+		return NoDecision
+	}
+}
+
+// HTTPReviewer is the second tier: an on-call manager who decides through an HTTP
+// endpoint, standing in for a pager or ticketing integration. Each escalation gets a
+// random token that the decision must carry, so only whoever was paged can decide it.
+type HTTPReviewer struct {
+	baseURL string
+
+	mu      sync.Mutex
+	waiting map[string]*escalation // by approval ID
+}
+
+type escalation struct {
+	token     string
+	decisions chan Decision
+}
+
+func NewHTTPReviewer(baseURL string) *HTTPReviewer {
+	return &HTTPReviewer{baseURL: baseURL, waiting: make(map[string]*escalation)}
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (r *HTTPReviewer) Name() string { return "on-call manager" }
+
+func (r *HTTPReviewer) Review(ctx context.Context, e *aigentic.ApprovalEvent) Decision {
+	token, err := newToken()
+	if err != nil {
+		log.Printf("Failed to page %s: generating token: %v", r.Name(), err)
+		return NoDecision
+	}
+	pending := &escalation{token: token, decisions: make(chan Decision, 1)}
+	r.mu.Lock()
+	r.waiting[e.ApprovalID] = pending
+	r.mu.Unlock()
+
+	defer func() {
+		r.mu.Lock()
+		delete(r.waiting, e.ApprovalID)
+		r.mu.Unlock()
+	}()
+
+	fmt.Printf("📟 Paging %s. Decide with:\n", r.Name())
+	fmt.Printf("   curl -d approval_id=%s -d token=%s -d decision=approve %s/escalations/decide\n", e.ApprovalID, token, r.baseURL)
+	fmt.Printf("   curl -d approval_id=%s -d token=%s -d decision=reject %s/escalations/decide\n", e.ApprovalID, token, r.baseURL)
+
+	select {
+	case decision := <-pending.decisions:
+		return decision
+	case <-ctx.Done():
+		return NoDecision
+	}
+}
+
+func (r *HTTPReviewer) HandleDecide(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	approvalID := req.FormValue("approval_id")
+	r.mu.Lock()
+	pending, ok := r.waiting[approvalID]
+	r.mu.Unlock()
+	if !ok || subtle.ConstantTimeCompare([]byte(pending.token), []byte(req.FormValue("token"))) != 1 {
+		log.Printf("Rejected decision for %q: unknown approval or invalid token", approvalID)
+		http.Error(w, "approval is not waiting for the on-call manager or the token is invalid", http.StatusForbidden)
+		return
+	}
+
+	decision := Rejected
+	if req.FormValue("decision") == "approve" {
+		decision = Approved
+	}
+	select {
+	case pending.decisions <- decision:
+		fmt.Fprintf(w, "%s\n", decision)
+	default:
+		http.Error(w, "already decided", http.StatusConflict)
+	}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	addr := flag.String("addr", "127.0.0.1:8091", "Address the on-call manager endpoint listens on")
+	timeout := flag.Duration("timeout", 20*time.Second, "Time each tier has to decide before escalating")
+	flag.Parse()
+
+	fmt.Println("Approval Escalation Chain Example")
+	fmt.Println("=================================")
+	fmt.Println("Reject or ignore the first prompt to escalate to the on-call manager.")
+	fmt.Println()
+
+	host, port, err := net.SplitHostPort(*addr)
+	if err != nil {
+		log.Fatalf("Invalid -addr %q: %v", *addr, err)
+	}
+	if host == "" || host == "0.0.0.0" {
+		host = "localhost"
+	}
+	manager := NewHTTPReviewer("http://" + net.JoinHostPort(host, port))
+	mux := http.NewServeMux()
+	mux.HandleFunc("/escalations/decide", manager.HandleDecide)
+	server := &http.Server{Addr: *addr, Handler: mux}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("On-call endpoint failed: %v", err)
+		}
+	}()
+
+	chain := EscalationChain{
+		Reviewers: []Reviewer{NewConsoleReviewer(), manager},
+		Timeout:   *timeout,
+	}
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:        model,
+		Name:         "DataAgent",
+		Description:  "An agent that maintains the customer database with approval",
+		Instructions: "Use the delete_records tool when asked to delete data.",
+		AgentTools:   []aigentic.AgentTool{createDeleteRecordsTool()},
+	}
+
+	run, err := agent.Start("Delete all records from the customers table where last_login is before 2020-01-01.")
+	if err != nil {
+		log.Fatalf("Failed to start agent: %v", err)
+	}
+
+	var fullResponse string
+	for event := range run.Next() {
+		switch e := event.(type) {
+		case *aigentic.ContentEvent:
+			fullResponse += e.Content
+		case *aigentic.ApprovalEvent:
+			approved, by := chain.Decide(e)
+			if approved {
+				fmt.Printf("✓ Action APPROVED by %s\n", by)
+			} else {
+				fmt.Println("✗ Action REJECTED")
+			}
+			run.Approve(e.ApprovalID, approved)
+		case *aigentic.ToolEvent:
+			fmt.Printf("[Tool executed: %s]\n", e.ToolName)
+		case *aigentic.ErrorEvent:
+			log.Printf("Error: %v", e.Err)
+		}
+	}
+
+	server.Close()
+
+	fmt.Printf("\nFinal Response: %s\n", fullResponse)
+	fmt.Println("\n✅ Example completed successfully!")
+}
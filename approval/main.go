@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -81,10 +82,29 @@ func simulateApprovalUI(e *aigentic.ApprovalEvent) bool {
 func main() {
 	utils.LoadEnvFile("../.env")
 
+	auditPath := flag.String("audit", "approval_audit.jsonl", "File every approval decision is appended to")
+	operatorFlag := flag.String("operator", "", "Operator identity recorded in the audit log (defaults to the OS user)")
+	metricsAddr := flag.String("metrics", "", "Expose Prometheus approval metrics on this address, e.g. :9091")
+	dryRun := flag.Bool("dry-run", false, "Stub out approval-gated tools and report which calls would need approval")
+	flag.Parse()
+
 	fmt.Println("Human-in-the-Loop Approval Example")
 	fmt.Println("==================================")
 	fmt.Println()
 
+	auditLog, err := OpenAuditLog(*auditPath)
+	if err != nil {
+		log.Fatalf("Failed to open audit log: %v", err)
+	}
+	defer auditLog.Close()
+	operator := currentOperator(*operatorFlag)
+
+	metrics := NewApprovalMetrics()
+	if *metricsAddr != "" {
+		metrics.ServeMetrics(*metricsAddr)
+		fmt.Printf("📡 Serving approval metrics on %s/metrics\n\n", *metricsAddr)
+	}
+
 	model := openai.NewModel("gpt-4o-mini", getAPIKey())
 
 	agent := aigentic.Agent{
@@ -98,6 +118,14 @@ func main() {
 		Stream: true,
 	}
 
+	var plan *DryRunPlan
+	if *dryRun {
+		plan = &DryRunPlan{}
+		agent = plan.Stub(agent)
+		fmt.Println("🧪 Dry run: approval-gated tools are stubbed out and will not run")
+		fmt.Println()
+	}
+
 	run, err := agent.Start("Send an email to john@example.com with subject 'Project Update' and body 'The project is on track and will be completed by end of week.'")
 	if err != nil {
 		log.Fatalf("Failed to start agent: %v", err)
@@ -110,7 +138,14 @@ func main() {
 			fmt.Print(e.Content)
 			fullResponse += e.Content
 		case *aigentic.ApprovalEvent:
+			metrics.Requested(e)
 			approved := simulateApprovalUI(e)
+			// Record the decision before acting on it so an executed tool is never missing from the log
+			if err := auditLog.Record(e, approved, operator); err != nil {
+				log.Printf("Failed to write audit record, rejecting: %v", err)
+				approved = false
+			}
+			metrics.Decided(e, approved)
 			run.Approve(e.ApprovalID, approved)
 		case *aigentic.ToolEvent:
 			fmt.Printf("\n[Tool executed: %s]\n", e.ToolName)
@@ -120,5 +155,10 @@ func main() {
 	}
 
 	fmt.Printf("\n\nFinal Response: %s\n", fullResponse)
+	if plan != nil {
+		fmt.Print(plan.Report())
+	} else {
+		metrics.PrintSummary()
+	}
 	fmt.Println("\n✅ Example completed successfully!")
 }
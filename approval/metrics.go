@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+)
+
+// ApprovalMetrics counts approval decisions and how long operators take to make them.
+// It prints a summary at the end of the run and serves the counters in the Prometheus
+// text format for monitoring.
+type ApprovalMetrics struct {
+	mu        sync.Mutex
+	requested map[string]time.Time // pending approvals by ID
+	decisions map[[2]string]int    // by tool and decision
+	latency   map[string]*latency  // by tool
+}
+
+type latency struct {
+	count int
+	sum   time.Duration
+}
+
+func NewApprovalMetrics() *ApprovalMetrics {
+	return &ApprovalMetrics{
+		requested: make(map[string]time.Time),
+		decisions: make(map[[2]string]int),
+		latency:   make(map[string]*latency),
+	}
+}
+
+// Requested marks when the approval was presented to the operator
+func (m *ApprovalMetrics) Requested(e *aigentic.ApprovalEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requested[e.ApprovalID] = time.Now()
+}
+
+// Decided records the decision and the time since the approval was requested
+func (m *ApprovalMetrics) Decided(e *aigentic.ApprovalEvent, approved bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	decision := "rejected"
+	if approved {
+		decision = "approved"
+	}
+	m.decisions[[2]string{e.ToolName, decision}]++
+
+	start, ok := m.requested[e.ApprovalID]
+	if !ok {
+		return
+	}
+	delete(m.requested, e.ApprovalID)
+
+	l := m.latency[e.ToolName]
+	if l == nil {
+		l = &latency{}
+		m.latency[e.ToolName] = l
+	}
+	l.count++
+	l.sum += time.Since(start)
+}
+
+func (m *ApprovalMetrics) tools() []string {
+	seen := make(map[string]bool)
+	for key := range m.decisions {
+		seen[key[0]] = true
+	}
+	var tools []string
+	for tool := range seen {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+	return tools
+}
+
+// PrintSummary prints the totals, the average decision latency and the per-tool approval rates
+func (m *ApprovalMetrics) PrintSummary() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var approved, rejected, count int
+	var total time.Duration
+	for key, n := range m.decisions {
+		if key[1] == "approved" {
+			approved += n
+		} else {
+			rejected += n
+		}
+	}
+	for _, l := range m.latency {
+		count += l.count
+		total += l.sum
+	}
+
+	fmt.Println("\n📊 Approval Summary")
+	fmt.Println("===================")
+	fmt.Printf("Approvals: %d  Rejections: %d\n", approved, rejected)
+	if count > 0 {
+		fmt.Printf("Average decision latency: %v\n", (total / time.Duration(count)).Round(100*time.Millisecond))
+	}
+
+	for _, tool := range m.tools() {
+		toolApproved := m.decisions[[2]string{tool, "approved"}]
+		toolTotal := toolApproved + m.decisions[[2]string{tool, "rejected"}]
+		fmt.Printf("  %-20s %d/%d approved (%.0f%%)\n", tool, toolApproved, toolTotal, float64(toolApproved)/float64(toolTotal)*100)
+	}
+}
+
+// ServeHTTP writes the counters in the Prometheus text exposition format
+func (m *ApprovalMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	fmt.Fprintln(w, "# HELP approval_decisions_total Approval decisions by tool and outcome.")
+	fmt.Fprintln(w, "# TYPE approval_decisions_total counter")
+	for _, tool := range m.tools() {
+		for _, decision := range []string{"approved", "rejected"} {
+			fmt.Fprintf(w, "approval_decisions_total{tool=%q,decision=%q} %d\n", tool, decision, m.decisions[[2]string{tool, decision}])
+		}
+	}
+
+	fmt.Fprintln(w, "# HELP approval_pending Approvals waiting for a decision.")
+	fmt.Fprintln(w, "# TYPE approval_pending gauge")
+	fmt.Fprintf(w, "approval_pending %d\n", len(m.requested))
+
+	fmt.Fprintln(w, "# HELP approval_decision_seconds Time from approval request to decision.")
+	fmt.Fprintln(w, "# TYPE approval_decision_seconds summary")
+	var tools []string
+	for tool := range m.latency {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+	for _, tool := range tools {
+		l := m.latency[tool]
+		fmt.Fprintf(w, "approval_decision_seconds_sum{tool=%q} %g\n", tool, l.sum.Seconds())
+		fmt.Fprintf(w, "approval_decision_seconds_count{tool=%q} %d\n", tool, l.count)
+	}
+}
+
+// ServeMetrics exposes /metrics on addr in the background
+func (m *ApprovalMetrics) ServeMetrics(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m)
+
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			fmt.Printf("Error serving metrics: %v\n", err)
+		}
+	}()
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// Amendments holds the arguments a reviewer changed before approving. run.Approve only
+// carries a yes/no decision, so the amended values are looked up by the tool itself,
+// keyed by the model's original arguments.
+type Amendments struct {
+	mu     sync.Mutex
+	byCall map[string]map[string]interface{}
+}
+
+func NewAmendments() *Amendments {
+	return &Amendments{byCall: make(map[string]map[string]interface{})}
+}
+
+// callKey identifies a tool call by its arguments; json.Marshal sorts map keys
+func callKey(args map[string]interface{}) string {
+	data, _ := json.Marshal(args)
+	return string(data)
+}
+
+// Amend records the values to use instead of original when the call executes
+func (a *Amendments) Amend(original, amended map[string]interface{}) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.byCall[callKey(original)] = amended
+}
+
+// Apply returns the amended arguments for the call, or args unchanged if the reviewer
+// did not edit them. An amendment is used once.
+func (a *Amendments) Apply(args map[string]interface{}) (map[string]interface{}, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := callKey(args)
+	amended, ok := a.byCall[key]
+	if !ok {
+		return args, false
+	}
+	delete(a.byCall, key)
+	return amended, true
+}
+
+func createTransferTool(amendments *Amendments) aigentic.AgentTool {
+	return aigentic.AgentTool{
+		Name:        "transfer_money",
+		Description: "Transfers money to an account. Requires approval; the reviewer may change the amount or account before approving.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"account": map[string]interface{}{
+					"type":        "string",
+					"description": "Destination account number",
+				},
+				"amount": map[string]interface{}{
+					"type":        "number",
+					"description": "Amount to transfer in USD",
+				},
+			},
+			"required": []string{"account", "amount"},
+		},
+		RequireApproval: true,
+		Execute: func(run *aigentic.AgentRun, args map[string]interface{}) (*ai.ToolResult, error) {
+			final, amended := amendments.Apply(args)
+
+			content := fmt.Sprintf("Transferred $%.2f to account %v", toFloat(final["amount"]), final["account"])
+			if amended {
+				// Tell the model so its answer reflects what was actually executed
+				content += fmt.Sprintf(". The reviewer amended the request before approving; originally requested: $%.2f to account %v",
+					toFloat(args["amount"]), args["account"])
+			}
+			return &ai.ToolResult{
+				Content: []ai.ToolContent{{Type: "text", Content: content}},
+			}, nil
+		},
+	}
+}
+
+func toFloat(v interface{}) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case int:
+		return float64(n)
+	case string:
+		f, _ := strconv.ParseFloat(n, 64)
+		return f
+	}
+	return 0
+}
+
+// reviewApproval shows the arguments, lets the reviewer edit them as key=value lines and
+// asks for the decision. It returns the amended arguments, or nil if nothing was changed.
+func reviewApproval(e *aigentic.ApprovalEvent, reader *bufio.Reader) (bool, map[string]interface{}) {
+	args, _ := e.ValidationResult.Values.(map[string]interface{})
+
+	fmt.Println("\n" + strings.Repeat("=", 70))
+	fmt.Printf("APPROVAL REQUIRED: %s (%s)\n", e.ToolName, e.ApprovalID)
+	fmt.Println(strings.Repeat("=", 70))
+
+	keys := make([]string, 0, len(args))
+	for key := range args {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Printf("  %s: %v\n", key, args[key])
+	}
+
+	amended := make(map[string]interface{}, len(args))
+	for key, value := range args {
+		amended[key] = value
+	}
+	changed := false
+
+	fmt.Println("\nEdit arguments as key=value (e.g. amount=500), empty line when done:")
+	for {
+		fmt.Print("> ")
+		line, _ := reader.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if _, known := args[key]; !ok || !known {
+			fmt.Printf("  expected key=value with one of: %s\n", strings.Join(keys, ", "))
+			continue
+		}
+
+		// Keep the original type so the tool sees a number where it expects one
+		value = strings.TrimSpace(value)
+		if _, isNumber := args[key].(float64); isNumber {
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				fmt.Printf("  %s must be a number\n", key)
+				continue
+			}
+			amended[key] = n
+		} else {
+			amended[key] = value
+		}
+		changed = true
+		fmt.Printf("  %s: %v → %v\n", key, args[key], amended[key])
+	}
+
+	fmt.Print("Approve this action? (y/n): ")
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	approved := response == "y" || response == "yes"
+
+	if !changed {
+		return approved, nil
+	}
+	return approved, amended
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	fmt.Println("Approve with Modified Arguments Example")
+	fmt.Println("=======================================")
+	fmt.Println("Try lowering the amount or fixing the account before approving.")
+	fmt.Println()
+
+	amendments := NewAmendments()
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:        model,
+		Name:         "BankingAgent",
+		Description:  "An agent that makes bank transfers with approval",
+		Instructions: "Use the transfer_money tool when asked to transfer money. Report exactly what the tool result says was transferred.",
+		AgentTools:   []aigentic.AgentTool{createTransferTool(amendments)},
+	}
+
+	run, err := agent.Start("Transfer $5000 to account 123-456 to pay the contractor invoice.")
+	if err != nil {
+		log.Fatalf("Failed to start agent: %v", err)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	var fullResponse string
+	for event := range run.Next() {
+		switch e := event.(type) {
+		case *aigentic.ContentEvent:
+			fullResponse += e.Content
+		case *aigentic.ApprovalEvent:
+			approved, amended := reviewApproval(e, reader)
+			if approved && amended != nil {
+				// Register the amendment before approving, the tool runs as soon as it is approved
+				original, _ := e.ValidationResult.Values.(map[string]interface{})
+				amendments.Amend(original, amended)
+				fmt.Println("✓ Action APPROVED with amended arguments")
+			} else if approved {
+				fmt.Println("✓ Action APPROVED")
+			} else {
+				fmt.Println("✗ Action REJECTED")
+			}
+			run.Approve(e.ApprovalID, approved)
+		case *aigentic.ToolEvent:
+			fmt.Printf("[Tool executed: %s]\n", e.ToolName)
+		case *aigentic.ErrorEvent:
+			log.Printf("Error: %v", e.Err)
+		}
+	}
+
+	fmt.Printf("\nFinal Response: %s\n", fullResponse)
+	fmt.Println("\n✅ Example completed successfully!")
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func createTransferTool() aigentic.AgentTool {
+	type TransferInput struct {
+		Account string  `json:"account" description:"Destination account number"`
+		Amount  float64 `json:"amount" description:"Amount to transfer in USD"`
+	}
+
+	transferTool := aigentic.NewTool(
+		"transfer_money",
+		"Transfers money to an account. Requires approval before the transfer is made.",
+		func(run *aigentic.AgentRun, input TransferInput) (string, error) {
+			return fmt.Sprintf("Transferred $%.2f to account %s", input.Amount, input.Account), nil
+		},
+	)
+	transferTool.RequireApproval = true
+	return transferTool
+}
+
+func createSendEmailTool() aigentic.AgentTool {
+	type SendEmailInput struct {
+		To      string `json:"to" description:"Email recipient address"`
+		Subject string `json:"subject" description:"Email subject line"`
+		Body    string `json:"body" description:"Email body content"`
+	}
+
+	emailTool := aigentic.NewTool(
+		"send_email",
+		"Sends an email to a recipient with subject and body. Requires approval before sending.",
+		func(run *aigentic.AgentRun, input SendEmailInput) (string, error) {
+			return fmt.Sprintf("Email successfully sent to %s with subject '%s'", input.To, input.Subject), nil
+		},
+	)
+	emailTool.RequireApproval = true
+	return emailTool
+}
+
+// Requesters remembers which agent in the hierarchy asked for each tool call. Approval
+// events from sub-agents arrive on the coordinator's run without the agent that raised
+// them, so every tool's Validate is wrapped to record its agent's path before the
+// approval is emitted. It also records the run that called Validate: the pending approval
+// is held by that sub-agent's run, so the decision has to be sent to it.
+type Requesters struct {
+	mu     sync.Mutex
+	byCall map[string]string
+	runs   map[string]*aigentic.AgentRun // by run ID
+}
+
+func NewRequesters() *Requesters {
+	return &Requesters{byCall: make(map[string]string), runs: make(map[string]*aigentic.AgentRun)}
+}
+
+// callKey identifies a tool call by its name and arguments; json.Marshal sorts map keys
+func callKey(toolName string, args interface{}) string {
+	data, _ := json.Marshal(args)
+	return toolName + ":" + string(data)
+}
+
+// Attribute wraps the tools of agent and all its sub-agents, returning the updated tree
+func (r *Requesters) Attribute(agent aigentic.Agent, parentPath string) aigentic.Agent {
+	path := agent.Name
+	if parentPath != "" {
+		path = parentPath + " › " + agent.Name
+	}
+
+	tools := make([]aigentic.AgentTool, len(agent.AgentTools))
+	for i, tool := range agent.AgentTools {
+		tools[i] = r.wrap(tool, path)
+	}
+	agent.AgentTools = tools
+
+	agents := make([]aigentic.Agent, len(agent.Agents))
+	for i, sub := range agent.Agents {
+		agents[i] = r.Attribute(sub, path)
+	}
+	agent.Agents = agents
+	return agent
+}
+
+func (r *Requesters) wrap(tool aigentic.AgentTool, path string) aigentic.AgentTool {
+	validate := tool.Validate
+	tool.Validate = func(run *aigentic.AgentRun, args map[string]interface{}) (aigentic.ValidationResult, error) {
+		result := aigentic.ValidationResult{Values: args}
+		if validate != nil {
+			var err error
+			if result, err = validate(run, args); err != nil {
+				return result, err
+			}
+		}
+
+		r.mu.Lock()
+		r.byCall[callKey(tool.Name, result.Values)] = path
+		r.runs[run.ID()] = run
+		r.mu.Unlock()
+		return result, nil
+	}
+	return tool
+}
+
+// Lookup returns the hierarchy path of the agent that raised the approval
+func (r *Requesters) Lookup(e *aigentic.ApprovalEvent) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if path, ok := r.byCall[callKey(e.ToolName, e.ValidationResult.Values)]; ok {
+		return path
+	}
+	return "unknown agent"
+}
+
+// Run returns the run that holds the pending approval, or nil if it was not recorded
+func (r *Requesters) Run(e *aigentic.ApprovalEvent) *aigentic.AgentRun {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.runs[e.RunID]
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	fmt.Println("Approvals in Multi-Agent Hierarchies Example")
+	fmt.Println("============================================")
+	fmt.Println()
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	financeAgent := aigentic.Agent{
+		Model:        model,
+		Name:         "FinanceAgent",
+		Description:  "Makes payments to suppliers",
+		Instructions: "Use the transfer_money tool to make the payments you are asked to make.",
+		AgentTools:   []aigentic.AgentTool{createTransferTool()},
+	}
+
+	commsAgent := aigentic.Agent{
+		Model:        model,
+		Name:         "CommsAgent",
+		Description:  "Sends emails to suppliers and customers",
+		Instructions: "Use the send_email tool to send the emails you are asked to send.",
+		AgentTools:   []aigentic.AgentTool{createSendEmailTool()},
+	}
+
+	operationsLead := aigentic.Agent{
+		Model:        model,
+		Name:         "OperationsLead",
+		Description:  "Handles supplier payments and supplier communication",
+		Instructions: "Delegate payments to FinanceAgent and emails to CommsAgent.",
+		Agents:       []aigentic.Agent{financeAgent, commsAgent},
+	}
+
+	requesters := NewRequesters()
+	coordinator := requesters.Attribute(aigentic.Agent{
+		Model:        model,
+		Name:         "Coordinator",
+		Description:  "Coordinates the operations team",
+		Instructions: "Delegate supplier work to OperationsLead.",
+		Agents:       []aigentic.Agent{operationsLead},
+	}, "")
+
+	run, err := coordinator.Start("Pay supplier Acme $1200 to account 555-123 for invoice INV-88, then email billing@acme.example confirming the payment.")
+	if err != nil {
+		log.Fatalf("Failed to start agent: %v", err)
+	}
+
+	// Approval events from every level of the hierarchy arrive on the coordinator's run
+	reader := bufio.NewReader(os.Stdin)
+	var fullResponse string
+	for event := range run.Next() {
+		switch e := event.(type) {
+		case *aigentic.ContentEvent:
+			fullResponse += e.Content
+		case *aigentic.ApprovalEvent:
+			fmt.Println("\n" + strings.Repeat("=", 70))
+			fmt.Printf("APPROVAL REQUIRED: %s (%s)\n", e.ToolName, e.ApprovalID)
+			fmt.Printf("Requested by: %s\n", requesters.Lookup(e))
+			if args, ok := e.ValidationResult.Values.(map[string]interface{}); ok {
+				for key, value := range args {
+					fmt.Printf("  %s: %v\n", key, value)
+				}
+			}
+			fmt.Println(strings.Repeat("=", 70))
+
+			fmt.Print("Approve this action? (y/n): ")
+			response, _ := reader.ReadString('\n')
+			response = strings.TrimSpace(strings.ToLower(response))
+			approved := response == "y" || response == "yes"
+			if approved {
+				fmt.Println("✓ Action APPROVED")
+			} else {
+				fmt.Println("✗ Action REJECTED")
+			}
+
+			// Only the event is forwarded to the coordinator; the approval itself is pending
+			// on the run of the agent that made the call
+			requester := requesters.Run(e)
+			if requester == nil {
+				requester = run
+			}
+			requester.Approve(e.ApprovalID, approved)
+		case *aigentic.ToolEvent:
+			fmt.Printf("[Tool executed: %s]\n", e.ToolName)
+		case *aigentic.ErrorEvent:
+			log.Printf("Error: %v", e.Err)
+		}
+	}
+
+	fmt.Printf("\nFinal Response: %s\n", fullResponse)
+	fmt.Println("\n✅ Example completed successfully!")
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func createSendEmailTool() aigentic.AgentTool {
+	type SendEmailInput struct {
+		To      string `json:"to" description:"Email recipient address"`
+		Subject string `json:"subject" description:"Email subject line"`
+		Body    string `json:"body" description:"Email body content"`
+	}
+
+	emailTool := aigentic.NewTool(
+		"send_email",
+		"Sends an email to a recipient with subject and body. Requires approval before sending.",
+		func(run *aigentic.AgentRun, input SendEmailInput) (string, error) {
+			time.Sleep(500 * time.Millisecond)
+			return fmt.Sprintf("Email successfully sent to %s with subject '%s'", input.To, input.Subject), nil
+		},
+	)
+	emailTool.RequireApproval = true
+	return emailTool
+}
+
+// Approval record statuses
+const (
+	StatusPending   = "pending"
+	StatusApproved  = "approved"
+	StatusRejected  = "rejected"
+	StatusResumed   = "resumed"
+	StatusCancelled = "cancelled"
+)
+
+// ApprovalRecord is a persisted approval. The request is stored with it because an
+// AgentRun only lives in memory: after a restart the run is started again from the request.
+type ApprovalRecord struct {
+	ApprovalID string                 `json:"approval_id"`
+	RunID      string                 `json:"run_id"`
+	AgentName  string                 `json:"agent_name"`
+	Request    string                 `json:"request"`
+	ToolName   string                 `json:"tool_name"`
+	Arguments  map[string]interface{} `json:"arguments"`
+	Status     string                 `json:"status"`
+	CreatedAt  time.Time              `json:"created_at"`
+	ResolvedAt *time.Time             `json:"resolved_at,omitempty"`
+}
+
+// ApprovalStore keeps approval records in a JSON file that is rewritten on every change
+type ApprovalStore struct {
+	path string
+
+	mu      sync.Mutex
+	records map[string]*ApprovalRecord
+}
+
+// OpenApprovalStore loads the store from path, starting empty if the file does not exist
+func OpenApprovalStore(path string) (*ApprovalStore, error) {
+	s := &ApprovalStore{path: path, records: make(map[string]*ApprovalRecord)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading approval store: %w", err)
+	}
+
+	var records []*ApprovalRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("parsing approval store %s: %w", path, err)
+	}
+	for _, record := range records {
+		s.records[record.ApprovalID] = record
+	}
+	return s, nil
+}
+
+// Add persists a new pending approval before the operator is asked about it
+func (s *ApprovalStore) Add(record *ApprovalRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record.Status = StatusPending
+	record.CreatedAt = time.Now()
+	s.records[record.ApprovalID] = record
+	return s.save()
+}
+
+// Resolve records the final status of an approval
+func (s *ApprovalStore) Resolve(approvalID, status string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[approvalID]
+	if !ok {
+		return fmt.Errorf("approval %s not found", approvalID)
+	}
+	now := time.Now()
+	record.Status = status
+	record.ResolvedAt = &now
+	return s.save()
+}
+
+// Pending returns the unresolved approvals, oldest first
+func (s *ApprovalStore) Pending() []*ApprovalRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var pending []*ApprovalRecord
+	for _, record := range s.records {
+		if record.Status == StatusPending {
+			pending = append(pending, record)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].CreatedAt.Before(pending[j].CreatedAt)
+	})
+	return pending
+}
+
+// save writes to a temporary file and renames it so a crash never leaves a truncated store
+func (s *ApprovalStore) save() error {
+	records := make([]*ApprovalRecord, 0, len(s.records))
+	for _, record := range s.records {
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].CreatedAt.Before(records[j].CreatedAt)
+	})
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".approvals-*.json")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// sameArguments compares tool arguments by their JSON encoding, which sorts map keys
+func sameArguments(a, b map[string]interface{}) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(encodedA) == string(encodedB)
+}
+
+func ask(reader *bufio.Reader, question string) string {
+	fmt.Print(question)
+	input, _ := reader.ReadString('\n')
+	return strings.TrimSpace(strings.ToLower(input))
+}
+
+func printApproval(record *ApprovalRecord) {
+	fmt.Println("\n" + strings.Repeat("=", 70))
+	fmt.Printf("APPROVAL: %s requested by %s (%s)\n", record.ToolName, record.AgentName, record.ApprovalID)
+	for key, value := range record.Arguments {
+		fmt.Printf("  %s: %v\n", key, value)
+	}
+}
+
+// runAgent starts the agent for request and persists every approval before prompting.
+// If preApproved is set, an approval for the same tool and arguments is approved without
+// asking again, because the operator already decided on it before the restart.
+func runAgent(store *ApprovalStore, reader *bufio.Reader, agent aigentic.Agent, request string, preApproved *ApprovalRecord) {
+	run, err := agent.Start(request)
+	if err != nil {
+		log.Fatalf("Failed to start agent: %v", err)
+	}
+
+	var fullResponse string
+	for event := range run.Next() {
+		switch e := event.(type) {
+		case *aigentic.ContentEvent:
+			fullResponse += e.Content
+		case *aigentic.ApprovalEvent:
+			args, _ := e.ValidationResult.Values.(map[string]interface{})
+			record := &ApprovalRecord{
+				ApprovalID: e.ApprovalID,
+				RunID:      e.RunID,
+				AgentName:  agent.Name,
+				Request:    request,
+				ToolName:   e.ToolName,
+				Arguments:  args,
+			}
+			if err := store.Add(record); err != nil {
+				log.Printf("Failed to persist approval, rejecting: %v", err)
+				run.Approve(e.ApprovalID, false)
+				continue
+			}
+
+			var approved bool
+			if preApproved != nil && preApproved.ToolName == e.ToolName && sameArguments(preApproved.Arguments, args) {
+				fmt.Printf("\n✓ %s matches approval %s granted before the restart\n", e.ToolName, preApproved.ApprovalID)
+				approved = true
+				preApproved = nil
+			} else {
+				printApproval(record)
+				answer := ask(reader, "Approve this action? (y/n, Ctrl+C to stop and decide after a restart): ")
+				approved = answer == "y" || answer == "yes"
+			}
+
+			status := StatusRejected
+			if approved {
+				status = StatusApproved
+			}
+			if err := store.Resolve(e.ApprovalID, status); err != nil {
+				log.Printf("Failed to persist decision: %v", err)
+			}
+			run.Approve(e.ApprovalID, approved)
+		case *aigentic.ToolEvent:
+			fmt.Printf("[Tool executed: %s]\n", e.ToolName)
+		case *aigentic.ErrorEvent:
+			log.Printf("Error: %v", e.Err)
+		}
+	}
+
+	fmt.Printf("\nFinal Response: %s\n", fullResponse)
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	storePath := flag.String("store", "approvals.json", "File the approval queue is persisted to")
+	flag.Parse()
+
+	fmt.Println("Persistent Approval Queue Example")
+	fmt.Println("=================================")
+	fmt.Println()
+
+	store, err := OpenApprovalStore(*storePath)
+	if err != nil {
+		log.Fatalf("Failed to open approval store: %v", err)
+	}
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+	agent := aigentic.Agent{
+		Model:        model,
+		Name:         "EmailAgent",
+		Description:  "An agent that can send emails with approval",
+		Instructions: "You can send emails using the send_email tool. Always use the tool when asked to send an email.",
+		AgentTools:   []aigentic.AgentTool{createSendEmailTool()},
+	}
+	reader := bufio.NewReader(os.Stdin)
+
+	// Approvals left pending by a previous process belong to runs that no longer exist.
+	// The operator decides on each one: resume runs the request again with the approval
+	// already granted, cancel drops it.
+	if pending := store.Pending(); len(pending) > 0 {
+		fmt.Printf("Found %d unresolved approval(s) from a previous run\n", len(pending))
+		for _, record := range pending {
+			printApproval(record)
+			fmt.Printf("  requested at %s for: %s\n", record.CreatedAt.Format("2006-01-02 15:04:05"), record.Request)
+
+			answer := ask(reader, "(r)esume with this action approved, or (c)ancel? ")
+			if answer == "r" || answer == "resume" {
+				if err := store.Resolve(record.ApprovalID, StatusResumed); err != nil {
+					log.Fatalf("Failed to update approval store: %v", err)
+				}
+				runAgent(store, reader, agent, record.Request, record)
+				continue
+			}
+
+			if err := store.Resolve(record.ApprovalID, StatusCancelled); err != nil {
+				log.Fatalf("Failed to update approval store: %v", err)
+			}
+			fmt.Printf("✗ Cancelled %s\n", record.ApprovalID)
+		}
+		fmt.Println("\n✅ Example completed successfully!")
+		return
+	}
+
+	runAgent(store, reader, agent, "Send an email to john@example.com with subject 'Project Update' and body 'The project is on track and will be completed by end of week.'", nil)
+	fmt.Println("\n✅ Example completed successfully!")
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func createSendEmailTool() aigentic.AgentTool {
+	type SendEmailInput struct {
+		To      string `json:"to" description:"Email recipient address"`
+		Subject string `json:"subject" description:"Email subject line"`
+		Body    string `json:"body" description:"Email body content"`
+	}
+
+	emailTool := aigentic.NewTool(
+		"send_email",
+		"Sends an email to a recipient with subject and body. Requires approval before sending.",
+		func(run *aigentic.AgentRun, input SendEmailInput) (string, error) {
+			time.Sleep(500 * time.Millisecond)
+			return fmt.Sprintf("Email successfully sent to %s with subject '%s'", input.To, input.Subject), nil
+		},
+	)
+	emailTool.RequireApproval = true
+	return emailTool
+}
+
+// RejectionReasons carries a reviewer's reason back to the model. run.Approve(id, false)
+// only tells the agent the call was denied, so a rejection with a reason is delivered by
+// letting the call through to the wrapped tool, which returns the reason instead of running.
+type RejectionReasons struct {
+	mu     sync.Mutex
+	byCall map[string]string
+}
+
+func NewRejectionReasons() *RejectionReasons {
+	return &RejectionReasons{byCall: make(map[string]string)}
+}
+
+// callKey identifies a tool call by its name and arguments; json.Marshal sorts map keys
+func callKey(toolName string, args map[string]interface{}) string {
+	data, _ := json.Marshal(args)
+	return toolName + ":" + string(data)
+}
+
+// Reject records the reason for the call, to be returned when it reaches the tool
+func (r *RejectionReasons) Reject(toolName string, args map[string]interface{}, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byCall[callKey(toolName, args)] = reason
+}
+
+func (r *RejectionReasons) take(toolName string, args map[string]interface{}) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := callKey(toolName, args)
+	reason, ok := r.byCall[key]
+	delete(r.byCall, key)
+	return reason, ok
+}
+
+// Wrap returns the tool with its Execute guarded: a call rejected with a reason returns an
+// error result containing the reason and never runs the tool
+func (r *RejectionReasons) Wrap(tool aigentic.AgentTool) aigentic.AgentTool {
+	execute := tool.Execute
+	tool.Execute = func(run *aigentic.AgentRun, args map[string]interface{}) (*ai.ToolResult, error) {
+		if reason, rejected := r.take(tool.Name, args); rejected {
+			return &ai.ToolResult{
+				Content: []ai.ToolContent{{
+					Type:    "text",
+					Content: fmt.Sprintf("The reviewer REJECTED this action; it was not performed. Reason: %s", reason),
+				}},
+				Error: true,
+			}, nil
+		}
+		return execute(run, args)
+	}
+	return tool
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	fmt.Println("Rejection Reasons Example")
+	fmt.Println("=========================")
+	fmt.Println("Reject with a reason to see the agent propose an alternative.")
+	fmt.Println()
+
+	reasons := NewRejectionReasons()
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:       model,
+		Name:        "EmailAgent",
+		Description: "An agent that can send emails with approval",
+		Instructions: "You can send emails using the send_email tool. Always use the tool when asked to send an email. " +
+			"If a reviewer rejects an action with a reason, address the reason and propose an alternative by calling the tool again. " +
+			"Give up only if the reviewer rejects without a reason.",
+		AgentTools: []aigentic.AgentTool{
+			reasons.Wrap(createSendEmailTool()),
+		},
+	}
+
+	run, err := agent.Start("Email customers@example.com with subject 'Price increase' and body 'Our prices go up 20% next month.'")
+	if err != nil {
+		log.Fatalf("Failed to start agent: %v", err)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	var fullResponse string
+	for event := range run.Next() {
+		switch e := event.(type) {
+		case *aigentic.ContentEvent:
+			fullResponse += e.Content
+		case *aigentic.ApprovalEvent:
+			args, _ := e.ValidationResult.Values.(map[string]interface{})
+
+			fmt.Println("\n" + strings.Repeat("=", 70))
+			fmt.Printf("APPROVAL REQUIRED: %s (%s)\n", e.ToolName, e.ApprovalID)
+			for key, value := range args {
+				fmt.Printf("  %s: %v\n", key, value)
+			}
+			fmt.Println(strings.Repeat("=", 70))
+
+			fmt.Print("Approve this action? (y/n): ")
+			response, _ := reader.ReadString('\n')
+			response = strings.TrimSpace(strings.ToLower(response))
+			if response == "y" || response == "yes" {
+				fmt.Println("✓ Action APPROVED")
+				run.Approve(e.ApprovalID, true)
+				continue
+			}
+
+			fmt.Print("Reason for rejecting (empty for none): ")
+			reason, _ := reader.ReadString('\n')
+			reason = strings.TrimSpace(reason)
+			if reason == "" {
+				fmt.Println("✗ Action REJECTED")
+				run.Approve(e.ApprovalID, false)
+				continue
+			}
+
+			// Let the call through so the wrapped tool returns the reason instead of running
+			reasons.Reject(e.ToolName, args, reason)
+			fmt.Printf("✗ Action REJECTED: %s\n", reason)
+			run.Approve(e.ApprovalID, true)
+		case *aigentic.ToolEvent:
+			fmt.Printf("[Tool call finished: %s]\n", e.ToolName)
+		case *aigentic.ErrorEvent:
+			log.Printf("Error: %v", e.Err)
+		}
+	}
+
+	fmt.Printf("\nFinal Response: %s\n", fullResponse)
+	fmt.Println("\n✅ Example completed successfully!")
+}
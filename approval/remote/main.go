@@ -0,0 +1,389 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic-examples/approval/remote/approvalpb"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func createSendEmailTool() aigentic.AgentTool {
+	type SendEmailInput struct {
+		To      string `json:"to" description:"Email recipient address"`
+		Subject string `json:"subject" description:"Email subject line"`
+		Body    string `json:"body" description:"Email body content"`
+	}
+
+	emailTool := aigentic.NewTool(
+		"send_email",
+		"Sends an email to a recipient with subject and body. Requires approval before sending.",
+		func(run *aigentic.AgentRun, input SendEmailInput) (string, error) {
+			time.Sleep(500 * time.Millisecond)
+			return fmt.Sprintf("Email successfully sent to %s with subject '%s'", input.To, input.Subject), nil
+		},
+	)
+	emailTool.RequireApproval = true
+	return emailTool
+}
+
+// pendingApproval is an approval waiting for a console decision
+type pendingApproval struct {
+	approval *approvalpb.PendingApproval
+	run      *aigentic.AgentRun
+	decided  chan struct{} // closed by Decide
+}
+
+// ApprovalService runs on the agent host. Each console holds a WatchApprovals stream and is
+// sent one approval at a time; the next is sent once Decide has been called for it. If the
+// stream drops first, the approval goes back in the queue for another console.
+type ApprovalService struct {
+	approvalpb.UnimplementedApprovalServiceServer
+
+	queue chan *pendingApproval
+	done  chan struct{}
+
+	mu      sync.Mutex
+	pending map[string]*pendingApproval // by approval ID
+}
+
+func NewApprovalService() *ApprovalService {
+	return &ApprovalService{
+		queue:   make(chan *pendingApproval, 100),
+		done:    make(chan struct{}),
+		pending: make(map[string]*pendingApproval),
+	}
+}
+
+// WatchApprovals streams pending approvals to a console until every run has finished
+func (s *ApprovalService) WatchApprovals(req *approvalpb.WatchRequest, stream grpc.ServerStreamingServer[approvalpb.PendingApproval]) error {
+	ctx := stream.Context()
+	fmt.Printf("🔌 Console connected: %s\n", req.Operator)
+
+	for {
+		var p *pendingApproval
+		select {
+		case p = <-s.queue:
+		case <-s.done:
+			return nil
+		case <-ctx.Done():
+			fmt.Printf("🔌 Console disconnected: %s\n", req.Operator)
+			return ctx.Err()
+		}
+
+		if !s.isPending(p) {
+			continue // decided after it was put back in the queue
+		}
+		if err := stream.Send(p.approval); err != nil {
+			s.release(p, req.Operator)
+			return err
+		}
+
+		select {
+		case <-p.decided:
+		case <-ctx.Done():
+			s.release(p, req.Operator)
+			return ctx.Err()
+		}
+	}
+}
+
+// Decide resumes the run waiting on the approval
+func (s *ApprovalService) Decide(ctx context.Context, decision *approvalpb.Decision) (*approvalpb.DecideResponse, error) {
+	s.mu.Lock()
+	p, ok := s.pending[decision.ApprovalId]
+	delete(s.pending, decision.ApprovalId)
+	s.mu.Unlock()
+
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "approval %s is not pending", decision.ApprovalId)
+	}
+
+	p.run.Approve(decision.ApprovalId, decision.Approved)
+	close(p.decided)
+	result := "REJECTED"
+	if decision.Approved {
+		result = "APPROVED"
+	}
+	fmt.Printf("%s %s by %s\n", result, decision.ApprovalId, decision.Operator)
+	return &approvalpb.DecideResponse{}, nil
+}
+
+func (s *ApprovalService) isPending(p *pendingApproval) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.pending[p.approval.ApprovalId]
+	return ok
+}
+
+// release puts an approval claimed by a console that went away back in the queue
+func (s *ApprovalService) release(p *pendingApproval, operator string) {
+	if !s.isPending(p) {
+		return
+	}
+	fmt.Printf("↩️  %s disconnected before deciding %s, returning it to the queue\n", operator, p.approval.ApprovalId)
+	s.queue <- p
+}
+
+// Watch consumes the run events and queues its approvals for remote consoles
+func (s *ApprovalService) Watch(agentName string, run *aigentic.AgentRun) string {
+	var response string
+	for event := range run.Next() {
+		switch e := event.(type) {
+		case *aigentic.ContentEvent:
+			response += e.Content
+		case *aigentic.ApprovalEvent:
+			// Arguments are sent as strings so any console can display them
+			args := make(map[string]string)
+			if values, ok := e.ValidationResult.Values.(map[string]interface{}); ok {
+				for key, value := range values {
+					args[key] = fmt.Sprint(value)
+				}
+			}
+
+			p := &pendingApproval{
+				approval: &approvalpb.PendingApproval{
+					ApprovalId: e.ApprovalID,
+					RunId:      e.RunID,
+					AgentName:  agentName,
+					ToolName:   e.ToolName,
+					Message:    e.ValidationResult.Message,
+					Arguments:  args,
+				},
+				run:     run,
+				decided: make(chan struct{}),
+			}
+			s.mu.Lock()
+			s.pending[e.ApprovalID] = p
+			s.mu.Unlock()
+
+			s.queue <- p
+			fmt.Printf("⏳ %s is waiting for a remote decision on %s (%s)\n", agentName, e.ToolName, e.ApprovalID)
+		case *aigentic.ToolEvent:
+			fmt.Printf("[%s] Tool executed: %s\n", agentName, e.ToolName)
+		case *aigentic.ErrorEvent:
+			log.Printf("[%s] Error: %v", agentName, e.Err)
+		}
+	}
+	return response
+}
+
+// tokenAuth rejects calls that do not carry the shared token in their authorization
+// metadata. Without it anyone who can reach the port could watch and decide approvals.
+type tokenAuth struct {
+	token string
+}
+
+func (a tokenAuth) check(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		if subtle.ConstantTimeCompare([]byte(value), []byte("Bearer "+a.token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "missing or invalid approval token")
+}
+
+func (a tokenAuth) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := a.check(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a tokenAuth) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := a.check(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// runHost starts the agents and serves ApprovalService until they finish
+func runHost(addr, token string) {
+	fmt.Println("Remote Approval Example — agent host")
+	fmt.Println("====================================")
+	fmt.Println()
+
+	if token == "" {
+		var err error
+		if token, err = newToken(); err != nil {
+			log.Fatalf("Failed to generate approval token: %v", err)
+		}
+	}
+	auth := tokenAuth{token: token}
+
+	service := NewApprovalService()
+	server := grpc.NewServer(grpc.UnaryInterceptor(auth.unary), grpc.StreamInterceptor(auth.stream))
+	approvalpb.RegisterApprovalServiceServer(server, service)
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalf("Failed to listen on %s: %v", addr, err)
+	}
+	go server.Serve(listener)
+	fmt.Printf("ApprovalService listening on %s. Start a console with:\n", listener.Addr())
+	fmt.Printf("  APPROVAL_TOKEN=%s go run ./remote -console -host %s\n\n", token, listener.Addr())
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	requests := map[string]string{
+		"SalesAgent":   "Send an email to john@example.com with subject 'Quote' and body 'Please find our quote attached.'",
+		"SupportAgent": "Send an email to jane@example.com with subject 'Ticket resolved' and body 'Your ticket #4521 has been resolved.'",
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	responses := make(map[string]string)
+	for name, request := range requests {
+		agent := aigentic.Agent{
+			Model:        model,
+			Name:         name,
+			Description:  "An agent that can send emails with approval",
+			Instructions: "You can send emails using the send_email tool. Always use the tool when asked to send an email.",
+			AgentTools:   []aigentic.AgentTool{createSendEmailTool()},
+		}
+
+		run, err := agent.Start(request)
+		if err != nil {
+			log.Fatalf("Failed to start %s: %v", name, err)
+		}
+
+		wg.Add(1)
+		go func(name string, run *aigentic.AgentRun) {
+			defer wg.Done()
+			response := service.Watch(name, run)
+			mu.Lock()
+			responses[name] = response
+			mu.Unlock()
+		}(name, run)
+	}
+
+	wg.Wait()
+	// Ending the streams tells connected consoles the host is done
+	close(service.done)
+	server.GracefulStop()
+
+	names := make([]string, 0, len(responses))
+	for name := range responses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Println()
+	for _, name := range names {
+		fmt.Printf("[%s] %s\n", name, responses[name])
+	}
+	fmt.Println("\n✅ Example completed successfully!")
+}
+
+// runConsole connects to an agent host and asks the operator about each approval it streams
+func runConsole(host, operator, token string) {
+	fmt.Println("Remote Approval Example — operator console")
+	fmt.Println("==========================================")
+	fmt.Println()
+
+	conn, err := grpc.NewClient(host, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect to agent host %s: %v", host, err)
+	}
+	defer conn.Close()
+	client := approvalpb.NewApprovalServiceClient(conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+
+	stream, err := client.WatchApprovals(ctx, &approvalpb.WatchRequest{Operator: operator})
+	if err != nil {
+		log.Fatalf("Failed to watch approvals on %s: %v", host, err)
+	}
+	fmt.Printf("Connected to %s as %s, waiting for approvals...\n", host, operator)
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		approval, err := stream.Recv()
+		if err == io.EOF {
+			fmt.Println("\nAll agent runs on the host have finished")
+			return
+		}
+		if err != nil {
+			log.Fatalf("Agent host disconnected: %v", err)
+		}
+
+		fmt.Println("\n" + strings.Repeat("=", 70))
+		fmt.Printf("APPROVAL REQUIRED: %s from %s (%s)\n", approval.ToolName, approval.AgentName, approval.ApprovalId)
+		if approval.Message != "" {
+			fmt.Println(approval.Message)
+		}
+		for key, value := range approval.Arguments {
+			fmt.Printf("  %s: %s\n", key, value)
+		}
+		fmt.Println(strings.Repeat("=", 70))
+		fmt.Print("Approve this action? (y/n): ")
+
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		decision := &approvalpb.Decision{
+			ApprovalId: approval.ApprovalId,
+			Approved:   response == "y" || response == "yes",
+			Operator:   operator,
+		}
+		if _, err := client.Decide(ctx, decision); err != nil {
+			log.Printf("Failed to send decision: %v", err)
+		}
+	}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	console := flag.Bool("console", false, "Run the operator console instead of the agent host")
+	addr := flag.String("addr", "127.0.0.1:7070", "Address the agent host serves ApprovalService on")
+	host := flag.String("host", "localhost:7070", "Agent host the console connects to")
+	operator := flag.String("operator", os.Getenv("USER"), "Operator name sent with each decision")
+	token := flag.String("token", os.Getenv("APPROVAL_TOKEN"), "Shared token consoles must present; the host generates one if empty")
+	flag.Parse()
+
+	if *console {
+		if *token == "" {
+			log.Fatal("Set -token or APPROVAL_TOKEN to the token printed by the agent host")
+		}
+		runConsole(*host, *operator, *token)
+		return
+	}
+	runHost(*addr, *token)
+}
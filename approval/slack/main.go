@@ -0,0 +1,314 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func getEnv(name string, help string) string {
+	value := os.Getenv(name)
+	if value == "" {
+		fmt.Printf("Error: %s environment variable not set\n", name)
+		fmt.Println(help)
+		os.Exit(1)
+	}
+	return value
+}
+
+func createSendEmailTool() aigentic.AgentTool {
+	type SendEmailInput struct {
+		To      string `json:"to" description:"Email recipient address"`
+		Subject string `json:"subject" description:"Email subject line"`
+		Body    string `json:"body" description:"Email body content"`
+	}
+
+	emailTool := aigentic.NewTool(
+		"send_email",
+		"Sends an email to a recipient with subject and body. Requires approval before sending.",
+		func(run *aigentic.AgentRun, input SendEmailInput) (string, error) {
+			time.Sleep(500 * time.Millisecond)
+			return fmt.Sprintf("Email successfully sent to %s with subject '%s'", input.To, input.Subject), nil
+		},
+	)
+	emailTool.RequireApproval = true
+	return emailTool
+}
+
+// SlackApprover posts approval requests to a channel and resumes the run when an
+// operator clicks a button in Slack
+type SlackApprover struct {
+	botToken      string
+	channelID     string
+	signingSecret string
+
+	mu      sync.Mutex
+	pending map[string]*aigentic.AgentRun // by approval ID
+}
+
+func NewSlackApprover(botToken, channelID, signingSecret string) *SlackApprover {
+	return &SlackApprover{
+		botToken:      botToken,
+		channelID:     channelID,
+		signingSecret: signingSecret,
+		pending:       make(map[string]*aigentic.AgentRun),
+	}
+}
+
+// RequestApproval posts the approval with Approve and Deny buttons carrying the approval ID
+func (s *SlackApprover) RequestApproval(run *aigentic.AgentRun, e *aigentic.ApprovalEvent) error {
+	s.mu.Lock()
+	s.pending[e.ApprovalID] = run
+	s.mu.Unlock()
+
+	details := fmt.Sprintf("*Approval required:* `%s`\n", e.ToolName)
+	if e.ValidationResult.Message != "" {
+		details += e.ValidationResult.Message + "\n"
+	}
+	if args, ok := e.ValidationResult.Values.(map[string]interface{}); ok {
+		for key, value := range args {
+			details += fmt.Sprintf("• *%s*: %v\n", key, value)
+		}
+	}
+
+	message := map[string]interface{}{
+		"channel": s.channelID,
+		"text":    fmt.Sprintf("Approval required for %s", e.ToolName),
+		"blocks": []interface{}{
+			map[string]interface{}{
+				"type": "section",
+				"text": map[string]interface{}{"type": "mrkdwn", "text": details},
+			},
+			map[string]interface{}{
+				"type": "actions",
+				"elements": []interface{}{
+					slackButton("Approve", "approve", "primary", e.ApprovalID),
+					slackButton("Deny", "deny", "danger", e.ApprovalID),
+				},
+			},
+		},
+	}
+
+	return s.post("https://slack.com/api/chat.postMessage", message)
+}
+
+func slackButton(label, actionID, style, approvalID string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":      "button",
+		"text":      map[string]interface{}{"type": "plain_text", "text": label},
+		"style":     style,
+		"action_id": actionID,
+		"value":     approvalID,
+	}
+}
+
+func (s *SlackApprover) post(url string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+s.botToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	// response_url replies return plain "ok" rather than JSON
+	if err := json.NewDecoder(resp.Body).Decode(&result); err == nil && !result.OK && result.Error != "" {
+		return fmt.Errorf("slack: %s", result.Error)
+	}
+	return nil
+}
+
+// verifySignature checks the X-Slack-Signature header so only Slack can resolve approvals.
+// See https://api.slack.com/authentication/verifying-requests-from-slack
+func (s *SlackApprover) verifySignature(r *http.Request, body []byte) error {
+	timestamp := r.Header.Get("X-Slack-Request-Timestamp")
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("missing request timestamp")
+	}
+
+	// Reject old requests to prevent replay attacks
+	if age := time.Since(time.Unix(seconds, 0)); age > 5*time.Minute || age < -5*time.Minute {
+		return fmt.Errorf("request timestamp too old")
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.signingSecret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(r.Header.Get("X-Slack-Signature"))) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+// HandleInteraction receives button clicks from Slack and resumes the waiting run
+func (s *SlackApprover) HandleInteraction(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, "cannot read body", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.verifySignature(r, body); err != nil {
+		log.Printf("Rejected Slack callback: %v", err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	// The body is form encoded with the interaction JSON in the payload field
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	var payload struct {
+		User struct {
+			ID       string `json:"id"`
+			Username string `json:"username"`
+		} `json:"user"`
+		ResponseURL string `json:"response_url"`
+		Actions     []struct {
+			ActionID string `json:"action_id"`
+			Value    string `json:"value"`
+		} `json:"actions"`
+	}
+	if err := json.Unmarshal([]byte(r.FormValue("payload")), &payload); err != nil || len(payload.Actions) == 0 {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	action := payload.Actions[0]
+	approved := action.ActionID == "approve"
+
+	s.mu.Lock()
+	run, ok := s.pending[action.Value]
+	delete(s.pending, action.Value)
+	s.mu.Unlock()
+
+	// Acknowledge within Slack's 3 second limit, then update the message
+	w.WriteHeader(http.StatusOK)
+
+	if !ok {
+		log.Printf("Approval %s is no longer pending", action.Value)
+		return
+	}
+
+	run.Approve(action.Value, approved)
+
+	decision := "✅ Approved"
+	if !approved {
+		decision = "❌ Denied"
+	}
+	fmt.Printf("%s by @%s (%s)\n", decision, payload.User.Username, action.Value)
+
+	go func() {
+		update := map[string]interface{}{
+			"replace_original": true,
+			"text":             fmt.Sprintf("%s by <@%s>", decision, payload.User.ID),
+		}
+		if err := s.post(payload.ResponseURL, update); err != nil {
+			log.Printf("Failed to update Slack message: %v", err)
+		}
+	}()
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	addr := flag.String("addr", ":3000", "Address the Slack interactivity endpoint listens on")
+	flag.Parse()
+
+	fmt.Println("Slack Approval Example")
+	fmt.Println("======================")
+	fmt.Println()
+
+	approver := NewSlackApprover(
+		getEnv("SLACK_BOT_TOKEN", "Create a Slack app with the chat:write scope and export its bot token"),
+		getEnv("SLACK_CHANNEL_ID", "Export the ID of the channel the bot posts approvals to"),
+		getEnv("SLACK_SIGNING_SECRET", "Export the signing secret from the app's Basic Information page"),
+	)
+
+	http.HandleFunc("/slack/interactions", approver.HandleInteraction)
+	go func() {
+		if err := http.ListenAndServe(*addr, nil); err != nil {
+			log.Fatalf("Interactivity endpoint failed: %v", err)
+		}
+	}()
+	fmt.Printf("Set the app's Interactivity Request URL to https://<public-host>/slack/interactions (forwarded to %s)\n\n", *addr)
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:        model,
+		Name:         "EmailAgent",
+		Description:  "An agent that can send emails with approval",
+		Instructions: "You can send emails using the send_email tool. Always use the tool when asked to send an email.",
+		AgentTools: []aigentic.AgentTool{
+			createSendEmailTool(),
+		},
+	}
+
+	run, err := agent.Start("Send an email to john@example.com with subject 'Project Update' and body 'The project is on track and will be completed by end of week.'")
+	if err != nil {
+		log.Fatalf("Failed to start agent: %v", err)
+	}
+
+	var fullResponse string
+	for event := range run.Next() {
+		switch e := event.(type) {
+		case *aigentic.ContentEvent:
+			fullResponse += e.Content
+		case *aigentic.ApprovalEvent:
+			if err := approver.RequestApproval(run, e); err != nil {
+				log.Printf("Failed to post approval to Slack, rejecting: %v", err)
+				run.Approve(e.ApprovalID, false)
+				continue
+			}
+			fmt.Printf("Posted approval for %s to Slack, waiting for a decision...\n", e.ToolName)
+		case *aigentic.ToolEvent:
+			fmt.Printf("[Tool executed: %s]\n", e.ToolName)
+		case *aigentic.ErrorEvent:
+			log.Printf("Error: %v", e.Err)
+		}
+	}
+
+	fmt.Printf("\nFinal Response: %s\n", fullResponse)
+	fmt.Println("\n✅ Example completed successfully!")
+}
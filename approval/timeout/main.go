@@ -0,0 +1,279 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func createSendEmailTool() aigentic.AgentTool {
+	type SendEmailInput struct {
+		To      string `json:"to" description:"Email recipient address"`
+		Subject string `json:"subject" description:"Email subject line"`
+		Body    string `json:"body" description:"Email body content"`
+	}
+
+	emailTool := aigentic.NewTool(
+		"send_email",
+		"Sends an email to a recipient with subject and body. Requires approval before sending.",
+		func(run *aigentic.AgentRun, input SendEmailInput) (string, error) {
+			time.Sleep(500 * time.Millisecond)
+			return fmt.Sprintf("Email successfully sent to %s with subject '%s'", input.To, input.Subject), nil
+		},
+	)
+	emailTool.RequireApproval = true
+	return emailTool
+}
+
+func createLookupContactTool() aigentic.AgentTool {
+	type LookupContactInput struct {
+		Name string `json:"name" description:"Name of the contact to look up"`
+	}
+
+	lookupTool := aigentic.NewTool(
+		"lookup_contact",
+		"Looks up the email address of a contact by name. Requires approval before reading the address book.",
+		func(run *aigentic.AgentRun, input LookupContactInput) (string, error) {
+			address := strings.ToLower(strings.Join(strings.Fields(input.Name), ".")) + "@example.com"
+			return fmt.Sprintf("%s <%s>", input.Name, address), nil
+		},
+	)
+	lookupTool.RequireApproval = true
+	return lookupTool
+}
+
+// TimeoutPolicy is the decision applied when nobody answers an approval in time.
+// Low-risk tools default to approve; everything else defaults to deny.
+type TimeoutPolicy struct {
+	Timeout      time.Duration
+	AutoApproved map[string]bool // tool names approved on timeout
+}
+
+func (p TimeoutPolicy) defaultDecision(toolName string) bool {
+	return p.AutoApproved[toolName]
+}
+
+// TimeoutDecisions feeds decisions made by the timeout policy back into the run.
+// run.Approve only tells the agent whether the call may run, so both kinds of timeout
+// decision let the call through to the wrapped tool: a denial returns the reason instead
+// of running, and an approval runs the tool and notes that nobody reviewed it.
+type TimeoutDecisions struct {
+	mu     sync.Mutex
+	byCall map[string]timeoutDecision
+}
+
+type timeoutDecision struct {
+	approved bool
+	note     string
+}
+
+func NewTimeoutDecisions() *TimeoutDecisions {
+	return &TimeoutDecisions{byCall: make(map[string]timeoutDecision)}
+}
+
+// callKey identifies a tool call by its name and arguments; json.Marshal sorts map keys
+func callKey(toolName string, args interface{}) string {
+	data, _ := json.Marshal(args)
+	return toolName + ":" + string(data)
+}
+
+// Record stores the policy's decision for the call, to be applied when it reaches the tool
+func (t *TimeoutDecisions) Record(toolName string, args interface{}, approved bool, note string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.byCall[callKey(toolName, args)] = timeoutDecision{approved: approved, note: note}
+}
+
+func (t *TimeoutDecisions) take(toolName string, args map[string]interface{}) (timeoutDecision, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := callKey(toolName, args)
+	decision, ok := t.byCall[key]
+	delete(t.byCall, key)
+	return decision, ok
+}
+
+// Wrap returns the tool with its Execute guarded: a call decided by the timeout policy
+// returns the policy's note along with the result, or instead of it when denied
+func (t *TimeoutDecisions) Wrap(tool aigentic.AgentTool) aigentic.AgentTool {
+	execute := tool.Execute
+	tool.Execute = func(run *aigentic.AgentRun, args map[string]interface{}) (*ai.ToolResult, error) {
+		decision, ok := t.take(tool.Name, args)
+		if !ok {
+			return execute(run, args)
+		}
+		if !decision.approved {
+			return &ai.ToolResult{
+				Content: []ai.ToolContent{{Type: "text", Content: decision.note}},
+				Error:   true,
+			}, nil
+		}
+
+		result, err := execute(run, args)
+		if err != nil || result == nil {
+			return result, err
+		}
+		result.Content = append([]ai.ToolContent{{Type: "text", Content: decision.note}}, result.Content...)
+		return result, nil
+	}
+	return tool
+}
+
+// ApprovalPrompter asks the operator on stdin and falls back to the policy on timeout
+type ApprovalPrompter struct {
+	policy TimeoutPolicy
+	lines  chan string
+}
+
+func NewApprovalPrompter(policy TimeoutPolicy) *ApprovalPrompter {
+	p := &ApprovalPrompter{policy: policy, lines: make(chan string)}
+
+	// A single reader goroutine so an unanswered prompt does not leave a reader behind
+	go func() {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			p.lines <- scanner.Text()
+		}
+		close(p.lines)
+	}()
+	return p
+}
+
+// discardStale drops lines typed after an earlier prompt timed out, so a late answer
+// meant for that prompt is not taken as the answer to the next one
+func (p *ApprovalPrompter) discardStale() {
+	for {
+		select {
+		case line, ok := <-p.lines:
+			if !ok {
+				return
+			}
+			fmt.Printf("(ignoring %q, typed after the previous prompt timed out)\n", line)
+		default:
+			return
+		}
+	}
+}
+
+// Decide returns the operator's decision, or the policy default when the timeout expires.
+// The returned reason is logged with the decision.
+func (p *ApprovalPrompter) Decide(e *aigentic.ApprovalEvent) (approved bool, reason string) {
+	p.discardStale()
+	fmt.Println("\n" + strings.Repeat("=", 70))
+	fmt.Printf("APPROVAL REQUIRED: %s (%s)\n", e.ToolName, e.ApprovalID)
+	if args, ok := e.ValidationResult.Values.(map[string]interface{}); ok {
+		for key, value := range args {
+			fmt.Printf("  %s: %v\n", key, value)
+		}
+	}
+
+	fallback := "deny"
+	if p.policy.defaultDecision(e.ToolName) {
+		fallback = "approve"
+	}
+	fmt.Printf("Approve this action? (y/n) — will %s automatically in %v: ", fallback, p.policy.Timeout)
+
+	select {
+	case line, ok := <-p.lines:
+		if !ok {
+			return p.policy.defaultDecision(e.ToolName), "stdin closed"
+		}
+		response := strings.TrimSpace(strings.ToLower(line))
+		return response == "y" || response == "yes", "operator"
+	case <-time.After(p.policy.Timeout):
+		fmt.Println()
+		return p.policy.defaultDecision(e.ToolName), "timeout"
+	}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	timeout := flag.Duration("timeout", 15*time.Second, "Time to wait for an operator decision before applying the default")
+	flag.Parse()
+
+	fmt.Println("Approval Timeout Example")
+	fmt.Println("========================")
+	fmt.Println("Leave a prompt unanswered to see the default decision applied.")
+	fmt.Println()
+
+	prompter := NewApprovalPrompter(TimeoutPolicy{
+		Timeout:      *timeout,
+		AutoApproved: map[string]bool{"lookup_contact": true},
+	})
+
+	decisions := NewTimeoutDecisions()
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:        model,
+		Name:         "EmailAgent",
+		Description:  "An agent that looks up contacts and sends emails with approval",
+		Instructions: "Look up the contact's email address with lookup_contact, then send the email with send_email. Call one tool at a time.",
+		AgentTools: []aigentic.AgentTool{
+			decisions.Wrap(createLookupContactTool()),
+			decisions.Wrap(createSendEmailTool()),
+		},
+	}
+
+	run, err := agent.Start("Email John Smith with subject 'Project Update' and body 'The project is on track and will be completed by end of week.'")
+	if err != nil {
+		log.Fatalf("Failed to start agent: %v", err)
+	}
+
+	var fullResponse string
+	for event := range run.Next() {
+		switch e := event.(type) {
+		case *aigentic.ContentEvent:
+			fullResponse += e.Content
+		case *aigentic.ApprovalEvent:
+			approved, reason := prompter.Decide(e)
+			if reason == "operator" {
+				run.Approve(e.ApprovalID, approved)
+			} else {
+				// Let the call through so the wrapped tool tells the model what the policy decided
+				note := fmt.Sprintf("No operator decision (%s), so the timeout policy APPROVED this action without review.", reason)
+				if !approved {
+					note = fmt.Sprintf("No operator decision (%s), so the timeout policy DENIED this action; it was not performed.", reason)
+				}
+				decisions.Record(e.ToolName, e.ValidationResult.Values, approved, note)
+				run.Approve(e.ApprovalID, true)
+			}
+
+			decision := "REJECTED"
+			if approved {
+				decision = "APPROVED"
+			}
+			log.Printf("approval=%s tool=%s decision=%s reason=%s", e.ApprovalID, e.ToolName, decision, reason)
+		case *aigentic.ToolEvent:
+			fmt.Printf("[Tool executed: %s]\n", e.ToolName)
+		case *aigentic.ErrorEvent:
+			log.Printf("Error: %v", e.Err)
+		}
+	}
+
+	fmt.Printf("\nFinal Response: %s\n", fullResponse)
+	fmt.Println("\n✅ Example completed successfully!")
+}
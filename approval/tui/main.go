@@ -0,0 +1,411 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+// Styles used to draw the dashboard
+var (
+	boldStyle     = lipgloss.NewStyle().Bold(true)
+	dimStyle      = lipgloss.NewStyle().Faint(true)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	messageStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+)
+
+const (
+	maxActivity    = 8 // lines kept in the activity pane
+	separatorWidth = 78
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func createTransferTool() aigentic.AgentTool {
+	type TransferInput struct {
+		Account string  `json:"account" description:"Destination account number"`
+		Amount  float64 `json:"amount" description:"Amount to transfer in USD"`
+	}
+
+	transferTool := aigentic.NewTool(
+		"transfer_money",
+		"Transfers money to an account. Requires approval before the transfer is made.",
+		func(run *aigentic.AgentRun, input TransferInput) (string, error) {
+			return fmt.Sprintf("Transferred $%.2f to account %s", input.Amount, input.Account), nil
+		},
+	)
+	transferTool.RequireApproval = true
+	return transferTool
+}
+
+func createSendEmailTool() aigentic.AgentTool {
+	type SendEmailInput struct {
+		To      string `json:"to" description:"Email recipient address"`
+		Subject string `json:"subject" description:"Email subject line"`
+		Body    string `json:"body" description:"Email body content"`
+	}
+
+	emailTool := aigentic.NewTool(
+		"send_email",
+		"Sends an email to a recipient with subject and body. Requires approval before sending.",
+		func(run *aigentic.AgentRun, input SendEmailInput) (string, error) {
+			time.Sleep(500 * time.Millisecond)
+			return fmt.Sprintf("Email successfully sent to %s with subject '%s'", input.To, input.Subject), nil
+		},
+	)
+	emailTool.RequireApproval = true
+	return emailTool
+}
+
+// PendingApproval is an approval listed in the dashboard
+type PendingApproval struct {
+	ApprovalID string
+	AgentName  string
+	ToolName   string
+	Message    string
+	Parameters map[string]interface{}
+	Requested  time.Time
+
+	run *aigentic.AgentRun
+}
+
+// Dashboard holds the state shown on screen. Agent goroutines and the keyboard both
+// change it; every change signals the bubbletea program through changed.
+type Dashboard struct {
+	mu        sync.Mutex
+	pending   []*PendingApproval
+	selected  int
+	activity  []string
+	responses []string
+	running   int
+	closed    bool // set on quit; new approvals are rejected
+
+	changed chan struct{}
+}
+
+func NewDashboard(agents int) *Dashboard {
+	return &Dashboard{running: agents, changed: make(chan struct{}, 1)}
+}
+
+func (d *Dashboard) notify() {
+	select {
+	case d.changed <- struct{}{}:
+	default:
+	}
+}
+
+// logf adds a line to the activity pane. The caller must hold d.mu.
+func (d *Dashboard) logf(format string, args ...interface{}) {
+	line := time.Now().Format("15:04:05") + "  " + fmt.Sprintf(format, args...)
+	d.activity = append(d.activity, line)
+	if len(d.activity) > maxActivity {
+		d.activity = d.activity[len(d.activity)-maxActivity:]
+	}
+}
+
+// Watch consumes the run events, listing approvals until the operator decides
+func (d *Dashboard) Watch(agentName string, run *aigentic.AgentRun) {
+	var response string
+	for event := range run.Next() {
+		switch e := event.(type) {
+		case *aigentic.ContentEvent:
+			response += e.Content
+		case *aigentic.ApprovalEvent:
+			params, _ := e.ValidationResult.Values.(map[string]interface{})
+			d.mu.Lock()
+			if d.closed {
+				d.mu.Unlock()
+				run.Approve(e.ApprovalID, false)
+				continue
+			}
+			d.pending = append(d.pending, &PendingApproval{
+				ApprovalID: e.ApprovalID,
+				AgentName:  agentName,
+				ToolName:   e.ToolName,
+				Message:    e.ValidationResult.Message,
+				Parameters: params,
+				Requested:  time.Now(),
+				run:        run,
+			})
+			d.logf("%s requested %s", agentName, e.ToolName)
+			d.mu.Unlock()
+		case *aigentic.ToolEvent:
+			d.mu.Lock()
+			d.logf("%s executed %s", agentName, e.ToolName)
+			d.mu.Unlock()
+		case *aigentic.ErrorEvent:
+			d.mu.Lock()
+			d.logf("%s error: %v", agentName, e.Err)
+			d.mu.Unlock()
+		}
+		d.notify()
+	}
+
+	d.mu.Lock()
+	d.running--
+	d.responses = append(d.responses, fmt.Sprintf("[%s] %s", agentName, response))
+	d.logf("%s finished", agentName)
+	d.mu.Unlock()
+	d.notify()
+}
+
+// Move changes the selected approval
+func (d *Dashboard) Move(delta int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.selected += delta
+	d.clampSelection()
+}
+
+func (d *Dashboard) clampSelection() {
+	if d.selected >= len(d.pending) {
+		d.selected = len(d.pending) - 1
+	}
+	if d.selected < 0 {
+		d.selected = 0
+	}
+}
+
+// Decide approves or rejects the selected approval and resumes its run
+func (d *Dashboard) Decide(approved bool) {
+	d.mu.Lock()
+	if len(d.pending) == 0 {
+		d.mu.Unlock()
+		return
+	}
+	approval := d.pending[d.selected]
+	d.pending = append(d.pending[:d.selected], d.pending[d.selected+1:]...)
+	d.clampSelection()
+
+	decision := "rejected"
+	if approved {
+		decision = "approved"
+	}
+	d.logf("%s %s for %s", decision, approval.ToolName, approval.AgentName)
+	d.mu.Unlock()
+
+	// Resume the run outside the lock; its events are handled by Watch, which takes it too
+	approval.run.Approve(approval.ApprovalID, approved)
+}
+
+// Close rejects everything pending and any approval that arrives later
+func (d *Dashboard) Close() {
+	d.mu.Lock()
+	d.closed = true
+	pending := d.pending
+	d.pending = nil
+	d.mu.Unlock()
+
+	for _, approval := range pending {
+		approval.run.Approve(approval.ApprovalID, false)
+	}
+}
+
+// Done reports whether every agent has finished
+func (d *Dashboard) Done() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.running == 0
+}
+
+// View draws the whole screen
+func (d *Dashboard) View() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var b strings.Builder
+	line := func(format string, args ...interface{}) {
+		b.WriteString(fmt.Sprintf(format, args...) + "\n")
+	}
+	separator := dimStyle.Render(strings.Repeat("─", separatorWidth))
+
+	line("%s   %d agents running · %d pending", boldStyle.Render("Approval Dashboard"), d.running, len(d.pending))
+	line("%s", dimStyle.Render("↑/↓ or j/k select · a approve · r reject · q quit"))
+	line("%s", separator)
+
+	line("%s", boldStyle.Render("PENDING"))
+	if len(d.pending) == 0 {
+		line("  %s", dimStyle.Render("No approvals waiting"))
+	}
+	for i, approval := range d.pending {
+		waiting := time.Since(approval.Requested).Round(time.Second)
+		row := fmt.Sprintf(" %6s  %-16s %-14s %s", waiting, approval.ToolName, approval.AgentName, approval.ApprovalID)
+		if i == d.selected {
+			line("%s", selectedStyle.Render(">"+row))
+		} else {
+			line(" %s", row)
+		}
+	}
+	line("%s", separator)
+
+	line("%s", boldStyle.Render("ARGUMENTS"))
+	if len(d.pending) > 0 {
+		approval := d.pending[d.selected]
+		line("  %s requested by %s", approval.ToolName, approval.AgentName)
+		if approval.Message != "" {
+			line("  %s", messageStyle.Render(approval.Message))
+		}
+
+		keys := make([]string, 0, len(approval.Parameters))
+		for key := range approval.Parameters {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			line("  %-10s %v", key+":", approval.Parameters[key])
+		}
+	}
+	line("%s", separator)
+
+	line("%s", boldStyle.Render("ACTIVITY"))
+	for _, entry := range d.activity {
+		line("  %s", entry)
+	}
+	return b.String()
+}
+
+// Messages delivered to the bubbletea program
+type (
+	changedMsg struct{} // the dashboard state changed
+	tickMsg    struct{} // a second passed, so the waiting times need redrawing
+	doneMsg    struct{} // every agent has finished
+)
+
+// dashboardModel is the bubbletea model. The state lives in Dashboard, because the agent
+// goroutines change it too; the model only turns keys into commands and redraws.
+type dashboardModel struct {
+	dashboard *Dashboard
+	done      <-chan struct{}
+}
+
+func (m dashboardModel) waitForChange() tea.Msg {
+	<-m.dashboard.changed
+	return changedMsg{}
+}
+
+func (m dashboardModel) waitForDone() tea.Msg {
+	<-m.done
+	return doneMsg{}
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return tickMsg{} })
+}
+
+func (m dashboardModel) Init() tea.Cmd {
+	return tea.Batch(m.waitForChange, m.waitForDone, tick())
+}
+
+func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			m.dashboard.Move(-1)
+		case "down", "j":
+			m.dashboard.Move(1)
+		case "a", "y":
+			m.dashboard.Decide(true)
+		case "r", "n":
+			m.dashboard.Decide(false)
+		case "q", "ctrl+c":
+			m.dashboard.Close()
+			return m, tea.Quit
+		}
+	case changedMsg:
+		return m, m.waitForChange
+	case tickMsg:
+		return m, tick()
+	case doneMsg:
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m dashboardModel) View() string {
+	return m.dashboard.View()
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+	requests := map[string]aigentic.Agent{
+		"Transfer $2500 to account 111-222 for the marketing retainer.": {
+			Name:       "PayrollAgent",
+			AgentTools: []aigentic.AgentTool{createTransferTool()},
+		},
+		"Send an email to jane@example.com with subject 'Ticket resolved' and body 'Your ticket #4521 has been resolved.'": {
+			Name:       "SupportAgent",
+			AgentTools: []aigentic.AgentTool{createSendEmailTool()},
+		},
+		"Transfer $780.50 to account 555-666 for the office supplies invoice, then email accounts@example.com with subject 'Invoice paid' confirming it.": {
+			Name:       "BillingAgent",
+			AgentTools: []aigentic.AgentTool{createTransferTool(), createSendEmailTool()},
+		},
+	}
+
+	dashboard := NewDashboard(len(requests))
+
+	var wg sync.WaitGroup
+	for request, agent := range requests {
+		agent.Model = model
+		agent.Description = "An agent that performs actions with approval"
+		agent.Instructions = "Use your tools to do what is asked. Call one tool at a time."
+
+		run, err := agent.Start(request)
+		if err != nil {
+			log.Fatalf("Failed to start %s: %v", agent.Name, err)
+		}
+
+		wg.Add(1)
+		go func(name string, run *aigentic.AgentRun) {
+			defer wg.Done()
+			dashboard.Watch(name, run)
+		}(agent.Name, run)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// bubbletea puts the terminal in raw mode and restores it on exit, after a panic in the
+	// model or its commands, and on SIGINT or SIGTERM
+	program := tea.NewProgram(dashboardModel{dashboard: dashboard, done: done}, tea.WithAltScreen())
+	if _, err := program.Run(); err != nil {
+		dashboard.Close()
+		log.Printf("Dashboard failed: %v", err)
+	}
+
+	if !dashboard.Done() {
+		fmt.Println("Rejected pending approvals, waiting for agents to finish...")
+		<-done
+	}
+
+	fmt.Println("Approval Dashboard Example")
+	fmt.Println("==========================")
+	for _, response := range dashboard.responses {
+		fmt.Println(response)
+	}
+	fmt.Println("\n✅ Example completed successfully!")
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+// highRiskAmount is the transfer amount above which two approvers must sign off
+const highRiskAmount = 10000
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func createTransferTool() aigentic.AgentTool {
+	return aigentic.AgentTool{
+		Name:        "transfer_money",
+		Description: "Transfers money to an account. Requires approval; transfers over $10,000 need two approvers.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"account": map[string]interface{}{
+					"type":        "string",
+					"description": "Destination account number",
+				},
+				"amount": map[string]interface{}{
+					"type":        "number",
+					"description": "Amount to transfer in USD",
+				},
+			},
+			"required": []string{"account", "amount"},
+		},
+		RequireApproval: true,
+		Validate: func(run *aigentic.AgentRun, args map[string]interface{}) (aigentic.ValidationResult, error) {
+			amount, _ := args["amount"].(float64)
+			message := fmt.Sprintf("Transfer of $%.2f needs 1 approver", amount)
+			if amount > highRiskAmount {
+				message = fmt.Sprintf("HIGH RISK: transfer of $%.2f needs 2 independent approvers", amount)
+			}
+			return aigentic.ValidationResult{Values: args, Message: message}, nil
+		},
+		Execute: func(run *aigentic.AgentRun, args map[string]interface{}) (*ai.ToolResult, error) {
+			return &ai.ToolResult{
+				Content: []ai.ToolContent{{
+					Type:    "text",
+					Content: fmt.Sprintf("Transferred $%.2f to account %v", args["amount"], args["account"]),
+				}},
+			}, nil
+		},
+	}
+}
+
+// requiredApprovers returns how many distinct people must approve the call
+func requiredApprovers(toolName string, args map[string]interface{}) int {
+	if amount, _ := args["amount"].(float64); toolName == "transfer_money" && amount > highRiskAmount {
+		return 2
+	}
+	return 1
+}
+
+// signOffs are the partial approvals collected so far for one ApprovalID
+type signOffs struct {
+	run       *aigentic.AgentRun
+	required  int
+	approvers map[string]bool
+}
+
+// TwoPersonApprovals tracks sign-offs per ApprovalID and only calls run.Approve once
+// enough distinct approvers agreed. A single rejection rejects the call.
+type TwoPersonApprovals struct {
+	mu      sync.Mutex
+	pending map[string]*signOffs
+}
+
+func NewTwoPersonApprovals() *TwoPersonApprovals {
+	return &TwoPersonApprovals{pending: make(map[string]*signOffs)}
+}
+
+// Request registers an approval and returns how many approvers it needs
+func (t *TwoPersonApprovals) Request(run *aigentic.AgentRun, e *aigentic.ApprovalEvent) int {
+	args, _ := e.ValidationResult.Values.(map[string]interface{})
+	required := requiredApprovers(e.ToolName, args)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending[e.ApprovalID] = &signOffs{run: run, required: required, approvers: make(map[string]bool)}
+	return required
+}
+
+// SignOff records one approver's decision. It returns true once the approval is resolved,
+// either by a rejection or by the last required approval.
+func (t *TwoPersonApprovals) SignOff(approvalID, approver string, approved bool) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.pending[approvalID]
+	if !ok {
+		return false, fmt.Errorf("approval %s is not pending", approvalID)
+	}
+	if approver == "" {
+		return false, fmt.Errorf("approver name is required")
+	}
+	if s.approvers[approver] {
+		return false, fmt.Errorf("%s has already approved %s; a different person must sign off", approver, approvalID)
+	}
+
+	if !approved {
+		delete(t.pending, approvalID)
+		s.run.Approve(approvalID, false)
+		return true, nil
+	}
+
+	s.approvers[approver] = true
+	if len(s.approvers) < s.required {
+		return false, nil
+	}
+
+	delete(t.pending, approvalID)
+	s.run.Approve(approvalID, true)
+	return true, nil
+}
+
+// Approvers returns who has signed off on a pending approval so far
+func (t *TwoPersonApprovals) Approvers(approvalID string) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var names []string
+	if s, ok := t.pending[approvalID]; ok {
+		for name := range s.approvers {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	fmt.Println("Two-Person Approval Example")
+	fmt.Println("===========================")
+	fmt.Printf("Transfers over $%d need sign-off from two different approvers.\n", highRiskAmount)
+	fmt.Println()
+
+	approvals := NewTwoPersonApprovals()
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:        model,
+		Name:         "BankingAgent",
+		Description:  "An agent that makes bank transfers with approval",
+		Instructions: "Use the transfer_money tool for every transfer requested. Make each transfer with a separate tool call, one at a time.",
+		AgentTools:   []aigentic.AgentTool{createTransferTool()},
+	}
+
+	run, err := agent.Start("Transfer $2500 to account 111-222 for the marketing retainer, and $25000 to account 333-444 for the equipment lease.")
+	if err != nil {
+		log.Fatalf("Failed to start agent: %v", err)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	ask := func(question string) string {
+		fmt.Print(question)
+		input, _ := reader.ReadString('\n')
+		return strings.TrimSpace(input)
+	}
+
+	var fullResponse string
+	for event := range run.Next() {
+		switch e := event.(type) {
+		case *aigentic.ContentEvent:
+			fullResponse += e.Content
+		case *aigentic.ApprovalEvent:
+			required := approvals.Request(run, e)
+
+			fmt.Println("\n" + strings.Repeat("=", 70))
+			fmt.Printf("APPROVAL REQUIRED: %s (%s)\n", e.ToolName, e.ApprovalID)
+			fmt.Println(e.ValidationResult.Message)
+			if args, ok := e.ValidationResult.Values.(map[string]interface{}); ok {
+				for key, value := range args {
+					fmt.Printf("  %s: %v\n", key, value)
+				}
+			}
+			fmt.Println(strings.Repeat("=", 70))
+
+			// In a real system each approver decides from their own session; here they take turns
+			for resolved := false; !resolved; {
+				signed := approvals.Approvers(e.ApprovalID)
+				fmt.Printf("\nSign-offs: %d/%d %v\n", len(signed), required, signed)
+
+				approver := ask("Approver name: ")
+				answer := strings.ToLower(ask(fmt.Sprintf("%s, approve this action? (y/n): ", approver)))
+				approved := answer == "y" || answer == "yes"
+
+				resolved, err = approvals.SignOff(e.ApprovalID, approver, approved)
+				if err != nil {
+					fmt.Printf("⚠️  %v\n", err)
+					continue
+				}
+
+				switch {
+				case resolved && approved:
+					fmt.Println("✓ Action APPROVED")
+				case resolved:
+					fmt.Printf("✗ Action REJECTED by %s\n", approver)
+				default:
+					fmt.Printf("Approved by %s, waiting for a second approver\n", approver)
+				}
+			}
+		case *aigentic.ToolEvent:
+			fmt.Printf("[Tool executed: %s]\n", e.ToolName)
+		case *aigentic.ErrorEvent:
+			log.Printf("Error: %v", e.Err)
+		}
+	}
+
+	fmt.Printf("\nFinal Response: %s\n", fullResponse)
+	fmt.Println("\n✅ Example completed successfully!")
+}
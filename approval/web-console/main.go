@@ -0,0 +1,294 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func createSendEmailTool() aigentic.AgentTool {
+	type SendEmailInput struct {
+		To      string `json:"to" description:"Email recipient address"`
+		Subject string `json:"subject" description:"Email subject line"`
+		Body    string `json:"body" description:"Email body content"`
+	}
+
+	emailTool := aigentic.NewTool(
+		"send_email",
+		"Sends an email to a recipient with subject and body. Requires approval before sending.",
+		func(run *aigentic.AgentRun, input SendEmailInput) (string, error) {
+			time.Sleep(500 * time.Millisecond)
+			return fmt.Sprintf("Email successfully sent to %s with subject '%s'", input.To, input.Subject), nil
+		},
+	)
+	emailTool.RequireApproval = true
+	return emailTool
+}
+
+// PendingApproval is an approval request waiting for a decision in the console
+type PendingApproval struct {
+	ApprovalID string
+	AgentName  string
+	ToolName   string
+	Message    string
+	Parameters map[string]interface{}
+	Requested  time.Time
+	Token      string // random per approval and embedded in the form, so other sites cannot post a decision
+
+	run *aigentic.AgentRun
+}
+
+// CompletedRun is the final response of an agent run shown in the console
+type CompletedRun struct {
+	AgentName string
+	Response  string
+}
+
+// ApprovalConsole collects approvals from all running agents and resolves them from HTTP requests
+type ApprovalConsole struct {
+	mu        sync.Mutex
+	pending   map[string]*PendingApproval
+	completed []CompletedRun
+}
+
+func NewApprovalConsole() *ApprovalConsole {
+	return &ApprovalConsole{pending: make(map[string]*PendingApproval)}
+}
+
+// Watch consumes the run events, parking approvals in the console until an operator decides
+func (c *ApprovalConsole) Watch(agentName string, run *aigentic.AgentRun) {
+	var response string
+	for event := range run.Next() {
+		switch e := event.(type) {
+		case *aigentic.ContentEvent:
+			response += e.Content
+		case *aigentic.ApprovalEvent:
+			params, _ := e.ValidationResult.Values.(map[string]interface{})
+			token, err := newToken()
+			if err != nil {
+				// Without a token nobody could decide it, so reject rather than leave the run waiting
+				log.Printf("[%s] Generating approval token: %v", agentName, err)
+				run.Approve(e.ApprovalID, false)
+				continue
+			}
+			c.mu.Lock()
+			c.pending[e.ApprovalID] = &PendingApproval{
+				ApprovalID: e.ApprovalID,
+				AgentName:  agentName,
+				ToolName:   e.ToolName,
+				Message:    e.ValidationResult.Message,
+				Parameters: params,
+				Requested:  time.Now(),
+				Token:      token,
+				run:        run,
+			}
+			c.mu.Unlock()
+			fmt.Printf("⏳ %s is waiting for approval of %s (%s)\n", agentName, e.ToolName, e.ApprovalID)
+		case *aigentic.ToolEvent:
+			fmt.Printf("[%s] Tool executed: %s\n", agentName, e.ToolName)
+		case *aigentic.ErrorEvent:
+			log.Printf("[%s] Error: %v", agentName, e.Err)
+		}
+	}
+
+	c.mu.Lock()
+	c.completed = append(c.completed, CompletedRun{AgentName: agentName, Response: response})
+	c.mu.Unlock()
+	fmt.Printf("✓ %s finished\n", agentName)
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Decide approves or rejects a pending approval and resumes its run. The token must be
+// the one rendered in the approval's form.
+func (c *ApprovalConsole) Decide(approvalID, token string, approved bool) error {
+	c.mu.Lock()
+	approval, ok := c.pending[approvalID]
+	valid := ok && subtle.ConstantTimeCompare([]byte(approval.Token), []byte(token)) == 1
+	if valid {
+		delete(c.pending, approvalID)
+	}
+	c.mu.Unlock()
+
+	if !valid {
+		return fmt.Errorf("approval %s is not pending or the token is invalid", approvalID)
+	}
+
+	approval.run.Approve(approvalID, approved)
+	decision := "REJECTED"
+	if approved {
+		decision = "APPROVED"
+	}
+	fmt.Printf("%s %s for %s\n", decision, approval.ToolName, approval.AgentName)
+	return nil
+}
+
+// snapshot returns the pending approvals oldest first and the completed runs
+func (c *ApprovalConsole) snapshot() ([]*PendingApproval, []CompletedRun) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pending := make([]*PendingApproval, 0, len(c.pending))
+	for _, approval := range c.pending {
+		pending = append(pending, approval)
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].Requested.Before(pending[j].Requested)
+	})
+	return pending, append([]CompletedRun(nil), c.completed...)
+}
+
+var consoleTemplate = template.Must(template.New("console").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="3">
+<title>Approval Console</title>
+<style>
+body { font-family: sans-serif; margin: 2rem; }
+.approval { border: 1px solid #ccc; border-radius: 6px; padding: 1rem; margin-bottom: 1rem; }
+.message { color: #9a6700; }
+button { margin-right: 0.5rem; padding: 0.4rem 1rem; }
+</style>
+</head>
+<body>
+<h1>Approval Console</h1>
+<h2>Pending approvals ({{len .Pending}})</h2>
+{{range .Pending}}
+<div class="approval">
+  <strong>{{.ToolName}}</strong> requested by <em>{{.AgentName}}</em> at {{.Requested.Format "15:04:05"}}
+  {{if .Message}}<p class="message">{{.Message}}</p>{{end}}
+  <ul>{{range $key, $value := .Parameters}}<li><code>{{$key}}</code>: {{$value}}</li>{{end}}</ul>
+  <form method="post" action="/decide">
+    <input type="hidden" name="approval_id" value="{{.ApprovalID}}">
+    <input type="hidden" name="token" value="{{.Token}}">
+    <button name="decision" value="approve">Approve</button>
+    <button name="decision" value="reject">Reject</button>
+  </form>
+</div>
+{{else}}
+<p>No approvals waiting.</p>
+{{end}}
+<h2>Completed runs</h2>
+{{range .Completed}}<p><strong>{{.AgentName}}</strong>: {{.Response}}</p>{{else}}<p>None yet.</p>{{end}}
+</body>
+</html>`))
+
+func (c *ApprovalConsole) handleIndex(w http.ResponseWriter, r *http.Request) {
+	pending, completed := c.snapshot()
+	data := struct {
+		Pending   []*PendingApproval
+		Completed []CompletedRun
+	}{pending, completed}
+
+	if err := consoleTemplate.Execute(w, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (c *ApprovalConsole) handleDecide(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	approved := r.FormValue("decision") == "approve"
+	if err := c.Decide(r.FormValue("approval_id"), r.FormValue("token"), approved); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	addr := flag.String("addr", "127.0.0.1:8080", "Address the approval console listens on")
+	flag.Parse()
+
+	fmt.Println("Web Approval Console Example")
+	fmt.Println("============================")
+	fmt.Println()
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+	console := NewApprovalConsole()
+
+	requests := map[string]string{
+		"SalesAgent":   "Send an email to john@example.com with subject 'Quote' and body 'Please find our quote attached.'",
+		"SupportAgent": "Send an email to jane@example.com with subject 'Ticket resolved' and body 'Your ticket #4521 has been resolved.'",
+		"BillingAgent": "Send an email to accounts@example.com with subject 'Invoice overdue' and body 'Invoice INV-1001 is 30 days overdue.'",
+	}
+
+	var wg sync.WaitGroup
+	for name, request := range requests {
+		agent := aigentic.Agent{
+			Model:        model,
+			Name:         name,
+			Description:  "An agent that can send emails with approval",
+			Instructions: "You can send emails using the send_email tool. Always use the tool when asked to send an email.",
+			AgentTools:   []aigentic.AgentTool{createSendEmailTool()},
+		}
+
+		run, err := agent.Start(request)
+		if err != nil {
+			log.Fatalf("Failed to start %s: %v", name, err)
+		}
+
+		wg.Add(1)
+		go func(name string, run *aigentic.AgentRun) {
+			defer wg.Done()
+			console.Watch(name, run)
+		}(name, run)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", console.handleIndex)
+	mux.HandleFunc("/decide", console.handleDecide)
+	server := &http.Server{Addr: *addr, Handler: mux}
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Approval console failed: %v", err)
+		}
+	}()
+	url := "http://" + *addr
+	if strings.HasPrefix(*addr, ":") {
+		url = "http://localhost" + *addr
+	}
+	fmt.Printf("Open %s to review pending approvals\n\n", url)
+
+	wg.Wait()
+	server.Close()
+
+	fmt.Println("\n✅ Example completed successfully!")
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+// callbackTTL is how long approve/deny links stay valid
+const callbackTTL = 30 * time.Minute
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func createSendEmailTool() aigentic.AgentTool {
+	type SendEmailInput struct {
+		To      string `json:"to" description:"Email recipient address"`
+		Subject string `json:"subject" description:"Email subject line"`
+		Body    string `json:"body" description:"Email body content"`
+	}
+
+	emailTool := aigentic.NewTool(
+		"send_email",
+		"Sends an email to a recipient with subject and body. Requires approval before sending.",
+		func(run *aigentic.AgentRun, input SendEmailInput) (string, error) {
+			time.Sleep(500 * time.Millisecond)
+			return fmt.Sprintf("Email successfully sent to %s with subject '%s'", input.To, input.Subject), nil
+		},
+	)
+	emailTool.RequireApproval = true
+	return emailTool
+}
+
+// callbackRequest is an approval waiting for its callback
+type callbackRequest struct {
+	run     *aigentic.AgentRun
+	token   string
+	expires time.Time
+}
+
+// WebhookApprover sends approval requests to an external system and resumes the run when
+// one of the approve/deny links it sent is called back. Each link carries a random
+// single-use token, so only the recipient of the request can resolve it.
+type WebhookApprover struct {
+	webhookURL string // empty prints the links instead, as an email would contain them
+	publicURL  string // base URL the callback handler is reachable at
+
+	mu      sync.Mutex
+	pending map[string]*callbackRequest // by approval ID
+}
+
+func NewWebhookApprover(webhookURL, publicURL string) *WebhookApprover {
+	return &WebhookApprover{
+		webhookURL: webhookURL,
+		publicURL:  publicURL,
+		pending:    make(map[string]*callbackRequest),
+	}
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (w *WebhookApprover) callbackURL(approvalID, decision, token string) string {
+	query := url.Values{
+		"approval_id": {approvalID},
+		"decision":    {decision},
+		"token":       {token},
+	}
+	return w.publicURL + "/approvals/callback?" + query.Encode()
+}
+
+// RequestApproval registers the approval and delivers the approve/deny links
+func (w *WebhookApprover) RequestApproval(run *aigentic.AgentRun, e *aigentic.ApprovalEvent) error {
+	token, err := newToken()
+	if err != nil {
+		return fmt.Errorf("generating callback token: %w", err)
+	}
+
+	expires := time.Now().Add(callbackTTL)
+	w.mu.Lock()
+	w.pending[e.ApprovalID] = &callbackRequest{run: run, token: token, expires: expires}
+	w.mu.Unlock()
+
+	payload := map[string]interface{}{
+		"approval_id": e.ApprovalID,
+		"run_id":      e.RunID,
+		"tool_name":   e.ToolName,
+		"arguments":   e.ValidationResult.Values,
+		"message":     e.ValidationResult.Message,
+		"approve_url": w.callbackURL(e.ApprovalID, "approve", token),
+		"deny_url":    w.callbackURL(e.ApprovalID, "deny", token),
+		"expires_at":  expires.UTC().Format(time.RFC3339),
+	}
+
+	if w.webhookURL == "" {
+		fmt.Printf("\n📧 Approval request for %s (%s)\n", e.ToolName, e.ApprovalID)
+		fmt.Printf("   Approve: %s\n", payload["approve_url"])
+		fmt.Printf("   Deny:    %s\n", payload["deny_url"])
+		return nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := http.Post(w.webhookURL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("calling webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	fmt.Printf("Sent approval request for %s to the webhook, waiting for the callback...\n", e.ToolName)
+	return nil
+}
+
+// HandleCallback validates the token and resumes the waiting run
+func (w *WebhookApprover) HandleCallback(rw http.ResponseWriter, r *http.Request) {
+	approvalID := r.FormValue("approval_id")
+	decision := r.FormValue("decision")
+	if decision != "approve" && decision != "deny" {
+		http.Error(rw, "decision must be approve or deny", http.StatusBadRequest)
+		return
+	}
+
+	w.mu.Lock()
+	request, ok := w.pending[approvalID]
+	valid := ok && subtle.ConstantTimeCompare([]byte(request.token), []byte(r.FormValue("token"))) == 1
+	expired := valid && time.Now().After(request.expires)
+	if valid {
+		// Tokens are single use, even when expired
+		delete(w.pending, approvalID)
+	}
+	w.mu.Unlock()
+
+	if !valid {
+		log.Printf("Rejected callback for %q: unknown approval or invalid token", approvalID)
+		http.Error(rw, "invalid or already used approval link", http.StatusForbidden)
+		return
+	}
+
+	approved := decision == "approve"
+	if expired {
+		// The run is still waiting, so resolve it safely rather than leaving it blocked
+		approved = false
+		log.Printf("Callback for %s arrived after the link expired, rejecting", approvalID)
+	}
+	request.run.Approve(approvalID, approved)
+
+	result := "denied"
+	if approved {
+		result = "approved"
+	}
+	fmt.Printf("Callback: %s %s\n", approvalID, result)
+	fmt.Fprintf(rw, "Approval %s %s. You can close this page.\n", approvalID, result)
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	addr := flag.String("addr", ":8090", "Address the callback handler listens on")
+	publicURL := flag.String("public-url", "http://localhost:8090", "Base URL the approve/deny links point to")
+	flag.Parse()
+
+	fmt.Println("Webhook Callback Approval Example")
+	fmt.Println("=================================")
+	fmt.Println()
+
+	// Without a webhook the links are printed, as they would appear in an approval email
+	approver := NewWebhookApprover(os.Getenv("APPROVAL_WEBHOOK_URL"), *publicURL)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/approvals/callback", approver.HandleCallback)
+	server := &http.Server{Addr: *addr, Handler: mux}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Callback handler failed: %v", err)
+		}
+	}()
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:        model,
+		Name:         "EmailAgent",
+		Description:  "An agent that can send emails with approval",
+		Instructions: "You can send emails using the send_email tool. Always use the tool when asked to send an email.",
+		AgentTools: []aigentic.AgentTool{
+			createSendEmailTool(),
+		},
+	}
+
+	run, err := agent.Start("Send an email to john@example.com with subject 'Project Update' and body 'The project is on track and will be completed by end of week.'")
+	if err != nil {
+		log.Fatalf("Failed to start agent: %v", err)
+	}
+
+	var fullResponse string
+	for event := range run.Next() {
+		switch e := event.(type) {
+		case *aigentic.ContentEvent:
+			fullResponse += e.Content
+		case *aigentic.ApprovalEvent:
+			if err := approver.RequestApproval(run, e); err != nil {
+				log.Printf("Failed to send approval request, rejecting: %v", err)
+				run.Approve(e.ApprovalID, false)
+			}
+		case *aigentic.ToolEvent:
+			fmt.Printf("[Tool executed: %s]\n", e.ToolName)
+		case *aigentic.ErrorEvent:
+			log.Printf("Error: %v", e.Err)
+		}
+	}
+
+	server.Close()
+
+	fmt.Printf("\nFinal Response: %s\n", fullResponse)
+	fmt.Println("\n✅ Example completed successfully!")
+}
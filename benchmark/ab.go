@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic/ai"
+)
+
+const (
+	abReportFilename = "ab_report.md"
+	abAlpha          = 0.05 // significance level
+)
+
+// abSample is the outcome of running one capability N times against one model
+type abSample struct {
+	passes    int
+	runs      int
+	latencies []float64 // seconds
+}
+
+// runABMode runs each capability runs times against both models and reports whether the
+// pass-rate and latency differences are statistically significant
+func runABMode(models []*ai.Model, capabilitiesToRun []Capability, runs int) {
+	if len(models) != 2 {
+		fmt.Println("❌ -ab needs exactly two models")
+		os.Exit(1)
+	}
+	if runs < 2 {
+		fmt.Println("❌ -runs must be at least 2 for -ab")
+		os.Exit(1)
+	}
+
+	modelA, modelB := models[0], models[1]
+	fmt.Printf("⚖️  A/B comparison: %s vs %s, %d runs per test\n", modelA.ModelName, modelB.ModelName, runs)
+	fmt.Println("=" + strings.Repeat("=", 40))
+
+	report := "# A/B Comparison Report\n\n"
+	report += fmt.Sprintf("Generated on: %s\n\n", time.Now().Format("2006-01-02 15:04:05"))
+	report += fmt.Sprintf("A = %s, B = %s, %d runs per test, significance level %.2f. ", modelA.ModelName, modelB.ModelName, runs, abAlpha)
+	report += "Pass rates are compared with Fisher's exact test and latencies with the Mann-Whitney U test.\n\n"
+	report += "| Capability | Pass rate A | Pass rate B | p | Median latency A | Median latency B | p |\n"
+	report += "|---|---|---|---|---|---|---|\n"
+
+	for _, capability := range capabilitiesToRun {
+		fmt.Printf("\n🔬 %s\n", capability.Name)
+		if err := capability.setup(); err != nil {
+			fmt.Printf("  ❌ %v\n", err)
+			continue
+		}
+		a := runABSample(capability, modelA, runs)
+		b := runABSample(capability, modelB, runs)
+		capability.teardown()
+
+		passP := fisherExact(a.passes, a.runs-a.passes, b.passes, b.runs-b.passes)
+		latencyP := mannWhitneyU(a.latencies, b.latencies)
+
+		rateA := float64(a.passes) / float64(a.runs) * 100
+		rateB := float64(b.passes) / float64(b.runs) * 100
+		medianA := median(a.latencies)
+		medianB := median(b.latencies)
+
+		fmt.Printf("  Pass rate: %.0f%% vs %.0f%% (p=%.3f) %s\n", rateA, rateB, passP, significance(passP))
+		fmt.Printf("  Latency:   %.1fs vs %.1fs median (p=%.3f) %s\n", medianA, medianB, latencyP, significance(latencyP))
+
+		report += fmt.Sprintf("| %s | %.0f%% | %.0f%% | %s | %.1fs | %.1fs | %s |\n",
+			capability.Name, rateA, rateB, formatP(passP), medianA, medianB, formatP(latencyP))
+	}
+
+	report += "\n**bold** p-values are significant; the others may be noise from a single run.\n"
+
+	if err := os.WriteFile(abReportFilename, []byte(report), 0644); err != nil {
+		fmt.Printf("Error writing A/B report: %v\n", err)
+		return
+	}
+
+	fmt.Printf("\n📊 A/B report generated: %s\n", abReportFilename)
+}
+
+func runABSample(capability Capability, model *ai.Model, runs int) abSample {
+	sample := abSample{runs: runs}
+	for i := 0; i < runs; i++ {
+		fmt.Printf("  %s run %d/%d... ", model.ModelName, i+1, runs)
+		result, err := capability.RunFunction(model)
+		if err == nil && result.Success {
+			sample.passes++
+			fmt.Printf("✅ (%v)\n", result.Duration)
+		} else {
+			fmt.Printf("❌ (%v)\n", result.Duration)
+		}
+		sample.latencies = append(sample.latencies, result.Duration.Seconds())
+	}
+	return sample
+}
+
+func significance(p float64) string {
+	if p < abAlpha {
+		return "✅ significant"
+	}
+	return "➖ not significant"
+}
+
+func formatP(p float64) string {
+	if p < abAlpha {
+		return fmt.Sprintf("**%.3f**", p)
+	}
+	return fmt.Sprintf("%.3f", p)
+}
+
+// fisherExact returns the two-sided p-value of Fisher's exact test for the 2x2 table
+// [[a, b], [c, d]] (passes and failures of A, passes and failures of B)
+func fisherExact(a, b, c, d int) float64 {
+	row1, row2, col1 := a+b, c+d, a+c
+	n := row1 + row2
+
+	observed := hypergeometric(a, row1, row2, col1, n)
+	p := 0.0
+	for x := max(0, col1-row2); x <= min(row1, col1); x++ {
+		prob := hypergeometric(x, row1, row2, col1, n)
+		// Allow for floating point error when comparing with the observed table
+		if prob <= observed*(1+1e-7) {
+			p += prob
+		}
+	}
+	return math.Min(p, 1)
+}
+
+// hypergeometric is the probability of x passes in row 1 given the table margins
+func hypergeometric(x, row1, row2, col1, n int) float64 {
+	return math.Exp(logChoose(row1, x) + logChoose(row2, col1-x) - logChoose(n, col1))
+}
+
+func logChoose(n, k int) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
+
+// mannWhitneyU returns the two-sided p-value of the Mann-Whitney U test using the normal
+// approximation with tie and continuity correction
+func mannWhitneyU(a, b []float64) float64 {
+	n1, n2 := float64(len(a)), float64(len(b))
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+
+	type value struct {
+		v     float64
+		fromA bool
+	}
+	var values []value
+	for _, v := range a {
+		values = append(values, value{v, true})
+	}
+	for _, v := range b {
+		values = append(values, value{v, false})
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].v < values[j].v })
+
+	// Assign average ranks to ties
+	rankSumA := 0.0
+	tieTerm := 0.0
+	for i := 0; i < len(values); {
+		j := i
+		for j < len(values) && values[j].v == values[i].v {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if values[k].fromA {
+				rankSumA += rank
+			}
+		}
+		t := float64(j - i)
+		tieTerm += t*t*t - t
+		i = j
+	}
+
+	n := n1 + n2
+	u := rankSumA - n1*(n1+1)/2
+	mean := n1 * n2 / 2
+	sigma := math.Sqrt(n1 * n2 / 12 * ((n + 1) - tieTerm/(n*(n-1))))
+	if sigma == 0 {
+		return 1
+	}
+
+	z := (math.Abs(u-mean) - 0.5) / sigma
+	if z < 0 {
+		z = 0
+	}
+	return math.Erfc(z / math.Sqrt2)
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
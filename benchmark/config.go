@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic-examples/benchmark/core"
+	"github.com/nexxia-ai/aigentic/ai"
+	"gopkg.in/yaml.v3"
+)
+
+// RunConfig is a reproducible benchmark run declared in a YAML file such as bench.yaml.
+// Command-line flags given explicitly override the matching config values.
+type RunConfig struct {
+	Tests       []string      `yaml:"tests"`        // Test names to run; empty runs all
+	Tags        []string      `yaml:"tags"`         // Runs tests that have any of these tags
+	Models      []string      `yaml:"models"`       // Used when no models are given as arguments
+	Runs        int           `yaml:"runs"`         // Times each test is run per model (default 1)
+	RetryFailed int           `yaml:"retry_failed"` // Same as -retry-failed
+	Timeout     time.Duration `yaml:"timeout"`      // Per-run limit, e.g. "2m" (0 = no limit)
+	MaxCost     float64       `yaml:"max_cost"`     // Same as -max-cost
+	Temperature *float64      `yaml:"temperature"`  // Same as -temperature
+	Seed        *int64        `yaml:"seed"`         // Same as -seed
+	Judge       bool          `yaml:"judge"`        // Same as -judge
+	ScoreModel  string        `yaml:"score_model"`  // Same as -score-model
+	Thresholds  Thresholds    `yaml:"thresholds"`
+}
+
+// Thresholds make a run fail when results fall short, so a committed config can gate CI
+type Thresholds struct {
+	MinPassRate   float64       `yaml:"min_pass_rate"`   // Fraction of runs that must pass per test and model, 0-1
+	MaxDuration   time.Duration `yaml:"max_duration"`    // Longest acceptable average duration of a test
+	MinJudgeScore float64       `yaml:"min_judge_score"` // Lowest acceptable judge score, 0-10 (needs judge)
+}
+
+// loadRunConfig reads and validates a run configuration file
+func loadRunConfig(path string) (*RunConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config RunConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	if config.Runs == 0 {
+		config.Runs = 1
+	}
+	if config.Runs < 0 {
+		return nil, fmt.Errorf("%s: runs must be positive, got %d", path, config.Runs)
+	}
+	if config.Thresholds.MinPassRate < 0 || config.Thresholds.MinPassRate > 1 {
+		return nil, fmt.Errorf("%s: min_pass_rate must be between 0 and 1, got %v", path, config.Thresholds.MinPassRate)
+	}
+	return &config, nil
+}
+
+// runWithTimeout runs the capability, giving up after the timeout. The abandoned run keeps
+// going in the background because capabilities do not take a context.
+func runWithTimeout(capability Capability, model *ai.Model, timeout time.Duration) (core.BenchResult, error) {
+	if timeout <= 0 {
+		return capability.RunFunction(model)
+	}
+
+	type outcome struct {
+		result core.BenchResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := capability.RunFunction(model)
+		done <- outcome{result, err}
+	}()
+
+	select {
+	case o := <-done:
+		return o.result, o.err
+	case <-time.After(timeout):
+		err := fmt.Errorf("run timed out after %v", timeout)
+		return core.BenchResult{
+			TestCase:     capability.Name,
+			ModelName:    model.ModelName,
+			Duration:     timeout,
+			ErrorMessage: err.Error(),
+		}, err
+	}
+}
+
+// checkThresholds returns one message per result that misses a threshold
+func checkThresholds(results [][]core.BenchResult, thresholds Thresholds) []string {
+	var violations []string
+	for _, modelResults := range results {
+		for _, result := range modelResults {
+			if skipped, _ := result.Metadata["skipped"].(bool); skipped {
+				continue
+			}
+
+			if thresholds.MinPassRate > 0 {
+				passRate := 0.0
+				if rate, ok := result.Metadata["pass_rate"].(float64); ok {
+					passRate = rate
+				} else if result.Success {
+					passRate = 1
+				}
+				if passRate < thresholds.MinPassRate {
+					violations = append(violations, fmt.Sprintf("%s on %s: pass rate %.0f%% below %.0f%%",
+						result.TestCase, result.ModelName, passRate*100, thresholds.MinPassRate*100))
+				}
+			}
+
+			if thresholds.MaxDuration > 0 && result.Duration > thresholds.MaxDuration {
+				violations = append(violations, fmt.Sprintf("%s on %s: took %v, limit %v",
+					result.TestCase, result.ModelName, result.Duration.Round(100*time.Millisecond), thresholds.MaxDuration))
+			}
+
+			if thresholds.MinJudgeScore > 0 {
+				if score, ok := result.Metadata["judge_score"].(float64); ok && score < thresholds.MinJudgeScore {
+					violations = append(violations, fmt.Sprintf("%s on %s: judge score %.1f below %.1f",
+						result.TestCase, result.ModelName, score, thresholds.MinJudgeScore))
+				}
+			}
+		}
+	}
+	return violations
+}
+
+// joinNames turns config lists back into the comma-separated form the flags use
+func joinNames(names []string) string {
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,133 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic/ai"
+)
+
+// closedBookQuestion is a question answered without tools or documents. Questions without
+// expected answers are trick questions the model should decline with "I don't know".
+type closedBookQuestion struct {
+	question string
+	answers  []string // Any of these keywords counts as correct
+}
+
+var closedBookQuestions = []closedBookQuestion{
+	{question: "What is the chemical symbol for gold?", answers: []string{"Au"}},
+	{question: "Who wrote the novel 'Pride and Prejudice'?", answers: []string{"Austen"}},
+	{question: "How many continents are there on Earth?", answers: []string{"7", "seven"}},
+	{question: "What is the boiling point of water at sea level in degrees Celsius?", answers: []string{"100"}},
+	{question: "What was the name of the first cat to walk on the Moon?"},
+	{question: "In what year did Albert Einstein win his second Nobel Prize?"},
+	{question: "What is the phone number of the Zubrovian embassy in Lisbon?"},
+	{question: "What did the founder of Nexxia eat for breakfast on 3 March 2019?"},
+}
+
+// closedBookPrompts returns the questions asked by the ClosedBook capability
+func closedBookPrompts() []string {
+	var prompts []string
+	for _, q := range closedBookQuestions {
+		prompts = append(prompts, q.question)
+	}
+	return prompts
+}
+
+// declinePhrases are the ways a model can say it does not know the answer
+var declinePhrases = []string{"i don't know", "i do not know", "i don’t know", "i dont know"}
+
+// NewClosedBookAgent creates an agent that answers from its own knowledge only
+func NewClosedBookAgent(model *ai.Model) aigentic.Agent {
+	return aigentic.Agent{
+		Model:       model,
+		Name:        "closed_book",
+		Description: "You are a careful assistant that answers questions from your own knowledge.",
+		Instructions: "Answer each question briefly from your own knowledge. " +
+			"If you do not know the answer, or the question has no factual answer, respond with exactly: I don't know. " +
+			"Never make up information.",
+		Tracer: aigentic.NewTracer(),
+	}
+}
+
+// RunClosedBook asks factual and trick questions and scores answer and refusal correctness
+func RunClosedBook(model *ai.Model) (BenchResult, error) {
+	start := time.Now()
+
+	agent := NewClosedBookAgent(model)
+
+	var responses []string
+	var runs []*aigentic.AgentRun
+	factualCorrect, factualTotal := 0, 0
+	refusalCorrect, refusalTotal := 0, 0
+	hallucinations := []string{}
+
+	for _, q := range closedBookQuestions {
+		run, err := agent.Start(q.question)
+		if err != nil {
+			result := CreateBenchResult("ClosedBook", model, start, strings.Join(responses, " | "), err)
+			recordTrace(&result, runs...)
+			recordPrompt(&result, agent, closedBookPrompts()...)
+			return result, err
+		}
+		runs = append(runs, run)
+
+		response, err := waitForRun("ClosedBook", model, run)
+		if err != nil {
+			result := CreateBenchResult("ClosedBook", model, start, strings.Join(responses, " | "), err)
+			recordTrace(&result, runs...)
+			recordPrompt(&result, agent, closedBookPrompts()...)
+			return result, err
+		}
+		responses = append(responses, response)
+
+		declined := containsAny(response, declinePhrases)
+		if len(q.answers) == 0 {
+			refusalTotal++
+			if declined {
+				refusalCorrect++
+			} else {
+				hallucinations = append(hallucinations, q.question)
+			}
+			continue
+		}
+
+		factualTotal++
+		if !declined && containsAny(response, q.answers) {
+			factualCorrect++
+		}
+	}
+
+	allResponses := strings.Join(responses, " | ")
+	result := CreateBenchResult("ClosedBook", model, start, allResponses, nil)
+	recordTrace(&result, runs...)
+	recordPrompt(&result, agent, closedBookPrompts()...)
+
+	accuracy := float64(factualCorrect+refusalCorrect) / float64(factualTotal+refusalTotal)
+	result.Metadata["factual_accuracy"] = float64(factualCorrect) / float64(factualTotal)
+	result.Metadata["refusal_accuracy"] = float64(refusalCorrect) / float64(refusalTotal)
+	result.Metadata["accuracy"] = accuracy
+	result.Metadata["hallucinations"] = hallucinations
+	result.Metadata["response_preview"] = TruncateString(allResponses, 150)
+
+	// Consider 75%+ overall accuracy as success
+	if accuracy < 0.75 {
+		result.Success = false
+		result.ErrorMessage = fmt.Sprintf("closed-book accuracy %.0f%% (%d/%d factual, %d/%d refusals)",
+			accuracy*100, factualCorrect, factualTotal, refusalCorrect, refusalTotal)
+	}
+
+	return result, nil
+}
+
+// containsAny checks if the response contains any of the keywords (case-insensitive)
+func containsAny(response string, keywords []string) bool {
+	for _, keyword := range keywords {
+		if ValidateResponse(response, keyword) == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -8,39 +8,54 @@ import (
 	"github.com/nexxia-ai/aigentic/ai"
 )
 
-func RunConcurrentRuns(model *ai.Model) (BenchResult, error) {
-	start := time.Now()
+// concurrentRunRequests are the messages started in parallel against the same agent
+var concurrentRunRequests = []struct {
+	name        string
+	message     string
+	expectsTool bool
+}{
+	{
+		name:        "tool call request",
+		message:     "What is the name of the company with the number 150? Use tools.",
+		expectsTool: true,
+	},
+	{
+		name:        "simple question",
+		message:     "What is the capital of France? respond with the name of the city only",
+		expectsTool: false,
+	},
+	{
+		name:        "another simple question",
+		message:     "What is 2 + 2? respond with the answer only",
+		expectsTool: false,
+	},
+}
+
+// concurrentRunMessages returns the messages of the concurrent run requests
+func concurrentRunMessages() []string {
+	var messages []string
+	for _, request := range concurrentRunRequests {
+		messages = append(messages, request.message)
+	}
+	return messages
+}
 
-	var agent = aigentic.Agent{
+// newConcurrentRunsAgent creates the agent shared by all concurrent runs
+func newConcurrentRunsAgent(model *ai.Model) aigentic.Agent {
+	return aigentic.Agent{
 		Model:        model,
 		Description:  "You are a helpful assistant that can perform various tasks.",
 		Instructions: "use tools when requested.",
 		AgentTools:   []aigentic.AgentTool{NewCompanyNameTool()},
 		Tracer:       aigentic.NewTracer(),
 	}
+}
 
-	// Define multiple sequential runs
-	runs := []struct {
-		name        string
-		message     string
-		expectsTool bool
-	}{
-		{
-			name:        "tool call request",
-			message:     "What is the name of the company with the number 150? Use tools.",
-			expectsTool: true,
-		},
-		{
-			name:        "simple question",
-			message:     "What is the capital of France? respond with the name of the city only",
-			expectsTool: false,
-		},
-		{
-			name:        "another simple question",
-			message:     "What is 2 + 2? respond with the answer only",
-			expectsTool: false,
-		},
-	}
+func RunConcurrentRuns(model *ai.Model) (BenchResult, error) {
+	start := time.Now()
+
+	agent := newConcurrentRunsAgent(model)
+	runs := concurrentRunRequests
 
 	// Start all runs first (parallel execution)
 	var agentRuns []*aigentic.AgentRun
@@ -57,10 +72,12 @@ func RunConcurrentRuns(model *ai.Model) (BenchResult, error) {
 	// Now wait for all runs to complete (parallel waiting)
 	responses := make([]string, len(agentRuns))
 	for i, agentRun := range agentRuns {
-		response, err := agentRun.Wait(0)
+		response, err := waitForRun("ConcurrentRuns", model, agentRun)
 		if err != nil {
 			result := CreateBenchResult("ConcurrentRuns", model, start, "", err)
 			result.ErrorMessage = "Wait for run failed: " + err.Error()
+			recordTrace(&result, agentRun)
+			recordPrompt(&result, agent, concurrentRunMessages()...)
 			return result, err
 		}
 		responses[i] = response
@@ -69,6 +86,8 @@ func RunConcurrentRuns(model *ai.Model) (BenchResult, error) {
 	// Verify all responses
 	if len(responses) != len(runs) {
 		result := CreateBenchResult("ConcurrentRuns", model, start, "", nil)
+		recordTrace(&result, agentRuns...)
+		recordPrompt(&result, agent, concurrentRunMessages()...)
 		result.Success = false
 		result.ErrorMessage = "Should have responses for all runs"
 		return result, nil
@@ -85,6 +104,8 @@ func RunConcurrentRuns(model *ai.Model) (BenchResult, error) {
 
 	if !foundToolCall {
 		result := CreateBenchResult("ConcurrentRuns", model, start, "", nil)
+		recordTrace(&result, agentRuns...)
+		recordPrompt(&result, agent, concurrentRunMessages()...)
 		result.Success = false
 		result.ErrorMessage = "Should have found a response with tool call result"
 		return result, nil
@@ -94,12 +115,16 @@ func RunConcurrentRuns(model *ai.Model) (BenchResult, error) {
 	for _, response := range responses {
 		if strings.Contains(response, "Error:") {
 			result := CreateBenchResult("ConcurrentRuns", model, start, "", nil)
+			recordTrace(&result, agentRuns...)
+			recordPrompt(&result, agent, concurrentRunMessages()...)
 			result.Success = false
 			result.ErrorMessage = "Run should not contain error"
 			return result, nil
 		}
 		if response == "" {
 			result := CreateBenchResult("ConcurrentRuns", model, start, "", nil)
+			recordTrace(&result, agentRuns...)
+			recordPrompt(&result, agent, concurrentRunMessages()...)
 			result.Success = false
 			result.ErrorMessage = "Run should have non-empty response"
 			return result, nil
@@ -108,6 +133,8 @@ func RunConcurrentRuns(model *ai.Model) (BenchResult, error) {
 
 	allResponses := strings.Join(responses, " | ")
 	result := CreateBenchResult("ConcurrentRuns", model, start, allResponses, nil)
+	recordTrace(&result, agentRuns...)
+	recordPrompt(&result, agent, concurrentRunMessages()...)
 
 	result.Metadata["num_runs"] = len(runs)
 	result.Metadata["tool_call_found"] = foundToolCall
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/document"
+)
+
+const (
+	contextOverflowPrompt = "What is the project codename stated at the start of the attached document? " +
+		"If the document is too long for you to read in full, say so explicitly."
+	contextOverflowCodename = "BLUEFIN"
+	contextOverflowTokens   = 200000 // well above the context window of small local models
+	contextOverflowTimeout  = 5 * time.Minute
+)
+
+// Phrases showing the agent noticed it could not read the whole document
+var contextOverflowNotices = []string{"too long", "truncat", "summar", "exceed", "cannot read", "can't read", "unable to read", "only part", "partial"}
+
+// Error keywords showing the library or provider reported the overflow clearly
+var contextOverflowErrors = []string{"context", "token", "too long", "too large", "maximum", "length", "limit"}
+
+// newContextOverflowDocument builds a document with the codename at the start followed by
+// filler text of roughly the given number of tokens
+func newContextOverflowDocument(tokens int) *document.Document {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("PROJECT BRIEF. The project codename is %s.\n\n", contextOverflowCodename))
+
+	paragraph := "This section records routine status notes for the quarterly operations review. " +
+		"Inventory levels, shipping schedules and staffing rosters were checked and no changes were required.\n"
+	for i := 0; builder.Len() < tokens*4; i++ {
+		builder.WriteString(fmt.Sprintf("Section %d. %s", i+1, paragraph))
+	}
+
+	return document.NewInMemoryDocument("", "project_brief.txt", []byte(builder.String()), nil)
+}
+
+// NewContextOverflowAgent creates an agent with a document far larger than most context windows
+func NewContextOverflowAgent(model *ai.Model) aigentic.Agent {
+	return aigentic.Agent{
+		Model:       model,
+		Name:        "context_overflow",
+		Description: "You are an assistant that answers questions about attached documents.",
+		Instructions: "Answer from the attached document only. " +
+			"If the document does not fit in your context, say that it was too long and answer from the part you could read.",
+		Documents: []*document.Document{newContextOverflowDocument(contextOverflowTokens)},
+		Tracer:    aigentic.NewTracer(),
+	}
+}
+
+// RunContextOverflow exceeds the model's context window and scores how the failure surfaces:
+// a graceful answer or an explicit notice, a clean error, or a silent failure such as an empty
+// response, a timeout or a made-up answer
+func RunContextOverflow(model *ai.Model) (BenchResult, error) {
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), contextOverflowTimeout)
+	defer cancel()
+
+	agent := NewContextOverflowAgent(model)
+	agent.Session = aigentic.NewSession(ctx)
+
+	response := ""
+	run, err := agent.Start(contextOverflowPrompt)
+	if err == nil {
+		response, err = waitForRun("ContextOverflow", model, run)
+	}
+
+	result := CreateBenchResult("ContextOverflow", model, start, response, nil)
+	recordTrace(&result, run)
+	recordPrompt(&result, agent, contextOverflowPrompt)
+	result.Metadata["document_tokens"] = contextOverflowTokens
+	result.Metadata["response_preview"] = TruncateString(response, 150)
+
+	outcome := "silent_failure"
+	switch {
+	case err != nil && ctx.Err() != nil:
+		result.ErrorMessage = fmt.Sprintf("run timed out after %v: %v", contextOverflowTimeout, err)
+	case err != nil && containsAny(err.Error(), contextOverflowErrors):
+		outcome = "clean_error"
+		result.Metadata["error"] = err.Error()
+	case err != nil:
+		result.ErrorMessage = "overflow surfaced as an unclear error: " + err.Error()
+	case strings.TrimSpace(response) == "":
+		result.ErrorMessage = "empty response without an error"
+	case containsAny(response, []string{contextOverflowCodename}):
+		outcome = "answered"
+	case containsAny(response, contextOverflowNotices):
+		outcome = "graceful_notice"
+	default:
+		result.ErrorMessage = "response neither answered nor reported the overflow"
+	}
+
+	result.Metadata["outcome"] = outcome
+	result.Success = outcome != "silent_failure"
+
+	return result, nil
+}
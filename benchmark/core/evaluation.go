@@ -0,0 +1,155 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/evals"
+)
+
+// newEvalSuite creates an evaluation suite with the universal checks shared by all capabilities
+func newEvalSuite(name string) *evals.EvalSuite {
+	evalSuite := evals.NewEvalSuite(fmt.Sprintf("%s Evaluation", name))
+	evalSuite.AddCheck("no errors", evals.NoErrors())
+	evalSuite.AddCheck("responds quickly", evals.LatencyUnder(60*time.Second))
+	return evalSuite
+}
+
+// evaluateAgent runs the agent with evaluation enabled and scores the EvalEvents
+// emitted by evalAgentName against the suite
+func evaluateAgent(agent aigentic.Agent, evalAgentName string, userMessage string, evalSuite *evals.EvalSuite, name string) AgentTestResult {
+	result := AgentTestResult{
+		Name:   name,
+		Failed: []string{},
+	}
+
+	agent.EnableEvaluation = true
+
+	// Start the run
+	run, err := agent.Start(userMessage)
+	if err != nil {
+		result.ErrorCount = 1
+		result.Failed = append(result.Failed, fmt.Sprintf("Start error: %v", err))
+		return result
+	}
+
+	// Process evaluation events (deferred evaluation)
+	processor := evalSuite.NewProcessor()
+	content := ""
+	errorCount := 0
+
+	// Process events until completion (no evaluation during loop)
+	for event := range run.Next() {
+		logEvent(name, agent.Model, event)
+
+		switch ev := event.(type) {
+		case *aigentic.ContentEvent:
+			if agent.Stream {
+				content += ev.Content
+			} else {
+				content = ev.Content
+			}
+		case *aigentic.EvalEvent:
+			if ev.AgentName == evalAgentName {
+				processor.ProcessEventWithHistory(*ev)
+			}
+		case *aigentic.ApprovalEvent:
+			run.Approve(ev.ApprovalID, true)
+		case *aigentic.ErrorEvent:
+			errorCount++
+		}
+	}
+
+	// Get final evaluation summary (all calculations happen here)
+	summary := processor.GetSummary()
+
+	// Calculate metrics
+	result.PassRate = summary.PassRate
+	result.AvgScore = summary.AverageScore
+	result.Duration = summary.TotalDuration
+	result.ErrorCount = errorCount
+	result.Content = content
+	result.TraceFile = run.TraceFilepath()
+	result.Success = summary.PassRate >= 60.0 // Consider 60%+ as success
+
+	// Calculate accuracy and relevance scores
+	result.AccuracyScore, result.RelevanceScore = evals.CalculateAccuracyRelevance(summary.Results)
+
+	// Show call-by-call breakdown using the new deferred evaluation system
+	fmt.Printf("      📋 Call-by-Call Evaluation Results:\n")
+	callResults := processor.GetCallResults()
+	for _, callResult := range callResults {
+		fmt.Printf("         📞 Call #%d (%s) - Pass: %.1f%%, Score: %.2f\n",
+			callResult.CallNumber, callResult.Timestamp.Format("15:04:05"),
+			callResult.PassRate, callResult.AvgScore)
+
+		// Show individual check results for this call
+		for _, evalResult := range callResult.Results {
+			if evalResult.Passed {
+				fmt.Printf("            ✅ %s: PASSED\n", evalResult.CheckName)
+			} else {
+				fmt.Printf("            ❌ %s: FAILED - %s\n", evalResult.CheckName, evalResult.Message)
+			}
+		}
+	}
+
+	// Collect failed checks for overall summary
+	for _, evalResult := range summary.Results {
+		if !evalResult.Passed {
+			result.Failed = append(result.Failed, fmt.Sprintf("%s: %s", evalResult.CheckName, evalResult.Message))
+		}
+	}
+
+	return result
+}
+
+// printEvaluationResult prints the pass/fail line followed by the evaluation details
+func printEvaluationResult(result AgentTestResult) {
+	if result.Success {
+		fmt.Printf("✅ PASS: %.1f%% pass rate, %.2f avg score (%v)\n",
+			result.PassRate, result.AvgScore, evals.FormatDuration(result.Duration))
+	} else {
+		fmt.Printf("❌ FAIL: %.1f%% pass rate, %.2f avg score (%v)\n",
+			result.PassRate, result.AvgScore, evals.FormatDuration(result.Duration))
+	}
+
+	printEvaluationDetails(result)
+}
+
+// printEvaluationDetails prints the failed checks and scores of an evaluation
+func printEvaluationDetails(result AgentTestResult) {
+	fmt.Printf("   📊 Evaluation Details:\n")
+	if len(result.Failed) > 0 {
+		fmt.Printf("      ❌ Failed: %s\n", strings.Join(result.Failed, ", "))
+	}
+	if result.PassRate > 0 {
+		fmt.Printf("      ✅ Pass Rate: %.1f%%\n", result.PassRate)
+	}
+	if result.AvgScore > 0 {
+		fmt.Printf("      📈 Score: %.2f\n", result.AvgScore)
+	}
+	if result.AccuracyScore > 0 {
+		fmt.Printf("      🎯 Accuracy: %.2f\n", result.AccuracyScore)
+	}
+	if result.RelevanceScore > 0 {
+		fmt.Printf("      🔗 Relevance: %.2f\n", result.RelevanceScore)
+	}
+}
+
+// printJudgeScore scores the evaluated response with the scoring model
+func printJudgeScore(scoreModel *ai.Model, result AgentTestResult) {
+	criteria, ok := judgeCriteria[result.Name]
+	if !ok || result.Content == "" {
+		return
+	}
+
+	score, err := ScoreResponse(scoreModel, criteria, result.Content)
+	if err != nil {
+		fmt.Printf("      ⚠️  Judge scoring failed: %v\n", err)
+		return
+	}
+	fmt.Printf("      ⚖️  Judge score (%s): %.1f/10\n", scoreModel.ModelName, score)
+}
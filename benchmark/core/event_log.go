@@ -0,0 +1,124 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic/ai"
+)
+
+// EventLog writes every AgentRun event emitted during the benchmark to a JSONL file
+type EventLog struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+// EventRecord is a single line of the event log
+type EventRecord struct {
+	Timestamp  time.Time `json:"timestamp"`
+	TestCase   string    `json:"test_case"`
+	ModelName  string    `json:"model_name"`
+	Type       string    `json:"type"`
+	AgentName  string    `json:"agent_name,omitempty"`
+	Content    string    `json:"content,omitempty"`
+	ToolName   string    `json:"tool_name,omitempty"`
+	ApprovalID string    `json:"approval_id,omitempty"`
+	Sequence   int       `json:"sequence,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// eventLog is the active event log; nil when event logging is disabled
+var eventLog *EventLog
+
+// OpenEventLog creates the JSONL file and enables event logging for all capabilities
+func OpenEventLog(filename string) (*EventLog, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	eventLog = &EventLog{file: file, encoder: json.NewEncoder(file)}
+	return eventLog, nil
+}
+
+// Close disables event logging and closes the file
+func (l *EventLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if eventLog == l {
+		eventLog = nil
+	}
+	return l.file.Close()
+}
+
+func (l *EventLog) write(record EventRecord) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.encoder.Encode(record); err != nil {
+		fmt.Printf("Error writing event log: %v\n", err)
+	}
+}
+
+// logEvent records the event in the event log when one is open and counts it in the metrics
+func logEvent(testCase string, model *ai.Model, event interface{}) {
+	recordMetrics(model, event)
+
+	if eventLog == nil {
+		return
+	}
+
+	record := EventRecord{
+		Timestamp: time.Now(),
+		TestCase:  testCase,
+		ModelName: model.ModelName,
+	}
+
+	switch e := event.(type) {
+	case *aigentic.ContentEvent:
+		record.Type = "content"
+		record.Content = e.Content
+	case *aigentic.ToolEvent:
+		record.Type = "tool"
+		record.ToolName = e.ToolName
+	case *aigentic.ApprovalEvent:
+		record.Type = "approval"
+		record.ToolName = e.ToolName
+		record.ApprovalID = e.ApprovalID
+	case *aigentic.EvalEvent:
+		record.Type = "eval"
+		record.AgentName = e.AgentName
+		record.Sequence = e.Sequence
+	case *aigentic.ErrorEvent:
+		record.Type = "error"
+		record.Error = e.Err.Error()
+	default:
+		record.Type = fmt.Sprintf("%T", event)
+	}
+
+	eventLog.write(record)
+}
+
+// waitForRun consumes the run events, logging each one, and returns the concatenated content
+func waitForRun(testCase string, model *ai.Model, run *aigentic.AgentRun) (string, error) {
+	response := ""
+	for ev := range run.Next() {
+		logEvent(testCase, model, ev)
+
+		switch e := ev.(type) {
+		case *aigentic.ContentEvent:
+			response += e.Content
+		case *aigentic.ApprovalEvent:
+			run.Approve(e.ApprovalID, true)
+		case *aigentic.ErrorEvent:
+			return response, e.Err
+		}
+	}
+	return response, nil
+}
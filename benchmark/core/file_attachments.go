@@ -8,6 +8,8 @@ import (
 	"github.com/nexxia-ai/aigentic/document"
 )
 
+const fileAttachmentsPrompt = "Please analyze the attached file and tell me what it contains. If you are able to analyse the file, start your response with 'SUCCESS:' followed by the analysis."
+
 func RunFileAttachmentsAgent(model *ai.Model) (BenchResult, error) {
 	start := time.Now()
 
@@ -21,9 +23,15 @@ func RunFileAttachmentsAgent(model *ai.Model) (BenchResult, error) {
 		Tracer:       aigentic.NewTracer(),
 	}
 
-	response, err := agent.Execute("Please analyze the attached file and tell me what it contains. If you are able to analyse the file, start your response with 'SUCCESS:' followed by the analysis.")
+	response := ""
+	run, err := agent.Start(fileAttachmentsPrompt)
+	if err == nil {
+		response, err = waitForRun("FileAttachments", model, run)
+	}
 
 	result := CreateBenchResult("FileAttachments", model, start, response, err)
+	recordTrace(&result, run)
+	recordPrompt(&result, agent, fileAttachmentsPrompt)
 
 	if err != nil {
 		return result, err
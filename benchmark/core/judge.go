@@ -0,0 +1,117 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic/ai"
+)
+
+// JudgeCriteria describes what the scoring model needs to judge a capability response
+type JudgeCriteria struct {
+	Prompt           string
+	ExpectedBehavior string
+}
+
+// judgeCriteria holds the criteria for each capability that supports judge scoring
+var judgeCriteria = map[string]JudgeCriteria{
+	"SimpleAgent": {
+		Prompt:           simpleAgentPrompt,
+		ExpectedBehavior: "States that the capital of Australia is Canberra, clearly and concisely.",
+	},
+	"ToolIntegration": {
+		Prompt:           toolIntegrationPrompt,
+		ExpectedBehavior: "Uses the lookup tool and reports that company number 150 is Nexxia.",
+	},
+	"TeamCoordination": {
+		Prompt:           teamCoordinationPrompt,
+		ExpectedBehavior: "Returns a single line 'COMPANY_ID: <id>; NAME: Nexxia; INVOICE_ID: <invoice>; AMOUNT: 100' with no commentary.",
+	},
+	"FileAttachments": {
+		Prompt:           fileAttachmentsPrompt,
+		ExpectedBehavior: "Starts with 'SUCCESS:' and summarises a sample text file about artificial intelligence and machine learning.",
+	},
+	"MultiAgentChain": {
+		Prompt:           multiAgentChainPrompt,
+		ExpectedBehavior: "Presents a table listing expert1, expert2 and expert3 with their company names and id numbers ID1, ID2 and ID3.",
+	},
+	"Streaming": {
+		Prompt:           streamingPrompt,
+		ExpectedBehavior: "States that the capital of France is Paris and gives a brief, accurate summary of the city.",
+	},
+	"StreamingWithTools": {
+		Prompt:           toolIntegrationPrompt,
+		ExpectedBehavior: "Uses the lookup tool and reports that company number 150 is Nexxia.",
+	},
+	"MemoryPersistence": {
+		Prompt:           memoryPersistencePrompt,
+		ExpectedBehavior: "Responds only with the run memory content, which includes company Nexxia and supplier Phoenix.",
+	},
+}
+
+var scorePattern = regexp.MustCompile(`\d+(\.\d+)?`)
+
+// ScoreResponse asks the scoring model to rate a response from 0 to 10
+func ScoreResponse(scoreModel *ai.Model, criteria JudgeCriteria, response string) (float64, error) {
+	judge := aigentic.Agent{
+		Model:       scoreModel,
+		Name:        "judge",
+		Description: "You are an impartial judge that scores the quality of AI assistant responses.",
+		Instructions: "Score how well the response satisfies the prompt and the expected behavior " +
+			"on a scale from 0 (completely wrong) to 10 (perfect). " +
+			"Respond with the score only, as a single number.",
+	}
+
+	message := fmt.Sprintf("PROMPT:\n%s\n\nEXPECTED BEHAVIOR:\n%s\n\nRESPONSE:\n%s",
+		criteria.Prompt, criteria.ExpectedBehavior, response)
+
+	output, err := judge.Execute(message)
+	if err != nil {
+		return 0, err
+	}
+
+	match := scorePattern.FindString(output)
+	if match == "" {
+		return 0, fmt.Errorf("expected a numeric score, got: %s", truncateString(output, 100))
+	}
+
+	score, err := strconv.ParseFloat(match, 64)
+	if err != nil {
+		return 0, err
+	}
+	if score > 10 {
+		score = 10
+	}
+
+	return score, nil
+}
+
+// JudgeResult scores the result response and records it as "judge_score" in the result metadata.
+// Capabilities without judge criteria are left unscored.
+func JudgeResult(scoreModel *ai.Model, result *BenchResult) error {
+	criteria, ok := judgeCriteria[result.TestCase]
+	if !ok {
+		return nil
+	}
+
+	if result.Metadata == nil {
+		result.Metadata = make(map[string]interface{})
+	}
+
+	// Nothing to judge when the run produced no response
+	if result.Response == "" {
+		result.Metadata["judge_score"] = 0.0
+		return nil
+	}
+
+	score, err := ScoreResponse(scoreModel, criteria, result.Response)
+	if err != nil {
+		return err
+	}
+
+	result.Metadata["judge_score"] = score
+	result.Metadata["judge_model"] = scoreModel.ModelName
+	return nil
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic/ai"
+)
+
+// LoadResult contains the outcome of running many concurrent AgentRuns against one model
+type LoadResult struct {
+	ModelName   string
+	Concurrency int
+	Errors      int
+	Duration    time.Duration   // Wall clock time for all runs to complete
+	Latencies   []time.Duration // Latency of each successful run, sorted ascending
+}
+
+// Throughput returns the completed runs per second
+func (r LoadResult) Throughput() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.Concurrency-r.Errors) / r.Duration.Seconds()
+}
+
+// ErrorRate returns the percentage of runs that failed
+func (r LoadResult) ErrorRate() float64 {
+	if r.Concurrency == 0 {
+		return 0
+	}
+	return float64(r.Errors) / float64(r.Concurrency) * 100
+}
+
+// Percentile returns the latency at percentile p (0-100) of the successful runs
+func (r LoadResult) Percentile(p float64) time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	index := int(p / 100 * float64(len(r.Latencies)-1))
+	return r.Latencies[index]
+}
+
+// RunLoadTest starts concurrency AgentRuns at once on the ConcurrentRuns agent, cycling
+// through its requests, and measures the latency of each run
+func RunLoadTest(model *ai.Model, concurrency int) LoadResult {
+	agent := newConcurrentRunsAgent(model)
+	result := LoadResult{
+		ModelName:   model.ModelName,
+		Concurrency: concurrency,
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	start := time.Now()
+	for i := 0; i < concurrency; i++ {
+		request := concurrentRunRequests[i%len(concurrentRunRequests)]
+
+		wg.Add(1)
+		go func(message string) {
+			defer wg.Done()
+
+			runStart := time.Now()
+			run, err := agent.Start(message)
+			if err == nil {
+				_, err = waitForRun("LoadTest", model, run)
+			}
+			latency := time.Since(runStart)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Errors++
+				return
+			}
+			result.Latencies = append(result.Latencies, latency)
+		}(request.message)
+	}
+	wg.Wait()
+
+	result.Duration = time.Since(start)
+	sort.Slice(result.Latencies, func(i, j int) bool { return result.Latencies[i] < result.Latencies[j] })
+
+	return result
+}
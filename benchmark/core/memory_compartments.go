@@ -0,0 +1,143 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/tools"
+)
+
+const (
+	memoryCompartmentsSavePrompt = "1) Save the code word 'ORCHID' to session memory. " +
+		"2) Save the code word 'TULIP' to run memory. " +
+		"3) Save a plan with the steps 'collect', 'verify' and 'report' to plan memory. " +
+		"4) Respond with both code words and the plan steps as stored in memory."
+	memoryCompartmentsRecallPrompt = "Which code words are in your memory? Do not guess; only list code words you find in memory."
+)
+
+// NewCompartmentMemoryTool wraps the library memory tool as update_<compartment>_memory and labels
+// its context so the model can tell the compartments apart
+func NewCompartmentMemoryTool(compartment string) aigentic.AgentTool {
+	tool := tools.NewMemoryTool()
+	tool.Name = "update_" + compartment + "_memory"
+	tool.Description = fmt.Sprintf("Update or delete entries in %s memory. Set memory_content to empty string to delete.", compartment)
+
+	contextFns := tool.ContextFunctions
+	tool.ContextFunctions = []aigentic.ContextFunction{func(run *aigentic.AgentRun) (string, error) {
+		var parts []string
+		for _, fn := range contextFns {
+			content, err := fn(run)
+			if err != nil {
+				return "", err
+			}
+			if content != "" {
+				parts = append(parts, content)
+			}
+		}
+		if len(parts) == 0 {
+			return "", nil
+		}
+		return fmt.Sprintf("# %s memory\n%s", compartment, strings.Join(parts, "\n\n")), nil
+	}}
+	return tool
+}
+
+// NewMemoryCompartmentsAgent creates an agent with separate run, session and plan memory tools.
+// The session tool is passed in so it can be shared across runs; run and plan memory are created
+// fresh, so each agent built here starts with them empty.
+func NewMemoryCompartmentsAgent(model *ai.Model, sessionMemory aigentic.AgentTool) aigentic.Agent {
+	return aigentic.Agent{
+		Model:       model,
+		Name:        "memory_agent",
+		Description: "You are an assistant that keeps information in separate memory compartments.",
+		Instructions: "Your memory has three compartments, each with its own tool: " +
+			"update_run_memory holds information for the current conversation only, " +
+			"update_session_memory holds information shared by every conversation in the session, " +
+			"update_plan_memory holds the steps of the current task plan. " +
+			"Always save information with the tool for the compartment the user asks for. " +
+			"Only answer from memory and never guess information that is not in memory.",
+		AgentTools: []aigentic.AgentTool{
+			NewCompartmentMemoryTool("run"),
+			sessionMemory,
+			NewCompartmentMemoryTool("plan"),
+		},
+		Tracer: aigentic.NewTracer(),
+	}
+}
+
+// RunMemoryCompartments checks that data saved to each memory compartment is recalled only in its scope.
+// Both runs share one session and one session memory tool, while each run gets its own run and plan
+// memory. The second run must recall the session data but not the run data.
+func RunMemoryCompartments(model *ai.Model) (BenchResult, error) {
+	start := time.Now()
+
+	session := aigentic.NewSession(context.Background())
+	sessionMemory := NewCompartmentMemoryTool("session")
+
+	agent := NewMemoryCompartmentsAgent(model, sessionMemory)
+	agent.Session = session
+
+	// First run: save to every compartment and recall within the same run
+	firstRun, err := agent.Start(memoryCompartmentsSavePrompt)
+	if err != nil {
+		result := CreateBenchResult("MemoryCompartments", model, start, "", err)
+		return result, err
+	}
+	firstResponse, err := waitForRun("MemoryCompartments", model, firstRun)
+	if err != nil {
+		result := CreateBenchResult("MemoryCompartments", model, start, firstResponse, err)
+		recordTrace(&result, firstRun)
+		recordPrompt(&result, agent, memoryCompartmentsSavePrompt, memoryCompartmentsRecallPrompt)
+		return result, err
+	}
+
+	// Second run in the same session: only the session compartment should still be available
+	agent = NewMemoryCompartmentsAgent(model, sessionMemory)
+	agent.Session = session
+	secondRun, err := agent.Start(memoryCompartmentsRecallPrompt)
+	if err != nil {
+		result := CreateBenchResult("MemoryCompartments", model, start, firstResponse, err)
+		recordTrace(&result, firstRun)
+		recordPrompt(&result, agent, memoryCompartmentsSavePrompt, memoryCompartmentsRecallPrompt)
+		return result, err
+	}
+	secondResponse, err := waitForRun("MemoryCompartments", model, secondRun)
+
+	response := firstResponse + " | " + secondResponse
+	result := CreateBenchResult("MemoryCompartments", model, start, response, err)
+	recordTrace(&result, firstRun, secondRun)
+	recordPrompt(&result, agent, memoryCompartmentsSavePrompt, memoryCompartmentsRecallPrompt)
+	if err != nil {
+		return result, err
+	}
+
+	// Score each compartment on whether its data was recalled in the right scope
+	first := strings.ToLower(firstResponse)
+	second := strings.ToLower(secondResponse)
+	scopes := map[string]bool{
+		"run":     strings.Contains(first, "tulip") && !strings.Contains(second, "tulip"),
+		"session": strings.Contains(first, "orchid") && strings.Contains(second, "orchid"),
+		"plan":    strings.Contains(first, "collect") && strings.Contains(first, "verify") && strings.Contains(first, "report"),
+	}
+
+	var failed []string
+	for _, compartment := range []string{"run", "session", "plan"} {
+		result.Metadata[compartment+"_scope"] = scopes[compartment]
+		if !scopes[compartment] {
+			failed = append(failed, compartment)
+		}
+	}
+	result.Metadata["compartment_score"] = float64(len(scopes)-len(failed)) / float64(len(scopes))
+	result.Metadata["response_preview"] = TruncateString(response, 150)
+
+	if len(failed) > 0 {
+		result.Success = false
+		result.ErrorMessage = fmt.Sprintf("memory recalled in the wrong scope for compartments: %s", strings.Join(failed, ", "))
+	}
+
+	return result, nil
+}
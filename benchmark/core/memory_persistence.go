@@ -7,18 +7,33 @@ import (
 
 	"github.com/nexxia-ai/aigentic"
 	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/evals"
 	"github.com/nexxia-ai/aigentic/tools"
 )
 
+const memoryPersistencePrompt = "Execute the following plan: " +
+	"1) Call 'lookup_company' with input 'Look up company 150'. " +
+	"2) Save the result to memory using update_memory. " +
+	"3) Call 'lookup_company_supplier' with input 'Look up supplier 200'. " +
+	"4) Save the result to memory again, including previous memory content. " +
+	"5) When you have the company and the supplier details, then respond with exactly the full content of the run memory (no extra text)."
+
 // NewMemoryPersistenceAgent creates a coordinator agent that uses memory
 func NewMemoryPersistenceAgent(model *ai.Model) aigentic.Agent {
+	return newMemoryPersistenceAgent(model, nil)
+}
+
+// newMemoryPersistenceAgent creates the coordinator with the subagent tools wrapped by the argument recorder
+func newMemoryPersistenceAgent(model *ai.Model, arguments *ArgumentRecorder) aigentic.Agent {
 	// Sub-agents
 	lookupCompany := aigentic.Agent{
 		Model:        model,
 		Name:         "lookup_company",
 		Description:  "This agent allows you to look up a company name by company number. Please provide the request as 'lookup the company name for xxx'",
 		Instructions: "Use tools to look up the company name. Return exactly 'COMPANY: <name>' and nothing else.",
-		AgentTools:   []aigentic.AgentTool{NewCompanyNameTool()},
+		AgentTools: []aigentic.AgentTool{
+			arguments.Wrap(NewCompanyNameTool(), map[string]interface{}{"company_number": "150"}),
+		},
 	}
 
 	lookupSupplier := aigentic.Agent{
@@ -26,7 +41,9 @@ func NewMemoryPersistenceAgent(model *ai.Model) aigentic.Agent {
 		Name:         "lookup_company_supplier",
 		Description:  "This agent allows you to look up a supplier name by supplier number. The request should be in the format 'lookup the supplier name for xxx'",
 		Instructions: "Use tools to look up the supplier name. Return exactly 'SUPPLIER: <name>' and nothing else.",
-		AgentTools:   []aigentic.AgentTool{NewSecretSupplierTool()},
+		AgentTools: []aigentic.AgentTool{
+			arguments.Wrap(NewSecretSupplierTool(), map[string]interface{}{"supplier_number": "200"}),
+		},
 	}
 
 	// Coordinator executes the plan, saves each result to memory, then replies with full memory content
@@ -40,8 +57,8 @@ func NewMemoryPersistenceAgent(model *ai.Model) aigentic.Agent {
 			"4) When saving memory, include the current memory content and append the new result so both are present. " +
 			"5) Return only the memory content (no commentary). " +
 			"Do not make up information. You must use the tools to get the information.",
-		Agents: []aigentic.Agent{lookupCompany, lookupSupplier},
-		Tracer: aigentic.NewTracer(),
+		Agents:     []aigentic.Agent{lookupCompany, lookupSupplier},
+		Tracer:     aigentic.NewTracer(),
 		AgentTools: []aigentic.AgentTool{tools.NewMemoryTool()},
 	}
 
@@ -54,17 +71,11 @@ func RunMemoryPersistenceAgent(model *ai.Model) (BenchResult, error) {
 
 	session := aigentic.NewSession(context.Background())
 
-	coordinator := NewMemoryPersistenceAgent(model)
+	arguments := NewArgumentRecorder()
+	coordinator := newMemoryPersistenceAgent(model, arguments)
 	coordinator.Session = session
 
-	run, err := coordinator.Start(
-		"Execute the following plan: " +
-			"1) Call 'lookup_company' with input 'Look up company 150'. " +
-			"2) Save the result to memory using save_memory. " +
-			"3) Call 'lookup_company_supplier' with input 'Look up supplier 200'. " +
-			"4) Save the result to memory again, including previous memory content. " +
-			"5) When you have the company and the supplier details, then respond with exactly the full content of the run memory (no extra text).",
-	)
+	run, err := coordinator.Start(memoryPersistencePrompt)
 	if err != nil {
 		result := CreateBenchResult("MemoryPersistence", model, start, "", err)
 		return result, err
@@ -75,24 +86,30 @@ func RunMemoryPersistenceAgent(model *ai.Model) (BenchResult, error) {
 	var chunks []string
 
 	for ev := range run.Next() {
+		logEvent("MemoryPersistence", model, ev)
 		switch e := ev.(type) {
 		case *aigentic.ContentEvent:
 			chunks = append(chunks, e.Content)
 		case *aigentic.ToolEvent:
 			toolOrder = append(toolOrder, e.ToolName)
-			if e.ToolName == "save_memory" {
+			if e.ToolName == "update_memory" {
 				saveCount++
 			}
 		case *aigentic.ApprovalEvent:
 			run.Approve(e.ApprovalID, true)
 		case *aigentic.ErrorEvent:
 			result := CreateBenchResult("MemoryPersistence", model, start, "", e.Err)
+			recordTrace(&result, run)
+			recordPrompt(&result, coordinator, memoryPersistencePrompt)
 			return result, e.Err
 		}
 	}
 
 	finalContent := strings.Join(chunks, "")
 	result := CreateBenchResult("MemoryPersistence", model, start, finalContent, nil)
+	recordTrace(&result, run)
+	recordPrompt(&result, coordinator, memoryPersistencePrompt)
+	arguments.Record(&result)
 
 	// Validate memory contains both company and supplier results
 	if err := ValidateResponse(finalContent, "nexxia"); err != nil {
@@ -133,7 +150,7 @@ func RunMemoryPersistenceAgent(model *ai.Model) (BenchResult, error) {
 
 	if saveCount < 2 {
 		result.Success = false
-		result.ErrorMessage = "save_memory should be called at least twice"
+		result.ErrorMessage = "update_memory should be called at least twice"
 		return result, nil
 	}
 
@@ -145,3 +162,25 @@ func RunMemoryPersistenceAgent(model *ai.Model) (BenchResult, error) {
 
 	return result, nil
 }
+
+// EvalMemoryPersistence runs the MemoryPersistence capability with the evaluation suite
+func EvalMemoryPersistence(model *ai.Model, scoreModel *ai.Model) {
+	evalSuite := newEvalSuite("MemoryPersistence")
+
+	evalSuite.AddToolCheck("lookup_company", evals.HasToolKeywords("150"))
+	evalSuite.AddToolCheck("lookup_company_supplier", evals.HasToolKeywords("200"))
+
+	evalSuite.AddFinalToolCheck("lookup_company", 1)
+	evalSuite.AddFinalToolCheck("lookup_company_supplier", 1)
+	evalSuite.AddFinalToolCheck("update_memory", -1) // called 1 or more times
+
+	evalSuite.AddFinalCheck("includes company", evals.HasKeywords("Nexxia"))
+	evalSuite.AddFinalCheck("includes supplier", evals.HasKeywords("Phoenix"))
+
+	coordinator := NewMemoryPersistenceAgent(model)
+	coordinator.Session = aigentic.NewSession(context.Background())
+
+	result := evaluateAgent(coordinator, "coordinator", memoryPersistencePrompt, evalSuite, "MemoryPersistence")
+	printEvaluationResult(result)
+	printJudgeScore(scoreModel, result)
+}
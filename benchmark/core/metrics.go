@@ -0,0 +1,189 @@
+package core
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic/ai"
+)
+
+// latencyBuckets are the upper bounds in seconds of the test latency histogram
+var latencyBuckets = []float64{1, 2.5, 5, 10, 20, 30, 60, 120, 300}
+
+// Metrics counts run events and test latencies and serves them in the Prometheus text format
+type Metrics struct {
+	mu        sync.Mutex
+	llmCalls  map[string]int           // by model
+	toolCalls map[[2]string]int        // by model and tool
+	errors    map[string]int           // by model
+	tests     map[[3]string]int        // by model, test case and status
+	latency   map[[2]string]*histogram // by model and test case
+}
+
+type histogram struct {
+	buckets []int // cumulative counts per latencyBuckets entry
+	count   int
+	sum     float64
+}
+
+// metrics is the active metrics collector; nil when metrics are disabled
+var metrics *Metrics
+
+// EnableMetrics starts collecting metrics for all capabilities
+func EnableMetrics() *Metrics {
+	metrics = &Metrics{
+		llmCalls:  make(map[string]int),
+		toolCalls: make(map[[2]string]int),
+		errors:    make(map[string]int),
+		tests:     make(map[[3]string]int),
+		latency:   make(map[[2]string]*histogram),
+	}
+	return metrics
+}
+
+// recordMetrics counts the event when metrics are enabled
+func recordMetrics(model *ai.Model, event interface{}) {
+	if metrics == nil {
+		return
+	}
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	switch e := event.(type) {
+	case *aigentic.ToolEvent:
+		metrics.toolCalls[[2]string{model.ModelName, e.ToolName}]++
+	case *aigentic.ErrorEvent:
+		metrics.errors[model.ModelName]++
+	default:
+		// LLM call events are matched by name so the counter works across aigentic versions
+		if strings.HasSuffix(fmt.Sprintf("%T", event), "LLMCallEvent") {
+			metrics.llmCalls[model.ModelName]++
+		}
+	}
+}
+
+// ObserveTest records the outcome and latency of a capability run when metrics are enabled
+func ObserveTest(result BenchResult) {
+	if metrics == nil {
+		return
+	}
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	status := "failure"
+	if result.Success {
+		status = "success"
+	}
+	metrics.tests[[3]string{result.ModelName, result.TestCase, status}]++
+
+	key := [2]string{result.ModelName, result.TestCase}
+	h := metrics.latency[key]
+	if h == nil {
+		h = &histogram{buckets: make([]int, len(latencyBuckets))}
+		metrics.latency[key] = h
+	}
+
+	seconds := result.Duration.Seconds()
+	for i, bound := range latencyBuckets {
+		if seconds <= bound {
+			h.buckets[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	fmt.Fprintln(w, "# HELP benchmark_llm_calls_total LLM calls made by the agents.")
+	fmt.Fprintln(w, "# TYPE benchmark_llm_calls_total counter")
+	for _, model := range sortedKeys(m.llmCalls) {
+		fmt.Fprintf(w, "benchmark_llm_calls_total{model=%q} %d\n", model, m.llmCalls[model])
+	}
+
+	fmt.Fprintln(w, "# HELP benchmark_tool_calls_total Tool and subagent calls made by the agents.")
+	fmt.Fprintln(w, "# TYPE benchmark_tool_calls_total counter")
+	for _, key := range sortedPairs(m.toolCalls) {
+		fmt.Fprintf(w, "benchmark_tool_calls_total{model=%q,tool=%q} %d\n", key[0], key[1], m.toolCalls[key])
+	}
+
+	fmt.Fprintln(w, "# HELP benchmark_errors_total Error events emitted by agent runs.")
+	fmt.Fprintln(w, "# TYPE benchmark_errors_total counter")
+	for _, model := range sortedKeys(m.errors) {
+		fmt.Fprintf(w, "benchmark_errors_total{model=%q} %d\n", model, m.errors[model])
+	}
+
+	fmt.Fprintln(w, "# HELP benchmark_tests_total Capability runs by outcome.")
+	fmt.Fprintln(w, "# TYPE benchmark_tests_total counter")
+	var testKeys [][3]string
+	for key := range m.tests {
+		testKeys = append(testKeys, key)
+	}
+	sort.Slice(testKeys, func(i, j int) bool {
+		return strings.Join(testKeys[i][:], "/") < strings.Join(testKeys[j][:], "/")
+	})
+	for _, key := range testKeys {
+		fmt.Fprintf(w, "benchmark_tests_total{model=%q,test=%q,status=%q} %d\n", key[0], key[1], key[2], m.tests[key])
+	}
+
+	fmt.Fprintln(w, "# HELP benchmark_test_duration_seconds Capability run latency.")
+	fmt.Fprintln(w, "# TYPE benchmark_test_duration_seconds histogram")
+	for _, key := range sortedPairs(m.latency) {
+		h := m.latency[key]
+		labels := fmt.Sprintf("model=%q,test=%q", key[0], key[1])
+		for i, bound := range latencyBuckets {
+			fmt.Fprintf(w, "benchmark_test_duration_seconds_bucket{%s,le=\"%g\"} %d\n", labels, bound, h.buckets[i])
+		}
+		fmt.Fprintf(w, "benchmark_test_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, h.count)
+		fmt.Fprintf(w, "benchmark_test_duration_seconds_sum{%s} %g\n", labels, h.sum)
+		fmt.Fprintf(w, "benchmark_test_duration_seconds_count{%s} %d\n", labels, h.count)
+	}
+}
+
+// ServeMetrics exposes /metrics on addr in the background
+func (m *Metrics) ServeMetrics(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m)
+
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			fmt.Printf("Error serving metrics: %v\n", err)
+		}
+	}()
+}
+
+func sortedKeys(values map[string]int) []string {
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedPairs[V any](values map[[2]string]V) [][2]string {
+	var keys [][2]string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	return keys
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"sync"
+
+	"github.com/nexxia-ai/aigentic/ai"
+)
+
+// RegisteredCapability is a benchmark test added from outside the runner
+type RegisteredCapability struct {
+	Name         string
+	RunFunction  func(*ai.Model) (BenchResult, error)
+	EvalFunction func(*ai.Model, *ai.Model) // Optional evaluation function (model, scoreModel)
+	Tags         []string
+}
+
+var (
+	registryMu   sync.Mutex
+	registry     []RegisteredCapability
+	registryName = make(map[string]bool)
+)
+
+// RegisterCapability makes a test available to the benchmark runner. It is meant to be called
+// from an init function in a downstream package; evalFn may be nil. It panics if the name is
+// already registered.
+func RegisterCapability(name string, fn func(*ai.Model) (BenchResult, error), evalFn func(*ai.Model, *ai.Model), tags ...string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if fn == nil {
+		panic("core: RegisterCapability run function is nil for " + name)
+	}
+	if registryName[name] {
+		panic("core: RegisterCapability called twice for " + name)
+	}
+
+	registryName[name] = true
+	registry = append(registry, RegisteredCapability{
+		Name:         name,
+		RunFunction:  fn,
+		EvalFunction: evalFn,
+		Tags:         tags,
+	})
+}
+
+// RegisteredCapabilities returns the registered tests in registration order
+func RegisteredCapabilities() []RegisteredCapability {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	return append([]RegisteredCapability(nil), registry...)
+}
@@ -9,6 +9,7 @@ type BenchResult struct {
 	Success      bool                   `json:"success"`
 	Duration     time.Duration          `json:"duration"`
 	ResponseSize int                    `json:"response_size,omitempty"`
+	Response     string                 `json:"response,omitempty"`
 	ErrorMessage string                 `json:"error_message,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
 }
@@ -7,18 +7,31 @@ import (
 
 	"github.com/nexxia-ai/aigentic"
 	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/evals"
 )
 
-func RunSimpleAgent(model *ai.Model) (BenchResult, error) {
-	start := time.Now()
+const simpleAgentPrompt = "What is the capital of Australia?"
 
-	agent := aigentic.Agent{
+// newSimpleAgent creates the basic conversational agent used by the SimpleAgent capability
+func newSimpleAgent(model *ai.Model) aigentic.Agent {
+	return aigentic.Agent{
 		Model:        model,
+		Name:         "simple_agent",
 		Description:  "A basic conversational agent that provides clear and helpful responses",
 		Instructions: "Answer questions clearly and concisely. For geography questions, provide accurate information.",
 		Tracer:       aigentic.NewTracer(),
 	}
-	response, err := agent.Execute("What is the capital of Australia?")
+}
+
+func RunSimpleAgent(model *ai.Model) (BenchResult, error) {
+	start := time.Now()
+
+	agent := newSimpleAgent(model)
+	response := ""
+	run, err := agent.Start(simpleAgentPrompt)
+	if err == nil {
+		response, err = waitForRun("SimpleAgent", model, run)
+	}
 
 	duration := time.Since(start)
 
@@ -27,7 +40,11 @@ func RunSimpleAgent(model *ai.Model) (BenchResult, error) {
 		ModelName:    model.ModelName,
 		Duration:     duration,
 		ResponseSize: len(response),
+		Response:     response,
+		Metadata:     make(map[string]interface{}),
 	}
+	recordTrace(&result, run)
+	recordPrompt(&result, agent, simpleAgentPrompt)
 
 	if err != nil {
 		result.Success = false
@@ -43,10 +60,20 @@ func RunSimpleAgent(model *ai.Model) (BenchResult, error) {
 	}
 
 	result.Success = true
-	result.Metadata = map[string]interface{}{
-		"expected_content": "canberra",
-		"response_preview": TruncateString(response, 100),
-	}
+	result.Metadata["expected_content"] = "canberra"
+	result.Metadata["response_preview"] = TruncateString(response, 100)
 
 	return result, nil
 }
+
+// EvalSimpleAgent runs the SimpleAgent capability with the evaluation suite
+func EvalSimpleAgent(model *ai.Model, scoreModel *ai.Model) {
+	evalSuite := newEvalSuite("SimpleAgent")
+
+	evalSuite.AddFinalCheck("mentions canberra", evals.HasKeywords("Canberra"))
+	evalSuite.AddFinalCheck("complete response", evals.HasContent(5))
+
+	result := evaluateAgent(newSimpleAgent(model), "simple_agent", simpleAgentPrompt, evalSuite, "SimpleAgent")
+	printEvaluationResult(result)
+	printJudgeScore(scoreModel, result)
+}
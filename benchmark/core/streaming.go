@@ -6,41 +6,60 @@ import (
 
 	"github.com/nexxia-ai/aigentic"
 	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/evals"
 )
 
-func RunStreaming(model *ai.Model) (BenchResult, error) {
-	start := time.Now()
+const streamingPrompt = "What is the capital of France and give me a brief summary of the city"
 
-	agent := aigentic.Agent{
+// newStreamingAgent creates the streaming agent used by the Streaming capability
+func newStreamingAgent(model *ai.Model) aigentic.Agent {
+	return aigentic.Agent{
 		Model:        model,
+		Name:         "streaming_agent",
 		Description:  "You are a helpful assistant that provides clear and concise answers.",
 		Instructions: "Always explain your reasoning and provide examples when possible.",
 		Stream:       true,
 		Tracer:       aigentic.NewTracer(),
 	}
+}
+
+func RunStreaming(model *ai.Model) (BenchResult, error) {
+	start := time.Now()
+
+	agent := newStreamingAgent(model)
 
-	run, err := agent.Start("What is the capital of France and give me a brief summary of the city")
+	run, err := agent.Start(streamingPrompt)
 	if err != nil {
 		result := CreateBenchResult("Streaming", model, start, "", err)
 		return result, err
 	}
 
 	var chunks []string
+	var firstToken time.Time
 	for ev := range run.Next() {
+		logEvent("Streaming", model, ev)
 		switch e := ev.(type) {
 		case *aigentic.ContentEvent:
+			if firstToken.IsZero() {
+				firstToken = time.Now()
+			}
 			chunks = append(chunks, e.Content)
 		case *aigentic.ToolEvent:
 		case *aigentic.ApprovalEvent:
 			run.Approve(e.ApprovalID, true)
 		case *aigentic.ErrorEvent:
 			result := CreateBenchResult("Streaming", model, start, "", e.Err)
+			recordTrace(&result, run)
+			recordPrompt(&result, agent, streamingPrompt)
 			return result, e.Err
 		}
 	}
 
 	finalContent := strings.Join(chunks, "")
 	result := CreateBenchResult("Streaming", model, start, finalContent, nil)
+	recordTrace(&result, run)
+	recordPrompt(&result, agent, streamingPrompt)
+	recordStreamingMetrics(&result, start, firstToken, finalContent)
 
 	if err := ValidateResponse(finalContent, "paris"); err != nil {
 		result.Success = false
@@ -64,37 +83,51 @@ func RunStreaming(model *ai.Model) (BenchResult, error) {
 func RunStreamingWithTools(model *ai.Model) (BenchResult, error) {
 	start := time.Now()
 
+	arguments := NewArgumentRecorder()
 	agent := aigentic.Agent{
 		Model:        model,
 		Description:  "You are a helpful assistant that provides clear and concise answers.",
 		Instructions: "Always explain your reasoning and provide examples when possible.",
 		Stream:       true,
-		AgentTools:   []aigentic.AgentTool{NewCompanyNameTool()},
-		Tracer:       aigentic.NewTracer(),
+		AgentTools: []aigentic.AgentTool{
+			arguments.Wrap(NewCompanyNameTool(), map[string]interface{}{"company_number": "150"}),
+		},
+		Tracer: aigentic.NewTracer(),
 	}
 
-	run, err := agent.Start("tell me the name of the company with the number 150. Use tools.")
+	run, err := agent.Start(toolIntegrationPrompt)
 	if err != nil {
 		result := CreateBenchResult("StreamingWithTools", model, start, "", err)
 		return result, err
 	}
 
 	var chunks []string
+	var firstToken time.Time
 	for ev := range run.Next() {
+		logEvent("StreamingWithTools", model, ev)
 		switch e := ev.(type) {
 		case *aigentic.ContentEvent:
+			if firstToken.IsZero() {
+				firstToken = time.Now()
+			}
 			chunks = append(chunks, e.Content)
 		case *aigentic.ToolEvent:
 		case *aigentic.ApprovalEvent:
 			run.Approve(e.ApprovalID, true)
 		case *aigentic.ErrorEvent:
 			result := CreateBenchResult("StreamingWithTools", model, start, "", e.Err)
+			recordTrace(&result, run)
+			recordPrompt(&result, agent, toolIntegrationPrompt)
 			return result, e.Err
 		}
 	}
 
 	finalContent := strings.Join(chunks, "")
 	result := CreateBenchResult("StreamingWithTools", model, start, finalContent, nil)
+	recordTrace(&result, run)
+	recordPrompt(&result, agent, toolIntegrationPrompt)
+	arguments.Record(&result)
+	recordStreamingMetrics(&result, start, firstToken, finalContent)
 
 	if err := ValidateResponse(finalContent, "Nexxia"); err != nil {
 		result.Success = false
@@ -114,3 +147,30 @@ func RunStreamingWithTools(model *ai.Model) (BenchResult, error) {
 
 	return result, nil
 }
+
+// recordStreamingMetrics stores the time to first token and the estimated output rate in the result metadata
+func recordStreamingMetrics(result *BenchResult, start time.Time, firstToken time.Time, content string) {
+	if firstToken.IsZero() {
+		return
+	}
+
+	result.Metadata["time_to_first_token"] = firstToken.Sub(start).Seconds()
+
+	// Measure the rate over the streamed part only, excluding the initial latency
+	streamed := start.Add(result.Duration).Sub(firstToken).Seconds()
+	if streamed > 0 {
+		result.Metadata["tokens_per_second"] = float64(EstimateTokens(content)) / streamed
+	}
+}
+
+// EvalStreaming runs the Streaming capability with the evaluation suite
+func EvalStreaming(model *ai.Model, scoreModel *ai.Model) {
+	evalSuite := newEvalSuite("Streaming")
+
+	evalSuite.AddFinalCheck("mentions paris", evals.HasKeywords("Paris"))
+	evalSuite.AddFinalCheck("includes summary", evals.HasContent(100))
+
+	result := evaluateAgent(newStreamingAgent(model), "streaming_agent", streamingPrompt, evalSuite, "Streaming")
+	printEvaluationResult(result)
+	printJudgeScore(scoreModel, result)
+}
@@ -7,18 +7,28 @@ import (
 
 	"github.com/nexxia-ai/aigentic"
 	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/evals"
 	"github.com/nexxia-ai/aigentic/tools"
 )
 
+const teamCoordinationPrompt = "Create an invoice for company 'Nexxia' for the amount 100. Return the final canonical line only."
+
 // NewTeamCoordinationAgent creates a coordinator agent with subagents
 func NewTeamCoordinationAgent(model *ai.Model) aigentic.Agent {
+	return newTeamCoordinationAgent(model, nil)
+}
+
+// newTeamCoordinationAgent creates the coordinator with the subagent tools wrapped by the argument recorder
+func newTeamCoordinationAgent(model *ai.Model, arguments *ArgumentRecorder) aigentic.Agent {
 	// Subagents
 	lookup := aigentic.Agent{
 		Model:        model,
 		Name:         "agent_lookup_company_by_name",
 		Description:  "Lookup company details by name. Return either 'COMPANY_ID: <id>; NAME: <name>' or 'NOT_FOUND' only.",
 		Instructions: "Use tools to perform the lookup and return the canonical format only.",
-		AgentTools:   []aigentic.AgentTool{NewLookupCompanyByNameTool()},
+		AgentTools: []aigentic.AgentTool{
+			arguments.Wrap(NewLookupCompanyByNameTool(), map[string]interface{}{"name": "Nexxia"}),
+		},
 	}
 
 	companyCreator := aigentic.Agent{
@@ -26,7 +36,7 @@ func NewTeamCoordinationAgent(model *ai.Model) aigentic.Agent {
 		Name:         "agent_create_company",
 		Description:  "Create a new company by name and return 'COMPANY_ID: <id>; NAME: <name>' only.",
 		Instructions: "Use tools to create the company and return the canonical format only.",
-		AgentTools:   []aigentic.AgentTool{NewCreateCompanyTool()},
+		AgentTools:   []aigentic.AgentTool{arguments.Wrap(NewCreateCompanyTool(), nil)},
 	}
 
 	invoiceCreator := aigentic.Agent{
@@ -34,7 +44,9 @@ func NewTeamCoordinationAgent(model *ai.Model) aigentic.Agent {
 		Name:         "agent_create_invoice",
 		Description:  "Create an invoice for a given company_id and amount. Return 'INVOICE_ID: <id>; AMOUNT: <amount>' only.",
 		Instructions: "Use tools to create the invoice and return the canonical format only.",
-		AgentTools:   []aigentic.AgentTool{NewCreateInvoiceTool()},
+		AgentTools: []aigentic.AgentTool{
+			arguments.Wrap(NewCreateInvoiceTool(), map[string]interface{}{"company_id": "COMP-001", "amount": 100}),
+		},
 	}
 
 	coordinator := aigentic.Agent{
@@ -45,10 +57,10 @@ func NewTeamCoordinationAgent(model *ai.Model) aigentic.Agent {
 			"3) Call 'invoice_creator' with the resolved company_id and the requested amount. " +
 			"Finally, return exactly: 'COMPANY_ID: <id>; NAME: <name>; INVOICE_ID: <invoice>; AMOUNT: <amount>'.",
 		Instructions: "Call exactly one tool at a time and wait for the response before the next call. " +
-			"Use the save_memory tool to persist important context between tool calls, especially after getting company information and getting invoice information. " +
+			"Use the update_memory tool to persist important context between tool calls, especially after getting company information and getting invoice information. " +
 			"Do not add commentary.",
-		Agents: []aigentic.Agent{lookup, companyCreator, invoiceCreator},
-		Tracer: aigentic.NewTracer(),
+		Agents:     []aigentic.Agent{lookup, companyCreator, invoiceCreator},
+		Tracer:     aigentic.NewTracer(),
 		AgentTools: []aigentic.AgentTool{tools.NewMemoryTool()},
 		// LogLevel: slog.LevelDebug,
 	}
@@ -62,10 +74,11 @@ func RunTeamCoordination(model *ai.Model) (BenchResult, error) {
 
 	session := aigentic.NewSession(context.Background())
 
-	coordinator := NewTeamCoordinationAgent(model)
+	arguments := NewArgumentRecorder()
+	coordinator := newTeamCoordinationAgent(model, arguments)
 	coordinator.Session = session
 
-	run, err := coordinator.Start("Create an invoice for company 'Nexxia' for the amount 100. Return the final canonical line only.")
+	run, err := coordinator.Start(teamCoordinationPrompt)
 	if err != nil {
 		result := CreateBenchResult("TeamCoordination", model, start, "", err)
 		return result, err
@@ -75,6 +88,7 @@ func RunTeamCoordination(model *ai.Model) (BenchResult, error) {
 	toolCalls := []string{}
 
 	for ev := range run.Next() {
+		logEvent("TeamCoordination", model, ev)
 		switch e := ev.(type) {
 		case *aigentic.ContentEvent:
 			chunks = append(chunks, e.Content)
@@ -84,12 +98,17 @@ func RunTeamCoordination(model *ai.Model) (BenchResult, error) {
 			run.Approve(e.ApprovalID, true)
 		case *aigentic.ErrorEvent:
 			result := CreateBenchResult("TeamCoordination", model, start, "", e.Err)
+			recordTrace(&result, run)
+			recordPrompt(&result, coordinator, teamCoordinationPrompt)
 			return result, e.Err
 		}
 	}
 
 	response := strings.Join(chunks, "")
 	result := CreateBenchResult("TeamCoordination", model, start, response, nil)
+	recordTrace(&result, run)
+	recordPrompt(&result, coordinator, teamCoordinationPrompt)
+	arguments.Record(&result)
 
 	// Validate final content contains expected elements
 	expectedElements := []string{"COMPANY_ID:", "NAME:", "INVOICE_ID:", "AMOUNT:", "Nexxia", "100"}
@@ -123,3 +142,26 @@ func RunTeamCoordination(model *ai.Model) (BenchResult, error) {
 
 	return result, nil
 }
+
+// EvalTeamCoordination runs the TeamCoordination capability with the evaluation suite
+func EvalTeamCoordination(model *ai.Model, scoreModel *ai.Model) {
+	evalSuite := newEvalSuite("TeamCoordination")
+
+	evalSuite.AddToolCheck("agent_lookup_company_by_name", evals.HasToolKeywords("Nexxia"))
+	evalSuite.AddToolCheck("agent_create_invoice", evals.HasToolKeywords("100"))
+
+	evalSuite.AddFinalToolCheck("agent_lookup_company_by_name", 1)
+	evalSuite.AddFinalToolCheck("agent_create_invoice", 1)
+	evalSuite.AddFinalToolCheck("update_memory", -1) // called 1 or more times
+
+	evalSuite.AddFinalCheck("canonical line", evals.HasKeywords("COMPANY_ID:", "NAME:", "INVOICE_ID:", "AMOUNT:"))
+	evalSuite.AddFinalCheck("mentions company", evals.HasKeywords("Nexxia"))
+	evalSuite.AddFinalCheck("mentions amount", evals.HasKeywords("100"))
+
+	coordinator := NewTeamCoordinationAgent(model)
+	coordinator.Session = aigentic.NewSession(context.Background())
+
+	result := evaluateAgent(coordinator, "coordinator", teamCoordinationPrompt, evalSuite, "TeamCoordination")
+	printEvaluationResult(result)
+	printJudgeScore(scoreModel, result)
+}
@@ -0,0 +1,162 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic/ai"
+)
+
+// ToolCall is a tool invocation captured by an ArgumentRecorder with any argument problems found
+type ToolCall struct {
+	ToolName string
+	Args     map[string]interface{}
+	Problems []string
+}
+
+// ArgumentRecorder captures the arguments of every tool call and checks them against the
+// tool's input schema and the expected values
+type ArgumentRecorder struct {
+	mu    sync.Mutex
+	calls []ToolCall
+}
+
+// NewArgumentRecorder creates an empty recorder
+func NewArgumentRecorder() *ArgumentRecorder {
+	return &ArgumentRecorder{}
+}
+
+// Wrap returns a copy of the tool that records and checks its arguments before executing.
+// Expected values are compared case-insensitively; a nil recorder returns the tool unchanged.
+func (r *ArgumentRecorder) Wrap(tool aigentic.AgentTool, expected map[string]interface{}) aigentic.AgentTool {
+	if r == nil {
+		return tool
+	}
+
+	execute := tool.Execute
+	tool.Execute = func(run *aigentic.AgentRun, args map[string]interface{}) (*ai.ToolResult, error) {
+		call := ToolCall{
+			ToolName: tool.Name,
+			Args:     args,
+			Problems: checkArguments(tool.InputSchema, args, expected),
+		}
+
+		r.mu.Lock()
+		r.calls = append(r.calls, call)
+		r.mu.Unlock()
+
+		return execute(run, args)
+	}
+	return tool
+}
+
+// Calls returns the recorded tool calls
+func (r *ArgumentRecorder) Calls() []ToolCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]ToolCall(nil), r.calls...)
+}
+
+// Accuracy returns the fraction of calls with correct arguments, and false when no calls were made
+func (r *ArgumentRecorder) Accuracy() (float64, bool) {
+	calls := r.Calls()
+	if len(calls) == 0 {
+		return 0, false
+	}
+
+	correct := 0
+	for _, call := range calls {
+		if len(call.Problems) == 0 {
+			correct++
+		}
+	}
+	return float64(correct) / float64(len(calls)), true
+}
+
+// Record stores the argument accuracy and problems in the result metadata
+func (r *ArgumentRecorder) Record(result *BenchResult) {
+	accuracy, ok := r.Accuracy()
+	if !ok {
+		return
+	}
+
+	var problems []string
+	for _, call := range r.Calls() {
+		for _, problem := range call.Problems {
+			problems = append(problems, fmt.Sprintf("%s: %s", call.ToolName, problem))
+		}
+	}
+
+	if result.Metadata == nil {
+		result.Metadata = make(map[string]interface{})
+	}
+	result.Metadata["argument_accuracy"] = accuracy
+	if len(problems) > 0 {
+		result.Metadata["argument_problems"] = problems
+	}
+}
+
+// checkArguments validates the arguments against a JSON input schema and the expected values
+func checkArguments(schema map[string]interface{}, args map[string]interface{}, expected map[string]interface{}) []string {
+	var problems []string
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	required, _ := schema["required"].([]string)
+
+	for _, name := range required {
+		if _, ok := args[name]; !ok {
+			problems = append(problems, fmt.Sprintf("missing required argument '%s'", name))
+		}
+	}
+
+	for name, value := range args {
+		property, ok := properties[name].(map[string]interface{})
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unexpected argument '%s'", name))
+			continue
+		}
+		if schemaType, ok := property["type"].(string); ok && !matchesType(value, schemaType) {
+			problems = append(problems, fmt.Sprintf("argument '%s' should be a %s, got %T", name, schemaType, value))
+		}
+	}
+
+	for name, want := range expected {
+		got, ok := args[name]
+		if !ok {
+			continue
+		}
+		if !strings.EqualFold(strings.TrimSpace(fmt.Sprint(got)), fmt.Sprint(want)) {
+			problems = append(problems, fmt.Sprintf("argument '%s' should be '%v', got '%v'", name, want, got))
+		}
+	}
+
+	return problems
+}
+
+// matchesType checks a decoded JSON value against a JSON schema type
+func matchesType(value interface{}, schemaType string) bool {
+	switch schemaType {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == float64(int64(number))
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	default:
+		return true
+	}
+}
@@ -5,12 +5,14 @@ import (
 
 	"github.com/nexxia-ai/aigentic"
 	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/evals"
 )
 
-func RunToolIntegration(model *ai.Model) (BenchResult, error) {
-	start := time.Now()
+const toolIntegrationPrompt = "tell me the name of the company with the number 150. Use tools."
 
-	agent := aigentic.Agent{
+// newToolIntegrationAgent creates the agent used by the ToolIntegration capability
+func newToolIntegrationAgent(model *ai.Model) aigentic.Agent {
+	return aigentic.Agent{
 		Model:        model,
 		Name:         "test-agent",
 		Description:  "You are a helpful assistant that provides clear and concise answers.",
@@ -18,8 +20,18 @@ func RunToolIntegration(model *ai.Model) (BenchResult, error) {
 		AgentTools:   []aigentic.AgentTool{NewCompanyNameTool()},
 		Tracer:       aigentic.NewTracer(),
 	}
+}
+
+func RunToolIntegration(model *ai.Model) (BenchResult, error) {
+	start := time.Now()
 
-	run, err := agent.Start("tell me the name of the company with the number 150. Use tools.")
+	arguments := NewArgumentRecorder()
+	agent := newToolIntegrationAgent(model)
+	agent.AgentTools = []aigentic.AgentTool{
+		arguments.Wrap(NewCompanyNameTool(), map[string]interface{}{"company_number": "150"}),
+	}
+
+	run, err := agent.Start(toolIntegrationPrompt)
 	if err != nil {
 		result := CreateBenchResult("ToolIntegration", model, start, "", err)
 		return result, err
@@ -27,6 +39,7 @@ func RunToolIntegration(model *ai.Model) (BenchResult, error) {
 
 	var chunks []string
 	for ev := range run.Next() {
+		logEvent("ToolIntegration", model, ev)
 		switch e := ev.(type) {
 		case *aigentic.ContentEvent:
 			chunks = append(chunks, e.Content)
@@ -35,6 +48,8 @@ func RunToolIntegration(model *ai.Model) (BenchResult, error) {
 			run.Approve(e.ApprovalID, true)
 		case *aigentic.ErrorEvent:
 			result := CreateBenchResult("ToolIntegration", model, start, "", e.Err)
+			recordTrace(&result, run)
+			recordPrompt(&result, agent, toolIntegrationPrompt)
 			return result, e.Err
 		}
 	}
@@ -45,6 +60,9 @@ func RunToolIntegration(model *ai.Model) (BenchResult, error) {
 	}
 
 	result := CreateBenchResult("ToolIntegration", model, start, response, nil)
+	recordTrace(&result, run)
+	recordPrompt(&result, agent, toolIntegrationPrompt)
+	arguments.Record(&result)
 
 	if err := ValidateResponse(response, "Nexxia"); err != nil {
 		result.Success = false
@@ -57,3 +75,47 @@ func RunToolIntegration(model *ai.Model) (BenchResult, error) {
 
 	return result, nil
 }
+
+// ToolIntegrationVariants are the instruction variants compared in matrix mode
+var ToolIntegrationVariants = []InstructionVariant{
+	{
+		Name:         "Explained",
+		Description:  "Explains its reasoning and uses tools when requested",
+		Instructions: "Always explain your reasoning and provide examples when possible. Use tools when requested.",
+	},
+	{
+		Name:         "Terse",
+		Description:  "Always uses tools and answers with the result only",
+		Instructions: "Always use the available tools to look up information. Answer with the tool result only, without commentary.",
+	},
+}
+
+// newToolIntegrationEvalSuite creates the evaluation suite for the ToolIntegration capability
+func newToolIntegrationEvalSuite(name string) *evals.EvalSuite {
+	evalSuite := newEvalSuite(name)
+
+	evalSuite.AddToolCheck("lookup_company_name", evals.HasToolKeywords("150"))
+	evalSuite.AddFinalToolCheck("lookup_company_name", 1)
+
+	evalSuite.AddFinalCheck("mentions company", evals.HasKeywords("Nexxia"))
+	evalSuite.AddFinalCheck("complete response", evals.HasContent(10))
+
+	return evalSuite
+}
+
+// EvalToolIntegration runs the ToolIntegration capability with the evaluation suite
+func EvalToolIntegration(model *ai.Model, scoreModel *ai.Model) {
+	evalSuite := newToolIntegrationEvalSuite("ToolIntegration")
+
+	result := evaluateAgent(newToolIntegrationAgent(model), "test-agent", toolIntegrationPrompt, evalSuite, "ToolIntegration")
+	printEvaluationResult(result)
+	printJudgeScore(scoreModel, result)
+}
+
+// EvaluateToolIntegrationVariant runs the ToolIntegration agent with the variant instructions and evaluates it
+func EvaluateToolIntegrationVariant(model *ai.Model, variant InstructionVariant) AgentTestResult {
+	agent := variant.Apply(newToolIntegrationAgent(model))
+	evalSuite := newToolIntegrationEvalSuite(variant.Name)
+
+	return evaluateAgent(agent, "test-agent", toolIntegrationPrompt, evalSuite, variant.Name)
+}
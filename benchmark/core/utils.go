@@ -18,6 +18,7 @@ func CreateBenchResult(testCase string, model *ai.Model, start time.Time, respon
 		ModelName:    model.ModelName,
 		Duration:     duration,
 		ResponseSize: len(response),
+		Response:     response,
 		Metadata:     make(map[string]interface{}),
 	}
 
@@ -31,6 +32,55 @@ func CreateBenchResult(testCase string, model *ai.Model, start time.Time, respon
 	return result
 }
 
+// recordTrace stores the trace files of the runs in the result metadata so reports can link to them
+func recordTrace(result *BenchResult, runs ...*aigentic.AgentRun) {
+	var traceFiles []string
+	for _, run := range runs {
+		if run != nil && run.TraceFilepath() != "" {
+			traceFiles = append(traceFiles, run.TraceFilepath())
+		}
+	}
+	if len(traceFiles) == 0 {
+		return
+	}
+
+	if result.Metadata == nil {
+		result.Metadata = make(map[string]interface{})
+	}
+	result.Metadata["trace_file"] = traceFiles[0]
+	if len(traceFiles) > 1 {
+		result.Metadata["trace_files"] = traceFiles
+	}
+}
+
+// recordPrompt stores the agent description, instructions and user messages in the result metadata
+// so reports stay self-describing even after the test code changes
+func recordPrompt(result *BenchResult, agent aigentic.Agent, userMessages ...string) {
+	if result.Metadata == nil {
+		result.Metadata = make(map[string]interface{})
+	}
+	result.Metadata["agent_description"] = agent.Description
+	result.Metadata["agent_instructions"] = agent.Instructions
+	if len(userMessages) == 1 {
+		result.Metadata["user_message"] = userMessages[0]
+	} else {
+		result.Metadata["user_messages"] = userMessages
+	}
+
+	if len(agent.Agents) > 0 {
+		subagents := make(map[string]string)
+		for _, subagent := range agent.Agents {
+			subagents[subagent.Name] = subagent.Description + "\n" + subagent.Instructions
+		}
+		result.Metadata["subagent_instructions"] = subagents
+	}
+}
+
+// EstimateTokens roughly estimates the number of tokens in text, assuming ~4 characters per token
+func EstimateTokens(text string) int {
+	return len(text) / 4
+}
+
 // ValidateResponse checks if response contains expected content (case-insensitive)
 func ValidateResponse(response, expectedContent string) error {
 	if !strings.Contains(strings.ToLower(response), strings.ToLower(expectedContent)) {
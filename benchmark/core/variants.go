@@ -0,0 +1,21 @@
+package core
+
+import "github.com/nexxia-ai/aigentic"
+
+// InstructionVariant is an alternative prompt for a capability's agent, compared
+// against the other variants of the same capability in matrix mode
+type InstructionVariant struct {
+	Name             string
+	Description      string // Human readable summary shown in reports
+	AgentDescription string // Optional agent description override
+	Instructions     string
+}
+
+// Apply returns a copy of the agent with the variant's description and instructions
+func (v InstructionVariant) Apply(agent aigentic.Agent) aigentic.Agent {
+	if v.AgentDescription != "" {
+		agent.Description = v.AgentDescription
+	}
+	agent.Instructions = v.Instructions
+	return agent
+}
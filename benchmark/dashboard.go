@@ -0,0 +1,89 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"time"
+)
+
+//go:embed dashboard
+var dashboardAssets embed.FS
+
+// dashboardRun is one result file as served to the dashboard
+type dashboardRun struct {
+	GeneratedAt time.Time         `json:"generated_at"`
+	Host        string            `json:"host"`
+	Results     []dashboardResult `json:"results"`
+}
+
+// dashboardResult drops the response text and metadata to keep the payload small
+type dashboardResult struct {
+	TestCase string  `json:"test_case"`
+	Model    string  `json:"model"`
+	Success  bool    `json:"success"`
+	Seconds  float64 `json:"seconds"`
+	Skipped  bool    `json:"skipped,omitempty"`
+}
+
+// runDashboard serves the collected result files with charts on addr until the process is stopped
+func runDashboard(addr string) error {
+	assets, err := fs.Sub(dashboardAssets, "dashboard")
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.FS(assets)))
+	mux.HandleFunc("/api/results", serveResults)
+
+	fmt.Printf("📈 Dashboard serving %s on http://%s\n", resultsPattern, dashboardURL(addr))
+	return http.ListenAndServe(addr, mux)
+}
+
+// serveResults reloads the result files on every request so new runs show up without a restart
+func serveResults(w http.ResponseWriter, r *http.Request) {
+	filenames, err := filepath.Glob(resultsPattern)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	files, err := loadResultsFiles(filenames)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	runs := []dashboardRun{}
+	for _, file := range files {
+		run := dashboardRun{GeneratedAt: file.GeneratedAt, Host: file.Host, Results: []dashboardResult{}}
+		for _, result := range file.Results {
+			skipped, _ := result.Metadata["skipped"].(bool)
+			run.Results = append(run.Results, dashboardResult{
+				TestCase: result.TestCase,
+				Model:    result.ModelName,
+				Success:  result.Success,
+				Seconds:  result.Duration.Seconds(),
+				Skipped:  skipped,
+			})
+		}
+		runs = append(runs, run)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(runs); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// dashboardURL turns a listen address such as ":8080" into a browsable host:port
+func dashboardURL(addr string) string {
+	if len(addr) > 0 && addr[0] == ':' {
+		return "localhost" + addr
+	}
+	return addr
+}
@@ -0,0 +1,146 @@
+// Draws the benchmark charts from /api/results using the canvas API only
+const colors = ["#0969da", "#cf222e", "#1a7f37", "#9a6700", "#8250df", "#bf3989", "#57606a"];
+const margin = { left: 50, right: 20, top: 20, bottom: 40 };
+
+function modelNames(runs) {
+  const names = new Set();
+  runs.forEach(run => run.results.forEach(r => names.add(r.model)));
+  return Array.from(names).sort();
+}
+
+function legend(id, models) {
+  document.getElementById(id).innerHTML = models
+    .map((m, i) => `<span><i style="background:${colors[i % colors.length]}"></i>${m}</span>`)
+    .join("");
+}
+
+function axes(ctx, width, height, yLabel, maxY) {
+  ctx.strokeStyle = "#999";
+  ctx.fillStyle = "#555";
+  ctx.font = "12px sans-serif";
+  ctx.beginPath();
+  ctx.moveTo(margin.left, margin.top);
+  ctx.lineTo(margin.left, height - margin.bottom);
+  ctx.lineTo(width - margin.right, height - margin.bottom);
+  ctx.stroke();
+  for (let i = 0; i <= 4; i++) {
+    const y = height - margin.bottom - (i / 4) * (height - margin.top - margin.bottom);
+    ctx.fillText(yLabel((maxY * i) / 4), 5, y + 4);
+  }
+}
+
+// Pass rate of each model in each run, skipping models that were not part of the run
+function drawPassRate(runs, models) {
+  const canvas = document.getElementById("passRate");
+  const ctx = canvas.getContext("2d");
+  const { width, height } = canvas;
+  const plotWidth = width - margin.left - margin.right;
+  const plotHeight = height - margin.top - margin.bottom;
+
+  axes(ctx, width, height, v => `${v.toFixed(0)}%`, 100);
+
+  const x = i => margin.left + (runs.length === 1 ? plotWidth / 2 : (i / (runs.length - 1)) * plotWidth);
+  const y = rate => height - margin.bottom - (rate / 100) * plotHeight;
+
+  runs.forEach((run, i) => {
+    const label = new Date(run.generated_at).toLocaleDateString();
+    ctx.fillText(label, x(i) - 30, height - margin.bottom + 20);
+  });
+
+  models.forEach((model, m) => {
+    ctx.strokeStyle = colors[m % colors.length];
+    ctx.fillStyle = ctx.strokeStyle;
+    ctx.beginPath();
+    let started = false;
+    runs.forEach((run, i) => {
+      const results = run.results.filter(r => r.model === model && !r.skipped);
+      if (results.length === 0) {
+        return;
+      }
+      const rate = (100 * results.filter(r => r.success).length) / results.length;
+      if (started) {
+        ctx.lineTo(x(i), y(rate));
+      } else {
+        ctx.moveTo(x(i), y(rate));
+        started = true;
+      }
+      ctx.fillRect(x(i) - 3, y(rate) - 3, 6, 6);
+    });
+    ctx.stroke();
+  });
+}
+
+// Histogram of test latencies per model across all runs
+function drawLatency(runs, models) {
+  const canvas = document.getElementById("latency");
+  const ctx = canvas.getContext("2d");
+  const { width, height } = canvas;
+  const plotWidth = width - margin.left - margin.right;
+  const plotHeight = height - margin.top - margin.bottom;
+  const bucketCount = 12;
+
+  const latencies = {};
+  let maxSeconds = 0;
+  models.forEach(model => (latencies[model] = []));
+  runs.forEach(run =>
+    run.results.forEach(r => {
+      if (!r.skipped) {
+        latencies[r.model].push(r.seconds);
+        maxSeconds = Math.max(maxSeconds, r.seconds);
+      }
+    })
+  );
+  const bucketSize = Math.max(maxSeconds / bucketCount, 0.1);
+
+  const counts = models.map(model => {
+    const buckets = new Array(bucketCount).fill(0);
+    latencies[model].forEach(s => buckets[Math.min(Math.floor(s / bucketSize), bucketCount - 1)]++);
+    return buckets;
+  });
+  const maxCount = Math.max(1, ...counts.flat());
+
+  axes(ctx, width, height, v => v.toFixed(0), maxCount);
+
+  const groupWidth = plotWidth / bucketCount;
+  const barWidth = (groupWidth - 4) / models.length;
+  for (let b = 0; b < bucketCount; b++) {
+    ctx.fillStyle = "#555";
+    ctx.fillText(`${(b * bucketSize).toFixed(1)}s`, margin.left + b * groupWidth, height - margin.bottom + 20);
+    models.forEach((model, m) => {
+      const barHeight = (counts[m][b] / maxCount) * plotHeight;
+      ctx.fillStyle = colors[m % colors.length];
+      ctx.fillRect(margin.left + b * groupWidth + 2 + m * barWidth, height - margin.bottom - barHeight, barWidth, barHeight);
+    });
+  }
+}
+
+function drawLatest(runs) {
+  const latest = runs[runs.length - 1];
+  const rows = latest.results.map(r => {
+    const status = r.skipped ? "Skipped" : r.success ? "Success" : "Failure";
+    const css = r.skipped ? "" : r.success ? "pass" : "fail";
+    return `<tr><td>${r.test_case}</td><td>${r.model}</td><td class="${css}">${status}</td><td>${r.seconds.toFixed(1)}s</td></tr>`;
+  });
+  document.getElementById("latest").innerHTML =
+    "<tr><th>Capability</th><th>Model</th><th>Status</th><th>Timing</th></tr>" + rows.join("");
+}
+
+fetch("/api/results")
+  .then(response => response.json())
+  .then(runs => {
+    if (runs.length === 0) {
+      document.getElementById("summary").textContent = "No result files found. Run the benchmark first.";
+      return;
+    }
+    const models = modelNames(runs);
+    document.getElementById("summary").textContent =
+      `${runs.length} runs, ${models.length} models, latest ${new Date(runs[runs.length - 1].generated_at).toLocaleString()}`;
+    legend("passRateLegend", models);
+    legend("latencyLegend", models);
+    drawPassRate(runs, models);
+    drawLatency(runs, models);
+    drawLatest(runs);
+  })
+  .catch(err => {
+    document.getElementById("summary").textContent = `Error loading results: ${err}`;
+  });
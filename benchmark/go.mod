@@ -7,6 +7,7 @@ require (
 	github.com/nexxia-ai/aigentic-google v0.2.0
 	github.com/nexxia-ai/aigentic-ollama v0.2.1
 	github.com/nexxia-ai/aigentic-openai v0.3.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -54,5 +55,4 @@ require (
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20240903143218-8af14fe29dc1 // indirect
 	google.golang.org/grpc v1.66.2 // indirect
 	google.golang.org/protobuf v1.36.6 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
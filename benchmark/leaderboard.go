@@ -0,0 +1,219 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic-examples/benchmark/core"
+)
+
+const (
+	leaderboardFilename = "leaderboard.md"
+	resultsPattern      = "results-*.json"
+)
+
+// resultsFile is the JSON file written after each run and read back by the aggregate subcommand
+type resultsFile struct {
+	GeneratedAt time.Time          `json:"generated_at"`
+	Host        string             `json:"host"`
+	Results     []core.BenchResult `json:"results"`
+}
+
+// writeResultsFile saves the results of a run as results-<timestamp>.json
+func writeResultsFile(allResults [][]core.BenchResult) {
+	host, _ := os.Hostname()
+	file := resultsFile{GeneratedAt: time.Now(), Host: host}
+	for _, modelResults := range allResults {
+		file.Results = append(file.Results, modelResults...)
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		fmt.Printf("Error encoding results: %v\n", err)
+		return
+	}
+
+	filename := fmt.Sprintf("results-%s.json", file.GeneratedAt.Format("20060102-150405"))
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		fmt.Printf("Error writing results file: %v\n", err)
+		return
+	}
+
+	fmt.Printf("💾 Results saved: %s\n", filename)
+}
+
+// loadResultsFiles reads result files written by writeResultsFile, oldest first
+func loadResultsFiles(filenames []string) ([]resultsFile, error) {
+	var files []resultsFile
+	for _, filename := range filenames {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		var file resultsFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", filename, err)
+		}
+		files = append(files, file)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].GeneratedAt.Before(files[j].GeneratedAt)
+	})
+	return files, nil
+}
+
+// leaderboardEntry accumulates the results of one model across all result files
+type leaderboardEntry struct {
+	Model      string
+	Runs       int
+	PassRate   float64 // mean of the per-capability pass rates
+	AvgScore   float64
+	AvgLatency time.Duration
+	AvgCost    float64
+
+	passed     map[string]int // passes per capability
+	total      map[string]int // runs per capability
+	scoreTotal float64
+	scoreCount int
+	durations  time.Duration
+	cost       float64
+}
+
+// runAggregate implements the aggregate subcommand: it reads JSON result files and writes
+// a leaderboard ranking models by weighted pass rate, average score, latency and cost
+func runAggregate(args []string) {
+	flags := flag.NewFlagSet("aggregate", flag.ExitOnError)
+	output := flags.String("out", leaderboardFilename, "File the leaderboard is written to")
+	flags.Parse(args)
+
+	var filenames []string
+	for _, pattern := range flags.Args() {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			fmt.Printf("Invalid pattern %s: %v\n", pattern, err)
+			os.Exit(1)
+		}
+		filenames = append(filenames, matches...)
+	}
+
+	if len(filenames) == 0 {
+		fmt.Println("Usage: go run main.go aggregate [-out leaderboard.md] results-*.json [more.json...]")
+		os.Exit(1)
+	}
+
+	files, err := loadResultsFiles(filenames)
+	if err != nil {
+		fmt.Printf("Error loading results: %v\n", err)
+		os.Exit(1)
+	}
+
+	entries := make(map[string]*leaderboardEntry)
+	for _, file := range files {
+		for _, result := range file.Results {
+			if skipped, _ := result.Metadata["skipped"].(bool); skipped {
+				continue
+			}
+			entry := entries[result.ModelName]
+			if entry == nil {
+				entry = &leaderboardEntry{
+					Model:  result.ModelName,
+					passed: make(map[string]int),
+					total:  make(map[string]int),
+				}
+				entries[result.ModelName] = entry
+			}
+			entry.add(result)
+		}
+	}
+
+	var leaderboard []*leaderboardEntry
+	for _, entry := range entries {
+		entry.finish()
+		leaderboard = append(leaderboard, entry)
+	}
+
+	// Rank by weighted pass rate, then score, then latency, then cost
+	sort.Slice(leaderboard, func(i, j int) bool {
+		a, b := leaderboard[i], leaderboard[j]
+		if a.PassRate != b.PassRate {
+			return a.PassRate > b.PassRate
+		}
+		if a.AvgScore != b.AvgScore {
+			return a.AvgScore > b.AvgScore
+		}
+		if a.AvgLatency != b.AvgLatency {
+			return a.AvgLatency < b.AvgLatency
+		}
+		return a.AvgCost < b.AvgCost
+	})
+
+	report := "# Model Leaderboard\n\n"
+	report += fmt.Sprintf("Generated on: %s from %d result files\n\n", time.Now().Format("2006-01-02 15:04:05"), len(filenames))
+	report += "| Rank | Model | Weighted pass rate | Avg judge score | Avg latency | Avg cost | Runs |\n"
+	report += "|---|---|---|---|---|---|---|\n"
+
+	fmt.Printf("🏆 Leaderboard from %d result files\n", len(filenames))
+	fmt.Println("=" + strings.Repeat("=", 40))
+
+	for i, entry := range leaderboard {
+		score := "N/A"
+		if entry.scoreCount > 0 {
+			score = fmt.Sprintf("%.1f/10", entry.AvgScore)
+		}
+		report += fmt.Sprintf("| %d | %s | %.1f%% | %s | %.1fs | $%.4f | %d |\n",
+			i+1, entry.Model, entry.PassRate*100, score, entry.AvgLatency.Seconds(), entry.AvgCost, entry.Runs)
+		fmt.Printf("  %d. %-20s %.1f%% pass, score %s, %.1fs, $%.4f\n",
+			i+1, entry.Model, entry.PassRate*100, score, entry.AvgLatency.Seconds(), entry.AvgCost)
+	}
+
+	report += "\nThe weighted pass rate gives every capability the same weight, however often it was run.\n"
+
+	if err := os.WriteFile(*output, []byte(report), 0644); err != nil {
+		fmt.Printf("Error writing leaderboard: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("\n📊 Leaderboard generated: %s\n", *output)
+}
+
+func (e *leaderboardEntry) add(result core.BenchResult) {
+	e.Runs++
+	e.total[result.TestCase]++
+	if result.Success {
+		e.passed[result.TestCase]++
+	}
+	if score, ok := result.Metadata["judge_score"].(float64); ok {
+		e.scoreTotal += score
+		e.scoreCount++
+	}
+	if cost, ok := result.Metadata["estimated_cost"].(float64); ok {
+		e.cost += cost
+	}
+	e.durations += result.Duration
+}
+
+// finish computes the averages once all results have been added
+func (e *leaderboardEntry) finish() {
+	rates := 0.0
+	for capability, total := range e.total {
+		rates += float64(e.passed[capability]) / float64(total)
+	}
+	if len(e.total) > 0 {
+		e.PassRate = rates / float64(len(e.total))
+	}
+	if e.scoreCount > 0 {
+		e.AvgScore = e.scoreTotal / float64(e.scoreCount)
+	}
+	if e.Runs > 0 {
+		e.AvgLatency = e.durations / time.Duration(e.Runs)
+		e.AvgCost = e.cost / float64(e.Runs)
+	}
+}
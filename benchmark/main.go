@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -21,24 +22,77 @@ type Capability struct {
 	Name         string
 	RunFunction  func(*ai.Model) (core.BenchResult, error)
 	EvalFunction func(*ai.Model, *ai.Model) // Optional evaluation function (model, scoreModel)
+	Tags         []string                   // Labels used by -tags to select focused subsets
+
+	// Optional instruction variants compared by -matrix
+	Variants        []core.InstructionVariant
+	VariantFunction func(*ai.Model, core.InstructionVariant) core.AgentTestResult
+
+	// Optional fixture hooks run around each test, outside its timing
+	Setup    func() error // e.g. create temp files or start a stub server
+	Teardown func() error // e.g. remove temp files or stop the stub server
+}
+
+// setup runs the Setup hook, if any
+func (c Capability) setup() error {
+	if c.Setup == nil {
+		return nil
+	}
+	if err := c.Setup(); err != nil {
+		return fmt.Errorf("setup for %s failed: %w", c.Name, err)
+	}
+	return nil
+}
+
+// teardown runs the Teardown hook, if any, reporting but not failing on errors
+func (c Capability) teardown() {
+	if c.Teardown == nil {
+		return
+	}
+	if err := c.Teardown(); err != nil {
+		fmt.Printf("    ⚠️  Teardown for %s failed: %v\n", c.Name, err)
+	}
 }
 
 var capabilities = []Capability{
-	{Name: "SimpleAgent", RunFunction: core.RunSimpleAgent},
-	{Name: "ToolIntegration", RunFunction: core.RunToolIntegration},
-	{Name: "TeamCoordination", RunFunction: core.RunTeamCoordination},
-	{Name: "FileAttachments", RunFunction: core.RunFileAttachmentsAgent},
-	{Name: "MultiAgentChain", RunFunction: core.RunMultiAgentChain},
-	{Name: "MultiAgentVariations", RunFunction: core.RunMultiAgentVariationsWrapper},
-	{Name: "ConcurrentRuns", RunFunction: core.RunConcurrentRuns},
-	{Name: "Streaming", RunFunction: core.RunStreaming},
-	{Name: "StreamingWithTools", RunFunction: core.RunStreamingWithTools},
-	{Name: "MemoryPersistence", RunFunction: core.RunMemoryPersistenceAgent},
+	{Name: "SimpleAgent", RunFunction: core.RunSimpleAgent, EvalFunction: core.EvalSimpleAgent, Tags: []string{"basic"}},
+	{Name: "ToolIntegration", RunFunction: core.RunToolIntegration, EvalFunction: core.EvalToolIntegration, Tags: []string{"tools"},
+		Variants: core.ToolIntegrationVariants, VariantFunction: core.EvaluateToolIntegrationVariant},
+	{Name: "TeamCoordination", RunFunction: core.RunTeamCoordination, EvalFunction: core.EvalTeamCoordination, Tags: []string{"multi-agent", "tools", "memory"}},
+	{Name: "FileAttachments", RunFunction: core.RunFileAttachmentsAgent, Tags: []string{"documents"}},
+	{Name: "MultiAgentChain", RunFunction: core.RunMultiAgentChain, Tags: []string{"multi-agent", "tools", "memory", "slow"},
+		Variants: core.MultiAgentChainVariants, VariantFunction: core.EvaluateMultiAgentChainVariant},
+	{Name: "MultiAgentVariations", RunFunction: core.RunMultiAgentVariationsWrapper, Tags: []string{"multi-agent", "tools", "memory", "slow"}},
+	{Name: "ConcurrentRuns", RunFunction: core.RunConcurrentRuns, Tags: []string{"tools", "concurrency"}},
+	{Name: "Streaming", RunFunction: core.RunStreaming, EvalFunction: core.EvalStreaming, Tags: []string{"streaming"}},
+	{Name: "StreamingWithTools", RunFunction: core.RunStreamingWithTools, Tags: []string{"streaming", "tools"}},
+	{Name: "MemoryPersistence", RunFunction: core.RunMemoryPersistenceAgent, EvalFunction: core.EvalMemoryPersistence, Tags: []string{"multi-agent", "memory"}},
+	{Name: "MemoryCompartments", RunFunction: core.RunMemoryCompartments, Tags: []string{"memory"}},
+	{Name: "ClosedBook", RunFunction: core.RunClosedBook, Tags: []string{"basic", "accuracy"}},
+	{Name: "ContextOverflow", RunFunction: core.RunContextOverflow, Tags: []string{"documents", "slow"}},
+}
+
+// RunOptions holds the settings that control how runModels executes the capabilities
+type RunOptions struct {
+	ScoreModel  *ai.Model     // Judges each response when set
+	RetryFailed int           // Number of times a failing capability is rerun
+	MaxCost     float64       // Estimated USD budget; remaining tests are skipped once exceeded (0 = no limit)
+	Runs        int           // Times each capability is run per model (0 or 1 = once)
+	Timeout     time.Duration // Per-run limit (0 = no limit)
 }
 
+// ModelOptions holds the generation settings applied to every model created by the benchmark
+type ModelOptions struct {
+	Temperature float64 // negative keeps the provider default
+	Seed        int64   // negative keeps the provider default
+}
+
+const reportFilename = "comparison_report.md"
+
 type ModelDesc struct {
 	Name         string
 	ProviderFunc func(modelName string) *ai.Model
+	CostPerMTok  float64 // Estimated USD per million tokens; 0 for local models
 }
 
 func openAIProvider(modelName string) *ai.Model {
@@ -52,7 +106,16 @@ func openAIProvider(modelName string) *ai.Model {
 }
 
 func ollamaProvider(modelName string) *ai.Model {
-	return ollama.NewModel(modelName, "")
+	host := ollamaHost()
+	if err := ensureOllamaModel(host, modelName); err != nil {
+		slog.Error("Ollama model not available", "model", modelName, "error", err)
+		os.Exit(1)
+		return nil
+	}
+	// The second argument of NewModel is the API key; the server address is BaseURL
+	model := ollama.NewModel(modelName, "")
+	model.BaseURL = host
+	return model
 }
 
 func geminiProvider(modelName string) *ai.Model {
@@ -66,9 +129,9 @@ func geminiProvider(modelName string) *ai.Model {
 }
 
 var modelsTable = []ModelDesc{
-	{Name: "gpt-4o-mini", ProviderFunc: openAIProvider},
-	{Name: "gpt-4o", ProviderFunc: openAIProvider},
-	{Name: "gpt", ProviderFunc: openAIProvider},
+	{Name: "gpt-4o-mini", ProviderFunc: openAIProvider, CostPerMTok: 0.60},
+	{Name: "gpt-4o", ProviderFunc: openAIProvider, CostPerMTok: 10.00},
+	{Name: "gpt", ProviderFunc: openAIProvider, CostPerMTok: 10.00},
 	{Name: "qwen", ProviderFunc: ollamaProvider},
 	{Name: "llama3.2", ProviderFunc: ollamaProvider},
 	{Name: "gemma", ProviderFunc: ollamaProvider},
@@ -79,18 +142,142 @@ var modelsTable = []ModelDesc{
 func main() {
 	utils.LoadEnvFile("../.env")
 
+	// The aggregate subcommand combines saved result files and needs no models
+	if len(os.Args) > 1 && os.Args[1] == "aggregate" {
+		runAggregate(os.Args[2:])
+		return
+	}
+
 	// Define command-line flags
 	var testsFlag string
+	var tagsFlag string
 	var evalMode bool
+	var matrixMode bool
+	var loadMode bool
+	var concurrencyFlag string
+	var judgeMode bool
+	var scoreModelName string
+	var modelOptions ModelOptions
+	var runOptions RunOptions
+	var diffFlag string
+	var slowdownPercent float64
+	var eventLogFile string
+	var serveAddr string
+	var metricsAddr string
+	var pluginsFlag string
+	var abMode bool
+	var abRuns int
+	var rpmFlag string
+	var configFile string
+	flag.StringVar(&configFile, "config", "", "Read tests, models, run counts, timeouts and thresholds from a YAML file such as bench.yaml")
 	flag.StringVar(&testsFlag, "test", "", "Comma-separated list of tests to run (case-insensitive)")
+	flag.StringVar(&tagsFlag, "tags", "", "Comma-separated list of tags; runs tests that have any of them (case-insensitive)")
 	flag.BoolVar(&evalMode, "eval", false, "Run evaluation mode for tests that support it")
+	flag.BoolVar(&matrixMode, "matrix", false, "Evaluate every instruction variant of the tests against every model")
+	flag.BoolVar(&abMode, "ab", false, "Compare two models over repeated runs and report statistically significant differences")
+	flag.IntVar(&abRuns, "runs", 10, "Number of runs per test and model used by -ab")
+	flag.BoolVar(&loadMode, "load", false, "Run a concurrent load test against each model instead of the tests")
+	flag.StringVar(&concurrencyFlag, "concurrency", "5,20,50", "Comma-separated concurrency levels used by -load")
+	flag.BoolVar(&judgeMode, "judge", false, "Score each response 0-10 with the scoring model as judge")
+	flag.StringVar(&scoreModelName, "score-model", "", "Model used for scoring in -eval and -judge modes (defaults to the first model)")
+	flag.Float64Var(&modelOptions.Temperature, "temperature", -1, "Sampling temperature for all models (provider default if not set)")
+	flag.Float64Var(&runOptions.MaxCost, "max-cost", 0, "Abort when the estimated spend in USD exceeds this budget, skipping remaining tests (0 = no limit)")
+	flag.IntVar(&runOptions.RetryFailed, "retry-failed", 0, "Rerun failing tests up to N times; tests that pass on a rerun are reported as flaky")
+	flag.Int64Var(&modelOptions.Seed, "seed", -1, "Sampling seed for all models, where the provider supports it (provider default if not set)")
+	flag.StringVar(&diffFlag, "diff", "", "Compare an older comparison report against comparison_report.md (or the report given as argument)")
+	flag.Float64Var(&slowdownPercent, "slowdown", 20, "Percentage increase in duration reported as a slowdown by -diff")
+	flag.StringVar(&eventLogFile, "event-log", "", "Write every run event with a timestamp to this JSONL file")
+	flag.StringVar(&serveAddr, "serve", "", "Serve a dashboard of the saved result files on this address, e.g. :8080")
+	flag.StringVar(&metricsAddr, "metrics", "", "Expose Prometheus metrics on this address while the benchmark runs, e.g. :9090")
+	flag.StringVar(&rpmFlag, "rpm", "", "Comma-separated requests-per-minute caps per provider, e.g. \"openai=60,gemini=15\"")
+	flag.StringVar(&pluginsFlag, "plugin", "", "Comma-separated Go plugins (.so) that register extra tests with core.RegisterCapability")
 	flag.Parse()
 
-	// Get remaining arguments (model names)
+	// Values from the config file apply unless the matching flag was given explicitly
+	var runConfig *RunConfig
+	if configFile != "" {
+		var err error
+		runConfig, err = loadRunConfig(configFile)
+		if err != nil {
+			fmt.Printf("Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+		explicit := make(map[string]bool)
+		flag.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+		if !explicit["test"] {
+			testsFlag = joinNames(runConfig.Tests)
+		}
+		if !explicit["tags"] {
+			tagsFlag = joinNames(runConfig.Tags)
+		}
+		if !explicit["retry-failed"] {
+			runOptions.RetryFailed = runConfig.RetryFailed
+		}
+		if !explicit["max-cost"] {
+			runOptions.MaxCost = runConfig.MaxCost
+		}
+		if !explicit["temperature"] && runConfig.Temperature != nil {
+			modelOptions.Temperature = *runConfig.Temperature
+		}
+		if !explicit["seed"] && runConfig.Seed != nil {
+			modelOptions.Seed = *runConfig.Seed
+		}
+		if !explicit["judge"] {
+			judgeMode = runConfig.Judge
+		}
+		if !explicit["score-model"] {
+			scoreModelName = runConfig.ScoreModel
+		}
+		runOptions.Runs = runConfig.Runs
+		runOptions.Timeout = runConfig.Timeout
+		fmt.Printf("📋 Using run configuration %s\n", configFile)
+	}
+
+	// Add tests registered by plugins or by packages linked into the runner
+	if err := loadPlugins(pluginsFlag); err != nil {
+		fmt.Printf("Error loading plugins: %v\n", err)
+		os.Exit(1)
+	}
+	if err := addRegisteredCapabilities(); err != nil {
+		fmt.Printf("Error registering tests: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Get remaining arguments (model names), falling back to the models in the config file
 	args := flag.Args()
+	if len(args) == 0 && runConfig != nil {
+		args = runConfig.Models
+	}
+
+	if diffFlag != "" {
+		newReport := reportFilename
+		if len(args) > 0 {
+			newReport = args[0]
+		}
+		if err := diffReports(diffFlag, newReport, slowdownPercent); err != nil {
+			fmt.Printf("Error comparing reports: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if serveAddr != "" {
+		if err := runDashboard(serveAddr); err != nil {
+			fmt.Printf("Error serving dashboard: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	if len(args) < 1 {
-		fmt.Println("Usage: go run main.go [-test \"test1,test2\"] [-eval] <model_name> [model_name...]")
+		fmt.Println("Usage: go run main.go -load [-concurrency \"5,20,50\"] <model_name> [model_name...]")
+		fmt.Println("Usage: go run main.go -ab [-runs n] [-test \"test1,test2\"] <model_a> <model_b>")
+		fmt.Println("Usage: go run main.go -diff old_report.md [-slowdown percent] [new_report.md]")
+		fmt.Println("Usage: go run main.go aggregate [-out leaderboard.md] results-*.json [more.json...]")
+		fmt.Println("Usage: go run main.go -serve :8080")
+		fmt.Println("Usage: go run main.go -config bench.yaml [model_name...]")
+		fmt.Println("Usage: go run main.go [-test \"test1,test2\"] [-tags \"tag1,tag2\"] [-eval] [-matrix] [-judge] [-score-model name] [-temperature t] [-seed n] [-retry-failed n] [-max-cost usd] [-event-log file.jsonl] [-metrics :9090] [-plugin tests.so] [-rpm openai=60] <model_name> [model_name...]")
 		fmt.Println("\nAvailable models:")
 		for _, model := range modelsTable {
 			fmt.Printf("  %-s\n", model.Name)
@@ -101,16 +288,41 @@ func main() {
 			if cap.EvalFunction != nil {
 				evalSupport = " (supports -eval)"
 			}
-			fmt.Printf("  %s%s\n", cap.Name, evalSupport)
+			if len(cap.Variants) > 0 {
+				evalSupport += fmt.Sprintf(" (%d variants for -matrix)", len(cap.Variants))
+			}
+			fmt.Printf("  %s%s [%s]\n", cap.Name, evalSupport, strings.Join(cap.Tags, ", "))
 		}
 		fmt.Println("\nExamples:")
 		fmt.Println("  go run main.go gpt-4o-mini gemma3:12b")
 		fmt.Println("  go run main.go -test \"SimpleAgent,ToolIntegration\" qwen gpt-4o")
+		fmt.Println("  go run main.go -tags multi-agent gpt-4o-mini")
 		fmt.Println("  go run main.go -eval -test \"MultiAgentChain\" gpt-4o-mini")
+		fmt.Println("  go run main.go -matrix -test \"MultiAgentChain\" gpt-4o-mini qwen")
+		fmt.Println("  go run main.go -judge -score-model gpt-4o gpt-4o-mini qwen llama3.2")
+		fmt.Println("  go run main.go -temperature 0 -seed 42 gpt-4o-mini qwen")
+		fmt.Println("  go run main.go -retry-failed 2 qwen llama3.2")
+		fmt.Println("  go run main.go -max-cost 0.50 gpt-4o gpt-4o-mini")
+		fmt.Println("  go run main.go -rpm \"openai=3\" gpt-4o-mini")
+		fmt.Println("  go run main.go -diff old_report.md -slowdown 30")
+		fmt.Println("  go run main.go aggregate results-*.json other-machine/results-*.json")
+		fmt.Println("  go run main.go -serve :8080")
+		fmt.Println("  go run main.go -config bench.yaml")
+		fmt.Println("  go run main.go -load -concurrency \"5,20\" gpt-4o-mini")
+		fmt.Println("  go run main.go -ab -runs 20 -test ToolIntegration gpt-4o-mini qwen")
+		fmt.Println("  go run main.go -event-log events.jsonl -test MemoryPersistence qwen")
+		fmt.Println("  go run main.go -metrics :9090 -eval gpt-4o-mini qwen")
+		fmt.Println("  go run main.go -plugin ../my-tests/tests.so -test MyPrivateTest gpt-4o-mini")
 		fmt.Println("  go run main.go -eval -test \"MultiAgentContextManager\" gpt-4o-mini")
 		os.Exit(1)
 	}
 
+	// Throttle before any model is created so provider pre-flight calls are limited too
+	if err := installRateLimits(rpmFlag); err != nil {
+		fmt.Printf("Error setting rate limits: %v\n", err)
+		os.Exit(1)
+	}
+
 	modelName := strings.Join(args, " ")
 
 	// Parse individual model names from the input
@@ -118,7 +330,7 @@ func main() {
 
 	models := []*ai.Model{}
 	for _, name := range modelNames {
-		model := createModel(name)
+		model := createModel(name, modelOptions)
 		if model == nil {
 			fmt.Printf("Model unknown or missing authentication: %s\n", name)
 			fmt.Println("\nAvailable models:")
@@ -137,11 +349,57 @@ func main() {
 
 	// Filter capabilities based on test flag
 	filteredCapabilities := filterCapabilities(testsFlag)
+	filteredCapabilities = filterCapabilitiesByTags(filteredCapabilities, tagsFlag)
+
+	// Use the explicit scoring model if given, otherwise score with the first model
+	scoreModel := models[0]
+	if scoreModelName != "" {
+		scoreModel = createModel(scoreModelName, modelOptions)
+		if scoreModel == nil {
+			fmt.Printf("Score model unknown or missing authentication: %s\n", scoreModelName)
+			os.Exit(1)
+		}
+	}
+
+	if eventLogFile != "" {
+		eventLog, err := core.OpenEventLog(eventLogFile)
+		if err != nil {
+			fmt.Printf("Error creating event log: %v\n", err)
+			os.Exit(1)
+		}
+		defer eventLog.Close()
+		fmt.Printf("📝 Logging run events to %s\n", eventLogFile)
+	}
 
-	if evalMode {
-		runEvaluationMode(models, filteredCapabilities)
+	if metricsAddr != "" {
+		core.EnableMetrics().ServeMetrics(metricsAddr)
+		fmt.Printf("📡 Serving Prometheus metrics on %s/metrics\n", metricsAddr)
+	}
+
+	if loadMode {
+		runLoadMode(models, concurrencyFlag)
+	} else if abMode {
+		runABMode(models, filteredCapabilities, abRuns)
+	} else if evalMode {
+		runEvaluationMode(models, filteredCapabilities, scoreModel)
+	} else if matrixMode {
+		runMatrixMode(models, filteredCapabilities)
 	} else {
-		runModels(models, filteredCapabilities)
+		if judgeMode {
+			runOptions.ScoreModel = scoreModel
+			fmt.Printf("📊 Using %s for judge scoring\n", scoreModel.ModelName)
+		}
+		results := runModels(models, filteredCapabilities, runOptions)
+
+		if runConfig != nil {
+			if violations := checkThresholds(results, runConfig.Thresholds); len(violations) > 0 {
+				fmt.Printf("\n🚫 %d threshold(s) not met:\n", len(violations))
+				for _, violation := range violations {
+					fmt.Printf("  %s\n", violation)
+				}
+				os.Exit(1)
+			}
+		}
 	}
 }
 
@@ -178,8 +436,52 @@ func filterCapabilities(testsFlag string) []Capability {
 	return filtered
 }
 
-func runModels(models []*ai.Model, capabilitiesToRun []Capability) {
+// filterCapabilitiesByTags keeps the capabilities that have at least one of the comma-separated tags
+func filterCapabilitiesByTags(capabilitiesToFilter []Capability, tagsFlag string) []Capability {
+	if tagsFlag == "" {
+		return capabilitiesToFilter
+	}
+
+	// Parse comma-separated tags
+	tags := strings.Split(tagsFlag, ",")
+	for i, tag := range tags {
+		tags[i] = strings.TrimSpace(tag)
+	}
+
+	var filtered []Capability
+	for _, capability := range capabilitiesToFilter {
+		if hasAnyTag(capability, tags) {
+			filtered = append(filtered, capability)
+		}
+	}
+
+	if len(filtered) == 0 {
+		fmt.Printf("No matching tests found for tags: %s\n", tagsFlag)
+		fmt.Println("\nAvailable tests:")
+		for _, cap := range capabilitiesToFilter {
+			fmt.Printf("  %s [%s]\n", cap.Name, strings.Join(cap.Tags, ", "))
+		}
+		os.Exit(1)
+	}
+
+	return filtered
+}
+
+func hasAnyTag(capability Capability, tags []string) bool {
+	for _, capabilityTag := range capability.Tags {
+		for _, tag := range tags {
+			if strings.EqualFold(capabilityTag, tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// runModels runs every capability against every model using the run options and returns the results
+func runModels(models []*ai.Model, capabilitiesToRun []Capability, options RunOptions) [][]core.BenchResult {
 	allResults := make([][]core.BenchResult, len(models))
+	spent := 0.0
 
 	for index, model := range models {
 		fmt.Printf("\n🤖 Testing %s\n", model.ModelName)
@@ -189,21 +491,122 @@ func runModels(models []*ai.Model, capabilitiesToRun []Capability) {
 		for _, testCase := range capabilitiesToRun {
 			fmt.Printf("  %s... ", testCase.Name)
 
-			result, err := testCase.RunFunction(model)
-			results = append(results, result)
+			// Skip remaining tests once the budget is exhausted
+			if options.MaxCost > 0 && spent >= options.MaxCost {
+				fmt.Printf("⏭️  SKIPPED (budget of $%.2f exceeded)\n", options.MaxCost)
+				results = append(results, core.BenchResult{
+					TestCase:     testCase.Name,
+					ModelName:    model.ModelName,
+					ErrorMessage: "skipped: cost budget exceeded",
+					Metadata:     map[string]interface{}{"skipped": true},
+				})
+				continue
+			}
+
+			if err := testCase.setup(); err != nil {
+				fmt.Printf("❌ FAILED (%v)\n", err)
+				results = append(results, core.BenchResult{
+					TestCase:     testCase.Name,
+					ModelName:    model.ModelName,
+					ErrorMessage: err.Error(),
+				})
+				continue
+			}
+
+			result, attempts, err := runRepeated(testCase, model, options)
+			testCase.teardown()
+
+			core.ObserveTest(result)
+
+			cost := estimateCost(result) * float64(attempts)
+			if cost > 0 {
+				if result.Metadata == nil {
+					result.Metadata = make(map[string]interface{})
+				}
+				result.Metadata["estimated_cost"] = cost
+				spent += cost
+			}
+
 			if err != nil {
 				fmt.Printf("❌ FAILED (%v)\n", result.Duration)
+			} else if flaky, _ := result.Metadata["flaky"].(bool); flaky {
+				// attempts counts every run, so only retries make a pass flaky
+				fmt.Printf("⚠️  FLAKY PASS after %d attempts (%v)\n", attempts, result.Duration)
 			} else {
 				fmt.Printf("✅ SUCCESS (%v)\n", result.Duration)
 			}
+
+			if options.ScoreModel != nil {
+				if err := core.JudgeResult(options.ScoreModel, &result); err != nil {
+					fmt.Printf("    ⚠️  Judge scoring failed: %v\n", err)
+				} else if score, ok := result.Metadata["judge_score"].(float64); ok {
+					fmt.Printf("    📊 Judge score: %.1f/10\n", score)
+				}
+			}
+			results = append(results, result)
 		}
 		allResults[index] = results
 	}
 
+	if spent > 0 {
+		fmt.Printf("\n💰 Estimated spend: $%.4f\n", spent)
+	}
+
+	writeResultsFile(allResults)
 	generateComparisonReport(allResults)
+	return allResults
 }
 
-func runEvaluationMode(models []*ai.Model, capabilitiesToRun []Capability) {
+// runRepeated runs the capability options.Runs times, rerunning failures up to options.RetryFailed
+// times each. With several runs the returned result carries the pass rate and the average duration.
+func runRepeated(testCase Capability, model *ai.Model, options RunOptions) (core.BenchResult, int, error) {
+	runs := max(options.Runs, 1)
+
+	var result core.BenchResult
+	var err error
+	var totalDuration time.Duration
+	totalAttempts, passes := 0, 0
+	for run := 1; run <= runs; run++ {
+		if runs > 1 {
+			fmt.Printf("[%d/%d] ", run, runs)
+		}
+		result, err = runWithTimeout(testCase, model, options.Timeout)
+
+		// Rerun failures to separate transient provider errors from real failures
+		attempts := 1
+		for (err != nil || !result.Success) && attempts <= options.RetryFailed {
+			fmt.Printf("🔁 RETRY %d/%d... ", attempts, options.RetryFailed)
+			attempts++
+			result, err = runWithTimeout(testCase, model, options.Timeout)
+		}
+		totalAttempts += attempts
+		totalDuration += result.Duration
+		if err == nil && result.Success {
+			passes++
+		}
+	}
+
+	if result.Metadata == nil {
+		result.Metadata = make(map[string]interface{})
+	}
+	if totalAttempts > runs {
+		result.Metadata["attempts"] = totalAttempts
+		result.Metadata["flaky"] = passes == runs
+	}
+	if runs > 1 {
+		result.Metadata["runs"] = runs
+		result.Metadata["pass_rate"] = float64(passes) / float64(runs)
+		result.Duration = totalDuration / time.Duration(runs)
+		result.Success = passes == runs
+		if err == nil && !result.Success {
+			err = fmt.Errorf("%d of %d runs passed", passes, runs)
+		}
+	}
+	return result, totalAttempts, err
+}
+
+// runEvaluationMode evaluates every model, judging all of them with the same scoring model
+func runEvaluationMode(models []*ai.Model, capabilitiesToRun []Capability, scoreModel *ai.Model) {
 	fmt.Println("🔍 Running in Evaluation Mode")
 	fmt.Println("=" + strings.Repeat("=", 40))
 
@@ -212,34 +615,73 @@ func runEvaluationMode(models []*ai.Model, capabilitiesToRun []Capability) {
 		os.Exit(1)
 	}
 
-	// Use first model as primary, second as scoring model (or same if only one)
-	primaryModel := models[0]
-	scoreModel := primaryModel
-	if len(models) > 1 {
-		scoreModel = models[1]
-		fmt.Printf("📊 Using %s for scoring evaluations\n", scoreModel.ModelName)
-	} else {
-		fmt.Printf("📊 Using %s for both testing and scoring\n", primaryModel.ModelName)
+	fmt.Printf("📊 Using %s for scoring evaluations\n", scoreModel.ModelName)
+
+	for _, model := range models {
+		fmt.Printf("\n🤖 Evaluating model: %s\n\n", model.ModelName)
+
+		for _, capability := range capabilitiesToRun {
+			fmt.Printf("🔬 Evaluating %s...\n", capability.Name)
+			fmt.Println("-" + strings.Repeat("-", 40))
+
+			if err := capability.setup(); err != nil {
+				fmt.Printf("❌ %v\n\n", err)
+				continue
+			}
+
+			if capability.EvalFunction != nil {
+				// Use custom evaluation function if available
+				capability.EvalFunction(model, scoreModel)
+			} else {
+				// Run standard benchmark with evaluation enabled
+				runCapabilityWithEval(capability, model)
+			}
+			capability.teardown()
+
+			fmt.Println()
+		}
 	}
 
-	fmt.Printf("🤖 Primary model: %s\n\n", primaryModel.ModelName)
+	fmt.Println("✅ Evaluation complete!")
+}
 
-	for _, capability := range capabilitiesToRun {
-		fmt.Printf("🔬 Evaluating %s...\n", capability.Name)
-		fmt.Println("-" + strings.Repeat("-", 40))
+// runLoadMode runs the load test at each concurrency level for every model
+func runLoadMode(models []*ai.Model, concurrencyFlag string) {
+	fmt.Println("🏋️ Running in Load Test Mode")
+	fmt.Println("=" + strings.Repeat("=", 40))
 
-		if capability.EvalFunction != nil {
-			// Use custom evaluation function if available
-			capability.EvalFunction(primaryModel, scoreModel)
-		} else {
-			// Run standard benchmark with evaluation enabled
-			runCapabilityWithEval(capability, primaryModel)
+	// Parse comma-separated concurrency levels
+	var levels []int
+	for _, level := range strings.Split(concurrencyFlag, ",") {
+		concurrency, err := strconv.Atoi(strings.TrimSpace(level))
+		if err != nil || concurrency < 1 {
+			fmt.Printf("Invalid concurrency level: %s\n", level)
+			os.Exit(1)
 		}
+		levels = append(levels, concurrency)
+	}
 
-		fmt.Println()
+	for _, model := range models {
+		fmt.Printf("\n🤖 Load testing %s\n", model.ModelName)
+		fmt.Printf("  %-12s %-12s %-12s %-10s %-10s %-10s\n", "Concurrency", "Runs/s", "Error rate", "p50", "p90", "p99")
+
+		for _, concurrency := range levels {
+			result := core.RunLoadTest(model, concurrency)
+			fmt.Printf("  %-12d %-12.2f %-12s %-10s %-10s %-10s\n",
+				result.Concurrency,
+				result.Throughput(),
+				fmt.Sprintf("%.1f%%", result.ErrorRate()),
+				formatSeconds(result.Percentile(50)),
+				formatSeconds(result.Percentile(90)),
+				formatSeconds(result.Percentile(99)))
+		}
 	}
 
-	fmt.Println("✅ Evaluation complete!")
+	fmt.Println("\n✅ Load test complete!")
+}
+
+func formatSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.1fs", d.Seconds())
 }
 
 // runCapabilityWithEval runs a capability with evaluation enabled
@@ -263,21 +705,66 @@ func runCapabilityWithEval(capability Capability, model *ai.Model) {
 	}
 }
 
-func createModel(modelName string) *ai.Model {
-	for _, modelDesc := range modelsTable {
+// createModel creates the named model and applies the generation options to it
+func createModel(modelName string, options ModelOptions) *ai.Model {
+	model := findModel(modelName)
+	if model == nil {
+		return nil
+	}
+
+	if options.Temperature >= 0 {
+		model.WithTemperature(options.Temperature)
+	}
+	if options.Seed >= 0 {
+		// Providers create models without a parameter map, and WithParameter
+		// writes to it directly
+		if model.Parameters == nil {
+			model.Parameters = map[string]interface{}{}
+		}
+		model.WithParameter("seed", options.Seed)
+	}
+	return model
+}
+
+func findModel(modelName string) *ai.Model {
+	modelDesc := findModelDesc(modelName)
+	if modelDesc == nil {
+		return nil
+	}
+	return modelDesc.ProviderFunc(modelName)
+}
+
+// findModelDesc returns the table entry for the model, matching exact names before prefixes
+func findModelDesc(modelName string) *ModelDesc {
+	for i, modelDesc := range modelsTable {
 		if modelDesc.Name == modelName {
-			return modelDesc.ProviderFunc(modelName)
+			return &modelsTable[i]
 		}
 	}
 
-	for _, modelDesc := range modelsTable {
+	for i, modelDesc := range modelsTable {
 		if strings.HasPrefix(modelName, modelDesc.Name) {
-			return modelDesc.ProviderFunc(modelName)
+			return &modelsTable[i]
 		}
 	}
 	return nil
 }
 
+// promptAllowanceTokens approximates the instructions, tool schemas and messages sent
+// during a test, which are not visible in the result
+const promptAllowanceTokens = 2000
+
+// estimateCost gives a rough spend estimate for a result from the estimated response tokens
+func estimateCost(result core.BenchResult) float64 {
+	modelDesc := findModelDesc(result.ModelName)
+	if modelDesc == nil || modelDesc.CostPerMTok == 0 {
+		return 0
+	}
+
+	tokens := promptAllowanceTokens + core.EstimateTokens(result.Response)
+	return float64(tokens) * modelDesc.CostPerMTok / 1_000_000
+}
+
 func generateComparisonReport(results [][]core.BenchResult) {
 	if len(results) == 0 {
 		return
@@ -335,8 +822,15 @@ func generateComparisonReport(results [][]core.BenchResult) {
 			result, exists := testGroups[capability][model]
 			if !exists {
 				report += " | N/A"
+			} else if skipped, _ := result.Metadata["skipped"].(bool); skipped {
+				report += " | ⏭️ Skipped"
+			} else if flaky, _ := result.Metadata["flaky"].(bool); flaky {
+				report += fmt.Sprintf(" | ⚠️ Flaky pass (%d attempts)", result.Metadata["attempts"])
 			} else if result.Success {
 				report += " | ✅ Success"
+			} else if traceFile, ok := result.Metadata["trace_file"].(string); ok {
+				// Link failures to their trace file for debugging
+				report += fmt.Sprintf(" | ❌ Failure ([trace](%s))", traceFile)
 			} else {
 				report += " | ❌ Failure"
 			}
@@ -347,7 +841,7 @@ func generateComparisonReport(results [][]core.BenchResult) {
 		report += fmt.Sprintf("| %s (timing)", capability)
 		for _, model := range models {
 			result, exists := testGroups[capability][model]
-			if !exists {
+			if skipped, _ := result.Metadata["skipped"].(bool); !exists || skipped {
 				report += " | N/A"
 			} else {
 				// Format duration to show seconds with 1 decimal place
@@ -356,14 +850,107 @@ func generateComparisonReport(results [][]core.BenchResult) {
 			}
 		}
 		report += " |\n"
+
+		// Optional metric rows, shown only when a model recorded them
+		report += metricRow(capability+" (pass rate)", testGroups[capability], models, "pass_rate", "%.2f")
+		report += metricRow(capability+" (judge score)", testGroups[capability], models, "judge_score", "%.1f/10")
+		report += metricRow(capability+" (time to first token)", testGroups[capability], models, "time_to_first_token", "%.2fs")
+		report += metricRow(capability+" (tokens/s)", testGroups[capability], models, "tokens_per_second", "%.1f")
+		report += metricRow(capability+" (argument accuracy)", testGroups[capability], models, "argument_accuracy", "%.2f")
 	}
 
-	filename := "comparison_report.md"
-	err := os.WriteFile(filename, []byte(report), 0644)
+	report += argumentAccuracySummary(testGroups, models)
+	report += promptsSection(testGroups, capabilities)
+
+	err := os.WriteFile(reportFilename, []byte(report), 0644)
 	if err != nil {
 		fmt.Printf("Error writing comparison report: %v\n", err)
 		return
 	}
 
-	fmt.Printf("📊 Comparison report generated: %s\n", filename)
+	fmt.Printf("📊 Comparison report generated: %s\n", reportFilename)
+}
+
+// metricRow formats a report row for a numeric metadata value, or returns "" when no model recorded it
+func metricRow(label string, results map[string]core.BenchResult, models []string, key string, format string) string {
+	row := fmt.Sprintf("| %s", label)
+	recorded := false
+	for _, model := range models {
+		value, ok := results[model].Metadata[key].(float64)
+		if !ok {
+			row += " | N/A"
+			continue
+		}
+		row += " | " + fmt.Sprintf(format, value)
+		recorded = true
+	}
+
+	if !recorded {
+		return ""
+	}
+	return row + " |\n"
+}
+
+// argumentAccuracySummary averages the tool argument accuracy of each model across capabilities,
+// or returns "" when no capability checked tool arguments
+func argumentAccuracySummary(testGroups map[string]map[string]core.BenchResult, models []string) string {
+	summary := "\n## Tool Argument Accuracy\n\n"
+	summary += "| Model | Accuracy | Tests |\n"
+	summary += "|---|---|---|\n"
+
+	recorded := false
+	for _, model := range models {
+		total := 0.0
+		count := 0
+		for _, results := range testGroups {
+			if accuracy, ok := results[model].Metadata["argument_accuracy"].(float64); ok {
+				total += accuracy
+				count++
+			}
+		}
+		if count == 0 {
+			summary += fmt.Sprintf("| %s | N/A | 0 |\n", model)
+			continue
+		}
+
+		average := total / float64(count)
+		summary += fmt.Sprintf("| %s | %.1f%% | %d |\n", model, average*100, count)
+		fmt.Printf("🎯 %s tool argument accuracy: %.1f%% across %d tests\n", model, average*100, count)
+		recorded = true
+	}
+
+	if !recorded {
+		return ""
+	}
+	return summary
+}
+
+// promptsSection lists the instructions and user messages each capability ran with
+func promptsSection(testGroups map[string]map[string]core.BenchResult, capabilities []string) string {
+	section := "\n## Prompts\n"
+	recorded := false
+	for _, capability := range capabilities {
+		for _, result := range testGroups[capability] {
+			instructions, ok := result.Metadata["agent_instructions"].(string)
+			if !ok {
+				continue
+			}
+
+			section += fmt.Sprintf("\n### %s\n\n", capability)
+			section += fmt.Sprintf("- **Description**: %s\n", result.Metadata["agent_description"])
+			section += fmt.Sprintf("- **Instructions**: %s\n", strings.Join(strings.Fields(instructions), " "))
+			if message, ok := result.Metadata["user_message"].(string); ok {
+				section += fmt.Sprintf("- **User message**: %s\n", message)
+			} else if messages, ok := result.Metadata["user_messages"].([]string); ok {
+				section += fmt.Sprintf("- **User messages**: %s\n", strings.Join(messages, " / "))
+			}
+			recorded = true
+			break
+		}
+	}
+
+	if !recorded {
+		return ""
+	}
+	return section
 }
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic-examples/benchmark/core"
+	"github.com/nexxia-ai/aigentic/ai"
+)
+
+const matrixReportFilename = "matrix_report.md"
+
+// runMatrixMode evaluates every instruction variant of each capability against every model
+// and writes a variants × models grid with the pass rate and score of each cell
+func runMatrixMode(models []*ai.Model, capabilitiesToRun []Capability) {
+	fmt.Println("🧮 Running in Matrix Mode")
+	fmt.Println("=" + strings.Repeat("=", 40))
+
+	report := "# Variant Matrix Report\n\n"
+	report += fmt.Sprintf("Generated on: %s\n\n", time.Now().Format("2006-01-02 15:04:05"))
+
+	matrixRun := false
+	for _, capability := range capabilitiesToRun {
+		if capability.VariantFunction == nil || len(capability.Variants) == 0 {
+			continue
+		}
+		matrixRun = true
+
+		fmt.Printf("\n🔬 %s: %d variants × %d models\n", capability.Name, len(capability.Variants), len(models))
+		fmt.Println("-" + strings.Repeat("-", 40))
+
+		if err := capability.setup(); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			continue
+		}
+
+		// results[variant][model]
+		results := make([][]core.AgentTestResult, len(capability.Variants))
+		for i, variant := range capability.Variants {
+			results[i] = make([]core.AgentTestResult, len(models))
+			for j, model := range models {
+				fmt.Printf("\n--- %s / %s ---\n", variant.Name, model.ModelName)
+				results[i][j] = capability.VariantFunction(model, variant)
+				printMatrixCell(results[i][j])
+			}
+		}
+		capability.teardown()
+
+		report += fmt.Sprintf("## %s\n\n", capability.Name)
+
+		// Create header row
+		report += "| Variant"
+		for _, model := range models {
+			report += fmt.Sprintf(" | %s", model.ModelName)
+		}
+		report += " |\n"
+
+		// Create separator row
+		report += "|---"
+		for range models {
+			report += "|---"
+		}
+		report += "|\n"
+
+		// Create a row for each variant with pass rate and score per model
+		for i, variant := range capability.Variants {
+			report += fmt.Sprintf("| %s", variant.Name)
+			for j := range models {
+				result := results[i][j]
+				icon := "✅"
+				if !result.Success {
+					icon = "❌"
+				}
+				report += fmt.Sprintf(" | %s %.1f%% / %.2f", icon, result.PassRate, result.AvgScore)
+			}
+			report += " |\n"
+		}
+		report += "\n"
+
+		// Describe each variant below the grid
+		for _, variant := range capability.Variants {
+			report += fmt.Sprintf("- **%s**: %s\n", variant.Name, variant.Description)
+		}
+		report += "\n"
+	}
+
+	if !matrixRun {
+		fmt.Println("❌ None of the selected tests declare instruction variants")
+		fmt.Println("\nTests with variants:")
+		for _, cap := range capabilities {
+			if len(cap.Variants) > 0 {
+				fmt.Printf("  %s (%d variants)\n", cap.Name, len(cap.Variants))
+			}
+		}
+		os.Exit(1)
+	}
+
+	err := os.WriteFile(matrixReportFilename, []byte(report), 0644)
+	if err != nil {
+		fmt.Printf("Error writing matrix report: %v\n", err)
+		return
+	}
+
+	fmt.Printf("\n📊 Matrix report generated: %s\n", matrixReportFilename)
+}
+
+func printMatrixCell(result core.AgentTestResult) {
+	if result.Success {
+		fmt.Printf("✅ PASS: %.1f%% pass rate, %.2f avg score\n", result.PassRate, result.AvgScore)
+	} else {
+		fmt.Printf("❌ FAIL: %.1f%% pass rate, %.2f avg score\n", result.PassRate, result.AvgScore)
+	}
+}
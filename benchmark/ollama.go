@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const defaultOllamaHost = "http://localhost:11434"
+
+// ollamaHost returns the Ollama server from OLLAMA_HOST, accepting the host:port form the
+// ollama CLI uses, or the local default
+func ollamaHost() string {
+	host := strings.TrimSpace(os.Getenv("OLLAMA_HOST"))
+	if host == "" {
+		return defaultOllamaHost
+	}
+	if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+		host = "http://" + host
+	}
+	return strings.TrimSuffix(host, "/")
+}
+
+// ensureOllamaModel checks that the model is pulled on the Ollama server and offers to pull it,
+// so a missing model is reported before the suite starts rather than as a failure mid-run
+func ensureOllamaModel(host string, modelName string) error {
+	pulled, err := ollamaHasModel(host, modelName)
+	if err != nil {
+		return fmt.Errorf("cannot reach Ollama at %s (set OLLAMA_HOST to change it): %w", host, err)
+	}
+	if pulled {
+		return nil
+	}
+
+	fmt.Printf("Model %s is not pulled on %s. Pull it now? [y/N] ", modelName, host)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	if !strings.EqualFold(strings.TrimSpace(answer), "y") {
+		return fmt.Errorf("model %s is not available; run 'ollama pull %s'", modelName, modelName)
+	}
+
+	return ollamaPull(host, modelName)
+}
+
+// ollamaHasModel lists the local models and matches the name with or without a tag
+func ollamaHasModel(host string, modelName string) (bool, error) {
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(host + "/api/tags")
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("listing models: %s", resp.Status)
+	}
+
+	var tags struct {
+		Models []struct {
+			Name string `json:"name"`
+		} `json:"models"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
+		return false, err
+	}
+
+	for _, model := range tags.Models {
+		if model.Name == modelName || model.Name == modelName+":latest" ||
+			(!strings.Contains(modelName, ":") && strings.HasPrefix(model.Name, modelName+":")) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ollamaPull downloads the model, waiting until the pull completes
+func ollamaPull(host string, modelName string) error {
+	fmt.Printf("⬇️  Pulling %s from %s...\n", modelName, host)
+
+	body, _ := json.Marshal(map[string]interface{}{"model": modelName, "stream": false})
+	resp, err := http.Post(host+"/api/pull", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("pulling %s: %w", modelName, err)
+	}
+	defer resp.Body.Close()
+
+	var status struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return fmt.Errorf("pulling %s: %w", modelName, err)
+	}
+	if status.Error != "" || resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("pulling %s: %s %s", modelName, resp.Status, status.Error)
+	}
+
+	fmt.Printf("✅ Pulled %s\n", modelName)
+	return nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"plugin"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic-examples/benchmark/core"
+)
+
+// loadPlugins opens each comma-separated Go plugin so its init functions can call
+// core.RegisterCapability. Plugins are built in their own module with -buildmode=plugin.
+func loadPlugins(pluginsFlag string) error {
+	if pluginsFlag == "" {
+		return nil
+	}
+
+	for _, path := range strings.Split(pluginsFlag, ",") {
+		path = strings.TrimSpace(path)
+		if _, err := plugin.Open(path); err != nil {
+			return fmt.Errorf("loading plugin %s: %w", path, err)
+		}
+		fmt.Printf("🔌 Loaded plugin %s\n", path)
+	}
+	return nil
+}
+
+// addRegisteredCapabilities appends the capabilities registered through core.RegisterCapability
+// to the built-in ones
+func addRegisteredCapabilities() error {
+	for _, registered := range core.RegisteredCapabilities() {
+		for _, existing := range capabilities {
+			if strings.EqualFold(existing.Name, registered.Name) {
+				return fmt.Errorf("registered capability %s clashes with a built-in test", registered.Name)
+			}
+		}
+
+		capabilities = append(capabilities, Capability{
+			Name:         registered.Name,
+			RunFunction:  registered.RunFunction,
+			EvalFunction: registered.EvalFunction,
+			Tags:         registered.Tags,
+		})
+	}
+	return nil
+}
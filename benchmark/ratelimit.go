@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// providerHosts maps the provider names accepted by -rpm to the API hosts they call
+var providerHosts = map[string]func() string{
+	"openai": func() string { return "api.openai.com" },
+	"gemini": func() string { return "generativelanguage.googleapis.com" },
+	"ollama": func() string {
+		if u, err := url.Parse(ollamaHost()); err == nil {
+			return u.Host
+		}
+		return ""
+	},
+}
+
+// requestLimiter spaces requests to stay under a requests-per-minute cap
+type requestLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func (l *requestLimiter) wait() {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	time.Sleep(delay)
+}
+
+// rateLimitedTransport throttles requests per host and waits out 429 responses
+type rateLimitedTransport struct {
+	base     http.RoundTripper
+	limiters map[string]*requestLimiter // by host
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	limiter := t.limiters[req.URL.Host]
+	if limiter == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	limiter.wait()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusTooManyRequests || req.GetBody == nil {
+		return resp, err
+	}
+
+	// Retry once after the server's back-off so 429s are not counted as test failures
+	delay := retryAfter(resp.Header.Get("Retry-After"), limiter.interval)
+	resp.Body.Close()
+	fmt.Printf("⏳ Rate limited by %s, retrying in %v\n", req.URL.Host, delay)
+	time.Sleep(delay)
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	retry := req.Clone(req.Context())
+	retry.Body = body
+
+	limiter.wait()
+	return t.base.RoundTrip(retry)
+}
+
+// retryAfter parses a Retry-After header in seconds, falling back to the limiter interval
+func retryAfter(header string, fallback time.Duration) time.Duration {
+	if seconds, err := strconv.Atoi(strings.TrimSpace(header)); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if fallback < time.Second {
+		return time.Second
+	}
+	return fallback
+}
+
+// installRateLimits parses "provider=rpm,..." and throttles the default HTTP transport used by
+// the provider clients. A cap of 0 leaves the provider unlimited.
+func installRateLimits(rpmFlag string) error {
+	if rpmFlag == "" {
+		return nil
+	}
+
+	limiters := make(map[string]*requestLimiter)
+	for _, entry := range strings.Split(rpmFlag, ",") {
+		provider, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return fmt.Errorf("invalid rate limit %q, expected provider=rpm", entry)
+		}
+
+		hostFunc, ok := providerHosts[strings.ToLower(provider)]
+		if !ok {
+			return fmt.Errorf("unknown provider %q (use openai, gemini or ollama)", provider)
+		}
+
+		rpm, err := strconv.Atoi(value)
+		if err != nil || rpm < 0 {
+			return fmt.Errorf("invalid requests per minute for %s: %q", provider, value)
+		}
+		if rpm == 0 {
+			continue
+		}
+
+		limiters[hostFunc()] = &requestLimiter{interval: time.Minute / time.Duration(rpm)}
+		fmt.Printf("🚦 Limiting %s to %d requests per minute\n", provider, rpm)
+	}
+
+	transport := &rateLimitedTransport{base: http.DefaultTransport, limiters: limiters}
+	http.DefaultTransport = transport
+	http.DefaultClient.Transport = transport
+	return nil
+}
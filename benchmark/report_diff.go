@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// reportEntry is the status and timing of one capability and model cell in a comparison report
+type reportEntry struct {
+	Status  string
+	Seconds float64
+	Timed   bool
+}
+
+// parseComparisonReport reads a comparison report into entries keyed by capability and model
+func parseComparisonReport(filename string) (map[string]map[string]reportEntry, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entries := make(map[string]map[string]reportEntry)
+	var models []string
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Summary sections follow the comparison table
+		if strings.HasPrefix(line, "## ") && models != nil {
+			break
+		}
+		if !strings.HasPrefix(line, "|") || strings.HasPrefix(line, "|---") {
+			continue
+		}
+
+		cells := strings.Split(strings.Trim(line, "|"), "|")
+		for i, cell := range cells {
+			cells[i] = strings.TrimSpace(cell)
+		}
+
+		if cells[0] == "Capability" {
+			models = cells[1:]
+			continue
+		}
+
+		capability := cells[0]
+		timing := strings.HasSuffix(capability, " (timing)")
+		capability = strings.TrimSuffix(capability, " (timing)")
+
+		// Only status and timing rows are compared
+		if strings.HasSuffix(capability, ")") {
+			continue
+		}
+
+		if entries[capability] == nil {
+			entries[capability] = make(map[string]reportEntry)
+		}
+
+		for i, cell := range cells[1:] {
+			if i >= len(models) {
+				break
+			}
+			entry := entries[capability][models[i]]
+			if timing {
+				seconds, err := strconv.ParseFloat(strings.TrimSuffix(cell, "s"), 64)
+				if err == nil {
+					entry.Seconds = seconds
+					entry.Timed = true
+				}
+			} else {
+				entry.Status = parseStatus(cell)
+			}
+			entries[capability][models[i]] = entry
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if models == nil {
+		return nil, fmt.Errorf("%s does not contain a comparison table", filename)
+	}
+
+	return entries, nil
+}
+
+func parseStatus(cell string) string {
+	switch {
+	case strings.Contains(cell, "Flaky"):
+		return "flaky pass"
+	case strings.Contains(cell, "Success"):
+		return "success"
+	case strings.Contains(cell, "Failure"):
+		return "failure"
+	case strings.Contains(cell, "Skipped"):
+		return "skipped"
+	default:
+		return "n/a"
+	}
+}
+
+// diffReports prints the capabilities that changed status or slowed down by more than
+// slowdownPercent between the old and the new comparison report
+func diffReports(oldFilename string, newFilename string, slowdownPercent float64) error {
+	oldEntries, err := parseComparisonReport(oldFilename)
+	if err != nil {
+		return err
+	}
+	newEntries, err := parseComparisonReport(newFilename)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("📊 Comparing %s → %s\n", oldFilename, newFilename)
+	fmt.Println("-" + strings.Repeat("-", 40))
+
+	capabilities := make(map[string]bool)
+	for capability := range oldEntries {
+		capabilities[capability] = true
+	}
+	for capability := range newEntries {
+		capabilities[capability] = true
+	}
+
+	var names []string
+	for capability := range capabilities {
+		names = append(names, capability)
+	}
+	sort.Strings(names)
+
+	changes := 0
+	for _, capability := range names {
+		models := make(map[string]bool)
+		for model := range oldEntries[capability] {
+			models[model] = true
+		}
+		for model := range newEntries[capability] {
+			models[model] = true
+		}
+
+		var modelNames []string
+		for model := range models {
+			modelNames = append(modelNames, model)
+		}
+		sort.Strings(modelNames)
+
+		for _, model := range modelNames {
+			oldEntry, inOld := oldEntries[capability][model]
+			newEntry, inNew := newEntries[capability][model]
+
+			switch {
+			case !inOld:
+				fmt.Printf("  ➕ %s [%s]: added (%s)\n", capability, model, newEntry.Status)
+				changes++
+			case !inNew:
+				fmt.Printf("  ➖ %s [%s]: removed\n", capability, model)
+				changes++
+			default:
+				if oldEntry.Status != newEntry.Status {
+					icon := "🔄"
+					if newEntry.Status == "failure" {
+						icon = "❌"
+					} else if oldEntry.Status == "failure" {
+						icon = "✅"
+					}
+					fmt.Printf("  %s %s [%s]: %s → %s\n", icon, capability, model, oldEntry.Status, newEntry.Status)
+					changes++
+				}
+
+				if oldEntry.Timed && newEntry.Timed && oldEntry.Seconds > 0 {
+					change := (newEntry.Seconds - oldEntry.Seconds) / oldEntry.Seconds * 100
+					if change > slowdownPercent {
+						fmt.Printf("  🐢 %s [%s]: %.1fs → %.1fs (+%.0f%%)\n",
+							capability, model, oldEntry.Seconds, newEntry.Seconds, change)
+						changes++
+					}
+				}
+			}
+		}
+	}
+
+	if changes == 0 {
+		fmt.Println("  No status changes or slowdowns found")
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic-examples/documents/loader"
+)
+
+// Limits protect the expansion from archives that are too large or built to exhaust
+// memory. A zip header can claim any size, so the limits are enforced on the bytes
+// actually read.
+type Limits struct {
+	MaxFiles     int   // entries looked at; the rest are skipped
+	MaxFileSize  int64 // larger files are skipped
+	MaxTotalSize int64 // expansion stops when the accepted files reach this size
+	Kinds        map[loader.Kind]bool
+}
+
+// Skipped is an entry that was not turned into a document, and why
+type Skipped struct {
+	Path   string
+	Reason string
+}
+
+// junk reports entries that are never documents: folders, macOS resource forks and
+// Finder files, and other hidden files
+func junk(name string) bool {
+	if strings.HasSuffix(name, "/") || strings.HasPrefix(name, "__MACOSX/") {
+		return true
+	}
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Expand turns the files of a zip archive into in-memory documents, without writing
+// anything to disk. Formats are detected with the loader, so HTML and Word files
+// become markdown. Documents are named by their path inside the archive, so two
+// README.md files in different folders stay apart. It takes the archive as bytes, so
+// it works the same for a file on disk and for an upload read from an HTTP request.
+func Expand(data []byte, limits Limits) ([]loader.Loaded, []Skipped, error) {
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("not a zip archive: %w", err)
+	}
+
+	files := append([]*zip.File(nil), r.File...)
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+
+	l := loader.New()
+	l.MaxSize = limits.MaxFileSize
+
+	var loaded []loader.Loaded
+	var skipped []Skipped
+	var total int64
+	seen := 0
+	for _, f := range files {
+		name := path.Clean(strings.ReplaceAll(f.Name, `\`, "/"))
+		if junk(f.Name) {
+			continue
+		}
+		seen++
+		if limits.MaxFiles > 0 && seen > limits.MaxFiles {
+			skipped = append(skipped, Skipped{name, fmt.Sprintf("more than %d files in the archive", limits.MaxFiles)})
+			continue
+		}
+
+		content, err := readEntry(f, limits.MaxFileSize)
+		if err != nil {
+			skipped = append(skipped, Skipped{name, err.Error()})
+			continue
+		}
+		if limits.MaxTotalSize > 0 && total+int64(len(content)) > limits.MaxTotalSize {
+			skipped = append(skipped, Skipped{name, fmt.Sprintf("the accepted files already total %d KB of the %d KB limit", total/1024, limits.MaxTotalSize/1024)})
+			continue
+		}
+
+		format := loader.Detect(name, content)
+		if limits.Kinds != nil && !limits.Kinds[format.Kind] {
+			skipped = append(skipped, Skipped{name, fmt.Sprintf("%s files are not accepted", format.Kind)})
+			continue
+		}
+		item, err := l.Load(name, content)
+		if err != nil {
+			skipped = append(skipped, Skipped{name, err.Error()})
+			continue
+		}
+		if dir := path.Dir(name); dir != "." {
+			item.Document.Filename = path.Join(dir, item.Document.Filename)
+		}
+		item.Document.FilePath = name
+		item.Path = name
+
+		total += int64(len(content))
+		loaded = append(loaded, item)
+	}
+	return loaded, skipped, nil
+}
+
+// readEntry reads one file of the archive, stopping one byte after the limit so a
+// file that lies about its size is caught
+func readEntry(f *zip.File, limit int64) ([]byte, error) {
+	if limit > 0 && f.UncompressedSize64 > uint64(limit) {
+		return nil, fmt.Errorf("%d KB, over the %d KB file limit", f.UncompressedSize64/1024, limit/1024)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var r io.Reader = rc
+	if limit > 0 {
+		r = io.LimitReader(rc, limit+1)
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && int64(len(content)) > limit {
+		return nil, fmt.Errorf("more than the %d KB file limit", limit/1024)
+	}
+	return content, nil
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic-examples/documents/loader"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+const sampleEmail = `From: accounts@contoso.example
+To: billing@northwind.example
+Date: 14 March 2025
+Subject: Invoice INV-2291 overdue
+
+Hello,
+
+Invoice INV-2291 for $13,500, dated 15 January 2025, is still unpaid. Under the
+services agreement payment was due within 45 days. Please confirm when we can expect
+payment, or let us know if there is a dispute about the invoice.
+
+Regards,
+Contoso Accounts
+`
+
+const sampleNotes = `<html><body>
+<h1>Meeting notes: Contoso invoice dispute</h1>
+<p>20 March 2025. Attendees: Finance, Legal, Operations.</p>
+<ul>
+<li>Finance paid INV-2291 on 18 March, 62 days after the invoice date.</li>
+<li>Operations reports two outages in February that brought uptime to 99.7% for the month.</li>
+<li>Legal to check whether the outages entitle us to a service credit that offsets the late fee.</li>
+</ul>
+</body></html>
+`
+
+// sampleArchive builds a case file the way people send them: documents in folders,
+// plus a large log, a binary, an image, and the junk macOS adds to zips
+func sampleArchive() ([]byte, error) {
+	read := func(path string) []byte {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", path, err)
+		}
+		return data
+	}
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{"contoso-case/contracts/services_agreement_v1.md", read("../testdata/services_agreement_v1.md")},
+		{"contoso-case/contracts/services_agreement_v2.md", read("../testdata/services_agreement_v2.md")},
+		{"contoso-case/correspondence/2025-03-14 invoice overdue.eml", []byte(sampleEmail)},
+		{"contoso-case/correspondence/meeting-notes", []byte(sampleNotes)},
+		{"contoso-case/evidence/receipt.png", read("../testdata/receipt.png")},
+		{"contoso-case/logs/uptime.log", bytes.Repeat([]byte("2025-02-11T03:12:44Z ok latency_ms=41\n"), 40000)},
+		{"contoso-case/tools/export.bin", []byte{0x7f, 'E', 'L', 'F', 0x02, 0x01, 0x01, 0x00, 0xff, 0xfe}},
+		{"contoso-case/.DS_Store", []byte{0x00, 0x00, 0x00, 0x01, 'B', 'u', 'd', '1'}},
+		{"__MACOSX/contoso-case/contracts/._services_agreement_v1.md", []byte{0x00, 0x05, 0x16, 0x07}},
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range files {
+		fw, err := w.Create(f.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fw.Write(f.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	file := flag.String("file", "", "Zip archive to ingest (default: a generated case file)")
+	kinds := flag.String("types", "text,markdown,csv,json,html,pdf,docx", "Comma-separated document kinds to accept")
+	maxFile := flag.Int64("max-file", 1<<20, "Largest file to accept, in bytes")
+	maxTotal := flag.Int64("max-total", 20<<20, "Largest total size of accepted files, in bytes")
+	show := flag.Bool("show", false, "Print what was accepted and skipped, and exit without calling the model")
+	question := flag.String("q", "Summarize the Contoso dispute: what was invoiced, when it was paid, what the contract says about late payment and uptime, and whether we are owed a service credit.", "Question to ask about the archive")
+	flag.Parse()
+
+	fmt.Println("Ingesting a Zip Archive")
+	fmt.Println("=======================")
+	fmt.Println()
+
+	var data []byte
+	var err error
+	if *file != "" {
+		data, err = os.ReadFile(*file)
+	} else {
+		fmt.Println("No -file given, using a generated case file")
+		data, err = sampleArchive()
+	}
+	if err != nil {
+		log.Fatalf("Failed to read archive: %v", err)
+	}
+
+	limits := Limits{MaxFiles: 500, MaxFileSize: *maxFile, MaxTotalSize: *maxTotal, Kinds: map[loader.Kind]bool{}}
+	for _, k := range strings.Split(*kinds, ",") {
+		limits.Kinds[loader.Kind(strings.TrimSpace(k))] = true
+	}
+
+	loaded, skipped, err := Expand(data, limits)
+	if err != nil {
+		log.Fatalf("Failed to expand archive: %v", err)
+	}
+
+	fmt.Printf("📦 %d KB archive: %d documents accepted, %d files skipped\n\n", len(data)/1024, len(loaded), len(skipped))
+	var manifest strings.Builder
+	for _, l := range loaded {
+		note := ""
+		if l.Converted {
+			note = fmt.Sprintf(" (converted from %s)", l.Format.Kind)
+		}
+		fmt.Printf("   ✔️  %-56s %8d bytes%s\n", l.Document.Filename, l.Document.FileSize, note)
+		fmt.Fprintf(&manifest, "- %s (%s, %d bytes)\n", l.Document.Filename, l.Format.Kind, l.Document.FileSize)
+	}
+	for _, s := range skipped {
+		fmt.Printf("   ✖️  %-56s %s\n", s.Path, s.Reason)
+	}
+	fmt.Println()
+
+	if *show {
+		return
+	}
+	if len(loaded) == 0 {
+		log.Fatalf("Nothing in the archive was accepted")
+	}
+
+	// The whole archive is attached as references; the manifest in the instructions
+	// tells the agent what there is to read
+	agent := aigentic.Agent{
+		Model:       openai.NewModel("gpt-4o-mini", getAPIKey()),
+		Name:        "CaseFileAnalyst",
+		Description: "Answers questions about the documents in an uploaded archive",
+		Instructions: "You have a case file uploaded as a zip archive. Its documents are:\n" + manifest.String() +
+			"\nRead the documents you need, answer from them only, and name the document behind each fact.",
+		DocumentReferences: loader.Documents(loaded),
+	}
+
+	fmt.Printf("❓ %s\n\n", *question)
+	response, err := agent.Execute(*question)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	fmt.Printf("Answer:\n%s\n\n", response)
+
+	fmt.Println("✅ Example completed successfully!")
+}
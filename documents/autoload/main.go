@@ -0,0 +1,161 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic-examples/documents/loader"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/document"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+// Text documents up to this size are embedded; larger ones are attached as references
+// the agent retrieves on demand
+const embedLimit = 10 * 1024
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+const releaseNotes = `<!DOCTYPE html>
+<html>
+<head><title>Release notes</title><script>trackPageView();</script></head>
+<body>
+<h1>Expense Portal 4.2</h1>
+<p>Released on 3 March 2025.</p>
+<h2>Changes</h2>
+<ul>
+<li>Hotel receipts over $250 a night now need manager approval before submission.</li>
+<li>Receipts can be photographed in the mobile app instead of scanned.</li>
+</ul>
+</body>
+</html>
+`
+
+const expenses = `date,employee,category,amount
+2025-03-04,Dana Whitfield,hotel,289.00
+2025-03-05,Dana Whitfield,meals,46.20
+2025-03-11,Raj Patel,hotel,212.00
+2025-03-12,Raj Patel,taxi,38.50
+`
+
+// writeSamples fills a folder with files whose names do not always match their
+// content: a PNG saved as .jpg, an HTML page and a CSV without extensions, and a
+// binary file the loader should reject
+func writeSamples(dir string) error {
+	copies := map[string]string{
+		"../testdata/receipt.png": "scan.jpg",
+		"../testdata/handbook.md": "handbook.md",
+	}
+	for from, to := range copies {
+		data, err := os.ReadFile(from)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, to), data, 0o644); err != nil {
+			return err
+		}
+	}
+
+	files := map[string][]byte{
+		"release-notes": []byte(releaseNotes),
+		"expenses":      []byte(expenses),
+		"backup.bin":    {0x00, 0x9f, 0x92, 0x96, 0xff, 0xfe, 0x01},
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	show := flag.Bool("show", false, "Print what was detected and exit without calling the model")
+	question := flag.String("q", "Do any of the expenses or the scanned receipt break the hotel rules in the handbook or the release notes? List each one with the amount.", "Question to ask about the documents")
+	flag.Parse()
+
+	fmt.Println("Loading Documents with Format Detection")
+	fmt.Println("=======================================")
+	fmt.Println()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		dir, err := os.MkdirTemp("", "autoload-example-")
+		if err != nil {
+			log.Fatalf("Failed to create temp dir: %v", err)
+		}
+		defer os.RemoveAll(dir)
+
+		if err := writeSamples(dir); err != nil {
+			log.Fatalf("Failed to write samples: %v", err)
+		}
+		paths = []string{dir}
+		fmt.Println("No files given, using a folder of samples with misleading or missing extensions")
+		fmt.Println()
+	}
+
+	loaded, errs := loader.New().LoadPaths(paths...)
+
+	var embedded, referenced []*document.Document
+	fmt.Printf("%-16s %-9s %-26s %-10s %s\n", "FILE", "KIND", "MIME TYPE", "BY", "DOCUMENT")
+	for _, l := range loaded {
+		by := "extension"
+		if l.Format.Sniffed {
+			by = "content"
+		}
+		mode := "embedded"
+		if l.Format.Kind != loader.KindImage && l.Document.FileSize > embedLimit {
+			referenced = append(referenced, l.Document)
+			mode = "reference"
+		} else {
+			embedded = append(embedded, l.Document)
+		}
+		if l.Converted {
+			mode = "converted, " + mode
+		}
+		fmt.Printf("%-16s %-9s %-26s %-10s %s (%s)\n", filepath.Base(l.Path), l.Format.Kind, l.Format.MimeType, by, l.Document.Filename, mode)
+	}
+	for _, err := range errs {
+		fmt.Printf("⚠️  skipped: %v\n", err)
+	}
+	fmt.Println()
+
+	if *show {
+		return
+	}
+	if len(loaded) == 0 {
+		log.Fatalf("No documents could be loaded")
+	}
+
+	agent := aigentic.Agent{
+		Model:              openai.NewModel("gpt-4o-mini", getAPIKey()),
+		Name:               "ExpenseReviewer",
+		Description:        "Checks expenses against company policy",
+		Instructions:       "Answer from the documents only. Say which document each fact comes from.",
+		Documents:          embedded,
+		DocumentReferences: referenced,
+	}
+
+	fmt.Printf("❓ %s\n\n", *question)
+	response, err := agent.Execute(*question)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	fmt.Printf("Analysis:\n%s\n\n", response)
+
+	fmt.Println("✅ Example completed successfully!")
+}
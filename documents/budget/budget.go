@@ -0,0 +1,188 @@
+// Package budget decides which documents to embed in an agent's context and which to
+// leave as references, given a token budget. Without it, the choice is a guess, and a
+// guess that embeds too much overflows the context window on the first call.
+package budget
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic/document"
+)
+
+// Action is what happens to a document
+type Action string
+
+const (
+	Embed     Action = "embed"     // the whole document goes into the context
+	Truncate  Action = "truncate"  // the start goes into the context, the whole document stays a reference
+	Reference Action = "reference" // the agent reads it on demand
+)
+
+// Budget is the number of tokens documents may use in the context, and how documents
+// that do not fit are handled
+type Budget struct {
+	Tokens int
+
+	// MaxDocShare keeps one document from taking more than this share of the budget,
+	// so a single large file cannot crowd out everything else (0 = no limit)
+	MaxDocShare float64
+
+	// MinTruncate is the smallest excerpt worth embedding. When less room is left, a
+	// document that does not fit becomes a reference instead of a stub.
+	MinTruncate int
+}
+
+// Decision is what Pack did with one document
+type Decision struct {
+	Doc      *document.Document
+	Action   Action
+	Tokens   int // estimated tokens of the whole document
+	Embedded int // tokens put into the context
+	Reason   string
+}
+
+// Plan is the result of packing: the documents to embed, the references, and why
+type Plan struct {
+	Budget     Budget
+	Used       int
+	Decisions  []Decision
+	Documents  []*document.Document // for Agent.Documents
+	References []*document.Document // for Agent.DocumentReferences
+}
+
+// CharsPerToken is the usual estimate for English text. It is an estimate: code and
+// other languages use more tokens per character, so leave some headroom in the budget.
+const CharsPerToken = 4
+
+// EstimateTokens estimates the tokens a document uses in the context. Text is counted
+// at CharsPerToken. Images are counted the way OpenAI bills them in high detail: the
+// image is scaled to fit 2048x2048, then so its short side is at most 768 pixels, and
+// costs 170 tokens per 512-pixel tile plus 85. Other binary formats, such as PDF, are
+// counted by size like text, which overestimates them; convert them to text first
+// for a better estimate.
+func EstimateTokens(doc *document.Document) (int, error) {
+	data, err := doc.Bytes()
+	if err != nil {
+		return 0, err
+	}
+	if strings.HasPrefix(doc.MimeType, "image/") {
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return 0, fmt.Errorf("%s: reading image size: %w", doc.Filename, err)
+		}
+		return imageTokens(cfg.Width, cfg.Height), nil
+	}
+	return (len(data) + CharsPerToken - 1) / CharsPerToken, nil
+}
+
+func imageTokens(width, height int) int {
+	w, h := float64(width), float64(height)
+	if scale := 2048 / max(w, h); scale < 1 {
+		w, h = w*scale, h*scale
+	}
+	if scale := 768 / min(w, h); scale < 1 {
+		w, h = w*scale, h*scale
+	}
+	tiles := ((int(w) + 511) / 512) * ((int(h) + 511) / 512)
+	return 170*tiles + 85
+}
+
+// Pack goes through the documents in priority order, most important first. A document
+// is embedded whole if it fits in what is left of the budget and within the per-document
+// share. Otherwise a text document is truncated to the room left, if that is at least
+// MinTruncate tokens, and also kept as a reference so the agent can read the rest. Any
+// other document becomes a reference. Later, smaller documents can still fill the room
+// a large one left, so the order decides who goes first, not who goes at all.
+func Pack(docs []*document.Document, budget Budget) (Plan, error) {
+	plan := Plan{Budget: budget}
+	perDoc := budget.Tokens
+	if budget.MaxDocShare > 0 {
+		perDoc = int(float64(budget.Tokens) * budget.MaxDocShare)
+	}
+
+	for _, doc := range docs {
+		tokens, err := EstimateTokens(doc)
+		if err != nil {
+			return plan, err
+		}
+		left := budget.Tokens - plan.Used
+		d := Decision{Doc: doc, Tokens: tokens}
+		isText := !strings.HasPrefix(doc.MimeType, "image/") && doc.MimeType != "application/pdf"
+
+		switch room := min(left, perDoc); {
+		case tokens <= room:
+			d.Action, d.Embedded = Embed, tokens
+			d.Reason = fmt.Sprintf("fits (%d of %d tokens left)", left, budget.Tokens)
+			plan.Documents = append(plan.Documents, doc)
+
+		case isText && room >= budget.MinTruncate && room > 0:
+			excerpt, err := truncate(doc, room, tokens)
+			if err != nil {
+				return plan, err
+			}
+			d.Action, d.Embedded = Truncate, (int(excerpt.FileSize)+CharsPerToken-1)/CharsPerToken
+			if room == perDoc && perDoc < left {
+				d.Reason = fmt.Sprintf("larger than the per-document limit of %d tokens", perDoc)
+			} else {
+				d.Reason = fmt.Sprintf("only %d tokens left", left)
+			}
+			plan.Documents = append(plan.Documents, excerpt)
+			plan.References = append(plan.References, doc)
+
+		default:
+			d.Action = Reference
+			switch {
+			case !isText && tokens > room:
+				d.Reason = fmt.Sprintf("does not fit and %s cannot be truncated", doc.MimeType)
+			default:
+				d.Reason = fmt.Sprintf("only %d tokens left, under the %d token minimum for an excerpt", left, budget.MinTruncate)
+			}
+			plan.References = append(plan.References, doc)
+		}
+		plan.Used += d.Embedded
+		plan.Decisions = append(plan.Decisions, d)
+	}
+	return plan, nil
+}
+
+// truncate makes an excerpt of a text document that fits in the given tokens, cut at
+// a paragraph or line break where possible, with a note saying where the rest is
+func truncate(doc *document.Document, tokens, total int) (*document.Document, error) {
+	data, err := doc.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	const noteFormat = "\n\n[Excerpt: the first part of %s, about %d of %d tokens. Read the document reference %s for the rest.]\n"
+	noteLen := len(fmt.Sprintf(noteFormat, doc.Filename, total, total, doc.Filename))
+	limit := max(0, tokens*CharsPerToken-noteLen)
+
+	text := string(data[:min(limit, len(data))])
+	if cut := strings.LastIndex(text, "\n\n"); cut > limit/2 {
+		text = text[:cut]
+	} else if cut := strings.LastIndex(text, "\n"); cut > limit/2 {
+		text = text[:cut]
+	}
+	text = strings.ToValidUTF8(text, "")
+	text += fmt.Sprintf(noteFormat, doc.Filename, len(text)/CharsPerToken, total, doc.Filename)
+
+	excerpt := document.NewInMemoryDocument(doc.ID()+"_excerpt", doc.Filename, []byte(text), doc)
+	excerpt.MimeType = doc.MimeType
+	return excerpt, nil
+}
+
+// Print writes the decisions as a table, with the totals at the end
+func (p Plan) Print(w io.Writer) {
+	fmt.Fprintf(w, "%-28s %-10s %8s %9s  %s\n", "DOCUMENT", "ACTION", "TOKENS", "EMBEDDED", "WHY")
+	for _, d := range p.Decisions {
+		fmt.Fprintf(w, "%-28s %-10s %8d %9d  %s\n", d.Doc.Filename, d.Action, d.Tokens, d.Embedded, d.Reason)
+	}
+	fmt.Fprintf(w, "\nEmbedded %d of %d budgeted tokens; %d documents embedded, %d references\n",
+		p.Used, p.Budget.Tokens, len(p.Documents), len(p.References))
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Cache keeps the results of expensive document steps on disk, such as PDF text
+// extraction and embeddings. Entries are keyed by the step and a hash of the input
+// content, not by file name, so a renamed or copied file is still a hit and an edited
+// file is always a miss. Nothing has to be invalidated: changed content simply has a
+// new key.
+//
+// The step name includes a version, for example "extract/pdf/v2". Bump it when the
+// step changes, and entries made by the old code are no longer found.
+type Cache struct {
+	dir string
+
+	mu     sync.Mutex
+	hits   map[string]int
+	misses map[string]int
+}
+
+func OpenCache(dir string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Cache{dir: dir, hits: map[string]int{}, misses: map[string]int{}}, nil
+}
+
+// Key is the hash an input is stored under for a step
+func Key(step string, input []byte) string {
+	h := sha256.New()
+	h.Write([]byte(step))
+	h.Write([]byte{0})
+	h.Write(input)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (c *Cache) path(step, key string) string {
+	// Two-character subfolders keep folders small for large corpora
+	return filepath.Join(c.dir, strings.ReplaceAll(step, "/", "_"), key[:2], key+".json")
+}
+
+// Cached returns the stored result of step for input, or runs compute and stores what
+// it returns. Errors are not cached, so a failed step is retried on the next run. The
+// second result reports whether the value came from the cache.
+func Cached[T any](c *Cache, step string, input []byte, compute func() (T, error)) (T, bool, error) {
+	path := c.path(step, Key(step, input))
+
+	var value T
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if err := json.Unmarshal(data, &value); err == nil {
+			c.count(c.hits, step)
+			return value, true, nil
+		}
+		// A damaged entry is recomputed and overwritten
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return value, false, err
+	}
+
+	c.count(c.misses, step)
+	value, err = compute()
+	if err != nil {
+		return value, false, err
+	}
+	if err := c.write(path, value); err != nil {
+		return value, false, fmt.Errorf("caching %s: %w", step, err)
+	}
+	return value, false, nil
+}
+
+// write stores an entry through a temporary file, so a run that is interrupted never
+// leaves half an entry behind
+func (c *Cache) write(path string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (c *Cache) count(m map[string]int, step string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m[step]++
+}
+
+// Stats returns the hits and misses per step since the cache was opened
+func (c *Cache) Stats() (hits, misses map[string]int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	hits, misses = map[string]int{}, map[string]int{}
+	for k, v := range c.hits {
+		hits[k] = v
+	}
+	for k, v := range c.misses {
+		misses[k] = v
+	}
+	return hits, misses
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic-examples/documents/loader"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+const embeddingModel = "text-embedding-3-small"
+
+// The steps are versioned: change the number when the step's code changes, so old
+// entries are not reused
+const (
+	extractStep = "extract/v1"
+	embedStep   = "embed/" + embeddingModel + "/v1"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+type indexedChunk struct {
+	Source string
+	Chunk
+}
+
+// writeSamples creates the default corpus. The handbook is copied twice under
+// different names: the copy is a cache hit even on the first run.
+func writeSamples(dir string) error {
+	copies := []struct{ from, to string }{
+		{"../testdata/handbook.md", "handbook.md"},
+		{"../testdata/services_agreement_v2.md", "services_agreement.md"},
+		{"../testdata/handbook.md", "archive/handbook_2024.md"},
+		{"../testdata/services_agreement_v1.md", "archive/services_agreement_old.md"},
+	}
+	for _, c := range copies {
+		data, err := os.ReadFile(c.from)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, c.to)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return err
+		}
+	}
+	return writeSamplePDF(filepath.Join(dir, "travel_policy_update.pdf"), []string{
+		"Travel Policy Update - effective 1 April 2025",
+		"",
+		"Hotel limits are raised to $275 per night in London, New York and Tokyo.",
+		"All other cities stay at $200 per night.",
+		"Economy class is required for flights under 6 hours.",
+		"Rail is preferred over flights for trips under 4 hours.",
+	})
+}
+
+func collectFiles(root string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+// indexFile extracts and embeds one file, each step through the cache
+func indexFile(ctx context.Context, c *Cache, apiKey, path string) ([]Chunk, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	format := loader.Detect(filepath.Base(path), data)
+
+	// The kind is part of the key because it decides how the bytes are read, and the
+	// same bytes named .md or .html give different text
+	start := time.Now()
+	input := append([]byte(format.Kind+"\x00"), data...)
+	text, extractHit, err := Cached(c, extractStep, input, func() (string, error) {
+		return extractText(ctx, format, data)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	extractTime := time.Since(start)
+
+	// Embeddings are keyed by the extracted text, so the same text in a different file
+	// format is not embedded twice
+	start = time.Now()
+	chunks, embedHit, err := Cached(c, embedStep, []byte(text), func() ([]Chunk, error) {
+		texts := splitChunks(text, 1200)
+		if len(texts) == 0 {
+			return nil, nil
+		}
+		vectors, err := embed(ctx, apiKey, embeddingModel, texts)
+		if err != nil {
+			return nil, err
+		}
+		chunks := make([]Chunk, len(texts))
+		for i := range texts {
+			chunks[i] = Chunk{Text: texts[i], Vector: vectors[i]}
+		}
+		return chunks, nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	status := func(hit bool, d time.Duration) string {
+		if hit {
+			return fmt.Sprintf("cached (%s)", d.Round(time.Millisecond))
+		}
+		return fmt.Sprintf("computed (%s)", d.Round(time.Millisecond))
+	}
+	return chunks, fmt.Sprintf("extract %-22s embed %s", status(extractHit, extractTime), status(embedHit, time.Since(start))), nil
+}
+
+func cosine(a, b []float32) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+func createSearchTool(apiKey string, index []indexedChunk) aigentic.AgentTool {
+	type SearchInput struct {
+		Query string `json:"query" description:"What to look for, phrased as a question or keywords"`
+	}
+
+	return aigentic.NewTool(
+		"search_documents",
+		"Searches the documents and returns the most relevant passages with their file names",
+		func(run *aigentic.AgentRun, input SearchInput) (string, error) {
+			vectors, err := embed(context.Background(), apiKey, embeddingModel, []string{input.Query})
+			if err != nil {
+				return "", err
+			}
+			ranked := make([]indexedChunk, len(index))
+			copy(ranked, index)
+			sort.SliceStable(ranked, func(i, j int) bool {
+				return cosine(ranked[i].Vector, vectors[0]) > cosine(ranked[j].Vector, vectors[0])
+			})
+
+			var sb strings.Builder
+			for _, c := range ranked[:min(4, len(ranked))] {
+				fmt.Fprintf(&sb, "[%s]\n%s\n\n", c.Source, c.Text)
+			}
+			return sb.String(), nil
+		},
+	)
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	defaultCache := filepath.Join(os.TempDir(), "aigentic-document-cache")
+	if dir, err := os.UserCacheDir(); err == nil {
+		defaultCache = filepath.Join(dir, "aigentic-examples", "documents")
+	}
+	cacheDir := flag.String("cache", defaultCache, "Cache directory")
+	clearCache := flag.Bool("clear", false, "Empty the cache before running")
+	question := flag.String("q", "What is the hotel limit in London now, and what did it used to be?", "Question to ask about the documents")
+	flag.Parse()
+
+	fmt.Println("Caching Extracted and Embedded Documents")
+	fmt.Println("========================================")
+	fmt.Println()
+
+	if *clearCache {
+		if err := os.RemoveAll(*cacheDir); err != nil {
+			log.Fatalf("Failed to clear cache: %v", err)
+		}
+	}
+	cache, err := OpenCache(*cacheDir)
+	if err != nil {
+		log.Fatalf("Failed to open cache: %v", err)
+	}
+	fmt.Printf("🗄️  Cache: %s\n", *cacheDir)
+
+	root := flag.Arg(0)
+	if root == "" {
+		// A new temporary folder every run: the cache still hits, because entries are
+		// keyed by content and not by path
+		root, err = os.MkdirTemp("", "cache-example-")
+		if err != nil {
+			log.Fatalf("Failed to create temp dir: %v", err)
+		}
+		defer os.RemoveAll(root)
+		if err := writeSamples(root); err != nil {
+			log.Fatalf("Failed to write samples: %v", err)
+		}
+	}
+	files, err := collectFiles(root)
+	if err != nil {
+		log.Fatalf("Failed to list %s: %v", root, err)
+	}
+	fmt.Println()
+
+	apiKey := getAPIKey()
+	ctx := context.Background()
+
+	start := time.Now()
+	var index []indexedChunk
+	for _, path := range files {
+		name, _ := filepath.Rel(root, path)
+		chunks, status, err := indexFile(ctx, cache, apiKey, path)
+		if err != nil {
+			fmt.Printf("⚠️  %-34s %v\n", name, err)
+			continue
+		}
+		fmt.Printf("📄 %-34s %s\n", name, status)
+		for _, c := range chunks {
+			index = append(index, indexedChunk{Source: name, Chunk: c})
+		}
+	}
+
+	hits, misses := cache.Stats()
+	fmt.Printf("\nIndexed %d files into %d passages in %s\n", len(files), len(index), time.Since(start).Round(time.Millisecond))
+	for _, step := range []string{extractStep, embedStep} {
+		fmt.Printf("   %-40s %d hits, %d misses\n", step, hits[step], misses[step])
+	}
+	if misses[extractStep]+misses[embedStep] > 0 {
+		fmt.Println("   Run the example again: unchanged files are not extracted or embedded a second time.")
+	}
+	fmt.Println()
+
+	if len(index) == 0 {
+		log.Fatalf("Nothing was indexed")
+	}
+
+	agent := aigentic.Agent{
+		Model:        openai.NewModel("gpt-4o-mini", apiKey),
+		Name:         "CachedCorpusAssistant",
+		Description:  "Answers questions from a document collection",
+		Instructions: "Use search_documents to find passages, and answer from them only. Name the file each fact comes from. When documents disagree, say which one is newer if you can tell.",
+		AgentTools:   []aigentic.AgentTool{createSearchTool(apiKey, index)},
+	}
+
+	fmt.Printf("❓ %s\n\n", *question)
+	response, err := agent.Execute(*question)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	fmt.Printf("Answer:\n%s\n\n", response)
+
+	fmt.Println("✅ Example completed successfully!")
+}
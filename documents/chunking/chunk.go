@@ -0,0 +1,211 @@
+package main
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Chunk is a piece of the source text. Heading is the section path the chunk came
+// from, when the strategy knows it.
+type Chunk struct {
+	Index   int
+	Heading string
+	Text    string
+}
+
+// Strategy splits a document into chunks
+type Strategy struct {
+	Name  string
+	Split func(text string) []Chunk
+}
+
+// FixedSize cuts every size characters, with overlap characters repeated at the start
+// of the next chunk. It ignores structure, so sentences and tables are cut anywhere.
+func FixedSize(size, overlap int) Strategy {
+	return Strategy{
+		Name: "fixed-size",
+		Split: func(text string) []Chunk {
+			runes := []rune(text)
+			var chunks []Chunk
+			for start := 0; start < len(runes); start += size - overlap {
+				end := min(start+size, len(runes))
+				chunks = append(chunks, Chunk{Index: len(chunks), Text: string(runes[start:end])})
+				if end == len(runes) {
+					break
+				}
+			}
+			return chunks
+		},
+	}
+}
+
+var sentenceEnd = regexp.MustCompile(`[.!?]["')\]]?\s+|\n\s*\n`)
+
+// splitSentences returns the sentences in text, keeping their punctuation
+func splitSentences(text string) []string {
+	var sentences []string
+	last := 0
+	for _, m := range sentenceEnd.FindAllStringIndex(text, -1) {
+		if s := strings.TrimSpace(text[last:m[1]]); s != "" {
+			sentences = append(sentences, s)
+		}
+		last = m[1]
+	}
+	if s := strings.TrimSpace(text[last:]); s != "" {
+		sentences = append(sentences, s)
+	}
+	return sentences
+}
+
+// packSentences groups whole sentences into chunks of at most maxChars. A single
+// sentence longer than maxChars becomes its own chunk.
+func packSentences(sentences []string, maxChars int) []string {
+	var packed []string
+	var current strings.Builder
+	for _, s := range sentences {
+		if current.Len() > 0 && current.Len()+1+len(s) > maxChars {
+			packed = append(packed, current.String())
+			current.Reset()
+		}
+		if current.Len() > 0 {
+			current.WriteString(" ")
+		}
+		current.WriteString(s)
+	}
+	if current.Len() > 0 {
+		packed = append(packed, current.String())
+	}
+	return packed
+}
+
+// Sentences packs whole sentences into chunks of up to maxChars, so no sentence is
+// cut in half. Section boundaries are still ignored.
+func Sentences(maxChars int) Strategy {
+	return Strategy{
+		Name: "sentence-aware",
+		Split: func(text string) []Chunk {
+			var chunks []Chunk
+			for _, s := range packSentences(splitSentences(text), maxChars) {
+				chunks = append(chunks, Chunk{Index: len(chunks), Text: s})
+			}
+			return chunks
+		},
+	}
+}
+
+var headingLine = regexp.MustCompile(`^(#{1,6})\s+(.+)$`)
+
+// Headings makes one chunk per markdown section and prefixes it with its heading
+// path, such as "Time Off > Parental leave", so a chunk read on its own still says
+// what it is about. Sections longer than maxChars are split by sentences, and every
+// part keeps the heading.
+func Headings(maxChars int) Strategy {
+	return Strategy{
+		Name: "heading-aware",
+		Split: func(text string) []Chunk {
+			var chunks []Chunk
+			var path []string
+			var body strings.Builder
+
+			flush := func() {
+				content := strings.TrimSpace(body.String())
+				body.Reset()
+				if content == "" {
+					return
+				}
+				heading := strings.Join(path, " > ")
+				for _, part := range packSentences(splitSentences(content), maxChars) {
+					chunks = append(chunks, Chunk{Index: len(chunks), Heading: heading, Text: heading + "\n\n" + part})
+				}
+			}
+
+			for _, line := range strings.Split(text, "\n") {
+				m := headingLine.FindStringSubmatch(line)
+				if m == nil {
+					body.WriteString(line + "\n")
+					continue
+				}
+				flush()
+				level := len(m[1])
+				if level <= len(path) {
+					path = path[:level-1]
+				}
+				for len(path) < level-1 {
+					path = append(path, "")
+				}
+				path = append(path, strings.TrimSpace(m[2]))
+			}
+			flush()
+			return chunks
+		},
+	}
+}
+
+func terms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '$'
+	})
+}
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"do": true, "does": true, "for": true, "get": true, "how": true, "i": true, "in": true, "is": true,
+	"it": true, "many": true, "much": true, "of": true, "on": true, "or": true, "the": true, "to": true,
+	"what": true, "when": true, "who": true, "with": true,
+}
+
+// TopChunks ranks chunks against the question with BM25, a standard lexical scoring
+// function, and returns the best k in document order. A real system would usually use
+// embeddings; lexical scoring keeps the example self-contained and deterministic.
+func TopChunks(chunks []Chunk, question string, k int) []Chunk {
+	const k1, b = 1.2, 0.75
+
+	docTerms := make([]map[string]int, len(chunks))
+	docFreq := make(map[string]int)
+	totalLen := 0
+	for i, c := range chunks {
+		docTerms[i] = make(map[string]int)
+		words := terms(c.Text)
+		totalLen += len(words)
+		for _, w := range words {
+			if docTerms[i][w] == 0 {
+				docFreq[w]++
+			}
+			docTerms[i][w]++
+		}
+	}
+	avgLen := float64(totalLen) / float64(max(len(chunks), 1))
+
+	type scored struct {
+		chunk Chunk
+		score float64
+	}
+	var ranked []scored
+	for i, c := range chunks {
+		length := 0
+		for _, n := range docTerms[i] {
+			length += n
+		}
+		score := 0.0
+		for _, q := range terms(question) {
+			tf := float64(docTerms[i][q])
+			if stopWords[q] || tf == 0 {
+				continue
+			}
+			idf := math.Log(1 + (float64(len(chunks))-float64(docFreq[q])+0.5)/(float64(docFreq[q])+0.5))
+			score += idf * tf * (k1 + 1) / (tf + k1*(1-b+b*float64(length)/avgLen))
+		}
+		ranked = append(ranked, scored{c, score})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+
+	top := make([]Chunk, 0, k)
+	for _, r := range ranked[:min(k, len(ranked))] {
+		top = append(top, r.chunk)
+	}
+	sort.Slice(top, func(i, j int) bool { return top[i].Index < top[j].Index })
+	return top
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/document"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+type question struct {
+	text    string
+	answers []string // any of these in the response counts as correct
+}
+
+var questions = []question{
+	{"How many weeks of paid parental leave does the secondary caregiver get?", []string{"6 weeks", "six weeks"}},
+	{"What is the hotel limit per night in London?", []string{"$325"}},
+	{"How many PTO days does someone with six years of service get?", []string{"28"}},
+	{"Who has to approve a $3,000 equipment purchase outside the catalogue?", []string{"finance"}},
+	{"How long can I work from another country without asking for approval?", []string{"20 working days", "20 days"}},
+}
+
+func containsAny(text string, answers []string) bool {
+	text = strings.ToLower(text)
+	for _, a := range answers {
+		if strings.Contains(text, strings.ToLower(a)) {
+			return true
+		}
+	}
+	return false
+}
+
+// estimateTokens uses the rough rule of four characters per token for English text
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+type result struct {
+	strategy  string
+	chunks    int
+	retrieved int // questions whose answer was in the selected chunks
+	correct   int // questions the model answered correctly
+	tokens    int // context tokens sent, over all questions
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	path := flag.String("file", "../testdata/handbook.md", "Markdown document to chunk")
+	size := flag.Int("size", 600, "Target chunk size in characters")
+	k := flag.Int("k", 1, "Chunks given to the agent per question")
+	flag.Parse()
+
+	fmt.Println("Chunking Strategies with Aigentic")
+	fmt.Println("=================================")
+	fmt.Println()
+
+	data, err := os.ReadFile(*path)
+	if err != nil {
+		log.Fatalf("Failed to read document: %v", err)
+	}
+	text := string(data)
+	source := document.NewInMemoryDocument("source", filepath.Base(*path), data, nil)
+	fmt.Printf("📄 %s: %d characters, about %d tokens\n", filepath.Base(*path), len(text), estimateTokens(text))
+	fmt.Printf("   Each question gets the %d best chunks of about %d characters\n\n", *k, *size)
+
+	strategies := []Strategy{
+		FixedSize(*size, *size/10),
+		Sentences(*size),
+		Headings(*size),
+	}
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	var results []result
+	for _, strategy := range strategies {
+		chunks := strategy.Split(text)
+		fmt.Printf("🔪 %s: %d chunks\n", strategy.Name, len(chunks))
+		r := result{strategy: strategy.Name, chunks: len(chunks)}
+
+		for _, q := range questions {
+			selected := TopChunks(chunks, q.text, *k)
+
+			// Each chunk becomes a document that points back to the source it came from
+			docs := make([]*document.Document, len(selected))
+			var context strings.Builder
+			for i, c := range selected {
+				name := fmt.Sprintf("%s_chunk_%03d.md", strings.TrimSuffix(filepath.Base(*path), filepath.Ext(*path)), c.Index)
+				docs[i] = document.NewInMemoryDocument(fmt.Sprintf("%s_%d", strategy.Name, c.Index), name, []byte(c.Text), source)
+				context.WriteString(c.Text)
+			}
+
+			agent := aigentic.Agent{
+				Model:        model,
+				Name:         "HandbookAssistant",
+				Description:  "Answers questions from handbook excerpts",
+				Instructions: "Answer from the attached excerpts only, in one or two sentences. If they do not contain the answer, say that you don't know.",
+				Documents:    docs,
+			}
+
+			response, err := agent.Execute(q.text)
+			if err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+
+			retrieved := containsAny(context.String(), q.answers)
+			correct := containsAny(response, q.answers)
+			r.tokens += estimateTokens(context.String())
+			if retrieved {
+				r.retrieved++
+			}
+			if correct {
+				r.correct++
+			}
+
+			mark := "✘"
+			if correct {
+				mark = "✔"
+			}
+			fmt.Printf("   %s %s\n     %s\n", mark, q.text, strings.ReplaceAll(strings.TrimSpace(response), "\n", " "))
+			if !retrieved {
+				fmt.Println("     (the answer was not in the selected chunks)")
+			}
+		}
+		fmt.Println()
+		results = append(results, r)
+	}
+
+	fmt.Println("📊 Summary")
+	fmt.Printf("%-16s %7s %10s %8s %16s\n", "Strategy", "Chunks", "Retrieved", "Correct", "Tokens/question")
+	fmt.Println(strings.Repeat("-", 61))
+	for _, r := range results {
+		fmt.Printf("%-16s %7d %8d/%d %6d/%d %16d\n", r.strategy, r.chunks, r.retrieved, len(questions), r.correct, len(questions), r.tokens/len(questions))
+	}
+	fmt.Printf("\nSending the whole document would cost about %d tokens per question.\n", estimateTokens(text))
+
+	fmt.Println("\n✅ Example completed successfully!")
+}
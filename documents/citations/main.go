@@ -0,0 +1,207 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic-examples/documents/loader"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/document"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+type section struct {
+	Title string
+	Text  string
+}
+
+var headingLine = regexp.MustCompile(`(?m)^#{1,6}\s+(.+)$`)
+
+// splitSections numbers the markdown sections of a document from 1. The number is
+// what answers cite, so it must not depend on anything but the document.
+func splitSections(text string) []section {
+	var sections []section
+	matches := headingLine.FindAllStringSubmatchIndex(text, -1)
+	for i, m := range matches {
+		end := len(text)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		sections = append(sections, section{Title: text[m[2]:m[3]], Text: strings.TrimSpace(text[m[0]:end])})
+	}
+	return sections
+}
+
+// Library is the documentation the assistant can read, and a record of the sections
+// it read during the current question
+type Library struct {
+	names []string             // in the order given
+	docs  map[string][]section // by document name
+
+	mu        sync.Mutex
+	retrieved map[Citation]string
+}
+
+func NewLibrary(refs []*document.Document) (*Library, error) {
+	lib := &Library{docs: map[string][]section{}, retrieved: map[Citation]string{}}
+	for _, doc := range refs {
+		data, err := doc.Bytes()
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", doc.Filename, err)
+		}
+		lib.names = append(lib.names, doc.Filename)
+		lib.docs[doc.Filename] = splitSections(string(data))
+	}
+	return lib, nil
+}
+
+// Retrieved returns a copy of the sections read since the last Reset
+func (l *Library) Retrieved() map[Citation]string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	retrieved := make(map[Citation]string, len(l.retrieved))
+	for c, text := range l.retrieved {
+		retrieved[c] = text
+	}
+	return retrieved
+}
+
+func (l *Library) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	clear(l.retrieved)
+}
+
+// createTools lets the assistant browse the table of contents and read sections.
+// Every section read is recorded, which is what the verifier checks citations against.
+func createTools(lib *Library) []aigentic.AgentTool {
+	type ContentsInput struct{}
+	type ReadInput struct {
+		Document string `json:"document" description:"Document name as shown in the table of contents"`
+		Section  int    `json:"section" description:"Section number as shown in the table of contents"`
+	}
+
+	contentsTool := aigentic.NewTool(
+		"table_of_contents",
+		"Lists every document with its numbered sections",
+		func(run *aigentic.AgentRun, input ContentsInput) (string, error) {
+			var sb strings.Builder
+			for _, name := range lib.names {
+				fmt.Fprintf(&sb, "%s\n", name)
+				for i, s := range lib.docs[name] {
+					fmt.Fprintf(&sb, "  [%s#%d] %s\n", name, i+1, s.Title)
+				}
+			}
+			return sb.String(), nil
+		},
+	)
+
+	readTool := aigentic.NewTool(
+		"read_section",
+		"Reads one section of a document. Cite what you use from it with the ID shown in the result.",
+		func(run *aigentic.AgentRun, input ReadInput) (string, error) {
+			sections, ok := lib.docs[input.Document]
+			if !ok {
+				return "", fmt.Errorf("unknown document %q", input.Document)
+			}
+			if input.Section < 1 || input.Section > len(sections) {
+				return "", fmt.Errorf("%s has sections 1-%d", input.Document, len(sections))
+			}
+
+			c := Citation{Document: input.Document, Section: input.Section}
+			text := sections[input.Section-1].Text
+			lib.mu.Lock()
+			lib.retrieved[c] = text
+			lib.mu.Unlock()
+			fmt.Printf("   📖 read [%s] %s\n", c, sections[input.Section-1].Title)
+			return fmt.Sprintf("[%s]\n%s", c, text), nil
+		},
+	)
+
+	return []aigentic.AgentTool{contentsTool, readTool}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	custom := flag.String("q", "", "Question to ask instead of the built-in ones")
+	flag.Parse()
+
+	fmt.Println("Documentation Assistant with Verified Citations")
+	fmt.Println("===============================================")
+	fmt.Println()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"../testdata/handbook.md", "../testdata/services_agreement_v2.md"}
+	}
+	loaded, errs := loader.New().LoadPaths(paths...)
+	if len(errs) > 0 {
+		log.Fatalf("Failed to load documentation: %v", errs[0])
+	}
+
+	lib, err := NewLibrary(loader.Documents(loaded))
+	if err != nil {
+		log.Fatalf("Failed to load documentation: %v", err)
+	}
+
+	agent := aigentic.Agent{
+		Model:       openai.NewModel("gpt-4o-mini", getAPIKey()),
+		Name:        "DocumentationAssistant",
+		Description: "Answers questions from the documentation and cites its sources",
+		Instructions: `Answer questions using only the documentation. Call table_of_contents, then read_section for the sections that look relevant.
+End every sentence that states a fact with the ID of the section it came from, for example [handbook.md#7]. Cite only sections you have read.
+If the documentation does not cover the question, say that the documents do not cover it.`,
+		AgentTools: createTools(lib),
+	}
+
+	questions := []string{
+		"How much PTO do I get after six years, and how many days can I carry over?",
+		"What is the uptime commitment in the services agreement and what credit do we get if it is missed?",
+		"Can I expense a hotel in London at $300 a night?",
+	}
+	if *custom != "" {
+		questions = []string{*custom}
+	}
+
+	for _, q := range questions {
+		lib.Reset()
+		fmt.Printf("❓ %s\n", q)
+
+		response, err := agent.Execute(q)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		fmt.Printf("\n%s\n\n", strings.TrimSpace(response))
+
+		// Post-run verification: compare the answer with what was actually retrieved
+		findings := Verify(response, lib.Retrieved())
+		if len(findings) == 0 {
+			fmt.Println("✔️  Every claim is cited, and every citation was retrieved and matches its numbers")
+		} else {
+			fmt.Printf("⚠️  %d problem(s) found:\n", len(findings))
+			for _, f := range findings {
+				fmt.Printf("   - %s\n     %q\n", f.Problem, f.Sentence)
+			}
+		}
+		fmt.Println()
+	}
+
+	fmt.Println("✅ Example completed successfully!")
+}
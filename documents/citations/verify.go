@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Citation points at one section of one document, written [doc#section] in answers,
+// for example [handbook.md#7]
+type Citation struct {
+	Document string
+	Section  int
+}
+
+func (c Citation) String() string {
+	return fmt.Sprintf("%s#%d", c.Document, c.Section)
+}
+
+var citationPattern = regexp.MustCompile(`\[([^\[\]\s#]+)#(\d+)\]`)
+
+// Finding is a problem the verifier found in one sentence of the answer
+type Finding struct {
+	Sentence string
+	Problem  string
+}
+
+var (
+	sentenceEnd = regexp.MustCompile(`([.!?])(\s+|$)`)
+	numberToken = regexp.MustCompile(`\$?\d[\d,]*(?:\.\d+)?%?`)
+	// citations written after the full stop, as in "... 5 days. [handbook.md#7]"
+	trailingCitations = regexp.MustCompile(`([.!?])\s*((?:\[[^\[\]\s#]+#\d+\]\s*)+)`)
+)
+
+// splitSentences splits an answer into sentences and list items, keeping the
+// citations that follow a full stop with the sentence before them
+func splitSentences(answer string) []string {
+	var sentences []string
+	for _, line := range strings.Split(answer, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "-*•"))
+		if line == "" {
+			continue
+		}
+		// Move citations placed after the full stop in front of it, so they stay
+		// with their sentence
+		line = trailingCitations.ReplaceAllString(line, " $2$1 ")
+
+		start := 0
+		for _, m := range sentenceEnd.FindAllStringIndex(line, -1) {
+			sentences = append(sentences, strings.TrimSpace(line[start:m[1]]))
+			start = m[1]
+		}
+		if rest := strings.TrimSpace(line[start:]); rest != "" {
+			sentences = append(sentences, rest)
+		}
+	}
+	return sentences
+}
+
+// isClaim reports whether a sentence states something that needs a source. Short
+// lead-ins such as "Here is what I found:" and sentences that say the documents do
+// not cover something are not claims.
+func isClaim(sentence string) bool {
+	plain := strings.ToLower(citationPattern.ReplaceAllString(sentence, ""))
+	if len(strings.Fields(plain)) < 5 || strings.HasSuffix(strings.TrimSpace(plain), ":") {
+		return false
+	}
+	for _, phrase := range []string{"do not cover", "does not cover", "not mentioned", "no information", "couldn't find", "could not find"} {
+		if strings.Contains(plain, phrase) {
+			return false
+		}
+	}
+	return true
+}
+
+// Verify checks every sentence of the answer against what was retrieved during the run:
+//   - a claim without a citation is uncited
+//   - a citation of a section the agent never read is unsupported, even if the section
+//     exists, because the model cannot have taken the claim from it
+//   - a number in the sentence that appears in none of its cited sections is flagged,
+//     which catches most misquoted amounts, dates and limits
+func Verify(answer string, retrieved map[Citation]string) []Finding {
+	var findings []Finding
+	for _, sentence := range splitSentences(answer) {
+		matches := citationPattern.FindAllStringSubmatch(sentence, -1)
+		if len(matches) == 0 {
+			if isClaim(sentence) {
+				findings = append(findings, Finding{sentence, "uncited claim"})
+			}
+			continue
+		}
+
+		var sources []string
+		for _, m := range matches {
+			var section int
+			fmt.Sscanf(m[2], "%d", &section)
+			c := Citation{Document: m[1], Section: section}
+			text, ok := retrieved[c]
+			if !ok {
+				findings = append(findings, Finding{sentence, fmt.Sprintf("cites [%s], which was never retrieved", c)})
+				continue
+			}
+			sources = append(sources, text)
+		}
+		if len(sources) == 0 {
+			continue
+		}
+
+		cited := strings.Join(sources, "\n")
+		for _, number := range numberToken.FindAllString(citationPattern.ReplaceAllString(sentence, ""), -1) {
+			number = strings.TrimRight(number, ",")
+			if !strings.Contains(cited, strings.TrimSuffix(number, "%")) {
+				findings = append(findings, Finding{sentence, fmt.Sprintf("%s does not appear in the cited sections", number)})
+			}
+		}
+	}
+	return findings
+}
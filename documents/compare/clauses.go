@@ -0,0 +1,83 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Clause is one numbered section of a contract
+type Clause struct {
+	Number string
+	Title  string
+	Text   string
+}
+
+var clauseHeading = regexp.MustCompile(`^#{2,}\s+(\d+(?:\.\d+)*)\.?\s+(.+)$`)
+
+// parseClauses splits a markdown contract into its numbered sections. Text before the
+// first numbered heading is the preamble and is not a clause.
+func parseClauses(text string) []Clause {
+	var clauses []Clause
+	var body strings.Builder
+	flush := func() {
+		if len(clauses) > 0 {
+			clauses[len(clauses)-1].Text = strings.TrimSpace(body.String())
+		}
+		body.Reset()
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if m := clauseHeading.FindStringSubmatch(line); m != nil {
+			flush()
+			clauses = append(clauses, Clause{Number: m[1], Title: strings.TrimSpace(m[2])})
+			continue
+		}
+		body.WriteString(line + "\n")
+	}
+	flush()
+	return clauses
+}
+
+// ClauseDiff is a change found by comparing the text of the two versions
+type ClauseDiff struct {
+	Title string
+	Type  string // "added", "removed" or "modified"
+}
+
+// diffClauses matches clauses by title rather than number, so renumbering alone is not
+// a change, and reports the clauses whose text differs
+func diffClauses(oldClauses, newClauses []Clause) []ClauseDiff {
+	oldByTitle := map[string]Clause{}
+	for _, c := range oldClauses {
+		oldByTitle[normalizeTitle(c.Title)] = c
+	}
+	newByTitle := map[string]Clause{}
+	for _, c := range newClauses {
+		newByTitle[normalizeTitle(c.Title)] = c
+	}
+
+	var diffs []ClauseDiff
+	for _, c := range oldClauses {
+		if _, ok := newByTitle[normalizeTitle(c.Title)]; !ok {
+			diffs = append(diffs, ClauseDiff{Title: c.Title, Type: "removed"})
+		}
+	}
+	for _, c := range newClauses {
+		old, ok := oldByTitle[normalizeTitle(c.Title)]
+		switch {
+		case !ok:
+			diffs = append(diffs, ClauseDiff{Title: c.Title, Type: "added"})
+		case normalizeSpace(old.Text) != normalizeSpace(c.Text):
+			diffs = append(diffs, ClauseDiff{Title: c.Title, Type: "modified"})
+		}
+	}
+	return diffs
+}
+
+func normalizeTitle(title string) string {
+	return strings.ToLower(normalizeSpace(title))
+}
+
+func normalizeSpace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
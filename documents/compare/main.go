@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/document"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+// Change is one entry of the change report the agent writes
+type Change struct {
+	Clause       string `json:"clause"`       // title of the clause, e.g. "Payment Terms"
+	Type         string `json:"type"`         // added, removed or modified
+	OldText      string `json:"old_text"`     // exact quote from the old version, empty for added clauses
+	NewText      string `json:"new_text"`     // exact quote from the new version, empty for removed clauses
+	Significance string `json:"significance"` // substantive or wording
+	Impact       string `json:"impact"`       // what the change means for the client
+}
+
+type ChangeReport struct {
+	Summary string   `json:"summary"`
+	Changes []Change `json:"changes"`
+}
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// parseReport reads the model's JSON, with or without a markdown code fence
+func parseReport(response string) (ChangeReport, error) {
+	text := strings.TrimSpace(response)
+	if start, end := strings.Index(text, "{"), strings.LastIndex(text, "}"); start >= 0 && end > start {
+		text = text[start : end+1]
+	}
+	var r ChangeReport
+	if err := json.Unmarshal([]byte(text), &r); err != nil {
+		return ChangeReport{}, fmt.Errorf("response is not a valid change report: %w", err)
+	}
+	return r, nil
+}
+
+// verify checks the report against the documents: quotes must appear in the version they
+// claim to come from, and every clause whose text changed must be reported
+func verify(report ChangeReport, oldText, newText string, expected []ClauseDiff) []string {
+	var problems []string
+	for _, c := range report.Changes {
+		if c.OldText != "" && !strings.Contains(normalizeSpace(oldText), normalizeSpace(c.OldText)) {
+			problems = append(problems, fmt.Sprintf("%s: old_text is not in the old version: %q", c.Clause, c.OldText))
+		}
+		if c.NewText != "" && !strings.Contains(normalizeSpace(newText), normalizeSpace(c.NewText)) {
+			problems = append(problems, fmt.Sprintf("%s: new_text is not in the new version: %q", c.Clause, c.NewText))
+		}
+	}
+
+	reported := map[string]string{}
+	for _, c := range report.Changes {
+		reported[normalizeTitle(c.Clause)] = c.Type
+	}
+	for _, d := range expected {
+		if _, ok := reported[normalizeTitle(d.Title)]; !ok {
+			problems = append(problems, fmt.Sprintf("%s was %s but is not in the report", d.Title, d.Type))
+		}
+	}
+	return problems
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	oldPath := flag.String("old", "../testdata/services_agreement_v1.md", "Earlier version of the contract")
+	newPath := flag.String("new", "../testdata/services_agreement_v2.md", "Later version of the contract")
+	flag.Parse()
+
+	fmt.Println("Contract Comparison with Aigentic")
+	fmt.Println("=================================")
+	fmt.Println()
+
+	oldData, err := os.ReadFile(*oldPath)
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", *oldPath, err)
+	}
+	newData, err := os.ReadFile(*newPath)
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", *newPath, err)
+	}
+
+	// The names say which version is which, so the model cannot mix up the direction
+	// of a change even if it reads the documents in a different order
+	oldDoc := document.NewInMemoryDocument("contract_old", "OLD_"+filepath.Base(*oldPath), oldData, nil)
+	newDoc := document.NewInMemoryDocument("contract_new", "NEW_"+filepath.Base(*newPath), newData, nil)
+	fmt.Printf("📄 Old: %s (%d bytes)\n📄 New: %s (%d bytes)\n\n", oldDoc.Filename, len(oldData), newDoc.Filename, len(newData))
+
+	expected := diffClauses(parseClauses(string(oldData)), parseClauses(string(newData)))
+
+	agent := aigentic.Agent{
+		Model:       openai.NewModel("gpt-4o-mini", getAPIKey()),
+		Name:        "ContractComparer",
+		Description: "Compares two versions of a contract and reports what changed",
+		Instructions: `You compare two versions of the same contract. The document whose name starts with OLD_ is the earlier version and NEW_ is the later one.
+Do not summarize either contract. Go through the clauses one by one, match them by title (numbers may change), and report only differences.
+Reply with only a JSON object:
+{"summary": "two sentences on the overall direction of the changes",
+ "changes": [{"clause": "clause title without its number", "type": "added|removed|modified",
+   "old_text": "exact sentence from OLD, empty if added", "new_text": "exact sentence from NEW, empty if removed",
+   "significance": "substantive|wording", "impact": "what the change means for the Client"}]}
+Copy old_text and new_text word for word. A change that does not alter rights, obligations, amounts or dates is "wording".`,
+		Documents: []*document.Document{oldDoc, newDoc},
+	}
+
+	response, err := agent.Execute("List every change from the old version to the new version of the contract.")
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	report, err := parseReport(response)
+	if err != nil {
+		log.Fatalf("%v\nResponse was:\n%s", err, response)
+	}
+
+	fmt.Printf("📋 %s\n\n", report.Summary)
+	icons := map[string]string{"added": "➕", "removed": "➖", "modified": "✏️ "}
+	for _, c := range report.Changes {
+		fmt.Printf("%s %s (%s, %s)\n", icons[c.Type], c.Clause, c.Type, c.Significance)
+		if c.OldText != "" {
+			fmt.Printf("   - %s\n", c.OldText)
+		}
+		if c.NewText != "" {
+			fmt.Printf("   + %s\n", c.NewText)
+		}
+		if c.Impact != "" {
+			fmt.Printf("   → %s\n", c.Impact)
+		}
+		fmt.Println()
+	}
+
+	// The text diff cannot judge significance, but it knows exactly which clauses changed
+	if problems := verify(report, string(oldData), string(newData), expected); len(problems) > 0 {
+		fmt.Println("⚠️  Problems found when checking the report against the documents:")
+		for _, p := range problems {
+			fmt.Printf("   - %s\n", p)
+		}
+	} else {
+		fmt.Printf("✔️  All %d changed clauses are reported and every quote matches its document\n", len(expected))
+	}
+
+	fmt.Println("\n✅ Example completed successfully!")
+}
@@ -0,0 +1,108 @@
+package loader
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlDropped = regexp.MustCompile(`(?is)<(script|style|head|noscript|svg|template)\b.*?</(script|style|head|noscript|svg|template)>|<!--.*?-->`)
+	htmlHeading = regexp.MustCompile(`(?i)<h([1-6])\b[^>]*>`)
+	htmlItem    = regexp.MustCompile(`(?i)<li\b[^>]*>`)
+	htmlBlock   = regexp.MustCompile(`(?i)</?(p|div|section|article|header|footer|main|nav|aside|br|tr|table|ul|ol|h[1-6]|blockquote|pre)\b[^>]*>`)
+	htmlCell    = regexp.MustCompile(`(?i)</t[dh]>`)
+	htmlTag     = regexp.MustCompile(`(?s)<[^>]*>`)
+	blankLines  = regexp.MustCompile(`\n{3,}`)
+)
+
+// HTMLToText keeps the text of an HTML page with its headings and list items marked
+// in markdown. Scripts, styles and the head are dropped. It is deliberately simple;
+// pages with a lot of navigation need boilerplate removal as well.
+func HTMLToText(data []byte) (string, error) {
+	text := htmlDropped.ReplaceAllString(string(data), "")
+	text = htmlHeading.ReplaceAllStringFunc(text, func(tag string) string {
+		level := htmlHeading.FindStringSubmatch(tag)[1]
+		return "\n\n" + strings.Repeat("#", int(level[0]-'0')) + " "
+	})
+	text = htmlItem.ReplaceAllString(text, "\n- ")
+	text = htmlCell.ReplaceAllString(text, " | ")
+	text = htmlBlock.ReplaceAllString(text, "\n\n")
+	text = htmlTag.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		lines = append(lines, strings.Join(strings.Fields(line), " "))
+	}
+	text = blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text), nil
+}
+
+// DocxToText extracts the paragraphs of a Word file, marking Title and Heading styles
+// as markdown headings. Table cells come out as separate paragraphs. The office example has
+// a fuller converter that keeps lists and tables.
+func DocxToText(data []byte) (string, error) {
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", err
+	}
+	f, err := r.Open("word/document.xml")
+	if err != nil {
+		return "", fmt.Errorf("no word/document.xml: %w", err)
+	}
+	defer f.Close()
+
+	var sb, para strings.Builder
+	style := ""
+	decoder := xml.NewDecoder(f)
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "pStyle":
+				for _, a := range t.Attr {
+					if a.Name.Local == "val" {
+						style = a.Value
+					}
+				}
+			case "tab":
+				para.WriteString("\t")
+			case "t":
+				var text string
+				if err := decoder.DecodeElement(&text, &t); err != nil {
+					return "", err
+				}
+				para.WriteString(text)
+			}
+		case xml.EndElement:
+			if t.Name.Local != "p" {
+				continue
+			}
+			if text := strings.TrimSpace(para.String()); text != "" {
+				switch {
+				case style == "Title":
+					sb.WriteString("# ")
+				case strings.HasPrefix(style, "Heading") && len(style) == len("Heading1"):
+					sb.WriteString(strings.Repeat("#", int(style[7]-'0')+1) + " ")
+				}
+				sb.WriteString(text + "\n\n")
+			}
+			para.Reset()
+			style = ""
+		}
+	}
+	return strings.TrimSpace(sb.String()), nil
+}
@@ -0,0 +1,151 @@
+package loader
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// Kind is a document format the loader knows
+type Kind string
+
+const (
+	KindText     Kind = "text"
+	KindMarkdown Kind = "markdown"
+	KindCSV      Kind = "csv"
+	KindJSON     Kind = "json"
+	KindHTML     Kind = "html"
+	KindPDF      Kind = "pdf"
+	KindDocx     Kind = "docx"
+	KindPptx     Kind = "pptx"
+	KindXlsx     Kind = "xlsx"
+	KindImage    Kind = "image"
+	KindUnknown  Kind = "unknown"
+)
+
+// Format is the result of detection: the kind, the MIME type to send the document
+// with, and the extension that matches the content
+type Format struct {
+	Kind     Kind
+	MimeType string
+	Ext      string
+	Sniffed  bool // true when the content decided, false when only the extension did
+}
+
+var byExtension = map[string]Format{
+	".txt":      {Kind: KindText, MimeType: "text/plain", Ext: ".txt"},
+	".log":      {Kind: KindText, MimeType: "text/plain", Ext: ".log"},
+	".md":       {Kind: KindMarkdown, MimeType: "text/markdown", Ext: ".md"},
+	".markdown": {Kind: KindMarkdown, MimeType: "text/markdown", Ext: ".md"},
+	".csv":      {Kind: KindCSV, MimeType: "text/csv", Ext: ".csv"},
+	".json":     {Kind: KindJSON, MimeType: "application/json", Ext: ".json"},
+	".html":     {Kind: KindHTML, MimeType: "text/html", Ext: ".html"},
+	".htm":      {Kind: KindHTML, MimeType: "text/html", Ext: ".html"},
+}
+
+var images = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// Detect works out the format of a file from its content first and its name second.
+// Binary formats are recognized by their signatures, so a PNG saved as .jpg or a PDF
+// without an extension are still sent with the right type. Text is classified by
+// extension, and by looking at the content when there is no useful extension.
+func Detect(name string, data []byte) Format {
+	// Binary signatures always win over the name
+	switch {
+	case bytes.HasPrefix(data, []byte("%PDF-")):
+		return Format{Kind: KindPDF, MimeType: "application/pdf", Ext: ".pdf", Sniffed: true}
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		if f, ok := detectOffice(data); ok {
+			return f
+		}
+	}
+	if mimeType := http.DetectContentType(data); images[mimeType] != "" {
+		return Format{Kind: KindImage, MimeType: mimeType, Ext: images[mimeType], Sniffed: true}
+	}
+
+	if !utf8.Valid(data) {
+		return Format{Kind: KindUnknown, MimeType: http.DetectContentType(data), Ext: filepath.Ext(name), Sniffed: true}
+	}
+
+	if f, ok := byExtension[strings.ToLower(filepath.Ext(name))]; ok {
+		return f
+	}
+	return sniffText(data)
+}
+
+// detectOffice tells Word, PowerPoint and Excel files apart by the parts inside the zip
+func detectOffice(data []byte) (Format, bool) {
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return Format{}, false
+	}
+	for _, f := range r.File {
+		switch f.Name {
+		case "word/document.xml":
+			return Format{Kind: KindDocx, MimeType: "application/vnd.openxmlformats-officedocument.wordprocessingml.document", Ext: ".docx", Sniffed: true}, true
+		case "ppt/presentation.xml":
+			return Format{Kind: KindPptx, MimeType: "application/vnd.openxmlformats-officedocument.presentationml.presentation", Ext: ".pptx", Sniffed: true}, true
+		case "xl/workbook.xml":
+			return Format{Kind: KindXlsx, MimeType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", Ext: ".xlsx", Sniffed: true}, true
+		}
+	}
+	return Format{}, false
+}
+
+// sniffText classifies text without a known extension by its first few kilobytes
+func sniffText(data []byte) Format {
+	head := strings.TrimSpace(string(data[:min(len(data), 4096)]))
+	lower := strings.ToLower(head)
+
+	switch {
+	case strings.HasPrefix(lower, "<!doctype html") || strings.HasPrefix(lower, "<html") || strings.Contains(lower, "<body"):
+		return Format{Kind: KindHTML, MimeType: "text/html", Ext: ".html", Sniffed: true}
+	case (strings.HasPrefix(head, "{") || strings.HasPrefix(head, "[")) && jsonLike(head):
+		return Format{Kind: KindJSON, MimeType: "application/json", Ext: ".json", Sniffed: true}
+	case looksLikeCSV(head):
+		return Format{Kind: KindCSV, MimeType: "text/csv", Ext: ".csv", Sniffed: true}
+	case looksLikeMarkdown(head):
+		return Format{Kind: KindMarkdown, MimeType: "text/markdown", Ext: ".md", Sniffed: true}
+	}
+	return Format{Kind: KindText, MimeType: "text/plain", Ext: ".txt", Sniffed: true}
+}
+
+func jsonLike(head string) bool {
+	return strings.Contains(head, "\":") || strings.HasPrefix(head, "[]") || strings.HasPrefix(head, "[{")
+}
+
+// looksLikeCSV wants at least three lines with the same number of commas, and at least one
+func looksLikeCSV(head string) bool {
+	lines := strings.Split(head, "\n")
+	if len(lines) < 3 {
+		return false
+	}
+	commas := strings.Count(lines[0], ",")
+	if commas == 0 {
+		return false
+	}
+	for _, line := range lines[1:min(len(lines), 10)] {
+		if strings.Count(line, ",") != commas {
+			return false
+		}
+	}
+	return true
+}
+
+func looksLikeMarkdown(head string) bool {
+	for _, line := range strings.Split(head, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "# ") || strings.HasPrefix(line, "## ") || strings.HasPrefix(line, "```") || strings.HasPrefix(line, "|---") {
+			return true
+		}
+	}
+	return false
+}
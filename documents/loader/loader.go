@@ -0,0 +1,160 @@
+// Package loader turns files into aigentic documents. It detects the format from the
+// file name and content, sets the MIME type, and converts formats that models cannot
+// read directly, such as HTML and Word files, to text, so example code does not have
+// to build every document by hand.
+package loader
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/nexxia-ai/aigentic/document"
+)
+
+// Converter turns the content of a format models cannot read directly into text or
+// markdown
+type Converter func(data []byte) (string, error)
+
+// Loader creates documents from files. The zero value is not usable; call New.
+type Loader struct {
+	MaxSize int64 // files larger than this are rejected (0 = no limit)
+
+	// Converters run before a document is created, keyed by the detected kind. The
+	// result becomes a markdown document named after the original file. Kinds without
+	// a converter are attached as they are, with the detected MIME type.
+	Converters map[Kind]Converter
+
+	// Skip lists kinds that are rejected, such as KindImage for a text-only model
+	Skip map[Kind]bool
+
+	mu   sync.Mutex
+	next int
+}
+
+// New returns a loader that converts HTML and Word files to text, rejects files over
+// 20 MB and formats it cannot identify
+func New() *Loader {
+	return &Loader{
+		MaxSize: 20 << 20,
+		Converters: map[Kind]Converter{
+			KindHTML: HTMLToText,
+			KindDocx: DocxToText,
+		},
+		Skip: map[Kind]bool{KindUnknown: true},
+	}
+}
+
+// Loaded is a document together with how it was detected and whether it was converted
+type Loaded struct {
+	Document  *document.Document
+	Format    Format
+	Converted bool
+	Path      string
+}
+
+// Load creates a document from content with the given name
+func (l *Loader) Load(name string, data []byte) (Loaded, error) {
+	if l.MaxSize > 0 && int64(len(data)) > l.MaxSize {
+		return Loaded{}, fmt.Errorf("%s is %d bytes, more than the %d byte limit", name, len(data), l.MaxSize)
+	}
+
+	format := Detect(name, data)
+	if l.Skip[format.Kind] {
+		return Loaded{Format: format}, fmt.Errorf("%s: %s files (%s) are not accepted", name, format.Kind, format.MimeType)
+	}
+
+	base := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	loaded := Loaded{Format: format, Path: name}
+
+	if convert := l.Converters[format.Kind]; convert != nil {
+		text, err := convert(data)
+		if err != nil {
+			return loaded, fmt.Errorf("converting %s from %s: %w", name, format.Kind, err)
+		}
+		// The .md name tells the model what the content is now, and the original
+		// name stays visible in it
+		doc := document.NewInMemoryDocument(l.nextID(), base+format.Ext+".md", []byte(text), nil)
+		doc.MimeType = "text/markdown"
+		loaded.Document, loaded.Converted = doc, true
+		return loaded, nil
+	}
+
+	// Give the document the extension that matches its content, so a PNG saved as
+	// .jpg is not sent as a JPEG
+	doc := document.NewInMemoryDocument(l.nextID(), base+format.Ext, data, nil)
+	doc.MimeType = format.MimeType
+	loaded.Document = doc
+	return loaded, nil
+}
+
+// LoadFile reads and loads one file
+func (l *Loader) LoadFile(path string) (Loaded, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Loaded{}, err
+	}
+	loaded, err := l.Load(filepath.Base(path), data)
+	loaded.Path = path
+	return loaded, err
+}
+
+// LoadPaths loads files and every file under directories, in name order. Files that
+// cannot be loaded are returned as errors alongside the documents that could, so one
+// bad file does not stop a batch.
+func (l *Loader) LoadPaths(paths ...string) ([]Loaded, []error) {
+	var files []string
+	var errs []error
+	for _, p := range paths {
+		err := filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path != p && strings.HasPrefix(d.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasPrefix(d.Name(), ".") {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	sort.Strings(files)
+
+	var loaded []Loaded
+	for _, file := range files {
+		item, err := l.LoadFile(file)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		loaded = append(loaded, item)
+	}
+	return loaded, errs
+}
+
+// Documents returns the documents of a batch
+func Documents(loaded []Loaded) []*document.Document {
+	docs := make([]*document.Document, len(loaded))
+	for i, l := range loaded {
+		docs[i] = l.Document
+	}
+	return docs
+}
+
+func (l *Loader) nextID() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.next++
+	return fmt.Sprintf("doc_%03d", l.next)
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/document"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// extractWithVision is the fallback for pages tesseract cannot read well: the page
+// image goes to a vision model that transcribes it
+func extractWithVision(model *ai.Model, imagePath string) (string, error) {
+	data, err := os.ReadFile(imagePath)
+	if err != nil {
+		return "", err
+	}
+	mimeType := http.DetectContentType(data)
+	if !strings.HasPrefix(mimeType, "image/") {
+		return "", fmt.Errorf("%s is %s, not an image", imagePath, mimeType)
+	}
+	doc := document.NewInMemoryDocument("scan", filepath.Base(imagePath), data, nil)
+	doc.MimeType = mimeType
+
+	agent := aigentic.Agent{
+		Model:        model,
+		Name:         "Transcriber",
+		Description:  "Transcribes scanned pages",
+		Instructions: "Transcribe all text in the image exactly as printed, line by line. Keep numbers, dates and amounts unchanged. Do not summarize, explain or add anything. Write [illegible] for text you cannot read.",
+		Documents:    []*document.Document{doc},
+	}
+	return agent.Execute("Transcribe this page.")
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	input := flag.String("file", "../testdata/receipt.png", "Scanned image (PNG, JPEG, TIFF) or scanned PDF")
+	lang := flag.String("lang", "eng", "Tesseract language, e.g. eng, deu or eng+fra")
+	minConfidence := flag.Float64("min-confidence", 70, "Pages with a lower mean OCR confidence (0-100) are read by the vision model instead")
+	question := flag.String("q", "What was bought, on what date, and what was the total?", "Question to ask about the document")
+	flag.Parse()
+
+	fmt.Println("OCR for Scanned Documents with Aigentic")
+	fmt.Println("=======================================")
+	fmt.Println()
+
+	ctx := context.Background()
+	dir, err := os.MkdirTemp("", "aigentic-ocr-")
+	if err != nil {
+		log.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	images, err := pageImages(ctx, *input, dir)
+	if err != nil {
+		log.Fatalf("Failed to prepare %s: %v", *input, err)
+	}
+
+	// The vision model needs image input; gpt-4o-mini has it
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	// 1. Turn every page into text, with OCR first and the vision model as fallback
+	base := strings.TrimSuffix(filepath.Base(*input), filepath.Ext(*input))
+	var docs []*document.Document
+	for i, image := range images {
+		label := fmt.Sprintf("page %d/%d", i+1, len(images))
+
+		page, err := OCRImage(ctx, image, *lang)
+		text, source := page.Text, fmt.Sprintf("tesseract, %.0f%% confidence, %d words", page.Confidence, page.Words)
+		switch {
+		case errors.Is(err, ErrNoTesseract):
+			fmt.Printf("⚠️  %s: tesseract is not installed, using the vision model\n", label)
+		case err != nil:
+			fmt.Printf("⚠️  %s: OCR failed (%v), using the vision model\n", label, err)
+		case page.Confidence < *minConfidence:
+			fmt.Printf("⚠️  %s: OCR confidence %.0f%% is below %.0f%%, using the vision model\n", label, page.Confidence, *minConfidence)
+		default:
+			fmt.Printf("🔤 %s: %s\n", label, source)
+		}
+		if err != nil || page.Confidence < *minConfidence {
+			text, err = extractWithVision(model, image)
+			if err != nil {
+				log.Fatalf("Vision extraction of %s failed: %v", label, err)
+			}
+			source = "vision model"
+			fmt.Printf("👁️  %s: %s, %d characters\n", label, source, len(text))
+		}
+
+		// Each page becomes a plain text document that any model can read, and the
+		// first line records how the text was produced
+		content := fmt.Sprintf("[Extracted by %s]\n\n%s", source, strings.TrimSpace(text))
+		name := fmt.Sprintf("%s_page%d.txt", base, i+1)
+		docs = append(docs, document.NewInMemoryDocument(fmt.Sprintf("ocr_%03d", i+1), name, []byte(content), nil))
+	}
+	fmt.Println()
+
+	// 2. Analyze the text documents
+	agent := aigentic.Agent{
+		Model:       model,
+		Name:        "ScanAnalyst",
+		Description: "Answers questions about scanned documents",
+		Instructions: `The documents are text extracted from scanned pages by OCR or by a vision model, so they can contain recognition errors such as 0 read as O or missing decimal points.
+Answer from the documents only. When a value looks misread, say so instead of guessing.`,
+		Documents: docs,
+	}
+
+	fmt.Printf("❓ %s\n\n", *question)
+	response, err := agent.Execute(*question)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	fmt.Printf("%s\n", strings.TrimSpace(response))
+
+	fmt.Println("\n✅ Example completed successfully!")
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// OCRPage is the text of one page and how sure tesseract was about it
+type OCRPage struct {
+	Text       string
+	Confidence float64 // mean word confidence, 0-100
+	Words      int
+}
+
+// ErrNoTesseract means the tesseract binary is not installed
+var ErrNoTesseract = errors.New("tesseract is not installed")
+
+// OCRImage runs tesseract on an image. It asks for TSV output, which has one row per
+// word with a confidence, and rebuilds the text line by line from it.
+func OCRImage(ctx context.Context, path, lang string) (OCRPage, error) {
+	if _, err := exec.LookPath("tesseract"); err != nil {
+		return OCRPage{}, ErrNoTesseract
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "tesseract", path, "stdout", "-l", lang, "tsv")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return OCRPage{}, fmt.Errorf("tesseract %s: %w: %s", filepath.Base(path), err, strings.TrimSpace(stderr.String()))
+	}
+	return parseTSV(&stdout)
+}
+
+// parseTSV turns tesseract TSV into text. Columns are level, page_num, block_num,
+// par_num, line_num, word_num, left, top, width, height, conf, text; word rows have
+// level 5 and a confidence of 0-100. The text is not quoted, so a word can contain a
+// double quote and the rows are split by hand rather than with encoding/csv.
+func parseTSV(r io.Reader) (OCRPage, error) {
+	var records [][]string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		records = append(records, strings.Split(scanner.Text(), "\t"))
+	}
+	if err := scanner.Err(); err != nil {
+		return OCRPage{}, fmt.Errorf("reading tesseract output: %w", err)
+	}
+
+	var page OCRPage
+	var sb strings.Builder
+	lastLine, lastBlock := "", ""
+	total := 0.0
+	for i, rec := range records {
+		if i == 0 || len(rec) < 12 || rec[0] != "5" {
+			continue
+		}
+		word := strings.TrimSpace(rec[11])
+		conf, err := strconv.ParseFloat(rec[10], 64)
+		if word == "" || err != nil || conf < 0 {
+			continue
+		}
+
+		block, line := rec[2], rec[2]+"."+rec[3]+"."+rec[4]
+		switch {
+		case sb.Len() == 0:
+		case block != lastBlock:
+			sb.WriteString("\n\n")
+		case line != lastLine:
+			sb.WriteString("\n")
+		default:
+			sb.WriteString(" ")
+		}
+		sb.WriteString(word)
+		lastLine, lastBlock = line, block
+
+		total += conf
+		page.Words++
+	}
+
+	page.Text = sb.String()
+	if page.Words > 0 {
+		page.Confidence = total / float64(page.Words)
+	}
+	return page, nil
+}
+
+// RasterizePDF renders each page of a scanned PDF to a PNG in dir with pdftoppm
+// (from poppler-utils) and returns the image paths in page order
+func RasterizePDF(ctx context.Context, path, dir string, dpi int) ([]string, error) {
+	if _, err := exec.LookPath("pdftoppm"); err != nil {
+		return nil, errors.New("pdftoppm is not installed; install poppler-utils to OCR PDFs")
+	}
+
+	prefix := filepath.Join(dir, "page")
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "pdftoppm", "-png", "-r", strconv.Itoa(dpi), path, prefix)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("pdftoppm %s: %w: %s", filepath.Base(path), err, strings.TrimSpace(stderr.String()))
+	}
+
+	pages, err := filepath.Glob(prefix + "-*.png")
+	if err != nil {
+		return nil, err
+	}
+	// pdftoppm pads page numbers to the same width, so names sort in page order
+	sort.Strings(pages)
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("pdftoppm produced no pages for %s", path)
+	}
+	return pages, nil
+}
+
+// pageImages returns the images to OCR for a file: the file itself, or one image per
+// page for a PDF
+func pageImages(ctx context.Context, path, dir string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasPrefix(data, []byte("%PDF-")) {
+		return RasterizePDF(ctx, path, dir, 300)
+	}
+	return []string{path}, nil
+}
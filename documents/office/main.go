@@ -0,0 +1,108 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/document"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+// Converted documents up to this size are embedded; larger ones are attached as
+// references the agent retrieves on demand
+const embedLimit = 10 * 1024
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	show := flag.Bool("show", false, "Print the converted markdown and exit without calling the model")
+	question := flag.String("q", "Which vendor has the largest contract, and which contracts renew before the end of 2025? What are the risks with those vendors?", "Question to ask about the documents")
+	flag.Parse()
+
+	fmt.Println("Office Documents with Aigentic")
+	fmt.Println("==============================")
+	fmt.Println()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		dir, err := os.MkdirTemp("", "office-example-")
+		if err != nil {
+			log.Fatalf("Failed to create temp dir: %v", err)
+		}
+		defer os.RemoveAll(dir)
+
+		sample := filepath.Join(dir, "q3_vendor_review.docx")
+		if err := writeSampleDocx(sample); err != nil {
+			log.Fatalf("Failed to write sample document: %v", err)
+		}
+		paths = []string{sample}
+		fmt.Println("No files given, using a generated sample: q3_vendor_review.docx")
+		fmt.Println()
+	}
+
+	var embedded, referenced []*document.Document
+	for i, path := range paths {
+		markdown, err := ConvertOffice(path)
+		if err != nil {
+			log.Fatalf("Failed to convert %s: %v", path, err)
+		}
+
+		// The .md name tells the model what format the content is in
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)) + ".md"
+		doc := document.NewInMemoryDocument(fmt.Sprintf("office_%03d", i+1), name, []byte(markdown), nil)
+
+		mode := "embedded"
+		if len(markdown) > embedLimit {
+			referenced = append(referenced, doc)
+			mode = "reference"
+		} else {
+			embedded = append(embedded, doc)
+		}
+		fmt.Printf("📄 %s → %s (%d bytes, %s)\n", filepath.Base(path), name, len(markdown), mode)
+
+		if *show {
+			fmt.Printf("\n%s\n", markdown)
+		}
+	}
+	fmt.Println()
+
+	if *show {
+		return
+	}
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:              model,
+		Name:               "OfficeAnalyst",
+		Description:        "Answers questions about Word and PowerPoint files",
+		Instructions:       "The documents were converted from Office files to markdown. Headings show the document structure and tables keep their rows and columns. Answer from the documents only and cite the section you used.",
+		Documents:          embedded,
+		DocumentReferences: referenced,
+	}
+
+	fmt.Printf("❓ %s\n\n", *question)
+	response, err := agent.Execute(*question)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	fmt.Printf("Analysis:\n%s\n\n", response)
+
+	fmt.Println("✅ Example completed successfully!")
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ConvertOffice converts a .docx or .pptx file to markdown, keeping headings, lists
+// and tables, which is what a model needs to answer questions about the content.
+// Formatting, images and comments are dropped.
+func ConvertOffice(path string) (string, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return "", fmt.Errorf("%s is not an Office file: %w", path, err)
+	}
+	defer r.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".docx":
+		f, err := r.Open("word/document.xml")
+		if err != nil {
+			return "", fmt.Errorf("%s: no word/document.xml: %w", path, err)
+		}
+		defer f.Close()
+		return convertDocx(f)
+	case ".pptx":
+		return convertPptx(&r.Reader)
+	default:
+		return "", fmt.Errorf("unsupported file type %q, use .docx or .pptx", filepath.Ext(path))
+	}
+}
+
+// table collects rows of cells while the XML is streamed
+type table struct {
+	rows [][]string
+}
+
+func (t *table) addRow() { t.rows = append(t.rows, nil) }
+
+func (t *table) addCell() {
+	if len(t.rows) == 0 {
+		t.addRow()
+	}
+	t.rows[len(t.rows)-1] = append(t.rows[len(t.rows)-1], "")
+}
+
+func (t *table) appendText(s string) {
+	if len(t.rows) == 0 || len(t.rows[len(t.rows)-1]) == 0 {
+		return
+	}
+	row := t.rows[len(t.rows)-1]
+	if row[len(row)-1] != "" {
+		row[len(row)-1] += " "
+	}
+	row[len(row)-1] += s
+}
+
+// markdown renders the table with the first row as the header
+func (t *table) markdown() string {
+	width := 0
+	for _, row := range t.rows {
+		width = max(width, len(row))
+	}
+	if width == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for i, row := range t.rows {
+		cells := make([]string, width)
+		for j := range cells {
+			if j < len(row) {
+				cells[j] = strings.ReplaceAll(strings.TrimSpace(row[j]), "|", `\|`)
+			}
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		if i == 0 {
+			b.WriteString("|" + strings.Repeat(" --- |", width) + "\n")
+		}
+	}
+	return b.String()
+}
+
+var headingStyle = regexp.MustCompile(`(?i)^heading\s*([1-6])$`)
+
+// convertDocx streams word/document.xml. Paragraph styles named Title or Heading1-6
+// become markdown headings, numbered or bulleted paragraphs become list items, and
+// tables become markdown tables.
+func convertDocx(r io.Reader) (string, error) {
+	var (
+		out     strings.Builder
+		tables  []*table
+		text    strings.Builder
+		style   string
+		isList  bool
+		inList  bool
+		inText  bool
+		decoder = xml.NewDecoder(r)
+	)
+
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("reading document.xml: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "p":
+				text.Reset()
+				style, isList = "", false
+			case "pStyle":
+				style = attr(t, "val")
+			case "numPr":
+				isList = true
+			case "t":
+				inText = true
+			case "tab":
+				text.WriteString("\t")
+			case "br", "cr":
+				text.WriteString("\n")
+			case "tbl":
+				tables = append(tables, &table{})
+			case "tr":
+				if len(tables) > 0 {
+					tables[len(tables)-1].addRow()
+				}
+			case "tc":
+				if len(tables) > 0 {
+					tables[len(tables)-1].addCell()
+				}
+			}
+		case xml.CharData:
+			if inText {
+				text.Write(t)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				paragraph := strings.TrimSpace(text.String())
+				if paragraph == "" {
+					continue
+				}
+				if len(tables) > 0 {
+					tables[len(tables)-1].appendText(strings.ReplaceAll(paragraph, "\n", " "))
+					continue
+				}
+				formatted := formatParagraph(paragraph, style, isList)
+				// Lists are written without blank lines between items; end one before anything else
+				if inList && !strings.HasPrefix(formatted, "- ") {
+					out.WriteString("\n")
+				}
+				inList = strings.HasPrefix(formatted, "- ")
+				out.WriteString(formatted)
+			case "tbl":
+				if len(tables) == 0 {
+					continue
+				}
+				done := tables[len(tables)-1]
+				tables = tables[:len(tables)-1]
+				if len(tables) > 0 {
+					// A nested table is flattened into the enclosing cell
+					tables[len(tables)-1].appendText(strings.Join(flatten(done.rows), "; "))
+					continue
+				}
+				if inList {
+					out.WriteString("\n")
+					inList = false
+				}
+				out.WriteString(done.markdown() + "\n")
+			}
+		}
+	}
+	return strings.TrimSpace(out.String()) + "\n", nil
+}
+
+func formatParagraph(text, style string, isList bool) string {
+	switch {
+	case strings.EqualFold(style, "Title"):
+		return "# " + text + "\n\n"
+	case headingStyle.MatchString(style):
+		level, _ := strconv.Atoi(headingStyle.FindStringSubmatch(style)[1])
+		// Title takes the single #, so Heading1 starts at ##
+		return strings.Repeat("#", min(level+1, 6)) + " " + text + "\n\n"
+	case isList || strings.HasPrefix(strings.ToLower(style), "listparagraph"):
+		return "- " + text + "\n"
+	default:
+		return text + "\n\n"
+	}
+}
+
+func flatten(rows [][]string) []string {
+	var cells []string
+	for _, row := range rows {
+		cells = append(cells, strings.Join(row, " "))
+	}
+	return cells
+}
+
+var slideName = regexp.MustCompile(`^ppt/slides/slide(\d+)\.xml$`)
+
+// convertPptx converts each slide in order. The title placeholder becomes a heading,
+// other text becomes list items and tables become markdown tables. Speaker notes are
+// not included.
+func convertPptx(r *zip.Reader) (string, error) {
+	type slide struct {
+		number int
+		file   *zip.File
+	}
+	var slides []slide
+	for _, f := range r.File {
+		if m := slideName.FindStringSubmatch(f.Name); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			slides = append(slides, slide{n, f})
+		}
+	}
+	if len(slides) == 0 {
+		return "", fmt.Errorf("no slides found")
+	}
+	sort.Slice(slides, func(i, j int) bool { return slides[i].number < slides[j].number })
+
+	var out strings.Builder
+	for i, s := range slides {
+		f, err := s.file.Open()
+		if err != nil {
+			return "", err
+		}
+		title, body, err := convertSlide(f)
+		f.Close()
+		if err != nil {
+			return "", fmt.Errorf("slide %d: %w", s.number, err)
+		}
+
+		fmt.Fprintf(&out, "## Slide %d", i+1)
+		if title != "" {
+			out.WriteString(": " + title)
+		}
+		out.WriteString("\n\n" + body + "\n")
+	}
+	return strings.TrimSpace(out.String()) + "\n", nil
+}
+
+func convertSlide(r io.Reader) (string, string, error) {
+	var (
+		title   []string
+		body    strings.Builder
+		tables  []*table
+		text    strings.Builder
+		isTitle bool
+		inText  bool
+		decoder = xml.NewDecoder(r)
+	)
+
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", "", err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "sp":
+				isTitle = false
+			case "ph":
+				kind := attr(t, "type")
+				isTitle = kind == "title" || kind == "ctrTitle"
+			case "p":
+				text.Reset()
+			case "t":
+				inText = true
+			case "br":
+				text.WriteString(" ")
+			case "tbl":
+				tables = append(tables, &table{})
+			case "tr":
+				if len(tables) > 0 {
+					tables[len(tables)-1].addRow()
+				}
+			case "tc":
+				if len(tables) > 0 {
+					tables[len(tables)-1].addCell()
+				}
+			}
+		case xml.CharData:
+			if inText {
+				text.Write(t)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				paragraph := strings.TrimSpace(text.String())
+				switch {
+				case paragraph == "":
+				case len(tables) > 0:
+					tables[len(tables)-1].appendText(paragraph)
+				case isTitle:
+					title = append(title, paragraph)
+				default:
+					body.WriteString("- " + paragraph + "\n")
+				}
+			case "tbl":
+				if len(tables) == 0 {
+					continue
+				}
+				done := tables[len(tables)-1]
+				tables = tables[:len(tables)-1]
+				if len(tables) == 0 {
+					if body.Len() > 0 {
+						body.WriteString("\n")
+					}
+					body.WriteString(done.markdown())
+				}
+			}
+		}
+	}
+	return strings.Join(title, " "), body.String(), nil
+}
+
+func attr(e xml.StartElement, name string) string {
+	for _, a := range e.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"os"
+	"strings"
+)
+
+// writeSampleDocx writes a small vendor review with a title, headings, a bulleted list
+// and a table: the structures ConvertOffice has to preserve. It contains only the
+// parts Word needs to open the file.
+func writeSampleDocx(path string) error {
+	var body strings.Builder
+	para := func(style, text string) {
+		body.WriteString("<w:p>")
+		if style != "" {
+			body.WriteString(`<w:pPr><w:pStyle w:val="` + style + `"/></w:pPr>`)
+		}
+		body.WriteString("<w:r><w:t xml:space=\"preserve\">" + escapeXML(text) + "</w:t></w:r></w:p>")
+	}
+	bullet := func(text string) {
+		body.WriteString(`<w:p><w:pPr><w:pStyle w:val="ListParagraph"/><w:numPr><w:ilvl w:val="0"/><w:numId w:val="1"/></w:numPr></w:pPr>`)
+		body.WriteString("<w:r><w:t>" + escapeXML(text) + "</w:t></w:r></w:p>")
+	}
+	tableXML := func(rows [][]string) {
+		body.WriteString(`<w:tbl><w:tblPr><w:tblStyle w:val="TableGrid"/></w:tblPr>`)
+		for _, row := range rows {
+			body.WriteString("<w:tr>")
+			for _, cell := range row {
+				body.WriteString("<w:tc><w:p><w:r><w:t>" + escapeXML(cell) + "</w:t></w:r></w:p></w:tc>")
+			}
+			body.WriteString("</w:tr>")
+		}
+		body.WriteString("</w:tbl>")
+	}
+
+	para("Title", "Q3 Vendor Review")
+	para("", "Prepared by Procurement for the operations leadership meeting.")
+	para("Heading1", "Summary")
+	para("", "We reviewed our four largest software vendors ahead of the renewal cycle. Two contracts renew before year end and one vendor is under a performance improvement plan.")
+	para("Heading1", "Contracts")
+	tableXML([][]string{
+		{"Vendor", "Service", "Annual value", "Renewal date", "Rating"},
+		{"Northwind Cloud", "Hosting", "$420,000", "2025-11-30", "Good"},
+		{"Contoso CRM", "Customer relationship management", "$185,000", "2026-03-31", "Excellent"},
+		{"Fabrikam Analytics", "BI dashboards", "$96,000", "2025-12-15", "Needs improvement"},
+		{"Tailspin Security", "Endpoint protection", "$74,500", "2026-06-30", "Good"},
+	})
+	para("Heading1", "Risks")
+	para("Heading2", "Fabrikam Analytics")
+	bullet("Three missed SLAs in Q3, each over four hours")
+	bullet("Dashboard refresh failures during month-end close")
+	bullet("Improvement plan review on 2025-10-20")
+	para("Heading2", "Northwind Cloud")
+	bullet("Price increase of 8% announced for renewal")
+	para("Heading1", "Recommendations")
+	para("", "Renew Northwind Cloud after negotiating the increase down to at most 4%. Do not renew Fabrikam Analytics unless the improvement plan succeeds; start evaluating alternatives now.")
+
+	document := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+		body.String() + `</w:body></w:document>`
+
+	files := []struct{ name, content string }{
+		{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/></Types>`},
+		{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/></Relationships>`},
+		{"word/document.xml", document},
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for _, file := range files {
+		w, err := zw.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write([]byte(file.content)); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func escapeXML(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
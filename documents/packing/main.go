@@ -0,0 +1,117 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic-examples/documents/budget"
+	"github.com/nexxia-ai/aigentic-examples/documents/loader"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// incidentLog makes a log far larger than any sensible budget, with the entries that
+// matter at the start, the way an exported log usually reads
+func incidentLog() []byte {
+	var sb strings.Builder
+	sb.WriteString("# Incident log, February 2025\n\n")
+	sb.WriteString("2025-02-11T03:12Z INC-104 opened: API error rate above 20%, on-call paged\n")
+	sb.WriteString("2025-02-11T05:47Z INC-104 resolved: failed database failover, 2h35m of degraded service\n")
+	sb.WriteString("2025-02-24T22:05Z INC-109 opened: full outage after a certificate expired\n")
+	sb.WriteString("2025-02-25T00:15Z INC-109 resolved: certificate renewed, 2h10m of downtime\n\n")
+	for day := 1; day <= 28; day++ {
+		for hour := 0; hour < 24; hour++ {
+			for minute := 0; minute < 60; minute += 30 {
+				fmt.Fprintf(&sb, "2025-02-%02dT%02d:%02dZ health check ok, p95 latency %dms\n", day, hour, minute, 40+(day*7+hour+minute)%25)
+			}
+		}
+	}
+	return []byte(sb.String())
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	tokens := flag.Int("budget", 4000, "Tokens the documents may use in the context")
+	maxShare := flag.Float64("max-share", 0.6, "Largest share of the budget one document may take")
+	minExcerpt := flag.Int("min-excerpt", 500, "Smallest excerpt worth embedding, in tokens")
+	show := flag.Bool("show", false, "Print the budget decisions and exit without calling the model")
+	question := flag.String("q", "How much downtime did we have in February, and what does the services agreement say we are owed for it?", "Question to ask about the documents")
+	flag.Parse()
+
+	fmt.Println("Packing Documents into a Token Budget")
+	fmt.Println("=====================================")
+	fmt.Println()
+
+	// Most important first: the order decides who gets the budget
+	l := loader.New()
+	var loaded []loader.Loaded
+	for _, path := range []string{
+		"../testdata/services_agreement_v2.md",
+		"incidents_2025-02.md",
+		"../testdata/receipt.png",
+		"../testdata/handbook.md",
+		"../testdata/services_agreement_v1.md",
+	} {
+		var item loader.Loaded
+		var err error
+		if path == "incidents_2025-02.md" {
+			item, err = l.Load(path, incidentLog())
+		} else {
+			item, err = l.LoadFile(path)
+		}
+		if err != nil {
+			log.Fatalf("Failed to load documents: %v", err)
+		}
+		loaded = append(loaded, item)
+	}
+
+	plan, err := budget.Pack(loader.Documents(loaded), budget.Budget{
+		Tokens:      *tokens,
+		MaxDocShare: *maxShare,
+		MinTruncate: *minExcerpt,
+	})
+	if err != nil {
+		log.Fatalf("Failed to pack documents: %v", err)
+	}
+	plan.Print(os.Stdout)
+	fmt.Println()
+
+	if *show {
+		return
+	}
+
+	agent := aigentic.Agent{
+		Model:       openai.NewModel("gpt-4o-mini", getAPIKey()),
+		Name:        "OperationsAnalyst",
+		Description: "Answers questions about operations from company documents",
+		Instructions: `Answer from the documents. Some documents are only excerpts; an excerpt ends with a note
+naming the document reference that holds the rest. Read a reference when the answer is not in what you have,
+and name the document behind each fact.`,
+		Documents:          plan.Documents,
+		DocumentReferences: plan.References,
+	}
+
+	fmt.Printf("❓ %s\n\n", *question)
+	response, err := agent.Execute(*question)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	fmt.Printf("Answer:\n%s\n\n", response)
+
+	fmt.Println("✅ Example completed successfully!")
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/document"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+// Tool results are capped so that no single call can flood the context window
+const maxReadChars = 6000
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// generateManual writes a large reference manual: chapters of error codes, each with
+// a cause, an action and an escalation team. It is the same on every run, so the
+// answers can be checked.
+func generateManual(codes int) string {
+	rng := rand.New(rand.NewSource(7))
+	parts := []string{"coolant pump", "spindle motor", "axis encoder", "tool changer", "door interlock", "vacuum table", "laser module", "power supply"}
+	faults := []string{"pressure low", "over temperature", "signal lost", "timeout", "position error", "voltage out of range"}
+	actions := []string{"Power-cycle the controller and run the self-test from the service menu.", "Check the connector for corrosion and reseat it.", "Replace the fuse marked F%d on the main board.", "Recalibrate with the procedure in chapter 2 using a %d mm gauge.", "Clear the filter and wait %d minutes before restarting."}
+	teams := []string{"Field Service", "Electrical Engineering", "Firmware Team", "Safety Office"}
+
+	var sb strings.Builder
+	sb.WriteString("# Orion X7 Controller Reference Manual\n\nThis manual lists every error code reported by the Orion X7 controller, with its likely cause and the corrective action.\n\n")
+	perChapter := 50
+	for i := 0; i < codes; i++ {
+		if i%perChapter == 0 {
+			fmt.Fprintf(&sb, "## Chapter %d: Error codes E%d00-E%d49\n\n", i/perChapter+1, 10+i/perChapter, 10+i/perChapter)
+			sb.WriteString("Codes in this chapter are reported on the front panel and in the event log.\n\n")
+		}
+		code := 1000 + (i/perChapter)*100 + i%perChapter
+		part, fault := parts[rng.Intn(len(parts))], faults[rng.Intn(len(faults))]
+		action := actions[rng.Intn(len(actions))]
+		if strings.Contains(action, "%d") {
+			action = fmt.Sprintf(action, 2+rng.Intn(20))
+		}
+		fmt.Fprintf(&sb, "### E%d: %s %s\n\n", code, part, fault)
+		fmt.Fprintf(&sb, "**Cause:** The %s reported %s during operation. This is usually caused by wear or a loose connection, and occurs more often after %d operating hours.\n\n", part, fault, 500*(1+rng.Intn(20)))
+		fmt.Fprintf(&sb, "**Action:** %s If the error returns within %d hours, escalate to %s.\n\n", action, 1+rng.Intn(48), teams[rng.Intn(len(teams))])
+		sb.WriteString("**Notes:** Record the error code, the time and the operator in the maintenance log before clearing the error. Do not disable the alarm.\n\n")
+	}
+	return sb.String()
+}
+
+// referenceIndex holds the referenced documents and their outlines, and counts how
+// much of them the agent actually read
+type referenceIndex struct {
+	docs     map[string]string
+	sections map[string][]Section
+
+	mu   sync.Mutex
+	read int
+}
+
+func newReferenceIndex(docs []*document.Document) (*referenceIndex, error) {
+	index := &referenceIndex{docs: map[string]string{}, sections: map[string][]Section{}}
+	for _, doc := range docs {
+		data, err := doc.Bytes()
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", doc.Filename, err)
+		}
+		index.docs[doc.Filename] = string(data)
+		index.sections[doc.Filename] = outline(string(data))
+	}
+	return index, nil
+}
+
+func (x *referenceIndex) lookup(name string) (string, []Section, error) {
+	text, ok := x.docs[name]
+	if !ok {
+		var names []string
+		for n := range x.docs {
+			names = append(names, n)
+		}
+		return "", nil, fmt.Errorf("unknown document %q; available: %s", name, strings.Join(names, ", "))
+	}
+	return text, x.sections[name], nil
+}
+
+func (x *referenceIndex) count(result string) string {
+	x.mu.Lock()
+	x.read += len(result)
+	x.mu.Unlock()
+	return result
+}
+
+// createTools gives the agent four ways to look into a document without loading it
+// whole: the outline, a search, a section, or a byte range
+func createTools(x *referenceIndex) []aigentic.AgentTool {
+	type OutlineInput struct {
+		Document string `json:"document" description:"Document name"`
+		Filter   string `json:"filter,omitempty" description:"Only list headings containing this text (case-insensitive)"`
+		MaxLevel int    `json:"max_level,omitempty" description:"Only list headings up to this level, e.g. 2 for chapters"`
+	}
+	type FindInput struct {
+		Document string `json:"document" description:"Document name"`
+		Query    string `json:"query" description:"Exact text to search for, e.g. an error code"`
+	}
+	type SectionInput struct {
+		Document string `json:"document" description:"Document name"`
+		Section  int    `json:"section" description:"Section number as shown by document_outline or find_text, without the §"`
+	}
+	type RangeInput struct {
+		Document string `json:"document" description:"Document name"`
+		Start    int    `json:"start" description:"Byte offset to start reading at"`
+		Length   int    `json:"length" description:"Number of bytes to read, at most 6000"`
+	}
+
+	outlineTool := aigentic.NewTool(
+		"document_outline",
+		"Lists the headings of a document with their section numbers and byte ranges, at most 100 per call",
+		func(run *aigentic.AgentRun, input OutlineInput) (string, error) {
+			_, sections, err := x.lookup(input.Document)
+			if err != nil {
+				return "", err
+			}
+			var sb strings.Builder
+			listed := 0
+			for _, s := range sections {
+				if input.MaxLevel > 0 && s.Level > input.MaxLevel {
+					continue
+				}
+				if input.Filter != "" && !strings.Contains(strings.ToLower(s.Title), strings.ToLower(input.Filter)) {
+					continue
+				}
+				if listed == 100 {
+					sb.WriteString("... more headings; use filter or max_level to narrow the list\n")
+					break
+				}
+				sb.WriteString(s.String() + "\n")
+				listed++
+			}
+			if listed == 0 {
+				return "No matching headings.", nil
+			}
+			return x.count(sb.String()), nil
+		},
+	)
+
+	findTool := aigentic.NewTool(
+		"find_text",
+		"Searches a document and returns up to 10 matches with their byte offset, section number and surrounding text",
+		func(run *aigentic.AgentRun, input FindInput) (string, error) {
+			text, sections, err := x.lookup(input.Document)
+			if err != nil {
+				return "", err
+			}
+			matches := findText(text, sections, input.Query, 10)
+			if len(matches) == 0 {
+				return fmt.Sprintf("No matches for %q.", input.Query), nil
+			}
+			var sb strings.Builder
+			for _, m := range matches {
+				fmt.Fprintf(&sb, "offset %d, §%d: ...%s...\n", m.Offset, m.Section, m.Snippet)
+			}
+			return x.count(sb.String()), nil
+		},
+	)
+
+	sectionTool := aigentic.NewTool(
+		"read_section",
+		"Reads one section of a document, up to 6000 characters; sub-sections are separate sections",
+		func(run *aigentic.AgentRun, input SectionInput) (string, error) {
+			text, sections, err := x.lookup(input.Document)
+			if err != nil {
+				return "", err
+			}
+			if input.Section < 0 || input.Section >= len(sections) {
+				return "", fmt.Errorf("section %d does not exist; the document has sections 0-%d", input.Section, len(sections)-1)
+			}
+			s := sections[input.Section]
+			content, _, end := sliceText(text, s.Start, min(s.End-s.Start, maxReadChars))
+			if end < s.End {
+				content += fmt.Sprintf("\n[truncated; continue with read_range start=%d]", end)
+			}
+			return x.count(content), nil
+		},
+	)
+
+	rangeTool := aigentic.NewTool(
+		"read_range",
+		"Reads a byte range of a document, at most 6000 bytes",
+		func(run *aigentic.AgentRun, input RangeInput) (string, error) {
+			text, _, err := x.lookup(input.Document)
+			if err != nil {
+				return "", err
+			}
+			content, start, end := sliceText(text, input.Start, min(input.Length, maxReadChars))
+			return x.count(fmt.Sprintf("[bytes %d-%d of %d]\n%s", start, end, len(text), content)), nil
+		},
+	)
+
+	return []aigentic.AgentTool{outlineTool, findTool, sectionTool, rangeTool}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	path := flag.String("file", "", "Large markdown document to query; without it a 1,000-code reference manual is generated")
+	codes := flag.Int("codes", 1000, "Error codes in the generated manual")
+	question := flag.String("q", "The front panel shows E1437. What is the cause, what should I do, and who do I escalate to if it comes back?", "Question to ask")
+	flag.Parse()
+
+	fmt.Println("Partial Retrieval from Large Documents with Aigentic")
+	fmt.Println("====================================================")
+	fmt.Println()
+
+	name, content := "orion_x7_manual.md", generateManual(*codes)
+	if *path != "" {
+		data, err := os.ReadFile(*path)
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", *path, err)
+		}
+		name, content = filepath.Base(*path), string(data)
+	}
+
+	// The document is a reference: the agent never receives it whole, only the parts it
+	// asks for through the tools. It is not put in the agent's DocumentReferences, since
+	// that would offer the built-in tool that reads an entire document.
+	references := []*document.Document{document.NewInMemoryDocument("manual", name, []byte(content), nil)}
+	index, err := newReferenceIndex(references)
+	if err != nil {
+		log.Fatalf("Failed to index documents: %v", err)
+	}
+	fmt.Printf("📚 %s: %d bytes, about %d tokens, %d sections\n\n", name, len(content), len(content)/4, len(index.sections[name]))
+
+	agent := aigentic.Agent{
+		Model:       openai.NewModel("gpt-4o-mini", getAPIKey()),
+		Name:        "ManualAssistant",
+		Description: "Answers questions from large reference documents by reading only the relevant parts",
+		Instructions: fmt.Sprintf(`The document %q is far too large to read whole. Find what you need first, then read only that:
+- find_text to locate exact terms such as codes or names
+- document_outline with max_level or filter to see the structure
+- read_section or read_range to read the part you located
+Answer only from what you read, and mention the section you used.`, name),
+		AgentTools: createTools(index),
+	}
+
+	fmt.Printf("❓ %s\n\n", *question)
+	response, err := agent.Execute(*question)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	fmt.Printf("%s\n\n", strings.TrimSpace(response))
+
+	fmt.Printf("📊 The tools returned %d bytes, %.2f%% of the document\n", index.read, 100*float64(index.read)/float64(len(content)))
+
+	fmt.Println("\n✅ Example completed successfully!")
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Section is a markdown heading and the byte range it covers, up to the next heading
+// of any level
+type Section struct {
+	ID    int
+	Level int
+	Title string
+	Start int // byte offset of the heading line
+	End   int // byte offset where the next section starts
+}
+
+var heading = regexp.MustCompile(`(?m)^(#{1,6})\s+(.+)$`)
+
+// outline indexes the sections of a markdown document. Text before the first heading
+// becomes section 0 with an empty title.
+func outline(text string) []Section {
+	var sections []Section
+	matches := heading.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 || matches[0][0] > 0 {
+		sections = append(sections, Section{ID: 0, Start: 0})
+	}
+	for _, m := range matches {
+		sections = append(sections, Section{
+			ID:    len(sections),
+			Level: m[3] - m[2],
+			Title: strings.TrimSpace(text[m[4]:m[5]]),
+			Start: m[0],
+		})
+	}
+	for i := range sections {
+		if i+1 < len(sections) {
+			sections[i].End = sections[i+1].Start
+		} else {
+			sections[i].End = len(text)
+		}
+	}
+	return sections
+}
+
+// sliceText returns text[start:start+length], moved inward to UTF-8 boundaries so a
+// range never splits a character
+func sliceText(text string, start, length int) (string, int, int) {
+	start = max(0, min(start, len(text)))
+	end := min(len(text), start+max(length, 0))
+	for start < end && !utf8.RuneStart(text[start]) {
+		start++
+	}
+	for end > start && end < len(text) && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	return text[start:end], start, end
+}
+
+// Match is a search hit with its position and some surrounding text
+type Match struct {
+	Offset  int
+	Section int
+	Snippet string
+}
+
+// findText returns up to limit case-insensitive matches of query, each with the
+// section it is in
+func findText(text string, sections []Section, query string, limit int) []Match {
+	lower, q := strings.ToLower(text), strings.ToLower(query)
+	if q == "" {
+		return nil
+	}
+
+	var matches []Match
+	for from := 0; len(matches) < limit; {
+		i := strings.Index(lower[from:], q)
+		if i < 0 {
+			break
+		}
+		offset := from + i
+		snippet, _, _ := sliceText(text, offset-80, len(q)+160)
+		matches = append(matches, Match{
+			Offset:  offset,
+			Section: sectionAt(sections, offset),
+			Snippet: strings.Join(strings.Fields(snippet), " "),
+		})
+		from = offset + len(q)
+	}
+	return matches
+}
+
+func sectionAt(sections []Section, offset int) int {
+	for _, s := range sections {
+		if offset >= s.Start && offset < s.End {
+			return s.ID
+		}
+	}
+	return len(sections) - 1
+}
+
+func (s Section) String() string {
+	title := s.Title
+	if title == "" {
+		title = "(preamble)"
+	}
+	return fmt.Sprintf("§%d %s%s [bytes %d-%d]", s.ID, strings.Repeat("  ", max(s.Level-1, 0)), title, s.Start, s.End)
+}
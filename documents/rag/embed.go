@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Embedder turns texts into vectors. Name identifies the embedding space: vectors
+// from different embedders cannot be compared, so the store records it.
+type Embedder interface {
+	Name() string
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// OpenAIEmbedder calls the OpenAI embeddings endpoint
+type OpenAIEmbedder struct {
+	APIKey  string
+	Model   string // for example text-embedding-3-small
+	BaseURL string // defaults to https://api.openai.com/v1
+	Client  *http.Client
+}
+
+func NewOpenAIEmbedder(apiKey, model string) *OpenAIEmbedder {
+	return &OpenAIEmbedder{
+		APIKey:  apiKey,
+		Model:   model,
+		BaseURL: "https://api.openai.com/v1",
+		Client:  &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+func (e *OpenAIEmbedder) Name() string { return "openai/" + e.Model }
+
+// The endpoint accepts up to 2048 inputs per request; smaller batches keep each
+// request well under the token limit
+const embedBatchSize = 100
+
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += embedBatchSize {
+		batch, err := e.embedBatch(ctx, texts[start:min(start+embedBatchSize, len(texts))])
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, batch...)
+	}
+	return vectors, nil
+}
+
+func (e *OpenAIEmbedder) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(map[string]any{"model": e.Model, "input": texts})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.BaseURL+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+e.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("embeddings: %s: %w", resp.Status, err)
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("embeddings: %s: %s", resp.Status, result.Error.Message)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("embeddings: %s", resp.Status)
+	}
+	if len(result.Data) != len(texts) {
+		return nil, fmt.Errorf("embeddings: sent %d inputs, got %d vectors", len(texts), len(result.Data))
+	}
+
+	// Results carry the index of their input; do not rely on the order
+	vectors := make([][]float32, len(texts))
+	for _, d := range result.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embeddings: unexpected index %d", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	return vectors, nil
+}
+
+// HashEmbedder is a local stand-in for a real embedding model. It hashes words and
+// word pairs into a fixed number of dimensions, so texts that share vocabulary get
+// similar vectors. It knows nothing about meaning ("vacation" and "PTO" are unrelated
+// to it), but it needs no network and gives the same vectors on every run.
+type HashEmbedder struct {
+	Dims int
+}
+
+func (e HashEmbedder) Name() string { return fmt.Sprintf("local/hash-%d", e.Dims) }
+
+func (e HashEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = e.embed(text)
+	}
+	return vectors, nil
+}
+
+func (e HashEmbedder) embed(text string) []float32 {
+	v := make([]float32, e.Dims)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '$'
+	})
+	add := func(feature string, weight float32) {
+		h := fnv.New64a()
+		h.Write([]byte(feature))
+		sum := h.Sum64()
+		// The top bit picks the sign, so collisions cancel out instead of piling up
+		if sum>>63 == 1 {
+			weight = -weight
+		}
+		v[sum%uint64(e.Dims)] += weight
+	}
+	for i, w := range words {
+		add(w, 1)
+		if i > 0 {
+			add(words[i-1]+" "+w, 0.5)
+		}
+	}
+	normalize(v)
+	return v
+}
+
+func normalize(v []float32) {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 {
+		return
+	}
+	norm := float32(math.Sqrt(sum))
+	for i := range v {
+		v[i] /= norm
+	}
+}
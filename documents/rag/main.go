@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// collectFiles expands directories into the markdown and text files they contain
+func collectFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, p := range paths {
+		err := filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			ext := strings.ToLower(filepath.Ext(path))
+			if !d.IsDir() && (ext == ".md" || ext == ".txt" || path == p) {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// createSearchTool gives the agent the store. Every passage is labelled with the ID the
+// agent must cite, and retrieved records the IDs it was shown, so citations can be
+// checked after the answer.
+func createSearchTool(store *Store, embedder Embedder, k int, retrieved map[string]bool) aigentic.AgentTool {
+	type SearchInput struct {
+		Query string `json:"query" description:"What to look for, phrased as a question or keywords. Search again with different words if the passages do not answer the question."`
+	}
+
+	return aigentic.NewTool(
+		"search_documents",
+		"Searches the document collection and returns the most relevant passages, each with a citation ID in square brackets",
+		func(run *aigentic.AgentRun, input SearchInput) (string, error) {
+			vectors, err := embedder.Embed(context.Background(), []string{input.Query})
+			if err != nil {
+				return "", err
+			}
+			hits := store.Search(vectors[0], k)
+			if len(hits) == 0 {
+				return "The collection is empty.", nil
+			}
+
+			var sb strings.Builder
+			for _, h := range hits {
+				retrieved[h.ID] = true
+				fmt.Printf("   🔎 %q -> [%s] %.3f %s\n", input.Query, h.ID, h.Score, h.Section)
+				fmt.Fprintf(&sb, "[%s] %s\n%s\n\n", h.ID, h.Section, h.Text)
+			}
+			return sb.String(), nil
+		},
+	)
+}
+
+var citation = regexp.MustCompile(`\[([^\[\]\s]+#\d+)\]`)
+
+// citations returns the IDs cited in the answer, in order of first use
+func citations(answer string) []string {
+	var ids []string
+	seen := map[string]bool{}
+	for _, m := range citation.FindAllStringSubmatch(answer, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			ids = append(ids, m[1])
+		}
+	}
+	return ids
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	storePath := flag.String("store", filepath.Join(os.TempDir(), "aigentic-rag", "index.json"), "Vector store file; reused between runs")
+	local := flag.Bool("local", false, "Use the local hashing embedder instead of the OpenAI embeddings API")
+	embedModel := flag.String("embed-model", "text-embedding-3-small", "OpenAI embedding model")
+	k := flag.Int("k", 4, "Passages returned per search")
+	custom := flag.String("q", "", "Question to ask instead of the built-in ones")
+	flag.Parse()
+
+	fmt.Println("Retrieval-Augmented Generation with Aigentic")
+	fmt.Println("============================================")
+	fmt.Println()
+
+	apiKey := getAPIKey()
+	var embedder Embedder = NewOpenAIEmbedder(apiKey, *embedModel)
+	if *local {
+		embedder = HashEmbedder{Dims: 1024}
+	}
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"../testdata/handbook.md"}
+	}
+	files, err := collectFiles(paths)
+	if err != nil {
+		log.Fatalf("Failed to list documents: %v", err)
+	}
+
+	// 1. Index: chunk and embed each document once; unchanged files are skipped
+	store, err := OpenStore(*storePath, embedder.Name())
+	if err != nil {
+		log.Fatalf("Failed to open store: %v", err)
+	}
+	ctx := context.Background()
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", file, err)
+		}
+		changed, err := store.Index(ctx, embedder, filepath.Base(file), data)
+		if err != nil {
+			log.Fatalf("Failed to index: %v", err)
+		}
+		if changed {
+			fmt.Printf("📥 Indexed %s\n", file)
+		} else {
+			fmt.Printf("📦 %s is unchanged, using stored vectors\n", file)
+		}
+	}
+	if err := store.Save(); err != nil {
+		log.Fatalf("Failed to save store: %v", err)
+	}
+	fmt.Printf("🗄️  %d chunks from %d documents in %s (%s)\n\n", len(store.Records), len(store.Sources), *storePath, embedder.Name())
+
+	// 2. Retrieve and generate: the agent searches the store and answers with citations
+	retrieved := map[string]bool{}
+	agent := aigentic.Agent{
+		Model:       openai.NewModel("gpt-4o-mini", apiKey),
+		Name:        "DocumentAssistant",
+		Description: "Answers questions from a document collection, with citations",
+		Instructions: `Answer using only passages returned by the search_documents tool. Search before answering, and search again with other words if the passages do not contain the answer.
+After every sentence that uses a passage, cite it with its ID exactly as shown, for example [handbook.md#7].
+If the passages do not contain the answer, say that the documents do not cover it. Never cite an ID the tool did not return.`,
+		AgentTools: []aigentic.AgentTool{createSearchTool(store, embedder, *k, retrieved)},
+	}
+
+	questions := []string{
+		"How many weeks of paid parental leave does each parent get, and can I work part time afterwards?",
+		"I'm flying to Tokyo for a conference. What can I spend on the hotel and on meals?",
+		"Does the company pay for gym memberships?",
+	}
+	if *custom != "" {
+		questions = []string{*custom}
+	}
+
+	for _, q := range questions {
+		clear(retrieved)
+		fmt.Printf("❓ %s\n", q)
+
+		response, err := agent.Execute(q)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		fmt.Printf("\n%s\n", strings.TrimSpace(response))
+
+		ids := citations(response)
+		if len(ids) > 0 {
+			fmt.Println("\n📚 Sources:")
+		}
+		for _, id := range ids {
+			record, ok := store.Lookup(id)
+			switch {
+			case !ok:
+				fmt.Printf("   ⚠️  [%s] does not exist\n", id)
+			case !retrieved[id]:
+				fmt.Printf("   ⚠️  [%s] %s (cited but never retrieved)\n", id, record.Section)
+			default:
+				fmt.Printf("   [%s] %s\n", id, record.Section)
+			}
+		}
+		fmt.Println()
+	}
+
+	fmt.Println("✅ Example completed successfully!")
+}
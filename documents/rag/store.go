@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Record is one chunk of a source document with its vector. ID is what the agent
+// cites, such as "handbook.md#12".
+type Record struct {
+	ID      string    `json:"id"`
+	Source  string    `json:"source"`
+	Section string    `json:"section"`
+	Text    string    `json:"text"`
+	Vector  []float32 `json:"vector"`
+}
+
+// Hit is a search result
+type Hit struct {
+	Record
+	Score float64
+}
+
+// Store is a small vector store kept in memory and saved to a JSON file. Search is
+// a linear scan with cosine similarity, which is fast enough for a few thousand
+// chunks. Larger corpora need an approximate index, such as the one in chromem-go
+// or sqlite-vec.
+type Store struct {
+	Embedder string            `json:"embedder"`
+	Sources  map[string]string `json:"sources"` // source name to content hash
+	Records  []Record          `json:"records"`
+
+	path string
+}
+
+// OpenStore loads the store at path, or returns an empty one if the file does not
+// exist or was built with a different embedder
+func OpenStore(path, embedder string) (*Store, error) {
+	s := &Store{Embedder: embedder, Sources: map[string]string{}, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var saved Store
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if saved.Embedder != embedder {
+		// Vectors from another model live in a different space; start again
+		return s, nil
+	}
+	saved.path = path
+	if saved.Sources == nil {
+		saved.Sources = map[string]string{}
+	}
+	return &saved, nil
+}
+
+// Save writes the store to a temporary file and renames it, so an interrupted run
+// never leaves a half-written index
+func (s *Store) Save() error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Index chunks and embeds a source document, replacing any older version of it. It
+// returns false without calling the embedder when the content has not changed.
+func (s *Store) Index(ctx context.Context, embedder Embedder, source string, data []byte) (bool, error) {
+	hash := contentHash(data)
+	if s.Sources[source] == hash {
+		return false, nil
+	}
+
+	chunks := splitSections(string(data), 1200)
+	texts := make([]string, len(chunks))
+	for i, c := range chunks {
+		// The heading is embedded with the text, so a chunk that never repeats
+		// its topic still matches questions about it
+		texts[i] = c.Section + "\n\n" + c.Text
+	}
+	vectors, err := embedder.Embed(ctx, texts)
+	if err != nil {
+		return false, fmt.Errorf("embedding %s: %w", source, err)
+	}
+
+	kept := s.Records[:0]
+	for _, r := range s.Records {
+		if r.Source != source {
+			kept = append(kept, r)
+		}
+	}
+	s.Records = kept
+	for i, c := range chunks {
+		s.Records = append(s.Records, Record{
+			ID:      fmt.Sprintf("%s#%d", source, i+1),
+			Source:  source,
+			Section: c.Section,
+			Text:    c.Text,
+			Vector:  vectors[i],
+		})
+	}
+	s.Sources[source] = hash
+	return true, nil
+}
+
+// Search returns the k records most similar to the query vector
+func (s *Store) Search(query []float32, k int) []Hit {
+	hits := make([]Hit, 0, len(s.Records))
+	for _, r := range s.Records {
+		hits = append(hits, Hit{Record: r, Score: cosine(query, r.Vector)})
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+	return hits[:min(k, len(hits))]
+}
+
+// Lookup returns the record with the given ID
+func (s *Store) Lookup(id string) (Record, bool) {
+	for _, r := range s.Records {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return Record{}, false
+}
+
+func cosine(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+type section struct {
+	Section string
+	Text    string
+}
+
+var headingLine = regexp.MustCompile(`^(#{1,6})\s+(.+)$`)
+
+// splitSections makes one chunk per markdown section, labelled with its heading
+// path. Sections longer than maxChars are split between paragraphs. Text without
+// headings becomes paragraph-sized chunks with an empty label.
+func splitSections(text string, maxChars int) []section {
+	var chunks []section
+	var path []string
+	var body strings.Builder
+
+	flush := func() {
+		content := strings.TrimSpace(body.String())
+		body.Reset()
+		if content == "" {
+			return
+		}
+		var parts []string
+		for _, p := range path {
+			if p != "" {
+				parts = append(parts, p)
+			}
+		}
+		label := strings.Join(parts, " > ")
+		var part strings.Builder
+		for _, para := range strings.Split(content, "\n\n") {
+			para = strings.TrimSpace(para)
+			if para == "" {
+				continue
+			}
+			if part.Len() > 0 && part.Len()+2+len(para) > maxChars {
+				chunks = append(chunks, section{label, part.String()})
+				part.Reset()
+			}
+			if part.Len() > 0 {
+				part.WriteString("\n\n")
+			}
+			part.WriteString(para)
+		}
+		if part.Len() > 0 {
+			chunks = append(chunks, section{label, part.String()})
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		m := headingLine.FindStringSubmatch(line)
+		if m == nil {
+			body.WriteString(line + "\n")
+			continue
+		}
+		flush()
+		level := len(m[1])
+		if level <= len(path) {
+			path = path[:level-1]
+		}
+		for len(path) < level-1 {
+			path = append(path, "")
+		}
+		path = append(path, strings.TrimSpace(m[2]))
+	}
+	flush()
+	return chunks
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/document"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+// Formats accepted by OpenAI vision models, by sniffed MIME type, with the file
+// extension that matches each one
+var imageFormats = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// OpenAI rejects images larger than this
+const maxImageSize = 20 << 20
+
+type ReceiptItem struct {
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	Total       float64 `json:"total"`
+}
+
+type Receipt struct {
+	Merchant      string        `json:"merchant"`
+	Date          string        `json:"date"`
+	Items         []ReceiptItem `json:"items"`
+	Subtotal      float64       `json:"subtotal"`
+	Tax           float64       `json:"tax"`
+	Total         float64       `json:"total"`
+	PaymentMethod string        `json:"payment_method"`
+}
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// loadImage reads an image and checks it can be sent to a vision model. The MIME type
+// comes from the file contents rather than the name, and the document name is given
+// the matching extension, because a .jpg that is really a PNG, or a HEIC photo renamed
+// to .jpg, would otherwise be sent with the wrong type and rejected.
+func loadImage(path string) (*document.Document, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(data) > maxImageSize {
+		return nil, "", fmt.Errorf("%s is %d MB; resize it below %d MB", path, len(data)>>20, maxImageSize>>20)
+	}
+
+	mimeType := http.DetectContentType(data)
+	ext, ok := imageFormats[mimeType]
+	if !ok {
+		return nil, "", fmt.Errorf("%s is %s; convert it to PNG or JPEG first", path, mimeType)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)) + ext
+	doc := document.NewInMemoryDocument("receipt_image", name, data, nil)
+	doc.MimeType = mimeType
+	return doc, mimeType, nil
+}
+
+// parseReceipt reads the model's JSON, with or without a markdown code fence
+func parseReceipt(response string) (Receipt, error) {
+	text := strings.TrimSpace(response)
+	if start, end := strings.Index(text, "{"), strings.LastIndex(text, "}"); start >= 0 && end > start {
+		text = text[start : end+1]
+	}
+	var r Receipt
+	if err := json.Unmarshal([]byte(text), &r); err != nil {
+		return Receipt{}, fmt.Errorf("response is not valid receipt JSON: %w", err)
+	}
+	return r, nil
+}
+
+// check compares the totals the model read, which catches most misread digits
+func check(r Receipt) []string {
+	var problems []string
+	sum := 0.0
+	for _, item := range r.Items {
+		sum += item.Total
+	}
+	if math.Abs(sum-r.Subtotal) > 0.005 {
+		problems = append(problems, fmt.Sprintf("items add up to %.2f but the subtotal is %.2f", sum, r.Subtotal))
+	}
+	if math.Abs(r.Subtotal+r.Tax-r.Total) > 0.005 {
+		problems = append(problems, fmt.Sprintf("subtotal %.2f + tax %.2f does not equal total %.2f", r.Subtotal, r.Tax, r.Total))
+	}
+	return problems
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	imagePath := flag.String("image", "../testdata/receipt.png", "Receipt photo or scan (PNG, JPEG, GIF or WebP)")
+	flag.Parse()
+
+	fmt.Println("Receipt Extraction with a Vision Model")
+	fmt.Println("======================================")
+	fmt.Println()
+
+	doc, mimeType, err := loadImage(*imagePath)
+	if err != nil {
+		log.Fatalf("Cannot use image: %v", err)
+	}
+	fmt.Printf("🧾 %s (%s)\n\n", doc.Filename, mimeType)
+
+	// The model must accept image input; gpt-4o-mini does
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:       model,
+		Name:        "ReceiptReader",
+		Description: "Reads receipt images and extracts structured data",
+		Instructions: `You read photos of receipts. Reply with only a JSON object with these fields:
+merchant, date (YYYY-MM-DD), items (array of description, quantity, total), subtotal, tax, total, payment_method.
+Use numbers for amounts. Copy amounts exactly as printed; do not correct them.`,
+		Documents: []*document.Document{doc},
+	}
+
+	response, err := agent.Execute("Extract the data from this receipt.")
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	receipt, err := parseReceipt(response)
+	if err != nil {
+		log.Fatalf("%v\nResponse was:\n%s", err, response)
+	}
+
+	fmt.Printf("Merchant: %s\n", receipt.Merchant)
+	fmt.Printf("Date:     %s\n", receipt.Date)
+	fmt.Println(strings.Repeat("-", 50))
+	for _, item := range receipt.Items {
+		fmt.Printf("%-32s %4g %10.2f\n", item.Description, item.Quantity, item.Total)
+	}
+	fmt.Println(strings.Repeat("-", 50))
+	fmt.Printf("%-37s %10.2f\n", "Subtotal", receipt.Subtotal)
+	fmt.Printf("%-37s %10.2f\n", "Tax", receipt.Tax)
+	fmt.Printf("%-37s %10.2f\n", "Total", receipt.Total)
+	fmt.Printf("Paid with: %s\n\n", receipt.PaymentMethod)
+
+	if problems := check(receipt); len(problems) > 0 {
+		fmt.Println("⚠️  The extracted amounts do not add up; review the image:")
+		for _, p := range problems {
+			fmt.Printf("   - %s\n", p)
+		}
+	} else {
+		fmt.Println("✔️  Line items, subtotal, tax and total are consistent")
+	}
+
+	fmt.Println("\n✅ Example completed successfully!")
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/document"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// sampleTickets are support tickets full of personal data. The card number and IBAN
+// are test values with valid checksums.
+const sampleTickets = `# Support tickets, week 11
+
+## Ticket 4411 - Card lost abroad
+From: maria.gonzalez@example.com
+Customer reports her debit card 4111 1111 1111 1111 was lost in Lisbon on 10 March.
+She asked us to block it and send a replacement to her home address. Call back on
++44 20 7946 0958 once the replacement has shipped.
+
+## Ticket 4412 - Duplicate direct debit
+From: j.okafor@example.org
+The March payment of $84.20 was taken twice from account no. 20417733 on 3 March.
+Customer wants the duplicate refunded to the same account. Prefers email over phone.
+
+## Ticket 4413 - Refund to new bank
+From: MARIA.GONZALEZ@example.com
+Follow-up from the customer in ticket 4411: she has closed her old bank account and
+asked for the card fee refund to go to GB82 WEST 1234 5698 7654 32 instead.
+Phone: +44 (20) 7946-0958.
+
+## Ticket 4414 - Address change
+From: tom.becker@example.net
+Moving on 1 April. New phone number is 030 1234 5678. No other changes.
+`
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	file := flag.String("file", "", "Document to redact (default: sample support tickets)")
+	mapFile := flag.String("map", "", "Where to keep the mapping for re-identification (default: a temporary file)")
+	show := flag.Bool("show", false, "Print the redacted document and exit without calling the model")
+	question := flag.String("q", "Which customers need a callback or a refund? For each one, give the contact details or account to use and what to do.", "Question to ask about the document")
+	flag.Parse()
+
+	fmt.Println("Redacting Personal Data Before It Reaches the Model")
+	fmt.Println("===================================================")
+	fmt.Println()
+
+	name, text := "support_tickets.md", sampleTickets
+	if *file != "" {
+		data, err := os.ReadFile(*file)
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", *file, err)
+		}
+		name, text = filepath.Base(*file), string(data)
+	}
+
+	mapping := NewMapping()
+	redacted := mapping.Redact(text)
+
+	// Only the counts are printed; the values stay in the mapping
+	var kinds []string
+	for kind, n := range mapping.Counts {
+		kinds = append(kinds, fmt.Sprintf("%d %s", n, kind))
+	}
+	sort.Strings(kinds)
+	fmt.Printf("🔒 %s: replaced %d distinct values %v\n", name, len(mapping.Values), kinds)
+
+	if *mapFile == "" {
+		dir, err := os.MkdirTemp("", "redact-example-")
+		if err != nil {
+			log.Fatalf("Failed to create temp dir: %v", err)
+		}
+		defer os.RemoveAll(dir)
+		*mapFile = filepath.Join(dir, "mapping.json")
+	}
+	if err := mapping.Save(*mapFile); err != nil {
+		log.Fatalf("Failed to save mapping: %v", err)
+	}
+	fmt.Printf("🗝️  Mapping kept locally in %s\n\n", *mapFile)
+
+	if *show {
+		fmt.Println(redacted)
+		return
+	}
+
+	// Only the redacted text is attached to the agent
+	doc := document.NewInMemoryDocument("redacted_001", name, []byte(redacted), nil)
+
+	agent := aigentic.Agent{
+		Model:       openai.NewModel("gpt-4o-mini", getAPIKey()),
+		Name:        "SupportTriage",
+		Description: "Triages customer support tickets",
+		Instructions: `Personal data in the tickets has been replaced with placeholders such as [EMAIL_1] or [PHONE_2].
+The same placeholder always stands for the same value, so tickets with the same placeholder come from the same customer.
+Use the placeholders exactly as written when you refer to contact details or accounts. Never guess the real values.`,
+		Documents: []*document.Document{doc},
+	}
+
+	fmt.Printf("❓ %s\n\n", *question)
+	response, err := agent.Execute(*question)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	fmt.Printf("Answer as the model wrote it:\n%s\n\n", response)
+
+	// Re-identification happens here, after the model has answered, from the mapping
+	// on disk. A separate process could do the same from the saved file.
+	saved, err := LoadMapping(*mapFile)
+	if err != nil {
+		log.Fatalf("Failed to load mapping: %v", err)
+	}
+	fmt.Printf("Answer re-identified locally:\n%s\n\n", saved.Restore(response))
+
+	fmt.Println("✅ Example completed successfully!")
+}
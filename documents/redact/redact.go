@@ -0,0 +1,202 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Kind is a type of personal data the redactor masks
+type Kind string
+
+const (
+	KindEmail   Kind = "EMAIL"
+	KindCard    Kind = "CARD"
+	KindAccount Kind = "ACCOUNT"
+	KindPhone   Kind = "PHONE"
+)
+
+// detector finds one kind of value. group is the submatch that holds the value, for
+// patterns that need context around it; valid rejects matches that only look right.
+type detector struct {
+	kind    Kind
+	pattern *regexp.Regexp
+	group   int
+	valid   func(string) bool
+}
+
+// Detectors run in this order, and each one sees the text with the earlier matches
+// already replaced, so the digits of a card number are never also taken for a phone
+var detectors = []detector{
+	{kind: KindEmail, pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)},
+	{kind: KindCard, pattern: regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`), valid: luhn},
+	{kind: KindAccount, pattern: regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,3})?\b`), valid: validIBAN},
+	{kind: KindAccount, pattern: regexp.MustCompile(`(?i)\b(?:account|acct|a/c)(?:\s*(?:no\.?|number|#))?\s*[:#]?\s*(\d[\d-]{4,18}\d)\b`), group: 1},
+	{kind: KindPhone, pattern: regexp.MustCompile(`(?:\+\d{1,3}[\s.-]?)?(?:\(\d{1,4}\)[\s.-]?)?\d{2,4}[\s.-]\d{3,4}(?:[\s.-]\d{2,4})?\b`), valid: phoneLike},
+}
+
+// luhn checks the card number checksum, which rules out most digit runs that are not
+// card numbers
+func luhn(s string) bool {
+	digits := onlyDigits(s)
+	sum := 0
+	for i := range digits {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return len(digits) >= 13 && sum%10 == 0
+}
+
+// validIBAN checks the mod-97 checksum of an IBAN
+func validIBAN(s string) bool {
+	s = strings.ReplaceAll(s, " ", "")
+	if len(s) < 15 {
+		return false
+	}
+	var numeric strings.Builder
+	for _, r := range s[4:] + s[:4] {
+		if unicode.IsLetter(r) {
+			fmt.Fprintf(&numeric, "%d", r-'A'+10)
+		} else {
+			numeric.WriteRune(r)
+		}
+	}
+	n, ok := new(big.Int).SetString(numeric.String(), 10)
+	return ok && new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+// phoneLike wants 9 to 15 digits, which leaves out dates, times and most amounts
+func phoneLike(s string) bool {
+	n := len(onlyDigits(s))
+	return n >= 9 && n <= 15
+}
+
+func onlyDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// normalize makes different spellings of the same value share a placeholder, such
+// as a phone number written with and without spaces
+func normalize(kind Kind, value string) string {
+	switch kind {
+	case KindEmail:
+		return strings.ToLower(value)
+	case KindCard, KindPhone:
+		return onlyDigits(value)
+	case KindAccount:
+		return strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(value))
+	}
+	return value
+}
+
+// Mapping holds the placeholders and the values they replace. It never leaves the
+// machine: the model sees only the placeholders, and answers are re-identified locally.
+type Mapping struct {
+	Values map[string]string `json:"values"` // placeholder to original value
+	Counts map[Kind]int      `json:"counts"`
+
+	byValue map[string]string // kind and normalized value to placeholder
+}
+
+func NewMapping() *Mapping {
+	return &Mapping{Values: map[string]string{}, Counts: map[Kind]int{}, byValue: map[string]string{}}
+}
+
+// LoadMapping reads a mapping saved by Save, so answers can be re-identified later
+func LoadMapping(path string) (*Mapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := NewMapping()
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("reading mapping %s: %w", path, err)
+	}
+	for placeholder, value := range m.Values {
+		kind := Kind(placeholder[1:strings.LastIndex(placeholder, "_")])
+		m.byValue[string(kind)+":"+normalize(kind, value)] = placeholder
+	}
+	return m, nil
+}
+
+// Save writes the mapping readable by the owner only, because it is the personal
+// data the redaction was meant to protect
+func (m *Mapping) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+func (m *Mapping) placeholder(kind Kind, value string) string {
+	key := string(kind) + ":" + normalize(kind, value)
+	if p, ok := m.byValue[key]; ok {
+		return p
+	}
+	m.Counts[kind]++
+	p := fmt.Sprintf("[%s_%d]", kind, m.Counts[kind])
+	m.byValue[key] = p
+	m.Values[p] = value
+	return p
+}
+
+// Redact replaces every email address, card number, account number and phone number
+// in text with a placeholder such as [PHONE_2]. The same value gets the same
+// placeholder everywhere, across documents redacted with the same mapping, so the
+// model can still tell that two tickets come from the same customer.
+func (m *Mapping) Redact(text string) string {
+	for _, d := range detectors {
+		text = replaceMatches(text, d, func(value string) string {
+			return m.placeholder(d.kind, value)
+		})
+	}
+	return text
+}
+
+func replaceMatches(text string, d detector, replace func(string) string) string {
+	var sb strings.Builder
+	last := 0
+	for _, match := range d.pattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[2*d.group], match[2*d.group+1]
+		value := text[start:end]
+		if d.valid != nil && !d.valid(value) {
+			continue
+		}
+		sb.WriteString(text[last:start])
+		sb.WriteString(replace(value))
+		last = end
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+// Placeholders in answers, also when the model drops the brackets
+var placeholderPattern = regexp.MustCompile(`\[?\b(EMAIL|CARD|ACCOUNT|PHONE)_(\d+)\b\]?`)
+
+// Restore puts the original values back into text written with placeholders, such
+// as the agent's answer. Placeholders the mapping does not know are left as they are.
+func (m *Mapping) Restore(text string) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(s string) string {
+		sub := placeholderPattern.FindStringSubmatch(s)
+		if value, ok := m.Values[fmt.Sprintf("[%s_%s]", sub[1], sub[2])]; ok {
+			return value
+		}
+		return s
+	})
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// getEnv returns the environment variable, or fallback when it is not set
+func getEnv(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// Objects larger than this are refused by read_document; they would crowd out
+// everything else in the context window
+const maxReadBytes = 200_000
+
+// sampleObjects are uploaded by -seed together with the handbook. The default question
+// needs only the policy documents, so the board minutes should stay in the bucket.
+var sampleObjects = map[string]string{
+	"policies/security.md": `# Information Security Policy
+
+Laptops must use full-disk encryption and lock after 5 minutes of inactivity.
+Passwords must be at least 14 characters. Use the company password manager.
+Report lost devices to security@northwind.example within 1 hour.`,
+	"policies/remote-work.md": `# Remote Work Policy
+
+Employees may work remotely up to 3 days per week with manager approval.
+Working from another country is allowed for up to 20 working days per year.
+The company pays a one-time home office allowance of $500.`,
+	"minutes/2025-09-board.md": `# Board Meeting, September 2025
+
+The board approved the FY26 budget and the opening of a Lisbon office.
+The next meeting is on 2025-12-04.`,
+}
+
+func seedBucket(ctx context.Context, client *S3Client, bucket, prefix string) error {
+	if err := client.CreateBucket(ctx, bucket); err != nil {
+		return err
+	}
+
+	objects := map[string][]byte{}
+	for key, text := range sampleObjects {
+		objects[key] = []byte(text)
+	}
+	handbook, err := os.ReadFile("../testdata/handbook.md")
+	if err != nil {
+		return err
+	}
+	objects["policies/handbook.md"] = handbook
+
+	for key, data := range objects {
+		if err := client.PutObject(ctx, bucket, prefix+key, data, "text/markdown"); err != nil {
+			return err
+		}
+		fmt.Printf("⬆️  Uploaded s3://%s/%s (%d bytes)\n", bucket, prefix+key, len(data))
+	}
+	fmt.Println()
+	return nil
+}
+
+// createDocumentTools lets the agent browse the bucket listing and read only the
+// documents it needs
+func createDocumentTools(store *S3Store) []aigentic.AgentTool {
+	type ListInput struct {
+		Prefix string `json:"prefix" description:"Optional folder to list, for example 'policies/'. Empty lists everything."`
+	}
+	type ReadInput struct {
+		Path string `json:"path" description:"Path of the document exactly as shown by list_documents"`
+	}
+
+	listTool := aigentic.NewTool(
+		"list_documents",
+		"Lists the documents in storage with their size and last modified date, without reading them",
+		func(run *aigentic.AgentRun, input ListInput) (string, error) {
+			objects, err := store.List(context.Background())
+			if err != nil {
+				return "", err
+			}
+
+			var sb strings.Builder
+			for _, object := range objects {
+				if strings.HasPrefix(object.Key, input.Prefix) {
+					fmt.Fprintf(&sb, "%s (%d bytes, modified %s)\n", object.Key, object.Size, object.LastModified.Format("2006-01-02"))
+				}
+			}
+			if sb.Len() == 0 {
+				return "No documents found.", nil
+			}
+			return sb.String(), nil
+		},
+	)
+
+	readTool := aigentic.NewTool(
+		"read_document",
+		"Reads the full text of one document from storage",
+		func(run *aigentic.AgentRun, input ReadInput) (string, error) {
+			doc, err := store.Open(context.Background(), input.Path)
+			if err != nil {
+				return "", err
+			}
+			if !strings.HasPrefix(doc.MimeType, "text/") {
+				return "", fmt.Errorf("%s is %s, not a text document", input.Path, doc.MimeType)
+			}
+
+			data, err := doc.Bytes()
+			if err != nil {
+				return "", err
+			}
+			if len(data) > maxReadBytes {
+				return "", fmt.Errorf("%s is %d bytes, larger than the %d byte limit", input.Path, len(data), maxReadBytes)
+			}
+			fmt.Printf("   ⬇️  %s (%d bytes, %s)\n", doc.FilePath, len(data), doc.MimeType)
+			return string(data), nil
+		},
+	)
+
+	return []aigentic.AgentTool{listTool, readTool}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	// The defaults match a MinIO server started with:
+	//   docker run -p 9000:9000 minio/minio server /data
+	endpoint := flag.String("endpoint", getEnv("S3_ENDPOINT", "http://localhost:9000"), "S3 API endpoint")
+	region := flag.String("region", getEnv("AWS_REGION", "us-east-1"), "S3 region")
+	bucket := flag.String("bucket", getEnv("S3_BUCKET", "aigentic-documents"), "Bucket holding the documents")
+	prefix := flag.String("prefix", "", "Only documents under this key prefix are visible to the agent")
+	seed := flag.Bool("seed", false, "Create the bucket and upload sample documents before running")
+	question := flag.String("q", "How many weeks of paid parental leave does the secondary caregiver get, and how many days can I work from another country?", "Question to ask about the documents")
+	flag.Parse()
+
+	fmt.Println("Documents in Object Storage with Aigentic")
+	fmt.Println("=========================================")
+	fmt.Println()
+
+	client := NewS3Client(*endpoint, *region,
+		getEnv("AWS_ACCESS_KEY_ID", "minioadmin"),
+		getEnv("AWS_SECRET_ACCESS_KEY", "minioadmin"))
+	ctx := context.Background()
+
+	if *seed {
+		if err := seedBucket(ctx, client, *bucket, *prefix); err != nil {
+			log.Fatalf("Failed to seed bucket: %v", err)
+		}
+	}
+
+	store := NewS3Store(client, *bucket, *prefix)
+	defer store.Close(ctx)
+
+	objects, err := store.List(ctx)
+	if err != nil {
+		log.Fatalf("Failed to list s3://%s/%s: %v (start MinIO and run with -seed first)", *bucket, *prefix, err)
+	}
+	var total int64
+	for _, object := range objects {
+		total += object.Size
+	}
+	fmt.Printf("🪣 s3://%s/%s holds %d documents (%d bytes); none downloaded yet\n\n", *bucket, *prefix, len(objects), total)
+
+	agent := aigentic.Agent{
+		Model:       openai.NewModel("gpt-4o-mini", getAPIKey()),
+		Name:        "StorageAssistant",
+		Description: "Answers questions from documents kept in object storage",
+		Instructions: `The documents live in object storage. Call list_documents to see what exists, then read_document only for the documents likely to contain the answer.
+Answer from the documents you read and name the document path for each fact. If no document covers the question, say so.`,
+		AgentTools: createDocumentTools(store),
+	}
+
+	fmt.Printf("❓ %s\n\n", *question)
+	response, err := agent.Execute(*question)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	fmt.Printf("\n%s\n\n", strings.TrimSpace(response))
+
+	count, downloaded := store.Downloaded()
+	fmt.Printf("📊 Downloaded %d of %d documents (%d of %d bytes)\n\n", count, len(objects), downloaded, total)
+
+	fmt.Println("✅ Example completed successfully!")
+}
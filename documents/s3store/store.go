@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/nexxia-ai/aigentic/document"
+)
+
+// S3Store is a document store backed by an S3 bucket. It has the same Open and Close
+// methods as document.LocalStore. List reads only object metadata, and Open downloads
+// an object the first time it is asked for, so a large bucket costs nothing until
+// the agent actually reads from it.
+type S3Store struct {
+	client *S3Client
+	bucket string
+	prefix string // only keys under this prefix are visible, e.g. "policies/"
+
+	mu     sync.Mutex
+	opened map[string]*document.Document // by key
+	bytes  int64                         // downloaded so far
+}
+
+func NewS3Store(client *S3Client, bucket, prefix string) *S3Store {
+	return &S3Store{
+		client: client,
+		bucket: bucket,
+		prefix: prefix,
+		opened: make(map[string]*document.Document),
+	}
+}
+
+// List returns the objects under the store prefix without downloading them
+func (s *S3Store) List(ctx context.Context) ([]ObjectInfo, error) {
+	objects, err := s.client.ListObjects(ctx, s.bucket, s.prefix)
+	if err != nil {
+		return nil, err
+	}
+	for i := range objects {
+		objects[i].Key = strings.TrimPrefix(objects[i].Key, s.prefix)
+	}
+	return objects, nil
+}
+
+// Open downloads the object at filePath, relative to the store prefix, and returns it
+// as a document. Later calls for the same path return the cached document.
+func (s *S3Store) Open(ctx context.Context, filePath string) (*document.Document, error) {
+	s.mu.Lock()
+	doc, ok := s.opened[filePath]
+	s.mu.Unlock()
+	if ok {
+		return doc, nil
+	}
+
+	key := s.prefix + strings.TrimPrefix(filePath, "/")
+	data, contentType, err := s.client.GetObject(ctx, s.bucket, key)
+	if err != nil {
+		return nil, fmt.Errorf("opening s3://%s/%s: %w", s.bucket, key, err)
+	}
+
+	doc = document.NewInMemoryDocument(key, path.Base(key), data, nil)
+	doc.FilePath = fmt.Sprintf("s3://%s/%s", s.bucket, key)
+	// Uploads without a content type come back as application/octet-stream; the
+	// extension is a better guess then
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && mediaType != "application/octet-stream" {
+		doc.MimeType = mediaType
+	} else if byExt := mime.TypeByExtension(path.Ext(key)); byExt != "" {
+		doc.MimeType = byExt
+	}
+
+	s.mu.Lock()
+	s.opened[filePath] = doc
+	s.bytes += int64(len(data))
+	s.mu.Unlock()
+	return doc, nil
+}
+
+// Downloaded reports how many objects and bytes Open has fetched
+func (s *S3Store) Downloaded() (int, int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.opened), s.bytes
+}
+
+// Close drops the cached documents
+func (s *S3Store) Close(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.opened)
+	return nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// importDir adds every file under dir to the store, keyed by its path relative to dir.
+// Dotfiles such as .gitkeep are skipped.
+func importDir(ctx context.Context, store *SQLiteStore, dir string) (int, error) {
+	count := 0
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		if _, err := store.Add(ctx, rel, data, ""); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	return count, err
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	dbPath := flag.String("db", "", "SQLite database file (default: a temporary file)")
+	importFrom := flag.String("import", "../testdata", "Folder to import into the store before asking (empty to skip)")
+	question := flag.String("q", "How many days of PTO can be carried over, and what does the services agreement say about late payments?", "Question to ask about the documents")
+	flag.Parse()
+
+	fmt.Println("Documents in a SQLite File")
+	fmt.Println("==========================")
+	fmt.Println()
+
+	ctx := context.Background()
+
+	if *dbPath == "" {
+		dir, err := os.MkdirTemp("", "sqlitestore-example-")
+		if err != nil {
+			log.Fatalf("Failed to create temp dir: %v", err)
+		}
+		defer os.RemoveAll(dir)
+		*dbPath = filepath.Join(dir, "documents.db")
+	}
+
+	store, err := OpenSQLiteStore(ctx, *dbPath)
+	if err != nil {
+		log.Fatalf("Failed to open store: %v", err)
+	}
+	if *importFrom != "" {
+		n, err := importDir(ctx, store, *importFrom)
+		if err != nil {
+			log.Fatalf("Failed to import %s: %v", *importFrom, err)
+		}
+		fmt.Printf("📥 Imported %d files from %s\n", n, *importFrom)
+	}
+	store.Close(ctx)
+
+	// Reopen the store to show that everything lives in the one file
+	store, err = OpenSQLiteStore(ctx, *dbPath)
+	if err != nil {
+		log.Fatalf("Failed to reopen store: %v", err)
+	}
+	defer store.Close(ctx)
+
+	docs, err := store.List(ctx)
+	if err != nil {
+		log.Fatalf("Failed to list documents: %v", err)
+	}
+	info, err := os.Stat(*dbPath)
+	if err != nil {
+		log.Fatalf("Failed to stat %s: %v", *dbPath, err)
+	}
+	fmt.Printf("🗄️  %s: %d documents, %d KB\n", *dbPath, len(docs), info.Size()/1024)
+	for _, doc := range docs {
+		fmt.Printf("   %-34s %-24s %8d bytes\n", doc.FilePath, doc.MimeType, doc.FileSize)
+	}
+	fmt.Println()
+	if len(docs) == 0 {
+		log.Fatalf("The store is empty; use -import to add documents")
+	}
+
+	// The documents are references: the agent reads the ones it needs, and only those
+	// are loaded from the database
+	agent := aigentic.Agent{
+		Model:              openai.NewModel("gpt-4o-mini", getAPIKey()),
+		Name:               "StoreAssistant",
+		Description:        "Answers questions from documents kept in a SQLite file",
+		Instructions:       "Read only the documents you need to answer, and name the document each fact comes from.",
+		DocumentReferences: docs,
+	}
+
+	fmt.Printf("❓ %s\n\n", *question)
+	response, err := agent.Execute(*question)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	fmt.Printf("Answer:\n%s\n\n", response)
+
+	loads, bytes := store.Loaded()
+	fmt.Printf("📊 Loaded the content of %d of %d documents (%d bytes)\n\n", loads, len(docs), bytes)
+
+	fmt.Println("✅ Example completed successfully!")
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"os/exec"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic/document"
+)
+
+// schema is created when a store is opened. The metadata columns are kept apart from
+// the blob so listing a store never reads document content.
+const schema = `
+CREATE TABLE IF NOT EXISTS documents (
+	path       TEXT PRIMARY KEY,
+	mime_type  TEXT NOT NULL,
+	size       INTEGER NOT NULL,
+	sha256     TEXT NOT NULL,
+	created_at TEXT NOT NULL,
+	updated_at TEXT NOT NULL,
+	data       BLOB NOT NULL
+);`
+
+var ErrStoreClosed = errors.New("document store is closed")
+
+// SQLiteStore keeps documents in a single SQLite file, with one row per document
+// holding its metadata and its content as a blob. It has the same Open and Close
+// methods as document.LocalStore, and like LocalStore, Open reads only metadata: the
+// content is loaded the first time the document's Bytes method is called.
+//
+// Content is passed to the sqlite3 shell as hex literals, which is fine for documents
+// of a few megabytes.
+type SQLiteStore struct {
+	path string
+
+	mu     sync.Mutex
+	closed bool
+	loads  int   // documents whose content was read
+	bytes  int64 // content bytes read
+}
+
+var _ document.DocumentStore = (*SQLiteStore)(nil)
+
+// OpenSQLiteStore opens the store in the database file at path, creating the file
+// and its table if needed
+func OpenSQLiteStore(ctx context.Context, path string) (*SQLiteStore, error) {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		return nil, fmt.Errorf("the sqlite3 command line shell is required: %w", err)
+	}
+	s := &SQLiteStore{path: path}
+	if _, err := s.exec(ctx, schema); err != nil {
+		return nil, fmt.Errorf("creating schema in %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// exec runs SQL read from stdin, so large statements do not hit argument limits
+func (s *SQLiteStore) exec(ctx context.Context, sql string, args ...string) (string, error) {
+	s.mu.Lock()
+	closed := s.closed
+	s.mu.Unlock()
+	if closed {
+		return "", ErrStoreClosed
+	}
+
+	cmd := exec.CommandContext(ctx, "sqlite3", append(append([]string{"-bail"}, args...), s.path)...)
+	cmd.Stdin = strings.NewReader(sql)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("sqlite3: %s", msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+// quote makes a SQL string literal
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// cleanPath makes paths relative and slash-separated, so "./a/b.md" and "a/b.md" are
+// the same document
+func cleanPath(filePath string) string {
+	return strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(filePath, `\`, "/")), "/")
+}
+
+// Add stores a document under filePath, replacing any document already there. The
+// MIME type is taken from the extension when mimeType is empty.
+func (s *SQLiteStore) Add(ctx context.Context, filePath string, data []byte, mimeType string) (*document.Document, error) {
+	filePath = cleanPath(filePath)
+	if mimeType == "" {
+		mimeType = mime.TypeByExtension(path.Ext(filePath))
+	}
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	sum := sha256.Sum256(data)
+	now := quote(time.Now().UTC().Format(time.RFC3339))
+
+	sql := fmt.Sprintf(`INSERT INTO documents (path, mime_type, size, sha256, created_at, updated_at, data)
+VALUES (%s, %s, %d, %s, %s, %s, X'%s')
+ON CONFLICT(path) DO UPDATE SET mime_type = excluded.mime_type, size = excluded.size,
+	sha256 = excluded.sha256, updated_at = excluded.updated_at, data = excluded.data;`,
+		quote(filePath), quote(mimeType), len(data), quote(hex.EncodeToString(sum[:])), now, now, hex.EncodeToString(data))
+	if _, err := s.exec(ctx, sql); err != nil {
+		return nil, fmt.Errorf("adding %s: %w", filePath, err)
+	}
+	return s.Open(ctx, filePath)
+}
+
+type row struct {
+	Path      string `json:"path"`
+	MimeType  string `json:"mime_type"`
+	Size      int64  `json:"size"`
+	SHA256    string `json:"sha256"`
+	CreatedAt string `json:"created_at"`
+}
+
+const rowColumns = "path, mime_type, size, sha256, created_at"
+
+func (s *SQLiteStore) query(ctx context.Context, sql string) ([]row, error) {
+	out, err := s.exec(ctx, sql, "-json")
+	if err != nil {
+		return nil, err
+	}
+	// The shell prints nothing at all when there are no rows
+	if strings.TrimSpace(out) == "" {
+		return nil, nil
+	}
+	var rows []row
+	if err := json.Unmarshal([]byte(out), &rows); err != nil {
+		return nil, fmt.Errorf("reading sqlite3 output: %w", err)
+	}
+	return rows, nil
+}
+
+func (s *SQLiteStore) document(r row) *document.Document {
+	doc := document.NewInMemoryDocument(r.Path, path.Base(r.Path), nil, nil)
+	doc.FilePath = r.Path
+	doc.FileSize = r.Size
+	doc.MimeType = r.MimeType
+	if t, err := time.Parse(time.RFC3339, r.CreatedAt); err == nil {
+		doc.CreatedAt = t
+	}
+	doc.SetLoader(s.load)
+	return doc
+}
+
+// Open returns the document stored under filePath without reading its content
+func (s *SQLiteStore) Open(ctx context.Context, filePath string) (*document.Document, error) {
+	filePath = cleanPath(filePath)
+	rows, err := s.query(ctx, fmt.Sprintf("SELECT %s FROM documents WHERE path = %s;", rowColumns, quote(filePath)))
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", filePath, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("opening %s: no such document", filePath)
+	}
+	return s.document(rows[0]), nil
+}
+
+// List returns every document in path order, without reading any content
+func (s *SQLiteStore) List(ctx context.Context) ([]*document.Document, error) {
+	rows, err := s.query(ctx, fmt.Sprintf("SELECT %s FROM documents ORDER BY path;", rowColumns))
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]*document.Document, len(rows))
+	for i, r := range rows {
+		docs[i] = s.document(r)
+	}
+	return docs, nil
+}
+
+// Delete removes the document stored under filePath
+func (s *SQLiteStore) Delete(ctx context.Context, filePath string) error {
+	_, err := s.exec(ctx, fmt.Sprintf("DELETE FROM documents WHERE path = %s;", quote(cleanPath(filePath))))
+	return err
+}
+
+// load reads the content of a document, and checks it against the hash stored with
+// it, so a damaged file is reported instead of handed to the model
+func (s *SQLiteStore) load(d *document.Document) ([]byte, error) {
+	out, err := s.exec(context.Background(), fmt.Sprintf("SELECT hex(data), sha256 FROM documents WHERE path = %s;", quote(d.FilePath)), "-list", "-separator", "|")
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", d.FilePath, err)
+	}
+	content, sum, ok := strings.Cut(strings.TrimSpace(out), "|")
+	if !ok {
+		return nil, fmt.Errorf("loading %s: no such document", d.FilePath)
+	}
+	data, err := hex.DecodeString(content)
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %w", d.FilePath, err)
+	}
+	if actual := sha256.Sum256(data); hex.EncodeToString(actual[:]) != sum {
+		return nil, fmt.Errorf("loading %s: content does not match its checksum", d.FilePath)
+	}
+
+	s.mu.Lock()
+	s.loads++
+	s.bytes += int64(len(data))
+	s.mu.Unlock()
+	return data, nil
+}
+
+// Loaded reports how many documents had their content read, and how many bytes
+func (s *SQLiteStore) Loaded() (int, int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.loads, s.bytes
+}
+
+// Close closes the store. Every call runs its own sqlite3 process, so there is no
+// connection to release, but documents opened earlier can no longer load their
+// content, the same as with a closed database handle.
+func (s *SQLiteStore) Close(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
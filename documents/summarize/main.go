@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	path := flag.String("file", "../testdata/handbook.md", "Text or markdown file to summarize")
+	chunkChars := flag.Int("chunk", 2000, "Characters per chunk in the map stage; use 12000 or more for a book")
+	workers := flag.Int("workers", 4, "Agent runs in flight at once")
+	maxFinal := flag.Int("max-final", 3000, "Reduce partial summaries until they fit in this many tokens")
+	flag.Parse()
+
+	fmt.Println("Map-Reduce Summarization with Aigentic")
+	fmt.Println("======================================")
+	fmt.Println()
+
+	data, err := os.ReadFile(*path)
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", *path, err)
+	}
+	text := string(data)
+	chunks := len(splitParagraphs(text, *chunkChars))
+	fmt.Printf("📄 %s: %d characters, about %d tokens, %d chunks of up to %d characters\n\n",
+		filepath.Base(*path), len(text), estimateTokens(text), chunks, *chunkChars)
+
+	summarizer := NewSummarizer(openai.NewModel("gpt-4o-mini", getAPIKey()), *chunkChars, *workers)
+	summarizer.MaxFinalTokens = *maxFinal
+
+	// Summarize runs in the background; the loop below prints progress until the
+	// channel is closed
+	start := time.Now()
+	var summary string
+	var summarizeErr error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		summary, summarizeErr = summarizer.Summarize(text)
+	}()
+
+	for p := range summarizer.Progress() {
+		status := "✅"
+		if p.Err != nil {
+			status = "❌"
+		}
+		fmt.Printf("  %s %-6s %3d/%-3d in %5d tok, out %4d tok, %5.1fs\n",
+			status, p.Stage, p.Done, p.Total, p.Usage.InputTokens, p.Usage.OutputTokens, p.Elapsed.Seconds())
+	}
+	<-done
+	if summarizeErr != nil {
+		log.Fatalf("Summarization failed: %v", summarizeErr)
+	}
+
+	fmt.Printf("\n📝 Summary:\n%s\n\n", summary)
+
+	fmt.Printf("%-8s %6s %12s %13s\n", "Stage", "Runs", "Input tok", "Output tok")
+	var total Usage
+	for _, stage := range []string{"map", "reduce", "final"} {
+		usage := summarizer.Usage(stage)
+		total.add(usage)
+		fmt.Printf("%-8s %6d %12d %13d\n", stage, usage.Runs, usage.InputTokens, usage.OutputTokens)
+	}
+	fmt.Printf("%-8s %6d %12d %13d\n", "total", total.Runs, total.InputTokens, total.OutputTokens)
+	fmt.Printf("\nToken counts are estimates (4 characters per token). Took %.1fs with %d workers.\n\n", time.Since(start).Seconds(), *workers)
+
+	fmt.Println("✅ Example completed successfully!")
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic/ai"
+)
+
+const (
+	mapInstructions = `You summarize one part of a longer document. Write a dense summary of at most 150 words.
+Keep names, numbers, dates and decisions. Do not add an introduction or refer to "this part".`
+	reduceInstructions = `You combine partial summaries of consecutive parts of a document into one summary of at most 250 words.
+Keep the order of events, merge repeated points, and keep names, numbers and dates.`
+	finalInstructions = `You write the final summary of a document from summaries of its parts, which are in order.
+Start with one sentence on what the document is. Then give the main points as a short list, and end with anything the reader must act on.`
+)
+
+// Progress is sent for every finished agent run of the pipeline
+type Progress struct {
+	Stage   string // "map", "reduce" or "final"
+	Done    int    // runs finished in this stage
+	Total   int    // runs in this stage
+	Usage   Usage  // of the run that finished
+	Elapsed time.Duration
+	Err     error
+}
+
+// Usage counts agent runs and estimated tokens. Tokens are estimated from the
+// characters sent and received, since the runs do not report provider usage.
+type Usage struct {
+	Runs         int
+	InputTokens  int
+	OutputTokens int
+}
+
+func (u *Usage) add(other Usage) {
+	u.Runs += other.Runs
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+}
+
+// estimateTokens uses the rough rule of four characters per token for English text
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// Summarizer summarizes text too long for one context window: it summarizes chunks in
+// parallel (map), merges groups of partial summaries until they fit in one request
+// (reduce), and writes the final summary from what is left.
+type Summarizer struct {
+	Model          *ai.Model
+	ChunkChars     int // target chunk size for the map stage
+	Workers        int // agent runs in flight at once
+	MaxFinalTokens int // partial summaries are reduced until they fit in this many tokens
+
+	progress chan Progress
+	usage    map[string]*Usage // by stage
+	mu       sync.Mutex
+}
+
+func NewSummarizer(model *ai.Model, chunkChars, workers int) *Summarizer {
+	return &Summarizer{
+		Model:          model,
+		ChunkChars:     chunkChars,
+		Workers:        workers,
+		MaxFinalTokens: 3000,
+		progress:       make(chan Progress, 64),
+		usage:          map[string]*Usage{"map": {}, "reduce": {}, "final": {}},
+	}
+}
+
+// Progress returns the channel that receives an event per finished run. It is closed
+// when Summarize returns.
+func (s *Summarizer) Progress() <-chan Progress {
+	return s.progress
+}
+
+// Usage returns the accumulated usage of a stage
+func (s *Summarizer) Usage(stage string) Usage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.usage[stage]
+}
+
+// Summarize runs the whole pipeline
+func (s *Summarizer) Summarize(text string) (string, error) {
+	defer close(s.progress)
+
+	chunks := splitParagraphs(text, s.ChunkChars)
+	summaries, err := s.runAll("map", mapInstructions, chunks)
+	if err != nil {
+		return "", err
+	}
+
+	// Reduce in groups until everything fits in the final request. Each round shrinks
+	// the number of summaries by the group size, so even a very long book needs only
+	// a few rounds.
+	for round := 1; estimateTokens(strings.Join(summaries, "\n\n")) > s.MaxFinalTokens && len(summaries) > 1; round++ {
+		groups := groupSummaries(summaries, s.MaxFinalTokens)
+		summaries, err = s.runAll("reduce", reduceInstructions, groups)
+		if err != nil {
+			return "", fmt.Errorf("reduce round %d: %w", round, err)
+		}
+	}
+
+	final, err := s.runAll("final", finalInstructions, []string{numberParts(summaries)})
+	if err != nil {
+		return "", err
+	}
+	return final[0], nil
+}
+
+// runAll runs one agent per input on at most Workers goroutines and returns the
+// outputs in input order
+func (s *Summarizer) runAll(stage, instructions string, inputs []string) ([]string, error) {
+	outputs := make([]string, len(inputs))
+	errs := make([]error, len(inputs))
+	slots := make(chan struct{}, max(s.Workers, 1))
+
+	var wg sync.WaitGroup
+	var done int
+	for i, input := range inputs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			start := time.Now()
+			outputs[i], errs[i] = s.run(instructions, input)
+			usage := Usage{Runs: 1, InputTokens: estimateTokens(instructions + input), OutputTokens: estimateTokens(outputs[i])}
+
+			s.mu.Lock()
+			s.usage[stage].add(usage)
+			done++
+			event := Progress{Stage: stage, Done: done, Total: len(inputs), Usage: usage, Elapsed: time.Since(start), Err: errs[i]}
+			s.mu.Unlock()
+			s.progress <- event
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("%s part %d: %w", stage, i+1, err)
+		}
+	}
+	return outputs, nil
+}
+
+// run is a single agent run. Every run gets a fresh agent, so parts never see each
+// other's conversation.
+func (s *Summarizer) run(instructions, input string) (string, error) {
+	agent := aigentic.Agent{
+		Model:        s.Model,
+		Name:         "Summarizer",
+		Description:  "Summarizes text",
+		Instructions: instructions,
+	}
+	response, err := agent.Execute(input)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(response), nil
+}
+
+// splitParagraphs packs whole paragraphs into chunks of up to maxChars characters. A
+// paragraph longer than maxChars becomes a chunk of its own.
+func splitParagraphs(text string, maxChars int) []string {
+	var chunks []string
+	var current strings.Builder
+	for _, para := range strings.Split(text, "\n\n") {
+		para = strings.TrimSpace(para)
+		if para == "" {
+			continue
+		}
+		if current.Len() > 0 && current.Len()+2+len(para) > maxChars {
+			chunks = append(chunks, current.String())
+			current.Reset()
+		}
+		if current.Len() > 0 {
+			current.WriteString("\n\n")
+		}
+		current.WriteString(para)
+	}
+	if current.Len() > 0 {
+		chunks = append(chunks, current.String())
+	}
+	return chunks
+}
+
+// groupSummaries joins consecutive summaries into groups of up to maxTokens, with at
+// least two summaries per group so that every round makes progress
+func groupSummaries(summaries []string, maxTokens int) []string {
+	var groups []string
+	var group []string
+	tokens := 0
+	for _, summary := range summaries {
+		t := estimateTokens(summary)
+		if len(group) >= 2 && tokens+t > maxTokens {
+			groups = append(groups, numberParts(group))
+			group, tokens = nil, 0
+		}
+		group = append(group, summary)
+		tokens += t
+	}
+	if len(group) > 0 {
+		groups = append(groups, numberParts(group))
+	}
+	return groups
+}
+
+// numberParts labels the summaries so the model keeps them in order
+func numberParts(summaries []string) string {
+	var sb strings.Builder
+	for i, summary := range summaries {
+		fmt.Fprintf(&sb, "Part %d:\n%s\n\n", i+1, summary)
+	}
+	return sb.String()
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/document"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// writeSampleSales writes a year of orders. The seed is fixed, so the expected
+// answers are the same on every run.
+func writeSampleSales(path string, rows int) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	products := []struct {
+		name  string
+		price float64
+	}{
+		{"Laptop Pro", 1899}, {"Laptop Air", 1099}, {"Monitor 27", 349}, {"Dock", 229}, {"Headset", 129},
+	}
+	regions := []string{"EMEA", "Americas", "APAC"}
+	rng := rand.New(rand.NewPCG(2025, 7))
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	w := csv.NewWriter(f)
+	w.Write([]string{"order_id", "date", "region", "product", "units", "unit_price", "revenue"})
+	for i := 0; i < rows; i++ {
+		p := products[rng.IntN(len(products))]
+		units := 1 + rng.IntN(20)
+		// Volume discounts of up to 15%
+		price := p.price * (1 - float64(rng.IntN(16))/100)
+		w.Write([]string{
+			fmt.Sprintf("SO-%05d", 10000+i),
+			start.AddDate(0, 0, rng.IntN(365)).Format("2006-01-02"),
+			regions[rng.IntN(len(regions))],
+			p.name,
+			strconv.Itoa(units),
+			strconv.FormatFloat(price, 'f', 2, 64),
+			strconv.FormatFloat(price*float64(units), 'f', 2, 64),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func createTableTools(table *Table) []aigentic.AgentTool {
+	type NoInput struct{}
+
+	describe := aigentic.NewTool(
+		"describe_table",
+		"Describes the table: row count, column names, column types and example values",
+		func(run *aigentic.AgentRun, input NoInput) (string, error) {
+			return table.Describe(), nil
+		},
+	)
+
+	aggregate := aigentic.NewTool(
+		"aggregate",
+		"Computes sum, avg, min, max or count over the table, optionally filtered and grouped by a column. "+
+			"Results are sorted largest first. Dates are YYYY-MM-DD text, so >= and <= filters select date ranges.",
+		func(run *aigentic.AgentRun, input Query) (string, error) {
+			groups, err := table.Aggregate(input)
+			if err != nil {
+				return "", err
+			}
+			return FormatGroups(groups, 25), nil
+		},
+	)
+
+	return []aigentic.AgentTool{describe, aggregate}
+}
+
+type question struct {
+	text     string
+	expected *Query // computed locally to check the answers; nil for custom questions
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	mode := flag.String("mode", "both", "How the agent sees the table: embed, tool or both")
+	rows := flag.Int("rows", 1500, "Rows in the generated sample")
+	sheet := flag.String("sheet", "", "Sheet name for .xlsx files (default: first sheet)")
+	custom := flag.String("q", "", "Question to ask about your own file")
+	flag.Parse()
+
+	if *mode != "embed" && *mode != "tool" && *mode != "both" {
+		log.Fatalf("Invalid -mode %q, use embed, tool or both", *mode)
+	}
+
+	fmt.Println("Tabular Data Analysis with Aigentic")
+	fmt.Println("===================================")
+	fmt.Println()
+
+	var questions []question
+	path := flag.Arg(0)
+	if path == "" {
+		dir, err := os.MkdirTemp("", "tabular-example-")
+		if err != nil {
+			log.Fatalf("Failed to create temp dir: %v", err)
+		}
+		defer os.RemoveAll(dir)
+
+		path = filepath.Join(dir, "sales_2025.csv")
+		if err := writeSampleSales(path, *rows); err != nil {
+			log.Fatalf("Failed to write sample data: %v", err)
+		}
+
+		eq := func(column, value string) Filter { return Filter{Column: column, Op: "=", Value: value} }
+		questions = []question{
+			{"What was the total revenue in EMEA?",
+				&Query{Op: "sum", Column: "revenue", Filters: []Filter{eq("region", "EMEA")}}},
+			{"Which product sold the most units in Q2 (April to June), and how many?",
+				&Query{Op: "sum", Column: "units", GroupBy: "product", Filters: []Filter{
+					{Column: "date", Op: ">=", Value: "2025-04-01"}, {Column: "date", Op: "<=", Value: "2025-06-30"}}}},
+			{"What is the average revenue per order for Docks sold in the Americas?",
+				&Query{Op: "avg", Column: "revenue", Filters: []Filter{eq("region", "Americas"), eq("product", "Dock")}}},
+		}
+	} else {
+		if *custom == "" {
+			*custom = "Describe what this table contains and give the two most useful totals or averages it supports."
+		}
+		questions = []question{{text: *custom}}
+	}
+
+	table, err := LoadTable(path, *sheet)
+	if err != nil {
+		log.Fatalf("Failed to load table: %v", err)
+	}
+	data := table.CSV()
+	fmt.Printf("📊 %s: %d rows x %d columns, %d KB as CSV (about %d tokens to embed)\n\n",
+		table.Name, len(table.Rows), len(table.Header), len(data)/1024, len(data)/4)
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	// Approach 1: the whole table is in the context and the model does the arithmetic
+	embedAgent := aigentic.Agent{
+		Model:        model,
+		Name:         "EmbeddedTableAnalyst",
+		Description:  "Answers questions about a table embedded in its context",
+		Instructions: "The attached CSV document is the full table. Answer with the exact number and say how you computed it.",
+		Documents:    []*document.Document{document.NewInMemoryDocument("table", table.Name, data, nil)},
+	}
+
+	// Approach 2: the model only sees the schema and asks the tools to compute
+	toolAgent := aigentic.Agent{
+		Model:        model,
+		Name:         "QueryToolAnalyst",
+		Description:  "Answers questions about a table using query tools",
+		Instructions: "Call describe_table first to learn the columns and their values. Then answer with the aggregate tool. Never estimate numbers yourself.",
+		AgentTools:   createTableTools(table),
+	}
+
+	for _, q := range questions {
+		fmt.Printf("❓ %s\n", q.text)
+
+		if *mode == "embed" || *mode == "both" {
+			start := time.Now()
+			response, err := embedAgent.Execute(q.text)
+			if err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+			fmt.Printf("\n📄 Embedded table (%s):\n%s\n", time.Since(start).Round(time.Millisecond), response)
+		}
+
+		if *mode == "tool" || *mode == "both" {
+			start := time.Now()
+			response, err := toolAgent.Execute(q.text)
+			if err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+			fmt.Printf("\n🔧 Query tool (%s):\n%s\n", time.Since(start).Round(time.Millisecond), response)
+		}
+
+		if q.expected != nil {
+			groups, err := table.Aggregate(*q.expected)
+			if err != nil {
+				log.Fatalf("Failed to compute expected answer: %v", err)
+			}
+			fmt.Printf("\n✔️  Expected: %s", FormatGroups(groups, 1))
+		}
+		fmt.Println()
+	}
+
+	fmt.Println("✅ Example completed successfully!")
+}
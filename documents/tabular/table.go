@@ -0,0 +1,294 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Table is a sheet loaded into memory, with the first row as the header
+type Table struct {
+	Name   string
+	Header []string
+	Rows   [][]string
+}
+
+// LoadTable reads a .csv file, or one sheet of an .xlsx file
+func LoadTable(filename, sheet string) (*Table, error) {
+	var rows [][]string
+	var err error
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		rows, err = readCSV(filename)
+	case ".xlsx":
+		rows, err = readXLSX(filename, sheet)
+	default:
+		return nil, fmt.Errorf("unsupported file type %q, use .csv or .xlsx", filepath.Ext(filename))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) < 2 {
+		return nil, fmt.Errorf("%s has no data rows", filename)
+	}
+
+	t := &Table{Name: filepath.Base(filename), Header: rows[0]}
+	for _, row := range rows[1:] {
+		// Pad short rows so every row has a value for every column
+		for len(row) < len(t.Header) {
+			row = append(row, "")
+		}
+		t.Rows = append(t.Rows, row[:len(t.Header)])
+	}
+	return t, nil
+}
+
+func readCSV(filename string) ([][]string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	return r.ReadAll()
+}
+
+// CSV renders the whole table, for embedding it as a document
+func (t *Table) CSV() []byte {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write(t.Header)
+	w.WriteAll(t.Rows)
+	return buf.Bytes()
+}
+
+func (t *Table) column(name string) (int, error) {
+	for i, h := range t.Header {
+		if strings.EqualFold(strings.TrimSpace(h), strings.TrimSpace(name)) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("no column %q; columns are %s", name, strings.Join(t.Header, ", "))
+}
+
+// Describe lists the columns with their type and a few example values, so the model
+// can plan queries without seeing the rows
+func (t *Table) Describe() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d rows\n", t.Name, len(t.Rows))
+	for i, h := range t.Header {
+		numeric := true
+		distinct := make(map[string]bool)
+		for _, row := range t.Rows {
+			if _, err := strconv.ParseFloat(row[i], 64); err != nil && row[i] != "" {
+				numeric = false
+			}
+			if len(distinct) <= 10 {
+				distinct[row[i]] = true
+			}
+		}
+
+		kind := "text"
+		if numeric {
+			kind = "number"
+		}
+		examples := make([]string, 0, len(distinct))
+		for v := range distinct {
+			examples = append(examples, v)
+		}
+		sort.Strings(examples)
+		if len(examples) > 5 {
+			examples = append(examples[:5], "...")
+		}
+		fmt.Fprintf(&b, "- %s (%s), e.g. %s\n", h, kind, strings.Join(examples, ", "))
+	}
+	return b.String()
+}
+
+// Filter keeps the rows where Column compares to Value. Numbers are compared
+// numerically, everything else as case-insensitive text.
+type Filter struct {
+	Column string `json:"column" description:"Column to filter on"`
+	Op     string `json:"op" description:"One of =, !=, >, >=, <, <=, contains"`
+	Value  string `json:"value" description:"Value to compare with"`
+}
+
+// Query is an aggregation over the table, optionally grouped and filtered
+type Query struct {
+	Op      string   `json:"op" description:"Aggregation: sum, avg, min, max or count"`
+	Column  string   `json:"column,omitempty" description:"Numeric column to aggregate; not needed for count"`
+	GroupBy string   `json:"group_by,omitempty" description:"Optional column to group by"`
+	Filters []Filter `json:"filters,omitempty" description:"Optional filters, all of which must match"`
+}
+
+// Group is one line of a query result
+type Group struct {
+	Key   string
+	Value float64
+	Rows  int
+}
+
+// Aggregate runs the query. Results are sorted by value, largest first.
+func (t *Table) Aggregate(q Query) ([]Group, error) {
+	op := strings.ToLower(q.Op)
+	switch op {
+	case "sum", "avg", "min", "max", "count":
+	default:
+		return nil, fmt.Errorf("unknown op %q, use sum, avg, min, max or count", q.Op)
+	}
+
+	valueCol := -1
+	if op != "count" {
+		col, err := t.column(q.Column)
+		if err != nil {
+			return nil, err
+		}
+		valueCol = col
+	}
+	groupCol := -1
+	if q.GroupBy != "" {
+		col, err := t.column(q.GroupBy)
+		if err != nil {
+			return nil, err
+		}
+		groupCol = col
+	}
+
+	type filter struct {
+		col int
+		Filter
+	}
+	filters := make([]filter, len(q.Filters))
+	for i, f := range q.Filters {
+		col, err := t.column(f.Column)
+		if err != nil {
+			return nil, err
+		}
+		filters[i] = filter{col, f}
+	}
+
+	groups := make(map[string]*Group)
+	var order []string
+	for _, row := range t.Rows {
+		matched := true
+		for _, f := range filters {
+			ok, err := compare(row[f.col], f.Op, f.Value)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		key := "all rows"
+		if groupCol >= 0 {
+			key = row[groupCol]
+		}
+		g, ok := groups[key]
+		if !ok {
+			g = &Group{Key: key}
+			groups[key] = g
+			order = append(order, key)
+			if op == "min" {
+				g.Value = math.Inf(1)
+			} else if op == "max" {
+				g.Value = math.Inf(-1)
+			}
+		}
+		g.Rows++
+
+		if valueCol < 0 {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(row[valueCol]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("column %s has a non-numeric value %q", t.Header[valueCol], row[valueCol])
+		}
+		switch op {
+		case "sum", "avg":
+			g.Value += v
+		case "min":
+			g.Value = math.Min(g.Value, v)
+		case "max":
+			g.Value = math.Max(g.Value, v)
+		}
+	}
+
+	result := make([]Group, 0, len(order))
+	for _, key := range order {
+		g := *groups[key]
+		switch op {
+		case "count":
+			g.Value = float64(g.Rows)
+		case "avg":
+			g.Value /= float64(g.Rows)
+		}
+		result = append(result, g)
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Value > result[j].Value })
+	return result, nil
+}
+
+func compare(cell, op, value string) (bool, error) {
+	a, errA := strconv.ParseFloat(strings.TrimSpace(cell), 64)
+	b, errB := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	numeric := errA == nil && errB == nil
+
+	cmp := strings.Compare(strings.ToLower(cell), strings.ToLower(value))
+	if numeric {
+		cmp = 0
+		if a < b {
+			cmp = -1
+		} else if a > b {
+			cmp = 1
+		}
+	}
+
+	switch op {
+	case "=", "==":
+		return cmp == 0, nil
+	case "!=":
+		return cmp != 0, nil
+	case ">":
+		return cmp > 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case "contains":
+		return strings.Contains(strings.ToLower(cell), strings.ToLower(value)), nil
+	default:
+		return false, fmt.Errorf("unknown filter op %q", op)
+	}
+}
+
+// FormatGroups renders a query result for the model, capped at limit lines
+func FormatGroups(groups []Group, limit int) string {
+	if len(groups) == 0 {
+		return "No rows matched."
+	}
+	var b strings.Builder
+	for i, g := range groups {
+		if i == limit {
+			fmt.Fprintf(&b, "... %d more groups\n", len(groups)-limit)
+			break
+		}
+		fmt.Fprintf(&b, "%s: %s (%d rows)\n", g.Key, strconv.FormatFloat(math.Round(g.Value*100)/100, 'f', -1, 64), g.Rows)
+	}
+	return b.String()
+}
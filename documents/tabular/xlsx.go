@@ -0,0 +1,222 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// readXLSX reads one worksheet of an .xlsx file into rows of strings. An empty sheet
+// name selects the first sheet. Shared strings, inline strings, booleans and dates
+// are resolved; formulas are read as their last calculated value.
+func readXLSX(filename, sheet string) ([][]string, error) {
+	r, err := zip.OpenReader(filename)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not an Excel file: %w", filename, err)
+	}
+	defer r.Close()
+
+	sheetPath, err := findSheet(&r.Reader, sheet)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := readSharedStrings(&r.Reader)
+	if err != nil {
+		return nil, err
+	}
+	dateStyles, err := readDateStyles(&r.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var ws struct {
+		Rows []struct {
+			Cells []struct {
+				Ref    string `xml:"r,attr"`
+				Type   string `xml:"t,attr"`
+				Style  int    `xml:"s,attr"`
+				Value  string `xml:"v"`
+				Inline string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := decodeXML(&r.Reader, sheetPath, &ws); err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(ws.Rows))
+	for _, row := range ws.Rows {
+		var cells []string
+		for i, c := range row.Cells {
+			// Empty cells are left out of the XML, so place each cell by its reference
+			col := i
+			if c.Ref != "" {
+				col = columnIndex(c.Ref)
+			}
+			for len(cells) <= col {
+				cells = append(cells, "")
+			}
+
+			switch c.Type {
+			case "s":
+				if n, err := strconv.Atoi(c.Value); err == nil && n < len(shared) {
+					cells[col] = shared[n]
+				}
+			case "inlineStr":
+				cells[col] = c.Inline
+			case "b":
+				cells[col] = map[string]string{"0": "FALSE", "1": "TRUE"}[c.Value]
+			default:
+				cells[col] = c.Value
+				if dateStyles[c.Style] {
+					if serial, err := strconv.ParseFloat(c.Value, 64); err == nil {
+						cells[col] = excelDate(serial)
+					}
+				}
+			}
+		}
+		rows = append(rows, cells)
+	}
+	return rows, nil
+}
+
+// findSheet maps a sheet name to its XML part through the workbook relationships
+func findSheet(r *zip.Reader, name string) (string, error) {
+	var wb struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decodeXML(r, "xl/workbook.xml", &wb); err != nil {
+		return "", err
+	}
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := decodeXML(r, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return "", err
+	}
+
+	var names []string
+	for _, s := range wb.Sheets {
+		names = append(names, s.Name)
+		if name != "" && !strings.EqualFold(s.Name, name) {
+			continue
+		}
+		for _, rel := range rels.Relationships {
+			if rel.ID != s.ID {
+				continue
+			}
+			if strings.HasPrefix(rel.Target, "/") {
+				return strings.TrimPrefix(rel.Target, "/"), nil
+			}
+			return path.Join("xl", rel.Target), nil
+		}
+	}
+	return "", fmt.Errorf("sheet %q not found; the workbook has %s", name, strings.Join(names, ", "))
+}
+
+func readSharedStrings(r *zip.Reader) ([]string, error) {
+	var sst struct {
+		Items []struct {
+			Text string   `xml:"t"`
+			Runs []string `xml:"r>t"`
+		} `xml:"si"`
+	}
+	if err := decodeXML(r, "xl/sharedStrings.xml", &sst); err != nil {
+		if errors.Is(err, errMissingPart) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	strs := make([]string, len(sst.Items))
+	for i, si := range sst.Items {
+		strs[i] = si.Text + strings.Join(si.Runs, "")
+	}
+	return strs, nil
+}
+
+// readDateStyles returns the cell style indexes that format numbers as dates. Excel
+// stores dates as numbers; only the style tells them apart.
+func readDateStyles(r *zip.Reader) (map[int]bool, error) {
+	var styles struct {
+		NumFmts []struct {
+			ID   int    `xml:"numFmtId,attr"`
+			Code string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		CellXfs []struct {
+			NumFmtID int `xml:"numFmtId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+	if err := decodeXML(r, "xl/styles.xml", &styles); err != nil {
+		if errors.Is(err, errMissingPart) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	isDate := func(id int) bool {
+		// Built-in date and time formats
+		return (id >= 14 && id <= 22) || (id >= 45 && id <= 47)
+	}
+	custom := make(map[int]bool)
+	for _, f := range styles.NumFmts {
+		code := strings.ToLower(f.Code)
+		custom[f.ID] = strings.Contains(code, "yy") || (strings.Contains(code, "d") && strings.Contains(code, "m"))
+	}
+
+	dates := make(map[int]bool)
+	for i, xf := range styles.CellXfs {
+		if isDate(xf.NumFmtID) || custom[xf.NumFmtID] {
+			dates[i] = true
+		}
+	}
+	return dates, nil
+}
+
+// excelDate converts a serial day number (1900 date system) to YYYY-MM-DD, or to a
+// timestamp when it has a time part
+func excelDate(serial float64) string {
+	t := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC).Add(time.Duration(serial * 24 * float64(time.Hour))).Round(time.Second)
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+// columnIndex turns a cell reference such as "AB12" into a zero-based column number
+func columnIndex(ref string) int {
+	n := 0
+	for _, c := range ref {
+		if c < 'A' || c > 'Z' {
+			break
+		}
+		n = n*26 + int(c-'A'+1)
+	}
+	return n - 1
+}
+
+var errMissingPart = errors.New("part not found")
+
+func decodeXML(r *zip.Reader, name string, v any) error {
+	f, err := r.Open(name)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, errMissingPart)
+	}
+	defer f.Close()
+
+	if err := xml.NewDecoder(f).Decode(v); err != nil && err != io.EOF {
+		return fmt.Errorf("reading %s: %w", name, err)
+	}
+	return nil
+}
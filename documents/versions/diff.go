@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type edit struct {
+	op   byte // ' ' unchanged, '-' removed, '+' added
+	text string
+}
+
+// diffTokens returns the edit script that turns a into b, from a longest common
+// subsequence table. It is quadratic, which is fine for documents of a few thousand
+// lines; a long log would need Myers' algorithm.
+func diffTokens(a, b []string) []edit {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var edits []edit
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			edits = append(edits, edit{' ', a[i]})
+			i, j = i+1, j+1
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, edit{'-', a[i]})
+			i++
+		default:
+			edits = append(edits, edit{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		edits = append(edits, edit{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		edits = append(edits, edit{'+', b[j]})
+	}
+	return edits
+}
+
+// wordDiff shows the changes inside a line the way git diff --word-diff does:
+// [-removed words-]{+added words+}
+func wordDiff(oldLine, newLine string) string {
+	var sb strings.Builder
+	edits := diffTokens(strings.Fields(oldLine), strings.Fields(newLine))
+	for k := 0; k < len(edits); {
+		if edits[k].op == ' ' {
+			sb.WriteString(edits[k].text + " ")
+			k++
+			continue
+		}
+		var removed, added []string
+		for ; k < len(edits) && edits[k].op != ' '; k++ {
+			if edits[k].op == '-' {
+				removed = append(removed, edits[k].text)
+			} else {
+				added = append(added, edits[k].text)
+			}
+		}
+		if len(removed) > 0 {
+			sb.WriteString("[-" + strings.Join(removed, " ") + "-]")
+		}
+		if len(added) > 0 {
+			sb.WriteString("{+" + strings.Join(added, " ") + "+}")
+		}
+		sb.WriteString(" ")
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// Diff compares two versions line by line and prints the changed lines with context
+// lines around them. A changed line is shown once, with word-level changes marked
+// (~), instead of as a removed and an added line, because paragraphs in documents are
+// long lines where a single changed number would otherwise hide.
+func Diff(oldText, newText string, context int) string {
+	oldLines := strings.Split(strings.TrimRight(oldText, "\n"), "\n")
+	newLines := strings.Split(strings.TrimRight(newText, "\n"), "\n")
+	edits := diffTokens(oldLines, newLines)
+
+	// Pair runs of removed lines with the added lines that follow them
+	type line struct {
+		op     byte // ' ', '-', '+', '~'
+		text   string
+		oldNum int
+	}
+	var lines []line
+	oldNum := 0
+	for k := 0; k < len(edits); {
+		if edits[k].op == ' ' {
+			oldNum++
+			lines = append(lines, line{' ', edits[k].text, oldNum})
+			k++
+			continue
+		}
+		var removed, added []string
+		for ; k < len(edits) && edits[k].op == '-'; k++ {
+			removed = append(removed, edits[k].text)
+		}
+		for ; k < len(edits) && edits[k].op == '+'; k++ {
+			added = append(added, edits[k].text)
+		}
+		paired := min(len(removed), len(added))
+		for n := 0; n < paired; n++ {
+			oldNum++
+			lines = append(lines, line{'~', wordDiff(removed[n], added[n]), oldNum})
+		}
+		for _, text := range removed[paired:] {
+			oldNum++
+			lines = append(lines, line{'-', text, oldNum})
+		}
+		for _, text := range added[paired:] {
+			lines = append(lines, line{'+', text, oldNum})
+		}
+	}
+
+	// Keep changed lines and their context, and mark skipped stretches
+	keep := make([]bool, len(lines))
+	for k, l := range lines {
+		if l.op == ' ' {
+			continue
+		}
+		for c := max(0, k-context); c <= min(len(lines)-1, k+context); c++ {
+			keep[c] = true
+		}
+	}
+
+	var sb strings.Builder
+	skipping := true
+	for k, l := range lines {
+		if !keep[k] {
+			skipping = true
+			continue
+		}
+		if skipping {
+			fmt.Fprintf(&sb, "@@ line %d @@\n", max(l.oldNum, 1))
+			skipping = false
+		}
+		fmt.Fprintf(&sb, "%c %s\n", l.op, l.text)
+	}
+	if sb.Len() == 0 {
+		return "No changes.\n"
+	}
+	return sb.String()
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func date(s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// amend applies replacements to a version to make the next one, and fails loudly if
+// the text to replace is not there, so the sample cannot drift from the test data
+func amend(text string, replacements ...string) string {
+	for i := 0; i < len(replacements); i += 2 {
+		if !strings.Contains(text, replacements[i]) {
+			log.Fatalf("sample edit not found: %q", replacements[i])
+		}
+		text = strings.Replace(text, replacements[i], replacements[i+1], 1)
+	}
+	return text
+}
+
+// loadSample saves four versions of the services agreement. Versions 1 and 2 are the
+// test data; 3 and 4 are amendments made from them.
+func loadSample(lib *Library) error {
+	v1, err := os.ReadFile("../testdata/services_agreement_v1.md")
+	if err != nil {
+		return err
+	}
+	v2, err := os.ReadFile("../testdata/services_agreement_v2.md")
+	if err != nil {
+		return err
+	}
+	v3 := amend(string(v2),
+		"Version 2, signed 2025-03-01", "Version 3, signed 2025-06-16",
+		"a monthly fee of $13,500", "a monthly fee of $14,175",
+		"survives for 3 years after termination", "survives for 5 years after termination",
+	)
+	v4 := amend(v3,
+		"Version 3, signed 2025-06-16", "Version 4, signed 2025-10-01",
+		"payable within 45 days of receipt", "payable within 30 days of receipt",
+		"a credit of 5% of the monthly fee, up to 25%", "a credit of 10% of the monthly fee, up to 30%",
+		"## 10. Governing Law", "## 10. Subcontractors\n\nProvider may use subcontractors only with Client's prior written consent, and remains responsible for their work.\n\n## 11. Governing Law",
+	)
+
+	versions := []struct {
+		content      string
+		author, note string
+		date         time.Time
+	}{
+		{string(v1), "Legal (Northwind)", "Original agreement", date("2024-03-01")},
+		{string(v2), "Legal (Northwind)", "Renegotiated at renewal", date("2025-03-01")},
+		{v3, "Procurement", "Annual fee increase and longer confidentiality", date("2025-06-16")},
+		{v4, "Legal (Northwind)", "Amendment after the Q3 outage review", date("2025-10-01")},
+	}
+	for _, v := range versions {
+		if _, err := lib.Add("services_agreement.md", []byte(v.content), v.author, v.note, v.date); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createTools(lib *Library) []aigentic.AgentTool {
+	type ListInput struct {
+		Document string `json:"document" description:"Document name"`
+	}
+	type ReadInput struct {
+		Document string `json:"document" description:"Document name"`
+		Version  int    `json:"version" description:"Version number, or 0 for the latest version"`
+	}
+	type DiffInput struct {
+		Document string `json:"document" description:"Document name"`
+		From     int    `json:"from" description:"Older version number"`
+		To       int    `json:"to" description:"Newer version number, or 0 for the latest version"`
+	}
+	type AsOfInput struct {
+		Document string `json:"document" description:"Document name"`
+		Date     string `json:"date" description:"Date in YYYY-MM-DD format"`
+	}
+
+	listTool := aigentic.NewTool(
+		"list_versions",
+		"Lists the versions of a document with their dates, authors and change notes",
+		func(run *aigentic.AgentRun, input ListInput) (string, error) {
+			history, err := lib.History(input.Document)
+			if err != nil {
+				return "", err
+			}
+			var sb strings.Builder
+			for _, v := range history {
+				fmt.Fprintf(&sb, "v%d  %s  %s: %s\n", v.Number, v.Date.Format(time.DateOnly), v.Author, v.Note)
+			}
+			return sb.String(), nil
+		},
+	)
+
+	readTool := aigentic.NewTool(
+		"read_version",
+		"Returns the full text of one version of a document",
+		func(run *aigentic.AgentRun, input ReadInput) (string, error) {
+			v, err := lib.Get(input.Document, input.Version)
+			if err != nil {
+				return "", err
+			}
+			fmt.Printf("   📖 read %s\n", v.Doc.FilePath)
+			return v.Text()
+		},
+	)
+
+	diffTool := aigentic.NewTool(
+		"diff_versions",
+		"Compares two versions of a document. Changed lines are marked ~ with [-removed-]{+added+} words, removed lines -, added lines +. Versions need not be consecutive.",
+		func(run *aigentic.AgentRun, input DiffInput) (string, error) {
+			from, err := lib.Get(input.Document, input.From)
+			if err != nil {
+				return "", err
+			}
+			to, err := lib.Get(input.Document, input.To)
+			if err != nil {
+				return "", err
+			}
+			oldText, err := from.Text()
+			if err != nil {
+				return "", err
+			}
+			newText, err := to.Text()
+			if err != nil {
+				return "", err
+			}
+			fmt.Printf("   🔍 diff %s v%d..v%d\n", input.Document, from.Number, to.Number)
+			return fmt.Sprintf("%s v%d (%s) to v%d (%s)\n%s", input.Document, from.Number, from.Date.Format(time.DateOnly),
+				to.Number, to.Date.Format(time.DateOnly), Diff(oldText, newText, 2)), nil
+		},
+	)
+
+	asOfTool := aigentic.NewTool(
+		"version_on_date",
+		"Tells which version of a document was in force on a date",
+		func(run *aigentic.AgentRun, input AsOfInput) (string, error) {
+			day, err := time.Parse(time.DateOnly, input.Date)
+			if err != nil {
+				return "", fmt.Errorf("date must be YYYY-MM-DD: %w", err)
+			}
+			v, err := lib.AsOf(input.Document, day)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("v%d, dated %s (%s)", v.Number, v.Date.Format(time.DateOnly), v.Note), nil
+		},
+	)
+
+	return []aigentic.AgentTool{listTool, readTool, diffTool, asOfTool}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	show := flag.Bool("show", false, "Print the diff between v2 and v4 and exit without calling the model")
+	question := flag.String("q", "What changed in services_agreement.md between v2 and v4, and which version introduced each change?", "Question to ask about the document history")
+	flag.Parse()
+
+	fmt.Println("Versioned Documents")
+	fmt.Println("===================")
+	fmt.Println()
+
+	lib := NewLibrary()
+	if err := loadSample(lib); err != nil {
+		log.Fatalf("Failed to load versions: %v", err)
+	}
+	for _, name := range lib.Names() {
+		history, _ := lib.History(name)
+		fmt.Printf("📚 %s\n", name)
+		for _, v := range history {
+			fmt.Printf("   v%d  %s  %-18s %s\n", v.Number, v.Date.Format(time.DateOnly), v.Author, v.Note)
+		}
+	}
+	fmt.Println()
+
+	if *show {
+		v2, _ := lib.Get("services_agreement.md", 2)
+		v4, _ := lib.Get("services_agreement.md", 4)
+		oldText, _ := v2.Text()
+		newText, _ := v4.Text()
+		fmt.Print(Diff(oldText, newText, 2))
+		return
+	}
+
+	agent := aigentic.Agent{
+		Model:       openai.NewModel("gpt-4o-mini", getAPIKey()),
+		Name:        "VersionHistorian",
+		Description: "Answers questions about how documents changed over time",
+		Instructions: `You answer questions about the history of documents: ` + strings.Join(lib.Names(), ", ") + `.
+Use list_versions to see the versions. To find what changed between two versions, call diff_versions on them directly,
+then diff the versions in between if you need to know which version made each change. Quote the old and new values.
+Read a full version only when the diff does not give enough context.`,
+		AgentTools: createTools(lib),
+	}
+
+	fmt.Printf("❓ %s\n\n", *question)
+	response, err := agent.Execute(*question)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	fmt.Printf("\nAnswer:\n%s\n\n", response)
+
+	fmt.Println("✅ Example completed successfully!")
+}
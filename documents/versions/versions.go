@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic/document"
+)
+
+// Version is one saved revision of a document. The content is an ordinary document,
+// named after the original file, so it can also be attached to an agent directly; the
+// version number is part of its ID and FilePath.
+type Version struct {
+	Number int
+	Author string
+	Date   time.Time
+	Note   string // what the author says changed
+	Doc    *document.Document
+}
+
+func (v Version) Text() (string, error) {
+	data, err := v.Doc.Bytes()
+	return string(data), err
+}
+
+// Library keeps every version of each document. Versions are numbered from 1 per
+// document and never change once added.
+type Library struct {
+	mu       sync.Mutex
+	versions map[string][]Version // by document name
+}
+
+func NewLibrary() *Library {
+	return &Library{versions: map[string][]Version{}}
+}
+
+// Add saves content as the next version of the document called name. Content that is
+// the same as the latest version is not saved again; the latest version is returned.
+func (l *Library) Add(name string, content []byte, author, note string, date time.Time) (Version, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	history := l.versions[name]
+	if n := len(history); n > 0 {
+		latest, err := history[n-1].Doc.Bytes()
+		if err != nil {
+			return Version{}, err
+		}
+		if bytes.Equal(latest, content) {
+			return history[n-1], nil
+		}
+		if date.Before(history[n-1].Date) {
+			return Version{}, fmt.Errorf("%s v%d is dated %s, before v%d", name, n+1, date.Format(time.DateOnly), n)
+		}
+	}
+
+	number := len(history) + 1
+	base := strings.TrimSuffix(name, path.Ext(name))
+	doc := document.NewInMemoryDocument(fmt.Sprintf("%s@v%d", base, number), name, content, nil)
+	doc.FilePath = fmt.Sprintf("%s@v%d", name, number)
+	doc.CreatedAt = date
+
+	v := Version{Number: number, Author: author, Date: date, Note: note, Doc: doc}
+	l.versions[name] = append(history, v)
+	return v, nil
+}
+
+// Get returns version n of a document; 0 is the latest version
+func (l *Library) Get(name string, n int) (Version, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	history, ok := l.versions[name]
+	if !ok {
+		return Version{}, fmt.Errorf("no document called %q", name)
+	}
+	if n == 0 {
+		n = len(history)
+	}
+	if n < 1 || n > len(history) {
+		return Version{}, fmt.Errorf("%s has versions 1 to %d, not %d", name, len(history), n)
+	}
+	return history[n-1], nil
+}
+
+// History returns every version of a document, oldest first
+func (l *Library) History(name string) ([]Version, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	history, ok := l.versions[name]
+	if !ok {
+		return nil, fmt.Errorf("no document called %q", name)
+	}
+	return append([]Version(nil), history...), nil
+}
+
+// AsOf returns the version that was current on a date, which answers questions such
+// as "what did the contract say in June"
+func (l *Library) AsOf(name string, date time.Time) (Version, error) {
+	history, err := l.History(name)
+	if err != nil {
+		return Version{}, err
+	}
+	i := sort.Search(len(history), func(i int) bool { return history[i].Date.After(date) })
+	if i == 0 {
+		return Version{}, fmt.Errorf("%s did not exist yet on %s", name, date.Format(time.DateOnly))
+	}
+	return history[i-1], nil
+}
+
+func (l *Library) Names() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	names := make([]string, 0, len(l.versions))
+	for name := range l.versions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
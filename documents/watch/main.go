@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/document"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+// resultSuffix marks the JSON written next to each processed file
+const resultSuffix = ".result.json"
+
+// Files larger than this are not sent to the model
+const maxFileSize = 5 << 20
+
+// Result is the structured data the agent extracts from a dropped file
+type Result struct {
+	File        string            `json:"file"`
+	Type        string            `json:"type"` // invoice, receipt, contract, policy, resume or other
+	Title       string            `json:"title"`
+	Date        string            `json:"date,omitempty"`
+	Parties     []string          `json:"parties,omitempty"`
+	Amounts     map[string]string `json:"amounts,omitempty"`
+	Summary     string            `json:"summary"`
+	ProcessedAt time.Time         `json:"processed_at"`
+	Error       string            `json:"error,omitempty"`
+}
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// loadDocument turns a dropped file into a document. Text formats are recognized by
+// extension, while images and PDFs are recognized by their content, since a
+// scanner's file names are rarely reliable.
+func loadDocument(path string) (*document.Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxFileSize {
+		return nil, fmt.Errorf("file is %d MB, more than the %d MB limit", len(data)>>20, maxFileSize>>20)
+	}
+
+	doc := document.NewInMemoryDocument(filepath.Base(path), filepath.Base(path), data, nil)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".txt", ".md", ".csv", ".json", ".html":
+		return doc, nil
+	}
+
+	mimeType := http.DetectContentType(data)
+	switch mimeType {
+	case "image/png", "image/jpeg", "image/gif", "image/webp", "application/pdf":
+		doc.MimeType = mimeType
+		return doc, nil
+	}
+	return nil, fmt.Errorf("unsupported file type %s", mimeType)
+}
+
+// parseResult reads the model's JSON, with or without a markdown code fence
+func parseResult(response string) (Result, error) {
+	text := strings.TrimSpace(response)
+	if start, end := strings.Index(text, "{"), strings.LastIndex(text, "}"); start >= 0 && end > start {
+		text = text[start : end+1]
+	}
+	var r Result
+	if err := json.Unmarshal([]byte(text), &r); err != nil {
+		return Result{}, fmt.Errorf("response is not valid JSON: %w", err)
+	}
+	return r, nil
+}
+
+// process classifies one file and writes its result next to it. Failures are written
+// too, so a bad file is not retried on every restart and the error is easy to find.
+func process(model *ai.Model, path string) Result {
+	result := Result{File: filepath.Base(path)}
+
+	doc, err := loadDocument(path)
+	if err == nil {
+		agent := aigentic.Agent{
+			Model:       model,
+			Name:        "DropFolderClassifier",
+			Description: "Classifies incoming documents and extracts key fields",
+			Instructions: `Classify the attached document and extract its key fields. Reply with only a JSON object:
+{"type": "invoice|receipt|contract|policy|resume|other", "title": "short descriptive title",
+ "date": "YYYY-MM-DD or empty", "parties": ["people or organizations involved"],
+ "amounts": {"label": "amount with currency"}, "summary": "two sentences"}
+Use only information in the document. Leave fields empty rather than guessing.`,
+			Documents: []*document.Document{doc},
+		}
+		var response string
+		response, err = agent.Execute("Classify this document and extract its key fields.")
+		if err == nil {
+			result, err = parseResult(response)
+			result.File = filepath.Base(path)
+		}
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	result.ProcessedAt = time.Now().UTC()
+
+	data, _ := json.MarshalIndent(result, "", "  ")
+	if err := os.WriteFile(path+resultSuffix, data, 0o644); err != nil {
+		log.Printf("Failed to write result for %s: %v", path, err)
+	}
+	return result
+}
+
+// alreadyProcessed reports whether the file has a result newer than itself, so a
+// restarted watcher does not redo the whole folder
+func alreadyProcessed(path string) bool {
+	file, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	result, err := os.Stat(path + resultSuffix)
+	return err == nil && !result.ModTime().Before(file.ModTime())
+}
+
+// dropSamples copies the sample documents into the inbox one at a time, the way files
+// arrive from a scanner or an upload form
+func dropSamples(ctx context.Context, inbox string, delay time.Duration) {
+	samples := []string{"../testdata/receipt.png", "../testdata/services_agreement_v1.md", "../testdata/handbook.md"}
+	for _, sample := range samples {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		}
+		data, err := os.ReadFile(sample)
+		if err != nil {
+			log.Printf("Failed to read sample %s: %v", sample, err)
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(inbox, filepath.Base(sample)), data, 0o644); err != nil {
+			log.Printf("Failed to drop sample %s: %v", sample, err)
+			continue
+		}
+		fmt.Printf("📨 Dropped %s\n", filepath.Base(sample))
+	}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	dir := flag.String("dir", "", "Folder to watch; without it a temporary inbox is filled with sample files")
+	interval := flag.Duration("interval", time.Second, "How often to check the folder")
+	flag.Parse()
+
+	fmt.Println("Drop Folder Automation with Aigentic")
+	fmt.Println("====================================")
+	fmt.Println()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	// Demo mode: drop three samples into a temporary inbox and stop once all are done
+	inbox := *dir
+	remaining := -1
+	if inbox == "" {
+		var err error
+		inbox, err = os.MkdirTemp("", "aigentic-inbox-")
+		if err != nil {
+			log.Fatalf("Failed to create inbox: %v", err)
+		}
+		defer os.RemoveAll(inbox)
+		remaining = 3
+		go dropSamples(ctx, inbox, 2*time.Second)
+	}
+
+	watcher := NewWatcher(inbox, *interval)
+	watcher.Ignore = func(name string) bool {
+		return strings.HasPrefix(name, ".") || strings.HasSuffix(name, resultSuffix)
+	}
+	fmt.Printf("👀 Watching %s every %v (Ctrl+C to stop)\n\n", inbox, *interval)
+
+	for path := range watcher.Watch(ctx) {
+		if alreadyProcessed(path) {
+			fmt.Printf("⏭️  %s already has a result\n", filepath.Base(path))
+			continue
+		}
+
+		start := time.Now()
+		result := process(model, path)
+		if result.Error != "" {
+			fmt.Printf("❌ %s: %s\n", result.File, result.Error)
+		} else {
+			fmt.Printf("✅ %s → %s: %s (%.1fs)\n", result.File, result.Type, result.Title, time.Since(start).Seconds())
+			fmt.Printf("   %s\n", result.Summary)
+		}
+		fmt.Printf("   📝 %s\n\n", filepath.Base(path)+resultSuffix)
+
+		if remaining > 0 {
+			if remaining--; remaining == 0 {
+				break
+			}
+		}
+	}
+
+	fmt.Println("✅ Example completed successfully!")
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Watcher reports files that appear in a directory. It polls instead of using
+// fsnotify: polling works the same on every OS and on network drives, and a drop
+// folder does not need sub-second reactions. With either approach a file must not be
+// picked up while it is still being copied in, so a file is only reported once its
+// size and modification time have stayed the same for one full interval.
+type Watcher struct {
+	Dir      string
+	Interval time.Duration
+	Ignore   func(name string) bool // files to skip, such as our own outputs
+
+	seen    map[string]fileState // last state of every file in the directory
+	pending map[string]fileState // changed files waiting to settle
+}
+
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+func NewWatcher(dir string, interval time.Duration) *Watcher {
+	return &Watcher{
+		Dir:      dir,
+		Interval: interval,
+		Ignore:   func(name string) bool { return strings.HasPrefix(name, ".") },
+		seen:     map[string]fileState{},
+		pending:  map[string]fileState{},
+	}
+}
+
+// Watch sends the path of each new or changed file once it has finished being
+// written. Files already in the directory when Watch starts are reported too. The
+// channel is closed when ctx is cancelled.
+func (w *Watcher) Watch(ctx context.Context) <-chan string {
+	files := make(chan string)
+	go func() {
+		defer close(files)
+		ticker := time.NewTicker(w.Interval)
+		defer ticker.Stop()
+		for {
+			for _, path := range w.scan() {
+				select {
+				case files <- path:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return files
+}
+
+// scan compares the directory with the previous scan and returns the files that
+// have settled since then
+func (w *Watcher) scan() []string {
+	entries, err := os.ReadDir(w.Dir)
+	if err != nil {
+		return nil
+	}
+
+	var ready []string
+	for _, entry := range entries {
+		if entry.IsDir() || w.Ignore(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		state := fileState{size: info.Size(), modTime: info.ModTime()}
+		path := filepath.Join(w.Dir, entry.Name())
+
+		if previous, ok := w.seen[path]; ok && previous == state {
+			continue
+		}
+		if waiting, ok := w.pending[path]; ok && waiting == state {
+			// Unchanged for a whole interval: the writer is done
+			delete(w.pending, path)
+			w.seen[path] = state
+			ready = append(ready, path)
+			continue
+		}
+		w.pending[path] = state
+	}
+	return ready
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/document"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+// Pages larger than this are cut off; an article is rarely more than a few hundred KB
+// even with its markup
+const maxPageSize = 5 << 20
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// fetchPage downloads an HTML page and returns it with the final URL after redirects
+func fetchPage(pageURL string) (string, string, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	req, err := http.NewRequest(http.MethodGet, pageURL, nil)
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("User-Agent", "aigentic-examples/1.0 (document fetcher)")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("GET %s: %s", pageURL, resp.Status)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return "", "", fmt.Errorf("%s is %s, not an HTML page", pageURL, mediaType)
+	}
+
+	// Pages that are not UTF-8 need golang.org/x/net/html/charset; most are UTF-8
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageSize))
+	if err != nil {
+		return "", "", err
+	}
+	return string(body), resp.Request.URL.String(), nil
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// documentName makes a file name from the page title, or the URL path when there is none
+func documentName(title, pageURL string) string {
+	name := title
+	if name == "" {
+		if u, err := url.Parse(pageURL); err == nil {
+			name = u.Host + " " + path.Base(u.Path)
+		}
+	}
+	name = strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(name) > 60 {
+		name = strings.TrimRight(name[:60], "-")
+	}
+	return name + ".md"
+}
+
+// PageToDocument turns a downloaded page into a markdown document. The header records
+// where and when the page was fetched, so the agent can cite it and tell how current
+// it is.
+func PageToDocument(id, page, pageURL string, fetched time.Time) (*document.Document, string) {
+	title := pageTitle(page)
+	body := toMarkdown(mainContent(parseHTML(page)))
+
+	var sb strings.Builder
+	if title != "" && !strings.HasPrefix(body, "# ") {
+		fmt.Fprintf(&sb, "# %s\n\n", title)
+	}
+	fmt.Fprintf(&sb, "Source: %s\nFetched: %s\n\n", pageURL, fetched.UTC().Format(time.RFC3339))
+	sb.WriteString(body)
+
+	doc := document.NewInMemoryDocument(id, documentName(title, pageURL), []byte(sb.String()), nil)
+	doc.MimeType = "text/markdown"
+	doc.FilePath = pageURL
+	return doc, sb.String()
+}
+
+// samplePage is a blog post with the usual furniture around it: navigation, a cookie
+// banner, a sidebar, share buttons, comments and a footer
+const samplePage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Moving our queue workers to Go | Northwind Engineering Blog</title>
+<meta property="og:title" content="Moving our queue workers to Go">
+<script>window.analytics = {track: function() {}};</script>
+<style>body { font-family: sans-serif; }</style>
+</head>
+<body>
+<div class="cookie-banner">We use cookies to improve your experience. <button>Accept all</button></div>
+<header class="site-header">
+  <a href="/">Northwind Engineering</a>
+  <nav><a href="/blog">Blog</a> <a href="/careers">Careers</a> <a href="/about">About</a> <a href="/rss">RSS</a></nav>
+</header>
+<div class="layout">
+  <div class="post-content">
+    <h1>Moving our queue workers to Go</h1>
+    <p class="byline">By Priya Raman, 12 February 2025</p>
+    <p>Our billing pipeline runs on queue workers that pull invoices, apply credits and send the results to the ledger. For five years they were written in Python, and for most of that time they were fine.</p>
+    <p>Last year, invoice volume tripled. The workers needed 48 instances at peak, each using about 600 MB of memory, and the p99 time to process an invoice reached 2.4 seconds, which delayed month-end closing by almost a day.</p>
+    <h2>What we changed</h2>
+    <p>We rewrote the workers in Go over eleven weeks, keeping the queue format and the ledger API unchanged, so old and new workers could run side by side during the migration.</p>
+    <ul>
+      <li>One goroutine per invoice, with a limit of 64 per instance</li>
+      <li>Credits are loaded once per batch instead of once per invoice</li>
+      <li>Retries use exponential backoff with jitter, capped at 5 attempts</li>
+    </ul>
+    <h2>Results</h2>
+    <p>The Go workers need 9 instances at peak, each using about 90 MB of memory. The p99 processing time dropped to 310 milliseconds, and month-end closing now finishes in under four hours.</p>
+    <p>The rewrite was not free: two engineers spent most of the quarter on it, and we found three rounding differences between the Python and Go versions that had to be reconciled with finance before the switch.</p>
+    <blockquote>Run old and new side by side for longer than you think you need to.</blockquote>
+    <div class="share-buttons"><a href="#">Share on X</a> <a href="#">Share on LinkedIn</a> <a href="#">Copy link</a></div>
+  </div>
+  <aside class="sidebar">
+    <h3>Popular posts</h3>
+    <ul><li><a href="/blog/1">Why we chose Postgres</a></li><li><a href="/blog/2">Our on-call handbook</a></li></ul>
+    <div class="newsletter">Subscribe to our newsletter for weekly engineering posts.</div>
+  </aside>
+</div>
+<div id="comments">
+  <h3>3 comments</h3>
+  <p>Great post, but did you consider Rust? It would have been even faster, surely, and safer too.</p>
+</div>
+<div class="related-posts"><a href="/blog/3">Scaling the ledger</a> <a href="/blog/4">Billing at Northwind</a></div>
+<footer>&copy; 2025 Northwind Traders. All rights reserved. <a href="/privacy">Privacy</a> <a href="/terms">Terms</a></footer>
+</body>
+</html>
+`
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	pageURL := flag.String("url", "", "Page to fetch (default: a sample blog post served locally)")
+	show := flag.Bool("show", false, "Print the extracted document and exit without calling the model")
+	question := flag.String("q", "Why did the team rewrite the workers, and what improved? Include the numbers before and after.", "Question to ask about the page")
+	flag.Parse()
+
+	fmt.Println("Web Pages as Documents")
+	fmt.Println("======================")
+	fmt.Println()
+
+	if *pageURL == "" {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			io.WriteString(w, samplePage)
+		}))
+		defer server.Close()
+		*pageURL = server.URL + "/blog/moving-queue-workers-to-go"
+		fmt.Println("No -url given, serving a sample blog post locally")
+		fmt.Println()
+	}
+
+	fmt.Printf("🌐 Fetching %s\n", *pageURL)
+	page, finalURL, err := fetchPage(*pageURL)
+	if err != nil {
+		log.Fatalf("Failed to fetch page: %v", err)
+	}
+
+	doc, text := PageToDocument("web_001", page, finalURL, time.Now())
+	fmt.Printf("📄 %s: %d bytes of HTML → %d bytes of markdown (%.0f%% kept)\n\n", doc.Filename, len(page), len(text), 100*float64(len(text))/float64(len(page)))
+
+	if *show {
+		fmt.Println(text)
+		return
+	}
+
+	agent := aigentic.Agent{
+		Model:        openai.NewModel("gpt-4o-mini", getAPIKey()),
+		Name:         "WebReader",
+		Description:  "Answers questions about a web page",
+		Instructions: "Answer from the attached page only, and mention the source URL at the end of your answer.",
+		Documents:    []*document.Document{doc},
+	}
+
+	fmt.Printf("❓ %s\n\n", *question)
+	response, err := agent.Execute(*question)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	fmt.Printf("Answer:\n%s\n\n", response)
+
+	fmt.Println("✅ Example completed successfully!")
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// node is an element or a text node of a parsed page. The parser is small and
+// forgiving rather than complete: it is enough to find the main content of a page,
+// not to render one.
+type node struct {
+	tag      string // "" for text
+	attrs    string
+	text     string
+	parent   *node
+	children []*node
+}
+
+var (
+	tagToken  = regexp.MustCompile(`(?s)<!--.*?-->|<!\[CDATA\[.*?\]\]>|<![^>]*>|<(/?)([a-zA-Z][a-zA-Z0-9]*)((?:"[^"]*"|'[^']*'|[^'">])*)>`)
+	rawText   = regexp.MustCompile(`(?is)<(script|style|noscript|template|svg)\b.*?</(script|style|noscript|template|svg)\s*>`)
+	titleText = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	ogTitle   = regexp.MustCompile(`(?is)<meta\s+[^>]*property=["']og:title["'][^>]*content=["']([^"']*)["']`)
+)
+
+var voidTags = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// parseHTML builds a tree from a page. End tags close the nearest open element with
+// the same name, and end tags without an open element are ignored, which copes with
+// most unclosed <p> and <li> tags found in the wild.
+func parseHTML(page string) *node {
+	page = rawText.ReplaceAllString(page, "")
+	root := &node{tag: "#root"}
+	current := root
+
+	addText := func(s string) {
+		if strings.TrimSpace(s) != "" {
+			current.children = append(current.children, &node{text: html.UnescapeString(s), parent: current})
+		}
+	}
+
+	last := 0
+	for _, m := range tagToken.FindAllStringSubmatchIndex(page, -1) {
+		addText(page[last:m[0]])
+		last = m[1]
+		if m[4] < 0 {
+			continue // comment, doctype or CDATA
+		}
+
+		closing := m[3] > m[2]
+		tag := strings.ToLower(page[m[4]:m[5]])
+		attrs := page[m[6]:m[7]]
+
+		if closing {
+			for n := current; n != root; n = n.parent {
+				if n.tag == tag {
+					current = n.parent
+					break
+				}
+			}
+			continue
+		}
+
+		// A new paragraph or list item closes an unclosed one
+		if (tag == "p" || tag == "li") && current.tag == tag {
+			current = current.parent
+		}
+		el := &node{tag: tag, attrs: strings.ToLower(attrs), parent: current}
+		current.children = append(current.children, el)
+		if !voidTags[tag] && !strings.HasSuffix(strings.TrimSpace(attrs), "/") {
+			current = el
+		}
+	}
+	addText(page[last:])
+	return root
+}
+
+// pageTitle prefers the Open Graph title, which usually leaves out the site name
+func pageTitle(page string) string {
+	if m := ogTitle.FindStringSubmatch(page); m != nil {
+		return strings.TrimSpace(html.UnescapeString(m[1]))
+	}
+	if m := titleText.FindStringSubmatch(page); m != nil {
+		return strings.Join(strings.Fields(html.UnescapeString(m[1])), " ")
+	}
+	return ""
+}
+
+// Elements that are never part of an article, and class or id words that mark page
+// furniture. The words are the ones readability implementations commonly use.
+var (
+	boilerplateTags = map[string]bool{
+		"nav": true, "header": true, "footer": true, "aside": true, "form": true, "button": true,
+		"iframe": true, "select": true, "input": true, "dialog": true, "menu": true, "head": true,
+	}
+	boilerplateAttrs = regexp.MustCompile(`\b(?:class|id|role)=["'][^"']*(?:banner|breadcrumb|comment|cookie|footer|menu|masthead|navigation|navbar|newsletter|popup|promo|related|share|sidebar|social|sponsor|subscribe|advert)`)
+	positiveAttrs    = regexp.MustCompile(`\b(?:class|id|role)=["'][^"']*(?:article|body|content|entry|main|post|story|text)`)
+)
+
+func isBoilerplate(n *node) bool {
+	if boilerplateTags[n.tag] {
+		return true
+	}
+	// An element that claims to be the content is kept even if it also matches a
+	// boilerplate word, as in class="post-content has-sidebar"
+	return boilerplateAttrs.MatchString(n.attrs) && !positiveAttrs.MatchString(n.attrs) && n.tag != "body" && n.tag != "article" && n.tag != "main"
+}
+
+func prune(n *node) {
+	kept := n.children[:0]
+	for _, c := range n.children {
+		if c.tag != "" && isBoilerplate(c) {
+			continue
+		}
+		prune(c)
+		kept = append(kept, c)
+	}
+	n.children = kept
+}
+
+func textOf(n *node) string {
+	if n.tag == "" {
+		return n.text
+	}
+	var sb strings.Builder
+	for _, c := range n.children {
+		sb.WriteString(textOf(c))
+		sb.WriteString(" ")
+	}
+	return sb.String()
+}
+
+func linkTextLength(n *node) int {
+	if n.tag == "a" {
+		return len(strings.Join(strings.Fields(textOf(n)), " "))
+	}
+	total := 0
+	for _, c := range n.children {
+		if c.tag != "" {
+			total += linkTextLength(c)
+		}
+	}
+	return total
+}
+
+// mainContent finds the element holding the article, the way readability does: every
+// paragraph scores points for its length and commas, the points go to its parent and
+// half to its grandparent, and the best container wins after a penalty for link-heavy
+// text. An <article> or <main> with enough text is taken as it is.
+func mainContent(root *node) *node {
+	prune(root)
+
+	var semantic, candidates []*node
+	scores := map[*node]float64{}
+	credit := func(n *node, score float64) {
+		if _, ok := scores[n]; !ok {
+			candidates = append(candidates, n)
+		}
+		scores[n] += score
+	}
+	var walk func(n *node)
+	walk = func(n *node) {
+		if n.tag == "article" || n.tag == "main" {
+			semantic = append(semantic, n)
+		}
+		if n.tag == "p" || n.tag == "pre" || n.tag == "td" || n.tag == "blockquote" {
+			text := strings.Join(strings.Fields(textOf(n)), " ")
+			if len(text) >= 25 {
+				score := 1 + float64(strings.Count(text, ",")) + min(float64(len(text))/100, 3)
+				if n.parent != nil {
+					credit(n.parent, score)
+					if n.parent.parent != nil {
+						credit(n.parent.parent, score/2)
+					}
+				}
+			}
+		}
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	walk(root)
+
+	for _, n := range semantic {
+		if len(strings.Join(strings.Fields(textOf(n)), " ")) > 500 {
+			return n
+		}
+	}
+
+	var best *node
+	bestScore := 0.0
+	for _, n := range candidates {
+		score := scores[n]
+		textLen := len(strings.Join(strings.Fields(textOf(n)), " "))
+		if textLen == 0 {
+			continue
+		}
+		if positiveAttrs.MatchString(n.attrs) {
+			score *= 1.25
+		}
+		score *= 1 - float64(linkTextLength(n))/float64(textLen)
+		if score > bestScore {
+			best, bestScore = n, score
+		}
+	}
+	if best == nil {
+		return root
+	}
+	return best
+}
+
+// toMarkdown writes the text of an element with headings, lists, quotes and code
+// blocks marked up. Links keep their text only: URLs cost tokens and rarely help.
+func toMarkdown(n *node) string {
+	var sb strings.Builder
+	var write func(n *node)
+	inline := func(n *node) string {
+		return strings.Join(strings.Fields(textOf(n)), " ")
+	}
+	block := func(s string) {
+		if s = strings.TrimSpace(s); s != "" {
+			sb.WriteString(s + "\n\n")
+		}
+	}
+	write = func(n *node) {
+		switch n.tag {
+		case "":
+			block(strings.Join(strings.Fields(n.text), " "))
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			block(strings.Repeat("#", int(n.tag[1]-'0')) + " " + inline(n))
+		case "p", "td", "th", "dt", "dd", "figcaption":
+			block(inline(n))
+		case "li":
+			sb.WriteString("- " + inline(n) + "\n")
+		case "ul", "ol":
+			for _, c := range n.children {
+				write(c)
+			}
+			sb.WriteString("\n")
+		case "blockquote":
+			block("> " + inline(n))
+		case "pre":
+			block("```\n" + strings.TrimSpace(textOf(n)) + "\n```")
+		case "img", "br", "hr":
+		default:
+			for _, c := range n.children {
+				write(c)
+			}
+		}
+	}
+	write(n)
+	return strings.TrimSpace(blankRuns.ReplaceAllString(sb.String(), "\n\n")) + "\n"
+}
+
+var blankRuns = regexp.MustCompile(`\n{3,}`)
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+const listName = "packing_list"
+
+// packers each add their own items to the shared packing list, at the same time
+var packers = []struct {
+	name, focus string
+	samples     []string // the items added with -show
+}{
+	{"Clothes", "clothes and shoes", []string{"Rain jacket", "Hiking boots", "Two warm jumpers"}},
+	{"Documents", "documents, money and bookings", []string{"Passports", "Travel insurance card", "Ferry tickets"}},
+	{"Gear", "gear, electronics and toiletries", []string{"Head torch", "Phone charger", "Sun cream"}},
+}
+
+// ledger records the items each agent added in writes that were accepted, so the final
+// list can be checked for lost updates
+type ledger struct {
+	mu    sync.Mutex
+	added map[string][]string
+}
+
+func (l *ledger) record(agent string, items []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.added[agent] = append(l.added[agent], items...)
+}
+
+// write writes a memory and records the items the write added to its base revision
+func write(mem *SharedMemory, added *ledger, agent, content string, base Revision) (WriteResult, error) {
+	result, err := mem.Write(agent, listName, content, base.Rev)
+	if err != nil {
+		return result, err
+	}
+	var items []string
+	for _, l := range lines(content) {
+		if !slices.Contains(lines(base.Content), l) {
+			items = append(items, l)
+		}
+	}
+	added.record(agent, items)
+	return result, nil
+}
+
+// createMemoryTools returns the memory tools for one agent. The run does not say which
+// agent is calling, so each agent gets its own tools that know its name.
+func createMemoryTools(mem *SharedMemory, added *ledger, agent string) []aigentic.AgentTool {
+	type ReadInput struct {
+		Name string `json:"name" description:"The memory to read, such as packing_list"`
+	}
+	type UpdateInput struct {
+		Name     string `json:"name" description:"The memory to update"`
+		Content  string `json:"content" description:"The complete new content, one item per line"`
+		Revision int    `json:"revision" description:"The revision number read_memory returned, which the new content is based on"`
+	}
+
+	read := aigentic.NewTool(
+		"read_memory",
+		"Reads a shared memory and its revision number. Other agents change shared memories at the same time.",
+		func(run *aigentic.AgentRun, input ReadInput) (string, error) {
+			rev := mem.Read(input.Name)
+			fmt.Printf("   👀 %-9s read  %s revision %d\n", agent, input.Name, rev.Rev)
+			return fmt.Sprintf("Revision %d:\n%s", rev.Rev, rev.Content), nil
+		},
+	)
+	update := aigentic.NewTool(
+		"update_memory",
+		"Replaces a shared memory with new content, based on the revision you read",
+		func(run *aigentic.AgentRun, input UpdateInput) (string, error) {
+			base := Revision{Rev: input.Revision}
+			if input.Revision > 0 {
+				history := mem.History(input.Name)
+				if input.Revision <= len(history) {
+					base = history[input.Revision-1]
+				}
+			}
+			result, err := write(mem, added, agent, input.Content, base)
+			if errors.Is(err, ErrConflict) {
+				fmt.Printf("   ⛔ %-9s write %s revision %d rejected: now at %d\n", agent, input.Name, input.Revision, result.Rev)
+				return fmt.Sprintf("Not saved: %v. Read it again and redo your change on the latest revision.", err), nil
+			}
+			if err != nil {
+				return "", err
+			}
+			note := ""
+			if result.Merged {
+				note = fmt.Sprintf(" (merged: based on %d)", input.Revision)
+			}
+			fmt.Printf("   💾 %-9s wrote %s revision %d%s\n", agent, input.Name, result.Rev, note)
+			if result.Merged {
+				return fmt.Sprintf("Saved as revision %d. Other agents had changed it; your changes were merged with theirs.", result.Rev), nil
+			}
+			return fmt.Sprintf("Saved as revision %d", result.Rev), nil
+		},
+	)
+	return []aigentic.AgentTool{read, update}
+}
+
+// simulate has every packer read the list, waits until all have read it, then has
+// them write at once, which is the worst case for lost updates. Rejected writes are
+// redone on the latest revision, as the model is told to do.
+func simulate(mem *SharedMemory, added *ledger) {
+	var read, done sync.WaitGroup
+	read.Add(len(packers))
+	for _, p := range packers {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			rev := mem.Read(listName)
+			fmt.Printf("   👀 %-9s read  %s revision %d\n", p.name, listName, rev.Rev)
+			read.Done()
+			read.Wait()
+			for {
+				content := strings.Join(append(lines(rev.Content), p.samples...), "\n")
+				result, err := write(mem, added, p.name, content, rev)
+				if errors.Is(err, ErrConflict) {
+					fmt.Printf("   ⛔ %-9s write %s revision %d rejected: now at %d\n", p.name, listName, rev.Rev, result.Rev)
+					rev = mem.Read(listName)
+					continue
+				}
+				if err != nil {
+					log.Fatalf("Failed to write memory: %v", err)
+				}
+				note := ""
+				if result.Merged {
+					note = fmt.Sprintf(" (merged: based on %d)", rev.Rev)
+				}
+				fmt.Printf("   💾 %-9s wrote %s revision %d%s\n", p.name, listName, result.Rev, note)
+				return
+			}
+		}()
+	}
+	done.Wait()
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	strategy := flag.String("strategy", "merge", "How conflicting writes are handled: last-write, reject or merge")
+	show := flag.Bool("show", false, "Simulate the agents' reads and writes and exit without calling the model")
+	flag.Parse()
+
+	fmt.Println("🔀 Concurrent Agents Writing Shared Memory")
+	fmt.Println("==========================================")
+	fmt.Println()
+
+	switch Strategy(*strategy) {
+	case LastWriteWins, Reject, Merge:
+	default:
+		log.Fatalf("Unknown strategy %q; use last-write, reject or merge", *strategy)
+	}
+	mem := NewSharedMemory(Strategy(*strategy))
+	added := &ledger{added: map[string][]string{}}
+
+	if _, err := mem.Write("User", listName, "Toothbrush\nSleeping bag", 0); err != nil {
+		log.Fatalf("Failed to write memory: %v", err)
+	}
+	fmt.Printf("Strategy: %s\n", mem.Strategy)
+	fmt.Printf("%s starts as: %s\n\n", listName, strings.Join(lines(mem.Read(listName).Content), ", "))
+
+	if *show {
+		simulate(mem, added)
+	} else {
+		model := openai.NewModel("gpt-4o-mini", getAPIKey())
+		var wg sync.WaitGroup
+		for _, p := range packers {
+			agent := aigentic.Agent{
+				Model:       model,
+				Name:        p.name,
+				Description: "Packs for a trip, sharing the packing list with other agents",
+				Instructions: fmt.Sprintf(`You help pack for a week of hiking in the Scottish Highlands in October. You are responsible for %s only; other agents add the rest at the same time.
+Read %s with read_memory, then save it with update_memory: the items already on it plus up to three of yours, one item per line, with the revision you read.
+If the update is not saved, read the list again and redo your change.`, p.focus, listName),
+				AgentTools: createMemoryTools(mem, added, p.name),
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := agent.Execute("Add your items to the packing list."); err != nil {
+					log.Printf("%s failed: %v", p.name, err)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+
+	final := lines(mem.Read(listName).Content)
+	fmt.Printf("\n📋 %s after %d revisions:\n", listName, len(mem.History(listName)))
+	for _, item := range final {
+		fmt.Printf("   - %s\n", item)
+	}
+	conflicts, merges := mem.Stats()
+	fmt.Printf("\nRejected writes: %d, merged writes: %d\n", conflicts, merges)
+
+	lost := 0
+	for _, p := range packers {
+		for _, item := range added.added[p.name] {
+			if !slices.Contains(final, item) {
+				fmt.Printf("❌ Lost update: %s added %q, which is no longer on the list\n", p.name, item)
+				lost++
+			}
+		}
+	}
+	if lost > 0 {
+		fmt.Printf("\n%d items were saved and then silently overwritten\n", lost)
+		os.Exit(1)
+	}
+	fmt.Println("No updates were lost")
+	fmt.Println()
+
+	fmt.Println("✅ Example completed successfully!")
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Strategy decides what happens when an agent writes a memory that another agent has
+// changed since it was read
+type Strategy string
+
+const (
+	// LastWriteWins stores every write as it is, so a write based on an old revision
+	// silently replaces the changes made since. This is what a plain map does.
+	LastWriteWins Strategy = "last-write"
+
+	// Reject refuses a write based on an old revision. The agent has to read the memory
+	// again and redo its change, like a compare-and-swap.
+	Reject Strategy = "reject"
+
+	// Merge applies the writer's changes to the current revision: lines the writer
+	// added since its revision are appended, and lines it removed are removed. Changes
+	// made by other agents in between are kept.
+	Merge Strategy = "merge"
+)
+
+// ErrConflict is returned by Write under the Reject strategy
+var ErrConflict = errors.New("memory was changed by another agent since it was read")
+
+// Revision is one version of a memory
+type Revision struct {
+	Rev     int // 0 for a memory that has never been written
+	Content string
+	Author  string
+}
+
+// WriteResult says how a write was applied
+type WriteResult struct {
+	Rev    int
+	Merged bool // the write was based on an old revision and was merged into the current one
+}
+
+// SharedMemory is session memory that several agents read and write at the same time.
+// Every memory keeps all its revisions, so a write can say which revision it is based
+// on and a merge can compare against it. The lock is held only while a single read or
+// write runs, never while a model is thinking, so agents do not wait for each other.
+type SharedMemory struct {
+	Strategy Strategy
+
+	mu        sync.Mutex
+	revisions map[string][]Revision
+	conflicts int
+	merges    int
+}
+
+func NewSharedMemory(strategy Strategy) *SharedMemory {
+	return &SharedMemory{Strategy: strategy, revisions: map[string][]Revision{}}
+}
+
+// Read returns the current revision of a memory
+func (m *SharedMemory) Read(name string) Revision {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.current(name)
+}
+
+func (m *SharedMemory) current(name string) Revision {
+	revs := m.revisions[name]
+	if len(revs) == 0 {
+		return Revision{}
+	}
+	return revs[len(revs)-1]
+}
+
+// Write stores content as the new revision of a memory, on behalf of author. base is
+// the revision the content was made from.
+func (m *SharedMemory) Write(author, name, content string, base int) (WriteResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cur := m.current(name)
+	if base < 0 || base > cur.Rev {
+		return WriteResult{}, fmt.Errorf("%s has no revision %d; the latest is %d", name, base, cur.Rev)
+	}
+
+	merged := false
+	if base != cur.Rev {
+		switch m.Strategy {
+		case Reject:
+			m.conflicts++
+			return WriteResult{Rev: cur.Rev}, ErrConflict
+		case Merge:
+			var baseContent string
+			if base > 0 {
+				baseContent = m.revisions[name][base-1].Content
+			}
+			content = mergeLines(baseContent, content, cur.Content)
+			merged = true
+			m.merges++
+		}
+	}
+
+	rev := Revision{Rev: cur.Rev + 1, Content: content, Author: author}
+	m.revisions[name] = append(m.revisions[name], rev)
+	return WriteResult{Rev: rev.Rev, Merged: merged}, nil
+}
+
+// History returns every revision of a memory, oldest first
+func (m *SharedMemory) History(name string) []Revision {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.revisions[name])
+}
+
+// Stats reports how many writes were rejected and how many were merged
+func (m *SharedMemory) Stats() (conflicts, merges int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.conflicts, m.merges
+}
+
+// mergeLines is a three-way merge for memories that are lists, one item per line.
+// Starting from theirs, it removes the lines mine removed from base and appends the
+// lines mine added, so both sides' changes survive. The order of theirs is kept.
+func mergeLines(base, mine, theirs string) string {
+	baseLines, mineLines := lines(base), lines(mine)
+
+	var result []string
+	for _, l := range lines(theirs) {
+		if slices.Contains(baseLines, l) && !slices.Contains(mineLines, l) {
+			continue
+		}
+		result = append(result, l)
+	}
+	for _, l := range mineLines {
+		if !slices.Contains(baseLines, l) && !slices.Contains(result, l) {
+			result = append(result, l)
+		}
+	}
+	return strings.Join(result, "\n")
+}
+
+// lines splits a list memory into its items, ignoring blank lines and surrounding space
+func lines(s string) []string {
+	var out []string
+	for _, l := range strings.Split(s, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			out = append(out, l)
+		}
+	}
+	return out
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic-examples/memory/store"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// memoryKey reads the key from MEMORY_KEY. Without one, the example makes a key for
+// this run only, which is fine for a demo but means the memories cannot be read by
+// the next run.
+func memoryKey() []byte {
+	encoded := os.Getenv("MEMORY_KEY")
+	if encoded == "" {
+		encoded = store.NewKey()
+		fmt.Println("⚠️  MEMORY_KEY is not set, using a key for this run only. To keep one:")
+		fmt.Printf("   export MEMORY_KEY=%s\n\n", encoded)
+	}
+	key, err := store.ParseKey(encoded)
+	if err != nil {
+		log.Fatalf("Invalid MEMORY_KEY: %v", err)
+	}
+	return key
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	dbPath := flag.String("db", "", "Memory database (default: a temporary file)")
+	show := flag.Bool("show", false, "Save sample memories directly instead of asking the model")
+	flag.Parse()
+
+	fmt.Println("🔐 Encrypted Memory at Rest")
+	fmt.Println("===========================")
+	fmt.Println()
+
+	if *dbPath == "" {
+		dir, err := os.MkdirTemp("", "aigentic-encrypted-*")
+		if err != nil {
+			log.Fatalf("Failed to create temp dir: %v", err)
+		}
+		defer os.RemoveAll(dir)
+		*dbPath = filepath.Join(dir, "memory.db")
+	}
+
+	ctx := context.Background()
+	db, err := store.Open(ctx, *dbPath)
+	if err != nil {
+		log.Fatalf("Failed to open memory: %v", err)
+	}
+	defer db.Close()
+	memories, err := store.NewEncrypted(db, memoryKey())
+	if err != nil {
+		log.Fatalf("Failed to set up encryption: %v", err)
+	}
+
+	session := aigentic.NewSession(ctx)
+	session.ID = "user-alice"
+
+	if *show {
+		samples := [][2]string{
+			{"name", "Alice Moreau"},
+			{"health", "Allergic to peanuts"},
+			{"meetings", "Prefers morning meetings"},
+		}
+		for _, s := range samples {
+			if err := memories.Save(ctx, session.ID, s[0], s[1]); err != nil {
+				log.Fatalf("Failed to save memory: %v", err)
+			}
+		}
+	} else {
+		agent := aigentic.Agent{
+			Model:       openai.NewModel("gpt-4o-mini", getAPIKey()),
+			Name:        "PersonalAssistant",
+			Description: "A personal assistant that remembers user preferences and context",
+			Instructions: `You are a personal assistant. Remember user preferences and important information using the update_memory tool.
+Memory names are stored unencrypted, so name memories by topic ("health", "meetings") and keep the details in the content.`,
+			Session:    session,
+			AgentTools: []aigentic.AgentTool{store.Tool(memories)},
+		}
+		message := "I'm Alice Moreau. I'm allergic to peanuts, so keep that in mind when booking lunches, and I prefer morning meetings."
+		fmt.Printf("👤 %s\n", message)
+		response, err := agent.Execute(message)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		fmt.Printf("🤖 %s\n\n", response)
+	}
+
+	// What is on disk: anyone who copies the file sees names and ciphertext
+	raw, err := db.List(ctx, session.ID)
+	if err != nil {
+		log.Fatalf("Failed to read memory: %v", err)
+	}
+	fmt.Printf("💽 On disk in %s:\n", *dbPath)
+	for _, e := range raw {
+		fmt.Printf("   %-10s %.60s...\n", e.Name, e.Content)
+	}
+	fmt.Println()
+
+	// What the agent sees with the key
+	plain, err := memories.List(ctx, session.ID)
+	if err != nil {
+		log.Fatalf("Failed to read memory: %v", err)
+	}
+	fmt.Println("🔓 With MEMORY_KEY:")
+	for _, e := range plain {
+		fmt.Printf("   %-10s %s\n", e.Name, e.Content)
+	}
+	fmt.Println()
+
+	// A wrong key, and a memory moved into another user's session, are both refused
+	wrong, err := store.NewEncrypted(db, []byte("0123456789abcdef0123456789abcdef"))
+	if err != nil {
+		log.Fatalf("Failed to set up encryption: %v", err)
+	}
+	if _, err := wrong.List(ctx, session.ID); errors.Is(err, store.ErrDecrypt) {
+		fmt.Printf("🔒 With another key: %v\n", err)
+	} else {
+		log.Fatalf("Expected a decryption error with the wrong key, got %v", err)
+	}
+
+	if len(raw) > 0 {
+		if err := db.Save(ctx, "user-mallory", raw[0].Name, raw[0].Content); err != nil {
+			log.Fatalf("Failed to copy memory: %v", err)
+		}
+		if _, err := memories.List(ctx, "user-mallory"); errors.Is(err, store.ErrDecrypt) {
+			fmt.Printf("🔒 Copied into another session: %v\n", err)
+		} else {
+			log.Fatalf("Expected a decryption error for the copied memory, got %v", err)
+		}
+	}
+	fmt.Println()
+
+	fmt.Println("✅ Example completed successfully!")
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Entry is one memory in a compartment
+type Entry struct {
+	Name       string
+	Content    string
+	Importance int    // 1 to 5, given when saved
+	LastUsed   uint64 // a tick of the memory's clock, not wall time, so order is exact
+}
+
+// Limit caps a compartment. Size is counted in characters of content, which is
+// what the memories cost in the context.
+type Limit struct {
+	MaxEntries int
+	MaxChars   int
+}
+
+// Policy picks the entry to evict from a compartment that is over its limit
+type Policy interface {
+	Name() string
+	Victim(entries []*Entry) *Entry
+}
+
+// LRU evicts the entry used longest ago. Saving a memory uses it, and so does saving
+// it again unchanged, which is how the agent says a memory still matters.
+type LRU struct{}
+
+func (LRU) Name() string { return "lru" }
+
+func (LRU) Victim(entries []*Entry) *Entry {
+	victim := entries[0]
+	for _, e := range entries[1:] {
+		if e.LastUsed < victim.LastUsed {
+			victim = e
+		}
+	}
+	return victim
+}
+
+// Importance evicts the least important entry, and the least recently used among
+// equals, so a trivial fact cannot push out an allergy however recent it is
+type Importance struct{}
+
+func (Importance) Name() string { return "importance" }
+
+func (Importance) Victim(entries []*Entry) *Entry {
+	victim := entries[0]
+	for _, e := range entries[1:] {
+		if e.Importance < victim.Importance || (e.Importance == victim.Importance && e.LastUsed < victim.LastUsed) {
+			victim = e
+		}
+	}
+	return victim
+}
+
+// BoundedMemory is memory split into compartments, each with a size limit. Without
+// a limit, an agent that saves a memory per message fills the context window within
+// weeks; with one, every save past the limit evicts an older memory, chosen by the
+// policy. OnEvict is called for each eviction.
+type BoundedMemory struct {
+	Policy  Policy
+	Limits  map[string]Limit // by compartment
+	OnEvict func(compartment string, e Entry)
+
+	mu           sync.Mutex
+	clock        uint64
+	compartments map[string]map[string]*Entry
+}
+
+func NewBoundedMemory(policy Policy, limits map[string]Limit) *BoundedMemory {
+	return &BoundedMemory{Policy: policy, Limits: limits, compartments: map[string]map[string]*Entry{}}
+}
+
+func chars(entries map[string]*Entry) int {
+	n := 0
+	for _, e := range entries {
+		n += len(e.Content)
+	}
+	return n
+}
+
+// Save adds or replaces a memory, then evicts until the compartment is within its
+// limit again, and returns the evicted entries. The memory just saved is a candidate
+// like any other: under LRU it is never chosen, but under Importance a trivial new
+// memory is dropped rather than an important old one, and Save reports it evicted.
+func (m *BoundedMemory) Save(compartment, name, content string, importance int) ([]Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	limit, ok := m.Limits[compartment]
+	if !ok {
+		return nil, fmt.Errorf("no compartment called %q", compartment)
+	}
+	if len(content) > limit.MaxChars {
+		return nil, fmt.Errorf("memory is %d characters, more than the %d the %s compartment holds", len(content), limit.MaxChars, compartment)
+	}
+	entries := m.compartments[compartment]
+	if entries == nil {
+		entries = map[string]*Entry{}
+		m.compartments[compartment] = entries
+	}
+
+	m.clock++
+	entries[name] = &Entry{Name: name, Content: content, Importance: max(1, min(importance, 5)), LastUsed: m.clock}
+
+	var evicted []Entry
+	for len(entries) > limit.MaxEntries || chars(entries) > limit.MaxChars {
+		candidates := make([]*Entry, 0, len(entries))
+		for _, e := range entries {
+			candidates = append(candidates, e)
+		}
+		// Map order is random; sorting makes ties between equal entries repeatable
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].Name < candidates[j].Name })
+		victim := m.Policy.Victim(candidates)
+		delete(entries, victim.Name)
+		evicted = append(evicted, *victim)
+		if m.OnEvict != nil {
+			m.OnEvict(compartment, *victim)
+		}
+	}
+	return evicted, nil
+}
+
+// Delete removes a memory
+func (m *BoundedMemory) Delete(compartment, name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.compartments[compartment], name)
+}
+
+// Entries returns a compartment's memories, most recently used first
+func (m *BoundedMemory) Entries(compartment string) []Entry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var entries []Entry
+	for _, e := range m.compartments[compartment] {
+		entries = append(entries, *e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].LastUsed > entries[j].LastUsed })
+	return entries
+}
+
+// Compartments returns the compartment names in order
+func (m *BoundedMemory) Compartments() []string {
+	names := make([]string, 0, len(m.Limits))
+	for name := range m.Limits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Format renders every compartment for the context, with how full it is, so the
+// model knows that saving more will push something out
+func (m *BoundedMemory) Format() string {
+	var sb strings.Builder
+	for _, c := range m.Compartments() {
+		entries := m.Entries(c)
+		size := 0
+		for _, e := range entries {
+			size += len(e.Content)
+		}
+		limit := m.Limits[c]
+		fmt.Fprintf(&sb, "## Memory compartment: %s (%d/%d entries, %d/%d characters)\n", c, len(entries), limit.MaxEntries, size, limit.MaxChars)
+		for _, e := range entries {
+			fmt.Fprintf(&sb, "- %s [importance %d]: %s\n", e.Name, e.Importance, e.Content)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// limits are small so the example evicts after a few messages; a real assistant
+// would allow a few thousand characters per compartment
+var limits = map[string]Limit{
+	"facts":       {MaxEntries: 4, MaxChars: 300},
+	"preferences": {MaxEntries: 3, MaxChars: 200},
+}
+
+// messages tell the assistant more than fits. The allergy comes first, so it is the
+// oldest memory when the facts compartment fills up.
+var messages = []string{
+	"I'm Alice, and I'm severely allergic to peanuts.",
+	"I prefer morning meetings and aisle seats when I fly.",
+	"My kids are Tom, 9, and Ella, 6.",
+	"I drive a 2022 Renault Zoe, and I go to the gym on Tuesdays.",
+	"Oh, I like jazz while I work, and I'd rather stay at Ibis hotels.",
+	"My mum's birthday is on 22 November.",
+	"Book lunch for me and a client on Friday. What should the restaurant know?",
+}
+
+// samples are the saves the model makes for those messages, for -show
+var samples = []struct {
+	compartment, name, content string
+	importance                 int
+}{
+	{"facts", "name", "Alice", 4},
+	{"facts", "allergy", "Severely allergic to peanuts", 5},
+	{"preferences", "meetings", "Prefers morning meetings", 3},
+	{"preferences", "seat", "Aisle seats on flights", 2},
+	{"facts", "kids", "Tom (9) and Ella (6)", 3},
+	{"facts", "car", "Drives a 2022 Renault Zoe", 2},
+	{"facts", "gym", "Goes to the gym on Tuesdays", 1},
+	{"preferences", "music", "Likes jazz while working", 1},
+	{"preferences", "hotel", "Prefers Ibis hotels", 2},
+	{"facts", "name", "Alice", 4}, // saved again unchanged: still relevant
+	{"facts", "mum_birthday", "Mum's birthday is 22 November", 3},
+}
+
+func createMemoryTool(mem *BoundedMemory) aigentic.AgentTool {
+	type SaveInput struct {
+		Compartment string `json:"compartment" description:"facts (who the user is, health, family, belongings) or preferences (how they like things done)"`
+		Name        string `json:"name" description:"Short name for the memory, such as allergy or seat. Saving under an existing name replaces it."`
+		Content     string `json:"content" description:"What to remember, in a few words. Empty to delete the memory."`
+		Importance  int    `json:"importance" description:"1 (nice to know) to 5 (must never be forgotten, such as allergies)"`
+	}
+
+	tool := aigentic.NewTool(
+		"save_memory",
+		"Saves a memory. Compartments have size limits; when one is full, saving evicts another memory from it.",
+		func(run *aigentic.AgentRun, input SaveInput) (string, error) {
+			if input.Content == "" {
+				mem.Delete(input.Compartment, input.Name)
+				return fmt.Sprintf("Deleted %s/%s", input.Compartment, input.Name), nil
+			}
+			evicted, err := mem.Save(input.Compartment, input.Name, input.Content, input.Importance)
+			if err != nil {
+				return "", err
+			}
+			fmt.Printf("   💾 %s/%s [%d]: %s\n", input.Compartment, input.Name, input.Importance, input.Content)
+			if len(evicted) == 0 {
+				return fmt.Sprintf("Saved %s/%s", input.Compartment, input.Name), nil
+			}
+			var names []string
+			for _, e := range evicted {
+				if e.Name == input.Name {
+					return fmt.Sprintf("Not kept: %s is full of more important memories", input.Compartment), nil
+				}
+				names = append(names, e.Name)
+			}
+			return fmt.Sprintf("Saved %s/%s; evicted %s to make room", input.Compartment, input.Name, strings.Join(names, ", ")), nil
+		},
+	)
+	tool.ContextFunctions = []aigentic.ContextFunction{
+		func(run *aigentic.AgentRun) (string, error) { return mem.Format(), nil },
+	}
+	return tool
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	policyName := flag.String("policy", "importance", "Eviction policy: lru or importance")
+	show := flag.Bool("show", false, "Save the sample memories directly and exit without calling the model")
+	flag.Parse()
+
+	fmt.Println("📏 Memory Size Limits with Eviction")
+	fmt.Println("===================================")
+	fmt.Println()
+
+	var policy Policy
+	switch *policyName {
+	case "lru":
+		policy = LRU{}
+	case "importance":
+		policy = Importance{}
+	default:
+		log.Fatalf("Unknown policy %q; use lru or importance", *policyName)
+	}
+
+	mem := NewBoundedMemory(policy, limits)
+	mem.OnEvict = func(compartment string, e Entry) {
+		fmt.Printf("   🗑️  evicted %s/%s [importance %d]: %s\n", compartment, e.Name, e.Importance, e.Content)
+	}
+	for _, c := range mem.Compartments() {
+		fmt.Printf("%-12s at most %d entries, %d characters\n", c, limits[c].MaxEntries, limits[c].MaxChars)
+	}
+	fmt.Printf("Eviction policy: %s\n\n", policy.Name())
+
+	if *show {
+		for _, s := range samples {
+			fmt.Printf("💾 %s/%s [%d]: %s\n", s.compartment, s.name, s.importance, s.content)
+			if _, err := mem.Save(s.compartment, s.name, s.content, s.importance); err != nil {
+				log.Fatalf("Failed to save memory: %v", err)
+			}
+		}
+		fmt.Printf("\n%s", mem.Format())
+		return
+	}
+
+	agent := aigentic.Agent{
+		Model:       openai.NewModel("gpt-4o-mini", getAPIKey()),
+		Name:        "PersonalAssistant",
+		Description: "A personal assistant with size-limited memory",
+		Instructions: `You are a personal assistant. Save what the user tells you with save_memory, one memory per topic, with an honest importance.
+Memory compartments are small. When something you already remember comes up again, save it again so it counts as recently used.`,
+		AgentTools: []aigentic.AgentTool{createMemoryTool(mem)},
+	}
+
+	for _, message := range messages {
+		fmt.Printf("👤 %s\n", message)
+		response, err := agent.Execute(message)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		fmt.Printf("🤖 %s\n\n", strings.TrimSpace(response))
+	}
+
+	fmt.Print(mem.Format())
+	fmt.Println("✅ Example completed successfully!")
+}
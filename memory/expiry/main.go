@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic-examples/memory/store"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func printMemories(entries []store.Entry) {
+	for _, e := range entries {
+		until := "kept"
+		if !e.ExpiresAt.IsZero() {
+			until = "until " + store.LastDay(e.ExpiresAt).Format("Mon 2006-01-02")
+		}
+		fmt.Printf("   🧠 %-16s %-22s %s\n", e.Name, until, e.Content)
+	}
+}
+
+// endOfWeek returns the Sunday of day's week
+func endOfWeek(day time.Time) time.Time {
+	return day.AddDate(0, 0, (7-int(day.Weekday()))%7)
+}
+
+// saveSample saves what the model would save from the first message
+func saveSample(ctx context.Context, memories *store.Store, session string, today time.Time) error {
+	if err := memories.SaveUntil(ctx, session, "reply_style", "Keep replies very short (back-to-back calls)", store.EndOfDay(today)); err != nil {
+		return err
+	}
+	if err := memories.SaveUntil(ctx, session, "location", "Working from the Lisbon office; put meetings there", store.EndOfDay(endOfWeek(today))); err != nil {
+		return err
+	}
+	return memories.Save(ctx, session, "drinks", "Drinks tea, never coffee")
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	start := flag.String("today", time.Now().Format(time.DateOnly), "Date of the first conversation, as YYYY-MM-DD")
+	show := flag.Bool("show", false, "Save the sample memories directly and show the sweeps, without calling the model")
+	flag.Parse()
+
+	fmt.Println("⏳ Memory with Expiry")
+	fmt.Println("=====================")
+	fmt.Println()
+
+	first, err := time.ParseInLocation(time.DateOnly, *start, time.Local)
+	if err != nil {
+		log.Fatalf("-today must be YYYY-MM-DD: %v", err)
+	}
+
+	dir, err := os.MkdirTemp("", "aigentic-expiry-*")
+	if err != nil {
+		log.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	memories, err := store.Open(ctx, filepath.Join(dir, "memory.db"))
+	if err != nil {
+		log.Fatalf("Failed to open memory: %v", err)
+	}
+	defer memories.Close()
+
+	session := aigentic.NewSession(ctx)
+	session.ID = "user-alice"
+
+	// The example plays out over more than a week, so "today" is simulated and given
+	// to the model in the context; the tool needs it to turn "this week" into a date
+	var today time.Time
+	agent := aigentic.Agent{
+		Name:        "PersonalAssistant",
+		Description: "A personal assistant that remembers user preferences and context",
+		Instructions: `You are a personal assistant. Remember user preferences and important information using the update_memory tool.
+When something is only true for a while ("this week", "today", "until Friday"), set keep_until to its last day so it is forgotten afterwards.
+Use one memory per topic.`,
+		Session:    session,
+		AgentTools: []aigentic.AgentTool{store.Tool(memories)},
+		ContextFunctions: []aigentic.ContextFunction{
+			func(run *aigentic.AgentRun) (string, error) {
+				return "Today is " + today.Format("Monday 2006-01-02"), nil
+			},
+		},
+	}
+	if !*show {
+		agent.Model = openai.NewModel("gpt-4o-mini", getAPIKey())
+	}
+
+	days := []struct {
+		offset  int
+		message string
+	}{
+		{0, "Heads up: I'm working from the Lisbon office this week only, so put any meetings there. Just for today, keep your replies very short, I'm in back-to-back calls. And generally, I drink tea, never coffee."},
+		{1, "Set up a catch-up with Priya tomorrow afternoon. Where will it be, and what should they order for me?"},
+		{8, "Set up a catch-up with Priya tomorrow afternoon. Where will it be, and what should they order for me?"},
+	}
+
+	for _, day := range days {
+		// Sweep at the start of the working day, before the agent runs
+		today = first.AddDate(0, 0, day.offset).Add(9 * time.Hour)
+		fmt.Printf("📅 %s\n", today.Format("Monday 2006-01-02"))
+
+		expired, err := memories.Sweep(ctx, today)
+		if err != nil {
+			log.Fatalf("Failed to sweep memory: %v", err)
+		}
+		for _, e := range expired {
+			fmt.Printf("   🧹 forgot %s: %s\n", e.Name, e.Content)
+		}
+
+		if *show {
+			if day.offset == 0 {
+				if err := saveSample(ctx, memories, session.ID, today); err != nil {
+					log.Fatalf("Failed to save memory: %v", err)
+				}
+			}
+		} else {
+			fmt.Printf("👤 %s\n", day.message)
+			response, err := agent.Execute(day.message)
+			if err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+			fmt.Printf("🤖 %s\n", response)
+		}
+
+		saved, err := memories.List(ctx, session.ID)
+		if err != nil {
+			log.Fatalf("Failed to read memory: %v", err)
+		}
+		printMemories(saved)
+		fmt.Println()
+	}
+
+	fmt.Println("✅ Example completed successfully!")
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic/ai"
+)
+
+// historyContextManager builds the prompt the way aigentic does by default, and puts
+// earlier turns of the conversation between the system prompt and the new message.
+// Agent.IncludeHistory does not do this across runs: in aigentic v0.8.0 every
+// Execute starts with an empty message history, so the history has to be supplied.
+type historyContextManager struct {
+	*aigentic.BasicContextManager
+	history []ai.Message
+}
+
+var _ aigentic.ContextManager = (*historyContextManager)(nil)
+
+// withHistory returns a copy of agent that replays history before message. The
+// default context manager holds the message, so it is made anew for every run.
+func withHistory(agent aigentic.Agent, message string, history []ai.Message) aigentic.Agent {
+	agent.ContextManager = &historyContextManager{
+		BasicContextManager: aigentic.NewBasicContextManager(agent, message),
+		history:             history,
+	}
+	return agent
+}
+
+func (m *historyContextManager) BuildPrompt(run *aigentic.AgentRun, messages []ai.Message, tools []ai.Tool) ([]ai.Message, error) {
+	msgs, err := m.BasicContextManager.BuildPrompt(run, messages, tools)
+	if err != nil || len(msgs) == 0 {
+		return msgs, err
+	}
+	prompt := make([]ai.Message, 0, len(msgs)+len(m.history))
+	prompt = append(prompt, msgs[0]) // the system prompt
+	prompt = append(prompt, m.history...)
+	return append(prompt, msgs[1:]...), nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nexxia-ai/aigentic/ai"
+)
+
+// Turn is one message of the conversation as the user saw it: what they typed and
+// the final answer. Tool calls and tool results are left out; what they produced is
+// in the answer, and what was worth keeping is in memory.
+type Turn struct {
+	Role    ai.MessageRole `json:"role"`
+	Content string         `json:"content"`
+	Time    time.Time      `json:"time"`
+}
+
+// MessageLog is the conversation on disk, one JSON turn per line. Turns are only
+// ever appended, so a crash can at worst lose the turn being written.
+type MessageLog struct {
+	path  string
+	turns []Turn
+}
+
+// OpenLog reads the log at path; a missing file is an empty conversation
+func OpenLog(path string) (*MessageLog, error) {
+	l := &MessageLog{path: path}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var t Turn
+		if err := json.Unmarshal(scanner.Bytes(), &t); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", path, line, err)
+		}
+		l.turns = append(l.turns, t)
+	}
+	return l, scanner.Err()
+}
+
+// Append adds turns to the log and syncs the file before returning
+func (l *MessageLog) Append(turns ...Turn) error {
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	for _, t := range turns {
+		line, err := json.Marshal(t)
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	if err := file.Sync(); err != nil {
+		return err
+	}
+	l.turns = append(l.turns, turns...)
+	return nil
+}
+
+func (l *MessageLog) Turns() []Turn {
+	return l.turns
+}
+
+// Recent returns the messages of the last n turns, oldest first, ready to put in a
+// prompt. The window starts on a user turn, so the model never sees an answer
+// without its question.
+func (l *MessageLog) Recent(n int) []ai.Message {
+	start := max(0, len(l.turns)-n)
+	for start < len(l.turns) && l.turns[start].Role != ai.UserRole {
+		start++
+	}
+	var msgs []ai.Message
+	for _, t := range l.turns[start:] {
+		switch t.Role {
+		case ai.UserRole:
+			msgs = append(msgs, ai.UserMessage{Role: ai.UserRole, Content: t.Content})
+		case ai.AssistantRole:
+			msgs = append(msgs, ai.AIMessage{Role: ai.AssistantRole, Content: t.Content})
+		}
+	}
+	return msgs
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic-examples/memory/store"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// script is the conversation, one message per run of the program. The third message
+// only makes sense with the history ("the second one"); the last one comes after the
+// start of the conversation has left the history window, and is answered from memory.
+var script = []string{
+	"Hi, I'm Alice. I'm organising a two-day team offsite for 12 people in May, and the budget is €9,000.",
+	"Let's go with somewhere near Lisbon. Give me three venue ideas, numbered.",
+	"Tell me more about the second one. Would it fit our budget?",
+	"Good. Draft a short invitation email for the team.",
+	"Remind me: how many people is the offsite for, what's the budget, and what's my name?",
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	dir := flag.String("dir", "chat_state", "Directory for the message log and the memory database")
+	message := flag.String("m", "", "Message to send instead of the next scripted one")
+	window := flag.Int("window", 4, "Past turns (user messages and answers) replayed into the prompt")
+	reset := flag.Bool("reset", false, "Delete the conversation and memory and start over")
+	show := flag.Bool("show", false, "Print the stored conversation and memory and exit without calling the model")
+	flag.Parse()
+
+	fmt.Println("💬 Conversation History across Restarts")
+	fmt.Println("=======================================")
+	fmt.Println()
+
+	if *reset {
+		if err := os.RemoveAll(*dir); err != nil {
+			log.Fatalf("Failed to delete %s: %v", *dir, err)
+		}
+		fmt.Printf("🗑️  Deleted %s\n\n", *dir)
+	}
+	if err := os.MkdirAll(*dir, 0700); err != nil {
+		log.Fatalf("Failed to create %s: %v", *dir, err)
+	}
+
+	chat, err := OpenLog(filepath.Join(*dir, "messages.jsonl"))
+	if err != nil {
+		log.Fatalf("Failed to read message log: %v", err)
+	}
+	ctx := context.Background()
+	memories, err := store.Open(ctx, filepath.Join(*dir, "memory.db"))
+	if err != nil {
+		log.Fatalf("Failed to open memory: %v", err)
+	}
+	defer memories.Close()
+
+	session := aigentic.NewSession(ctx)
+	session.ID = "user-alice"
+	saved, err := memories.List(ctx, session.ID)
+	if err != nil {
+		log.Fatalf("Failed to read memory: %v", err)
+	}
+
+	history := chat.Recent(*window)
+	fmt.Printf("📜 %d turns in the log; the last %d are replayed into the prompt\n", len(chat.Turns()), len(history))
+	fmt.Printf("🧠 %d memories\n", len(saved))
+	for _, e := range saved {
+		fmt.Printf("   %-14s %s\n", e.Name, e.Content)
+	}
+	fmt.Println()
+
+	if *show {
+		for _, t := range chat.Turns() {
+			fmt.Printf("[%s] %s: %s\n\n", t.Time.Local().Format(time.DateTime), t.Role, t.Content)
+		}
+		return
+	}
+
+	text := *message
+	if text == "" {
+		asked := 0
+		for _, t := range chat.Turns() {
+			if t.Role == ai.UserRole {
+				asked++
+			}
+		}
+		if asked >= len(script) {
+			fmt.Println("The scripted conversation is over. Send your own message with -m, or start over with -reset.")
+			return
+		}
+		text = script[asked]
+	}
+
+	// History and memory do different jobs. History is the verbatim recent exchange,
+	// which resolves "the second one"; it is trimmed to the window, so it cannot carry
+	// facts for long. Memory holds the facts worth keeping, however old, but not how
+	// the conversation went.
+	agent := aigentic.Agent{
+		Model:       openai.NewModel("gpt-4o-mini", getAPIKey()),
+		Name:        "PersonalAssistant",
+		Description: "A personal assistant that remembers user preferences and context",
+		Instructions: `You are a personal assistant in a long-running conversation. Earlier messages are included when they are recent.
+Save lasting facts about the user and their plans (names, numbers, dates, decisions) with the update_memory tool, one memory per topic,
+because older messages will drop out of the conversation.`,
+		Session:        session,
+		IncludeHistory: true,
+		AgentTools:     []aigentic.AgentTool{store.Tool(memories)},
+	}
+
+	fmt.Printf("👤 %s\n", text)
+	sent := time.Now()
+	response, err := withHistory(agent, text, history).Execute(text)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	fmt.Printf("🤖 %s\n\n", strings.TrimSpace(response))
+
+	// The turn is logged only once it has an answer, so a failed run can be retried
+	// without leaving an unanswered question in the history
+	err = chat.Append(
+		Turn{Role: ai.UserRole, Content: text, Time: sent},
+		Turn{Role: ai.AssistantRole, Content: response, Time: time.Now()},
+	)
+	if err != nil {
+		log.Fatalf("Failed to write message log: %v", err)
+	}
+	fmt.Println("Run the program again to continue the conversation in a new process.")
+	fmt.Println()
+
+	fmt.Println("✅ Example completed successfully!")
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic-examples/memory/store"
+)
+
+const help = `Commands:
+  sessions                 list the sessions that have memories
+  use <session>            switch to another session
+  list [text]              list memories, or only those whose name or content contains text
+  show <name>              print one memory in full
+  edit <name> [content]    replace a memory's content; without content, opens $EDITOR
+  delete <name>            delete a memory
+  expired                  list memories past their expiry that a sweep would delete
+  help                     show this help
+  quit                     leave
+`
+
+// inspector runs commands against one session of a memory database
+type inspector struct {
+	memories    *store.Store
+	session     string
+	interactive bool
+	in          *bufio.Scanner
+	out         io.Writer
+}
+
+func (ins *inspector) find(ctx context.Context, name string) (store.Entry, error) {
+	entries, err := ins.memories.List(ctx, ins.session)
+	if err != nil {
+		return store.Entry{}, err
+	}
+	for _, e := range entries {
+		if e.Name == name {
+			return e, nil
+		}
+	}
+	return store.Entry{}, fmt.Errorf("%s has no memory called %q", ins.session, name)
+}
+
+func (ins *inspector) printEntries(entries []store.Entry) {
+	if len(entries) == 0 {
+		fmt.Fprintln(ins.out, "no memories")
+		return
+	}
+	fmt.Fprintf(ins.out, "%-20s %-16s %-10s %s\n", "NAME", "UPDATED", "EXPIRES", "CONTENT")
+	for _, e := range entries {
+		expires := "-"
+		if !e.ExpiresAt.IsZero() {
+			expires = store.LastDay(e.ExpiresAt).Format(time.DateOnly)
+		}
+		content := strings.ReplaceAll(e.Content, "\n", " ⏎ ")
+		if len([]rune(content)) > 60 {
+			content = string([]rune(content)[:57]) + "..."
+		}
+		fmt.Fprintf(ins.out, "%-20s %-16s %-10s %s\n", e.Name, e.UpdatedAt.Local().Format("2006-01-02 15:04"), expires, content)
+	}
+}
+
+// confirm asks before a destructive command; commands given on the command line are
+// taken as already confirmed
+func (ins *inspector) confirm(question string) bool {
+	if !ins.interactive {
+		return true
+	}
+	fmt.Fprintf(ins.out, "%s [y/N] ", question)
+	if !ins.in.Scan() {
+		return false
+	}
+	answer := strings.ToLower(strings.TrimSpace(ins.in.Text()))
+	return answer == "y" || answer == "yes"
+}
+
+// editContent opens content in $EDITOR and returns what was saved
+func editContent(content string) (string, error) {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		return "", errors.New("$EDITOR is not set; give the new content after the name instead")
+	}
+	file, err := os.CreateTemp("", "memory-*.md")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		return "", err
+	}
+	file.Close()
+
+	cmd := exec.Command(editor, file.Name())
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s: %w", editor, err)
+	}
+	data, err := os.ReadFile(file.Name())
+	return strings.TrimRight(string(data), "\n"), err
+}
+
+// run carries out one command line. It returns false for quit.
+func (ins *inspector) run(ctx context.Context, line string) (bool, error) {
+	command, rest, _ := strings.Cut(strings.TrimSpace(line), " ")
+	rest = strings.TrimSpace(rest)
+	name, content, _ := strings.Cut(rest, " ")
+	content = strings.TrimSpace(content)
+
+	switch command {
+	case "":
+		return true, nil
+
+	case "help", "?":
+		fmt.Fprint(ins.out, help)
+
+	case "quit", "exit", "q":
+		return false, nil
+
+	case "sessions":
+		ids, err := ins.memories.Sessions(ctx)
+		if err != nil {
+			return true, err
+		}
+		for _, id := range ids {
+			entries, err := ins.memories.List(ctx, id)
+			if err != nil {
+				return true, err
+			}
+			marker := " "
+			if id == ins.session {
+				marker = "*"
+			}
+			fmt.Fprintf(ins.out, "%s %-30s %d memories\n", marker, id, len(entries))
+		}
+
+	case "use":
+		if rest == "" {
+			return true, errors.New("usage: use <session>")
+		}
+		ins.session = rest
+		fmt.Fprintf(ins.out, "using %s\n", ins.session)
+
+	case "list", "ls":
+		entries, err := ins.memories.List(ctx, ins.session)
+		if err != nil {
+			return true, err
+		}
+		filter := strings.ToLower(rest)
+		var matched []store.Entry
+		for _, e := range entries {
+			if strings.Contains(strings.ToLower(e.Name), filter) || strings.Contains(strings.ToLower(e.Content), filter) {
+				matched = append(matched, e)
+			}
+		}
+		ins.printEntries(matched)
+
+	case "show", "cat":
+		e, err := ins.find(ctx, rest)
+		if err != nil {
+			return true, err
+		}
+		fmt.Fprintf(ins.out, "%s\n", store.Format([]store.Entry{e}))
+		fmt.Fprintf(ins.out, "\ncreated %s, updated %s\n", e.CreatedAt.Local().Format(time.DateTime), e.UpdatedAt.Local().Format(time.DateTime))
+
+	case "edit", "set":
+		if name == "" {
+			return true, errors.New("usage: edit <name> [content]")
+		}
+		e, err := ins.find(ctx, name)
+		if err != nil {
+			return true, err
+		}
+		if content == "" {
+			if content, err = editContent(e.Content); err != nil {
+				return true, err
+			}
+		}
+		if content == e.Content {
+			fmt.Fprintln(ins.out, "unchanged")
+			return true, nil
+		}
+		if content == "" {
+			return true, errors.New("the new content is empty; use delete to remove a memory")
+		}
+		if err := ins.memories.SaveUntil(ctx, ins.session, name, content, e.ExpiresAt); err != nil {
+			return true, err
+		}
+		fmt.Fprintf(ins.out, "updated %s\n", name)
+
+	case "delete", "rm":
+		if rest == "" {
+			return true, errors.New("usage: delete <name>")
+		}
+		if _, err := ins.find(ctx, rest); err != nil {
+			return true, err
+		}
+		if !ins.confirm(fmt.Sprintf("delete %s from %s?", rest, ins.session)) {
+			fmt.Fprintln(ins.out, "kept")
+			return true, nil
+		}
+		if err := ins.memories.Delete(ctx, ins.session, rest); err != nil {
+			return true, err
+		}
+		fmt.Fprintf(ins.out, "deleted %s\n", rest)
+
+	case "expired":
+		entries, err := ins.memories.List(ctx, ins.session)
+		if err != nil {
+			return true, err
+		}
+		var expired []store.Entry
+		for _, e := range entries {
+			if !e.ExpiresAt.IsZero() && !e.ExpiresAt.After(time.Now()) {
+				expired = append(expired, e)
+			}
+		}
+		ins.printEntries(expired)
+
+	default:
+		return true, fmt.Errorf("unknown command %q; type help for the commands", command)
+	}
+	return true, nil
+}
+
+func main() {
+	dbPath := flag.String("db", "../persistent/assistant_memory.db", "Memory database to inspect")
+	session := flag.String("session", "user-alice", "Session whose memories to inspect")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: go run . [-db file] [-session id] [command]\n\n")
+		fmt.Fprintf(os.Stderr, "Without a command, starts an interactive prompt.\n\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\n%s", help)
+	}
+	flag.Parse()
+
+	if _, err := os.Stat(*dbPath); err != nil {
+		log.Fatalf("Cannot inspect %s: %v", *dbPath, err)
+	}
+	ctx := context.Background()
+	memories, err := store.Open(ctx, *dbPath)
+	if err != nil {
+		log.Fatalf("Failed to open memory: %v", err)
+	}
+	defer memories.Close()
+
+	ins := &inspector{memories: memories, session: *session, in: bufio.NewScanner(os.Stdin), out: os.Stdout}
+
+	// A command on the command line runs once, so the CLI can be used from scripts
+	if flag.NArg() > 0 {
+		if _, err := ins.run(ctx, strings.Join(flag.Args(), " ")); err != nil {
+			log.Fatalf("%v", err)
+		}
+		return
+	}
+
+	ins.interactive = true
+	fmt.Printf("🔎 Inspecting %s, session %s. Type help for the commands.\n", *dbPath, ins.session)
+	for {
+		fmt.Printf("%s> ", ins.session)
+		if !ins.in.Scan() {
+			fmt.Println()
+			return
+		}
+		more, err := ins.run(ctx, ins.in.Text())
+		if err != nil {
+			fmt.Printf("error: %v\n", err)
+		}
+		if !more {
+			return
+		}
+	}
+}
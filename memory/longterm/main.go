@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic-examples/memory/store"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// sample is a session memory with the judgement the curator would make of it, for
+// running the example with -show
+type sample struct {
+	name, content string
+	candidate     Candidate
+}
+
+var sessions = []struct {
+	message string
+	samples []sample
+}{
+	{
+		"Hi, I'm Alice. I'm vegetarian, and I'm cooking dinner for four friends tonight. Suggest a main course.",
+		[]sample{
+			{"name", "Alice", Candidate{"name", "The user's name is Alice", 4, true}},
+			{"diet", "Vegetarian", Candidate{"diet", "Alice is vegetarian", 5, true}},
+			{"tonight", "Cooking dinner for four friends tonight", Candidate{"dinner_tonight", "Alice is cooking for four friends tonight", 2, false}},
+		},
+	},
+	{
+		"Book me a table for two on Friday, vegetarian-friendly please, near the architecture studio where I work. I'm moving to Porto in March, so I'll need a removal company too. Oh, and I take oat milk in coffee.",
+		[]sample{
+			{"diet", "Vegetarian", Candidate{"diet", "Alice is vegetarian", 5, true}},
+			{"friday", "Table for two on Friday", Candidate{"friday_booking", "Alice wants a table for two on Friday", 2, false}},
+			{"move", "Moving to Porto in March; needs a removal company", Candidate{"home", "Alice is moving to Porto in March", 4, true}},
+			{"work", "Works at an architecture studio", Candidate{"work", "Alice works at an architecture studio", 3, true}},
+			{"coffee", "Oat milk in coffee", Candidate{"coffee", "Alice takes oat milk in coffee", 2, true}},
+		},
+	},
+	{
+		"Plan a relaxed Saturday for me, with lunch somewhere nice.",
+		nil,
+	},
+}
+
+// curate asks a model which session memories are worth keeping and how much. It
+// only judges; the promotion rule itself is Profile.Promote, so it can be tested and
+// tuned without a model.
+func curate(model *ai.Model, entries []store.Entry) ([]Candidate, error) {
+	var listing strings.Builder
+	for _, e := range entries {
+		fmt.Fprintf(&listing, "- %s: %s\n", e.Name, e.Content)
+	}
+	curator := aigentic.Agent{
+		Model:       model,
+		Name:        "MemoryCurator",
+		Description: "Decides which session memories belong in a long-term user profile",
+		Instructions: `You review what an assistant remembered during one conversation, to decide what belongs in the user's long-term profile.
+For every memory, reply with a candidate:
+- key: a short snake_case topic, the same for the same topic every time (name, diet, home, work, family, ...)
+- fact: one sentence about the user, naming them
+- importance: 1 (trivia) to 5 (must never be forgotten, such as allergies or diet)
+- durable: true if it will still be true next month, false for plans and requests of the moment
+Reply with JSON only: {"candidates": [{"key": "...", "fact": "...", "importance": 3, "durable": true}]}`,
+	}
+	response, err := curator.Execute("Session memories:\n" + listing.String())
+	if err != nil {
+		return nil, err
+	}
+
+	text := strings.TrimSpace(response)
+	if start, end := strings.Index(text, "{"), strings.LastIndex(text, "}"); start >= 0 && end > start {
+		text = text[start : end+1]
+	}
+	var result struct {
+		Candidates []Candidate `json:"candidates"`
+	}
+	if err := json.Unmarshal([]byte(text), &result); err != nil {
+		return nil, fmt.Errorf("curator response is not valid JSON: %w\n%s", err, response)
+	}
+	return result.Candidates, nil
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	dir := flag.String("dir", "longterm_state", "Directory for the session memories and the profile")
+	top := flag.Int("top", 3, "Profile facts injected into a new session")
+	minImportance := flag.Int("min-importance", 3, "Smallest importance for a new topic to be promoted")
+	reset := flag.Bool("reset", false, "Delete the profile and session memories and start over")
+	show := flag.Bool("show", false, "Use sample session memories and judgements instead of calling the model")
+	flag.Parse()
+
+	fmt.Println("🗄️  Long-Term Memory across Sessions")
+	fmt.Println("====================================")
+	fmt.Println()
+
+	if *reset {
+		if err := os.RemoveAll(*dir); err != nil {
+			log.Fatalf("Failed to delete %s: %v", *dir, err)
+		}
+	}
+	if err := os.MkdirAll(*dir, 0700); err != nil {
+		log.Fatalf("Failed to create %s: %v", *dir, err)
+	}
+
+	ctx := context.Background()
+	memories, err := store.Open(ctx, filepath.Join(*dir, "sessions.db"))
+	if err != nil {
+		log.Fatalf("Failed to open memory: %v", err)
+	}
+	defer memories.Close()
+	profile, err := LoadProfile(filepath.Join(*dir, "profile.json"), "alice")
+	if err != nil {
+		log.Fatalf("Failed to load profile: %v", err)
+	}
+
+	var model *ai.Model
+	if !*show {
+		model = openai.NewModel("gpt-4o-mini", getAPIKey())
+	}
+
+	for i, s := range sessions {
+		now := time.Now()
+		session := aigentic.NewSession(ctx)
+		session.ID = "alice-" + session.ID[:8]
+		fmt.Printf("━━ Session %d (%s) ━━\n", i+1, session.ID)
+
+		// 1. A new session starts with the best profile facts only
+		injected := profile.Top(*top, now)
+		fmt.Printf("📇 profile has %d facts; injecting the top %d, leaving out %d\n", len(profile.Facts), len(injected), len(profile.Facts)-len(injected))
+		for _, f := range injected {
+			fmt.Printf("   %.1f  %s\n", f.Score(now), f.Text)
+		}
+		profileContext := Context(injected)
+
+		// 2. The conversation uses session memory as usual
+		if *show {
+			for _, sm := range s.samples {
+				if err := memories.Save(ctx, session.ID, sm.name, sm.content); err != nil {
+					log.Fatalf("Failed to save memory: %v", err)
+				}
+			}
+		} else {
+			agent := aigentic.Agent{
+				Model:       model,
+				Name:        "PersonalAssistant",
+				Description: "A personal assistant that remembers user preferences and context",
+				Instructions: `You are a personal assistant. Use what you know about the user from earlier conversations without asking again.
+Save what the user tells you in this conversation with the update_memory tool, one memory per topic.`,
+				Session:    session,
+				AgentTools: []aigentic.AgentTool{store.Tool(memories)},
+				ContextFunctions: []aigentic.ContextFunction{
+					func(run *aigentic.AgentRun) (string, error) { return profileContext, nil },
+				},
+			}
+			fmt.Printf("👤 %s\n", s.message)
+			response, err := agent.Execute(s.message)
+			if err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+			fmt.Printf("🤖 %s\n", strings.TrimSpace(response))
+		}
+
+		// 3. When the session ends, its memories are judged and the best promoted
+		entries, err := memories.List(ctx, session.ID)
+		if err != nil {
+			log.Fatalf("Failed to read memory: %v", err)
+		}
+		var candidates []Candidate
+		if *show {
+			for _, sm := range s.samples {
+				candidates = append(candidates, sm.candidate)
+			}
+		} else if len(entries) > 0 {
+			if candidates, err = curate(model, entries); err != nil {
+				log.Fatalf("Failed to curate memories: %v", err)
+			}
+		}
+		fmt.Printf("🧠 %d session memories\n", len(entries))
+		for _, d := range profile.Promote(candidates, *minImportance, time.Now()) {
+			fmt.Printf("   %-10s %-44s %s\n", d.Outcome, d.Fact, d.Reason)
+		}
+		if err := profile.Save(); err != nil {
+			log.Fatalf("Failed to save profile: %v", err)
+		}
+		fmt.Println()
+	}
+
+	fmt.Printf("📇 Profile saved to %s; run again and the first session starts with it.\n\n", filepath.Join(*dir, "profile.json"))
+	fmt.Println("✅ Example completed successfully!")
+}
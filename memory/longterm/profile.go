@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Fact is one piece of long-term knowledge about a user, with the evidence for it
+type Fact struct {
+	Key        string    `json:"key"` // topic, such as "diet"; a newer fact on the same topic replaces the text
+	Text       string    `json:"text"`
+	Importance int       `json:"importance"` // 1 to 5, as judged when promoted
+	Seen       int       `json:"seen"`       // sessions in which it came up
+	FirstSeen  time.Time `json:"first_seen"`
+	LastSeen   time.Time `json:"last_seen"`
+}
+
+// Score ranks facts for injection. Importance counts most; each further session
+// the fact came up in adds half a point, up to two; a fact loses a point for every
+// 90 days it has not come up, so stale facts give way to current ones.
+func (f Fact) Score(now time.Time) float64 {
+	reinforced := 0.5 * float64(min(f.Seen-1, 4))
+	stale := now.Sub(f.LastSeen).Hours() / 24 / 90
+	return float64(f.Importance) + reinforced - stale
+}
+
+// Profile is the third memory tier: what is known about a user across sessions.
+// Run memory lasts for one Execute, session memory for one conversation; the profile
+// lasts until facts are replaced, and only its best facts reach a new session.
+type Profile struct {
+	User  string  `json:"user"`
+	Facts []*Fact `json:"facts"`
+
+	path string
+}
+
+func LoadProfile(path, user string) (*Profile, error) {
+	p := &Profile{User: user, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("reading profile %s: %w", path, err)
+	}
+	return p, nil
+}
+
+func (p *Profile) Save() error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p.path, append(data, '\n'), 0600)
+}
+
+// Top returns the n best facts by score
+func (p *Profile) Top(n int, now time.Time) []*Fact {
+	facts := append([]*Fact(nil), p.Facts...)
+	sort.SliceStable(facts, func(i, j int) bool { return facts[i].Score(now) > facts[j].Score(now) })
+	return facts[:min(n, len(facts))]
+}
+
+// Context renders facts for a new session's context
+func Context(facts []*Fact) string {
+	if len(facts) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("## What you know about the user from earlier conversations\n")
+	for _, f := range facts {
+		fmt.Fprintf(&sb, "- %s\n", f.Text)
+	}
+	return sb.String()
+}
+
+// Candidate is a session memory judged for promotion
+type Candidate struct {
+	Key        string `json:"key"`
+	Fact       string `json:"fact"`
+	Importance int    `json:"importance"`
+	Durable    bool   `json:"durable"` // still true next month, unlike "wants pizza tonight"
+}
+
+// Decision records what Promote did with a candidate
+type Decision struct {
+	Candidate
+	Outcome string // promoted, reinforced, updated or skipped
+	Reason  string
+}
+
+// Promote moves the candidates that deserve it from a session into the profile.
+// Passing facts (not durable) never are. A durable fact on a topic the profile
+// already has is reinforced, whatever its importance, because coming up again is
+// evidence it matters; a new topic needs at least minImportance.
+func (p *Profile) Promote(candidates []Candidate, minImportance int, now time.Time) []Decision {
+	byKey := map[string]*Fact{}
+	for _, f := range p.Facts {
+		byKey[f.Key] = f
+	}
+
+	var decisions []Decision
+	for _, c := range candidates {
+		c.Importance = max(1, min(c.Importance, 5))
+		existing := byKey[c.Key]
+		d := Decision{Candidate: c}
+		switch {
+		case !c.Durable:
+			d.Outcome, d.Reason = "skipped", "only true for now"
+		case existing != nil:
+			d.Outcome, d.Reason = "reinforced", fmt.Sprintf("came up in %d sessions", existing.Seen+1)
+			if existing.Text != c.Fact {
+				d.Outcome = "updated"
+				existing.Text = c.Fact
+			}
+			existing.Seen++
+			existing.Importance = max(existing.Importance, c.Importance)
+			existing.LastSeen = now
+		case c.Importance < minImportance:
+			d.Outcome, d.Reason = "skipped", fmt.Sprintf("importance %d is under %d", c.Importance, minImportance)
+		default:
+			d.Outcome, d.Reason = "promoted", fmt.Sprintf("importance %d", c.Importance)
+			f := &Fact{Key: c.Key, Text: c.Fact, Importance: c.Importance, Seen: 1, FirstSeen: now, LastSeen: now}
+			p.Facts = append(p.Facts, f)
+			byKey[c.Key] = f
+		}
+		decisions = append(decisions, d)
+	}
+	return decisions
+}
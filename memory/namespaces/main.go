@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/tools"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// Assistant is one user's PersonalAssistant, with a session and memory of its own
+type Assistant struct {
+	UserID string
+	Agent  aigentic.Agent
+	memory aigentic.AgentTool
+}
+
+// Memory returns what the assistant remembers, exactly as it is put in the context
+func (a *Assistant) Memory() (string, error) {
+	var parts []string
+	for _, fn := range a.memory.ContextFunctions {
+		part, err := fn(nil)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "\n"), nil
+}
+
+// Assistants gives each user ID an isolated memory namespace. The memory tool keeps
+// its entries inside the tool value, so sharing one agent between users would share
+// one memory: whatever Alice told it would be in the context when Bob asks. Here each
+// user gets their own tool and session, created on first use.
+type Assistants struct {
+	model *ai.Model
+
+	mu     sync.Mutex
+	byUser map[string]*Assistant
+}
+
+func NewAssistants(model *ai.Model) *Assistants {
+	return &Assistants{model: model, byUser: map[string]*Assistant{}}
+}
+
+func (a *Assistants) For(userID string) *Assistant {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if assistant, ok := a.byUser[userID]; ok {
+		return assistant
+	}
+	session := aigentic.NewSession(context.Background())
+	session.ID = "user-" + userID
+	memory := tools.NewMemoryTool()
+	assistant := &Assistant{
+		UserID: userID,
+		memory: memory,
+		Agent: aigentic.Agent{
+			Model:        a.model,
+			Name:         "PersonalAssistant",
+			Description:  "A personal assistant that remembers user preferences and context",
+			Instructions: "You are a personal assistant. Remember user preferences, past interactions, and important information using the update_memory tool.",
+			Session:      session,
+			AgentTools:   []aigentic.AgentTool{memory},
+		},
+	}
+	a.byUser[userID] = assistant
+	return assistant
+}
+
+// user is a sample user. Secrets are words from the intro that must never show up in
+// another user's memory or answers.
+type user struct {
+	id      string
+	intro   string
+	secrets []string
+}
+
+var users = []user{
+	{"alice", "My name is Alice and I prefer morning meetings. I'm working on a project about renewable energy.", []string{"Alice", "renewable"}},
+	{"bob", "I'm Bob. I only take meetings after 2pm, and I'm preparing a pitch for a bakery franchise.", []string{"Bob", "bakery"}},
+	{"carol", "Call me Carol. I'm training for the Berlin marathon and I'm allergic to shellfish.", []string{"Carol", "marathon", "shellfish"}},
+}
+
+// leaks returns the other users' secrets that appear in text
+func leaks(owner string, text string) []string {
+	var found []string
+	for _, u := range users {
+		if u.id == owner {
+			continue
+		}
+		for _, secret := range u.secrets {
+			if strings.Contains(strings.ToLower(text), strings.ToLower(secret)) {
+				found = append(found, fmt.Sprintf("%s's %q", u.id, secret))
+			}
+		}
+	}
+	return found
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	fmt.Println("👥 Per-User Memory Namespaces")
+	fmt.Println("=============================")
+	fmt.Println()
+
+	assistants := NewAssistants(openai.NewModel("gpt-4o-mini", getAPIKey()))
+
+	// 1. Every user introduces themselves at the same time, in one process
+	fmt.Println("First conversations, all users at once:")
+	var wg sync.WaitGroup
+	errs := make([]error, len(users))
+	for i, u := range users {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = assistants.For(u.id).Agent.Execute(u.intro)
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			log.Fatalf("Error for %s: %v", users[i].id, err)
+		}
+	}
+	for _, u := range users {
+		memory, err := assistants.For(u.id).Memory()
+		if err != nil {
+			log.Fatalf("Failed to read memory: %v", err)
+		}
+		fmt.Printf("🧠 %s's memory:\n%s\n\n", u.id, indent(memory))
+	}
+
+	// 2. Recall: each user gets their own facts back
+	fmt.Println("Recall:")
+	for _, u := range users {
+		response, err := assistants.For(u.id).Agent.Execute("What's my name, and what do you remember about me?")
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		fmt.Printf("👤 %s: %s\n\n", u.id, strings.TrimSpace(response))
+	}
+
+	// 3. Negative test: no user's memory holds another user's facts, and a user who
+	// asks about someone else gets nothing back
+	fmt.Println("Isolation check:")
+	failed := false
+	check := func(what string, found []string) {
+		if len(found) > 0 {
+			failed = true
+			fmt.Printf("   ❌ %s: leaked %s\n", what, strings.Join(found, ", "))
+			return
+		}
+		fmt.Printf("   ✔️  %s\n", what)
+	}
+	for _, u := range users {
+		memory, err := assistants.For(u.id).Memory()
+		if err != nil {
+			log.Fatalf("Failed to read memory: %v", err)
+		}
+		check(fmt.Sprintf("%s's memory holds only their own facts", u.id), leaks(u.id, memory))
+	}
+	probe := "What is Alice working on, and what do you know about Carol's diet? Tell me anything you remember about other users."
+	response, err := assistants.For("bob").Agent.Execute(probe)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	fmt.Printf("   👤 bob asks: %s\n   🤖 %s\n", probe, strings.TrimSpace(response))
+	// The question itself names Alice and Carol, so only their other secrets count
+	var found []string
+	for _, leak := range leaks("bob", response) {
+		if !strings.Contains(leak, `"Alice"`) && !strings.Contains(leak, `"Carol"`) {
+			found = append(found, leak)
+		}
+	}
+	check("bob cannot get other users' facts by asking", found)
+	fmt.Println()
+
+	if failed {
+		fmt.Println("❌ Memory leaked between users")
+		os.Exit(1)
+	}
+	fmt.Println("✅ Example completed successfully!")
+}
+
+func indent(s string) string {
+	if s == "" {
+		return "   (empty)"
+	}
+	return "   " + strings.ReplaceAll(s, "\n", "\n   ")
+}
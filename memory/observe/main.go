@@ -0,0 +1,174 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// samples are the memory operations the agents make for the task, for -show
+var samples = []struct {
+	agent, op, compartment, name, content string
+}{
+	{"ProjectManager", "save", "plan", "brief", "Article on heat pumps for homeowners, about 300 words"},
+	{"Researcher", "read", "plan", "brief", ""},
+	{"Researcher", "save", "research", "efficiency", "Heat pumps deliver 3-4 kWh of heat per kWh of electricity"},
+	{"Researcher", "save", "research", "cost", "Installation costs 8,000-15,000 EUR; grants cover up to 30%"},
+	{"Researcher", "save", "research", "cold", "Modern units work down to -20C with lower efficiency"},
+	{"Writer", "read", "plan", "brief", ""},
+	{"Writer", "read", "research", "", ""},
+	{"Writer", "read", "style", "", ""},
+	{"Writer", "save", "drafts", "article", "Heat pumps: cheaper warmth for most homes. A heat pump moves heat rather than making it..."},
+	{"ProjectManager", "read", "drafts", "article", ""},
+	{"ProjectManager", "save", "research", "cold", "Modern units work down to -20C; efficiency drops to about 2 below -10C"},
+	{"ProjectManager", "save", "plan", "brief", ""},
+}
+
+// createMemoryTools returns the memory tools for one agent. The run does not say which
+// agent is calling, so each agent gets its own tools that know its name.
+func createMemoryTools(mem *SharedMemory, agent string) []aigentic.AgentTool {
+	type SaveInput struct {
+		Compartment string `json:"compartment" description:"plan (the brief and decisions), research (facts found) or drafts (written content)"`
+		Name        string `json:"name" description:"Short name for the memory, such as brief or cost. Saving under an existing name replaces it."`
+		Content     string `json:"content" description:"What to remember. Empty to delete the memory."`
+	}
+	type GetInput struct {
+		Compartment string `json:"compartment" description:"plan, research or drafts"`
+		Name        string `json:"name,omitempty" description:"The memory to read; leave empty to read the whole compartment"`
+	}
+
+	save := aigentic.NewTool(
+		"save_memory",
+		"Saves a memory that the whole team can read",
+		func(run *aigentic.AgentRun, input SaveInput) (string, error) {
+			mem.Save(agent, input.Compartment, input.Name, input.Content)
+			if input.Content == "" {
+				return fmt.Sprintf("Deleted %s/%s", input.Compartment, input.Name), nil
+			}
+			return fmt.Sprintf("Saved %s/%s", input.Compartment, input.Name), nil
+		},
+	)
+	get := aigentic.NewTool(
+		"get_memory",
+		"Reads memories the team has saved",
+		func(run *aigentic.AgentRun, input GetInput) (string, error) {
+			entries := mem.Get(agent, input.Compartment, input.Name)
+			if len(entries) == 0 {
+				return fmt.Sprintf("Nothing saved in %s", strings.TrimSuffix(input.Compartment+"/"+input.Name, "/")), nil
+			}
+			var sb strings.Builder
+			for _, e := range entries {
+				fmt.Fprintf(&sb, "## %s/%s (by %s)\n%s\n\n", input.Compartment, e.Name, e.Author, e.Content)
+			}
+			return sb.String(), nil
+		},
+	)
+	return []aigentic.AgentTool{save, get}
+}
+
+// logEvents returns a hook that writes each event as a JSON line, for whatever
+// collects logs in production
+func logEvents(logger *slog.Logger) func(Event) {
+	return func(e Event) {
+		logger.Info("memory",
+			slog.String("op", string(e.Op)),
+			slog.String("agent", e.Agent),
+			slog.String("compartment", e.Compartment),
+			slog.String("name", e.Name),
+			slog.Int("bytes", e.Bytes),
+			slog.Int("entries", e.Entries),
+			slog.Int("total", e.Total),
+			slog.Bool("found", e.Found),
+		)
+	}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	logPath := flag.String("log", "memory_events.jsonl", "File the structured memory events are appended to")
+	show := flag.Bool("show", false, "Replay sample memory operations and exit without calling the model")
+	flag.Parse()
+
+	fmt.Println("🔭 Observing Memory Activity in a Multi-Agent Run")
+	fmt.Println("=================================================")
+	fmt.Println()
+
+	logFile, err := os.OpenFile(*logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		log.Fatalf("Failed to open event log: %v", err)
+	}
+	defer logFile.Close()
+
+	view := NewConsoleView(os.Stdout)
+	mem := NewSharedMemory(view.Observe, logEvents(slog.New(slog.NewJSONHandler(logFile, nil))))
+
+	fmt.Println("📡 Memory activity:")
+	if *show {
+		for _, s := range samples {
+			if s.op == "read" {
+				mem.Get(s.agent, s.compartment, s.name)
+			} else {
+				mem.Save(s.agent, s.compartment, s.name, s.content)
+			}
+		}
+	} else {
+		model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+		researchAgent := aigentic.Agent{
+			Model:        model,
+			Name:         "Researcher",
+			Description:  "Expert at gathering and analyzing information on any topic",
+			Instructions: "You are a research specialist. Read the brief in plan memory, then save each key fact you find to research memory under its own name. Be thorough but concise.",
+			AgentTools:   createMemoryTools(mem, "Researcher"),
+		}
+
+		writerAgent := aigentic.Agent{
+			Model:        model,
+			Name:         "Writer",
+			Description:  "Expert at creating clear, engaging written content",
+			Instructions: "You are a professional writer. Read the brief from plan memory and the facts from research memory, then write the content and save it to drafts memory as article.",
+			AgentTools:   createMemoryTools(mem, "Writer"),
+		}
+
+		coordinator := aigentic.Agent{
+			Model:       model,
+			Name:        "ProjectManager",
+			Description: "Coordinates research and writing tasks to produce high-quality content",
+			Instructions: `You manage a team of specialists who share memory. First save the brief to plan memory as brief.
+Then have the Researcher gather facts and the Writer write the article; they read and save their work in memory, so you do not need to repeat it to them.
+Finally read the article from drafts memory and return it.`,
+			Agents:     []aigentic.Agent{researchAgent, writerAgent},
+			AgentTools: createMemoryTools(mem, "ProjectManager"),
+		}
+
+		response, err := coordinator.Execute("Create a brief article for homeowners about heat pumps: how efficient they are, what they cost and whether they work in cold winters.")
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		fmt.Printf("\nFinal Article:\n%s\n", response)
+	}
+
+	fmt.Println("\n📊 Memory activity by agent:")
+	view.Summary(os.Stdout)
+	fmt.Printf("\n📝 Events appended to %s\n\n", *logPath)
+
+	fmt.Println("✅ Example completed successfully!")
+}
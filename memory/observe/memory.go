@@ -0,0 +1,116 @@
+package main
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Op is a kind of memory operation
+type Op string
+
+const (
+	OpSave   Op = "save"
+	OpDelete Op = "delete"
+	OpRead   Op = "read"
+)
+
+// Event describes one memory operation: who did what to which compartment, and the
+// sizes involved. Every operation emits exactly one event, including failed reads.
+type Event struct {
+	Time        time.Time `json:"time"`
+	Op          Op        `json:"op"`
+	Agent       string    `json:"agent"`
+	Compartment string    `json:"compartment"`
+	Name        string    `json:"name,omitempty"` // empty for a read of the whole compartment
+	Bytes       int       `json:"bytes"`          // written, or returned by a read
+	Entries     int       `json:"entries"`        // in the compartment afterwards
+	Total       int       `json:"total"`          // bytes in the compartment afterwards
+	Found       bool      `json:"found"`
+}
+
+type record struct {
+	content string
+	author  string
+	updated time.Time
+}
+
+// SharedMemory is memory that several agents read and write, split into named
+// compartments. Hooks are called for every operation, after it completes and
+// outside the lock, so a slow hook cannot block other agents' memory access.
+type SharedMemory struct {
+	hooks []func(Event)
+
+	mu           sync.Mutex
+	compartments map[string]map[string]record
+}
+
+func NewSharedMemory(hooks ...func(Event)) *SharedMemory {
+	return &SharedMemory{hooks: hooks, compartments: map[string]map[string]record{}}
+}
+
+func (m *SharedMemory) emit(e Event) {
+	for _, hook := range m.hooks {
+		hook(e)
+	}
+}
+
+// stats returns the entry count and byte size of a compartment; the lock is held
+func (m *SharedMemory) stats(compartment string) (int, int) {
+	total := 0
+	for _, r := range m.compartments[compartment] {
+		total += len(r.content)
+	}
+	return len(m.compartments[compartment]), total
+}
+
+// Save writes a memory on behalf of agent; empty content deletes it
+func (m *SharedMemory) Save(agent, compartment, name, content string) {
+	m.mu.Lock()
+	e := Event{Time: time.Now(), Op: OpSave, Agent: agent, Compartment: compartment, Name: name, Bytes: len(content)}
+	if content == "" {
+		_, e.Found = m.compartments[compartment][name]
+		e.Op = OpDelete
+		delete(m.compartments[compartment], name)
+	} else {
+		if m.compartments[compartment] == nil {
+			m.compartments[compartment] = map[string]record{}
+		}
+		_, e.Found = m.compartments[compartment][name]
+		m.compartments[compartment][name] = record{content: content, author: agent, updated: e.Time}
+	}
+	e.Entries, e.Total = m.stats(compartment)
+	m.mu.Unlock()
+
+	m.emit(e)
+}
+
+// Entry is a memory as returned by Get
+type Entry struct {
+	Name    string
+	Content string
+	Author  string
+	Updated time.Time
+}
+
+// Get reads one memory, or the whole compartment when name is empty, on behalf of
+// agent
+func (m *SharedMemory) Get(agent, compartment, name string) []Entry {
+	m.mu.Lock()
+	var entries []Entry
+	for n, r := range m.compartments[compartment] {
+		if name == "" || n == name {
+			entries = append(entries, Entry{Name: n, Content: r.content, Author: r.author, Updated: r.updated})
+		}
+	}
+	e := Event{Time: time.Now(), Op: OpRead, Agent: agent, Compartment: compartment, Name: name, Found: len(entries) > 0}
+	for _, entry := range entries {
+		e.Bytes += len(entry.Content)
+	}
+	e.Entries, e.Total = m.stats(compartment)
+	m.mu.Unlock()
+
+	m.emit(e)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
+}
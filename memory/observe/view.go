@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ConsoleView prints memory activity as it happens, one line per event, and keeps
+// running totals per agent and compartment for a summary at the end
+type ConsoleView struct {
+	w io.Writer
+
+	mu     sync.Mutex
+	counts map[[2]string]*activity // by agent and compartment
+}
+
+type activity struct {
+	saves, deletes, reads, misses int
+	written, read                 int
+}
+
+func NewConsoleView(w io.Writer) *ConsoleView {
+	return &ConsoleView{w: w, counts: map[[2]string]*activity{}}
+}
+
+var opIcons = map[Op]string{OpSave: "✏️ ", OpDelete: "🗑️ ", OpRead: "👀"}
+
+// Observe is the hook for SharedMemory
+func (v *ConsoleView) Observe(e Event) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	key := [2]string{e.Agent, e.Compartment}
+	a := v.counts[key]
+	if a == nil {
+		a = &activity{}
+		v.counts[key] = a
+	}
+
+	target := e.Compartment + "/" + e.Name
+	if e.Name == "" {
+		target = e.Compartment + "/*"
+	}
+	detail := ""
+	switch {
+	case e.Op == OpSave:
+		a.saves++
+		a.written += e.Bytes
+		detail = fmt.Sprintf("+%d B", e.Bytes)
+		if e.Found {
+			detail += " (overwrote)"
+		}
+	case e.Op == OpDelete:
+		a.deletes++
+	case e.Found:
+		a.reads++
+		a.read += e.Bytes
+		detail = fmt.Sprintf("%d B", e.Bytes)
+	default:
+		a.misses++
+		detail = "not found"
+	}
+	fmt.Fprintf(v.w, "   %s %s %-8s %-14s %-24s %-20s %s: %d entries, %d B\n", e.Time.Format("15:04:05.000"), opIcons[e.Op], e.Op, e.Agent,
+		target, detail, e.Compartment, e.Entries, e.Total)
+}
+
+// Summary writes the totals per agent and compartment
+func (v *ConsoleView) Summary(w io.Writer) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	keys := make([][2]string, 0, len(v.counts))
+	for k := range v.counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i][0] < keys[j][0] || (keys[i][0] == keys[j][0] && keys[i][1] < keys[j][1])
+	})
+
+	fmt.Fprintf(w, "%-14s %-12s %6s %8s %6s %6s %9s %8s\n", "AGENT", "COMPARTMENT", "SAVES", "DELETES", "READS", "MISSES", "WRITTEN", "READ")
+	fmt.Fprintln(w, strings.Repeat("-", 76))
+	for _, k := range keys {
+		a := v.counts[k]
+		fmt.Fprintf(w, "%-14s %-12s %6d %8d %6d %6d %7d B %6d B\n", k[0], k[1], a.saves, a.deletes, a.reads, a.misses, a.written, a.read)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic-examples/memory/store"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func printMemories(entries []store.Entry) {
+	for _, e := range entries {
+		fmt.Printf("   🧠 %-20s %s  (saved %s)\n", e.Name, e.Content, e.UpdatedAt.Local().Format(time.DateTime))
+	}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	dbPath := flag.String("db", "assistant_memory.db", "SQLite file that holds the memories")
+	user := flag.String("user", "alice", "User ID; each user's memories are kept apart")
+	reset := flag.Bool("reset", false, "Delete the memory file and start over")
+	show := flag.Bool("show", false, "Print the stored memories and exit without calling the model")
+	flag.Parse()
+
+	fmt.Println("💾 Persistent Memory with SQLite")
+	fmt.Println("================================")
+	fmt.Println()
+
+	if *reset {
+		if err := os.Remove(*dbPath); err != nil && !os.IsNotExist(err) {
+			log.Fatalf("Failed to delete %s: %v", *dbPath, err)
+		}
+		fmt.Printf("🗑️  Deleted %s\n\n", *dbPath)
+	}
+
+	ctx := context.Background()
+	memories, err := store.Open(ctx, *dbPath)
+	if err != nil {
+		log.Fatalf("Failed to open memory: %v", err)
+	}
+	defer memories.Close()
+
+	// The session ID is the key for the stored memories, so it must be the same on
+	// every run. A new session would otherwise get a random ID and find nothing.
+	session := aigentic.NewSession(ctx)
+	session.ID = "user-" + *user
+
+	saved, err := memories.List(ctx, session.ID)
+	if err != nil {
+		log.Fatalf("Failed to read memory: %v", err)
+	}
+	if *show {
+		fmt.Printf("%d memories for %s in %s\n", len(saved), session.ID, *dbPath)
+		printMemories(saved)
+		return
+	}
+
+	agent := aigentic.Agent{
+		Model:        openai.NewModel("gpt-4o-mini", getAPIKey()),
+		Name:         "PersonalAssistant",
+		Description:  "A personal assistant that remembers user preferences and context",
+		Instructions: "You are a personal assistant. Remember user preferences, past interactions, and important information using the update_memory tool. Use one memory per topic, such as name, meetings or projects.",
+		Session:      session,
+		AgentTools:   []aigentic.AgentTool{store.Tool(memories)},
+	}
+
+	var message string
+	if len(saved) == 0 {
+		fmt.Printf("First run: nothing remembered for %s yet\n\n", session.ID)
+		message = "My name is Alice and I prefer morning meetings. I'm working on a project about renewable energy."
+	} else {
+		fmt.Printf("Restarted: %d memories for %s loaded from %s\n", len(saved), session.ID, *dbPath)
+		printMemories(saved)
+		fmt.Println()
+		message = "What's my name, what project am I working on, and when do I prefer to have meetings?"
+	}
+
+	fmt.Printf("👤 %s\n", message)
+	response, err := agent.Execute(message)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	fmt.Printf("🤖 %s\n\n", response)
+
+	saved, err = memories.List(ctx, session.ID)
+	if err != nil {
+		log.Fatalf("Failed to read memory: %v", err)
+	}
+	fmt.Printf("Memories in %s after this run:\n", *dbPath)
+	printMemories(saved)
+	fmt.Println()
+	fmt.Println("Run the program again: the new process starts with these memories.")
+	fmt.Println()
+
+	fmt.Println("✅ Example completed successfully!")
+}
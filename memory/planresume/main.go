@@ -0,0 +1,192 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+const goal = "Organise a two-day offsite in Lisbon in March for a team of 12"
+
+// samples are the plan and step results the model produces, for -show
+var (
+	sampleSteps = []string{
+		"Agree dates and budget with the team lead",
+		"Shortlist three venues",
+		"Book the venue",
+		"Book flights for the team",
+		"Book hotel rooms",
+		"Draft the two-day agenda",
+		"Send the invitation with travel details",
+	}
+	sampleResults = []string{
+		"12-13 March, budget 18,000 EUR including travel",
+		"LX Factory loft, Palácio Chiado, Village Underground",
+		"LX Factory loft booked for both days, 2,400 EUR",
+		"12 return flights on TAP, arriving 11 March, 5,900 EUR",
+		"12 rooms at Hotel Lis Baixa for 3 nights, 4,300 EUR",
+		"Day 1 strategy and roadmap, day 2 workshops and team dinner",
+		"Invitation sent with flights, hotel, venue and agenda",
+	}
+)
+
+func createPlanTool(path string, plan **Plan) aigentic.AgentTool {
+	type PlanInput struct {
+		Steps []string `json:"steps" description:"The steps in order, each a task that can be done in one sitting"`
+	}
+	return aigentic.NewTool(
+		"create_plan",
+		"Saves the plan for the goal to plan memory",
+		func(run *aigentic.AgentRun, input PlanInput) (string, error) {
+			p, err := NewPlan(path, goal, input.Steps)
+			if err != nil {
+				return "", err
+			}
+			*plan = p
+			return fmt.Sprintf("Plan saved with %d steps", len(p.Steps)), nil
+		},
+	)
+}
+
+func createStepTool(plan *Plan) aigentic.AgentTool {
+	type CompleteInput struct {
+		ID     int    `json:"id" description:"The number of the step"`
+		Result string `json:"result" description:"What was done and decided, with the details later steps need"`
+	}
+	tool := aigentic.NewTool(
+		"complete_step",
+		"Marks the step in progress as done and saves its result to plan memory",
+		func(run *aigentic.AgentRun, input CompleteInput) (string, error) {
+			if err := plan.Complete(input.ID, input.Result); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Step %d saved as done", input.ID), nil
+		},
+	)
+	tool.ContextFunctions = []aigentic.ContextFunction{
+		func(run *aigentic.AgentRun) (string, error) { return "Plan memory:\n" + plan.Format(), nil },
+	}
+	return tool
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	planPath := flag.String("plan", "plan_state/plan.json", "File the plan memory is saved in")
+	steps := flag.Int("steps", 2, "Steps to work on before this process stops, like one working day")
+	interrupt := flag.Bool("interrupt", false, "Stop the process in the middle of its first step, as a crash would")
+	reset := flag.Bool("reset", false, "Delete the saved plan and start again")
+	show := flag.Bool("show", false, "Use sample plan steps and results without calling the model")
+	flag.Parse()
+
+	fmt.Println("🗓️  Resuming a Long Plan from Plan Memory")
+	fmt.Println("========================================")
+	fmt.Println()
+
+	if *reset {
+		if err := os.Remove(*planPath); err != nil && !os.IsNotExist(err) {
+			log.Fatalf("Failed to delete plan: %v", err)
+		}
+	}
+
+	plan, err := LoadPlan(*planPath)
+	if err != nil {
+		log.Fatalf("Failed to load plan: %v", err)
+	}
+
+	var model *ai.Model
+	if !*show {
+		model = openai.NewModel("gpt-4o-mini", getAPIKey())
+	}
+
+	if plan == nil {
+		fmt.Printf("📝 No plan in %s; planning: %s\n", *planPath, goal)
+		if *show {
+			if plan, err = NewPlan(*planPath, goal, sampleSteps); err != nil {
+				log.Fatalf("Failed to save plan: %v", err)
+			}
+		} else {
+			agent := aigentic.Agent{
+				Model:        model,
+				Name:         "ProjectPlanner",
+				Description:  "A project manager that plans long tasks and works through them step by step",
+				Instructions: "Break the goal into five to eight concrete steps, in order, and save them with create_plan.",
+				AgentTools:   []aigentic.AgentTool{createPlanTool(*planPath, &plan)},
+			}
+			if _, err := agent.Execute(goal); err != nil {
+				log.Fatalf("Error: %v", err)
+			}
+			if plan == nil {
+				log.Fatalf("The model did not save a plan")
+			}
+		}
+	} else {
+		fmt.Printf("📂 Loaded the plan from %s (updated %s): %d of %d steps done\n", *planPath,
+			plan.UpdatedAt.Format("2006-01-02 15:04:05"), plan.Done(), len(plan.Steps))
+	}
+	fmt.Printf("\n%s\n", plan.Format())
+
+	for range *steps {
+		step := plan.Next()
+		if step == nil {
+			break
+		}
+		if step.Status == StepInProgress {
+			fmt.Printf("♻️  Step %d was started by an earlier process that stopped; redoing it\n", step.ID)
+		}
+		fmt.Printf("▶️  Step %d: %s\n", step.ID, step.Title)
+		if err := plan.Start(step.ID); err != nil {
+			log.Fatalf("Failed to save plan: %v", err)
+		}
+		if *interrupt {
+			fmt.Println("💥 Process stopped in the middle of the step")
+			os.Exit(1)
+		}
+
+		if *show {
+			err = plan.Complete(step.ID, sampleResults[(step.ID-1)%len(sampleResults)])
+		} else {
+			agent := aigentic.Agent{
+				Model:       model,
+				Name:        "ProjectPlanner",
+				Description: "A project manager that plans long tasks and works through them step by step",
+				Instructions: `You work through a long plan, one step per request. Earlier steps may have been done on other days; their results are in plan memory.
+Do the step you are given, making reasonable decisions where you lack information, then save the result with complete_step. The result must contain every detail later steps need.`,
+				AgentTools: []aigentic.AgentTool{createStepTool(plan)},
+			}
+			_, err = agent.Execute(fmt.Sprintf("Do step %d: %s", step.ID, step.Title))
+		}
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		if step.Status != StepDone {
+			fmt.Printf("⚠️  Step %d was not completed; the next process will redo it\n", step.ID)
+			break
+		}
+		fmt.Printf("   ✅ %s\n", step.Result)
+	}
+
+	if next := plan.Next(); next != nil {
+		fmt.Printf("\n⏸️  Stopping with %d of %d steps done. Run again to continue at step %d: %s\n\n", plan.Done(), len(plan.Steps), next.ID, next.Title)
+	} else {
+		fmt.Printf("\n🎉 All %d steps are done.\n\n", len(plan.Steps))
+	}
+
+	fmt.Println("✅ Example completed successfully!")
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Step statuses. A step is marked in progress before the agent starts it, so a process
+// that dies mid-step leaves a record of it and the next process redoes that step.
+const (
+	StepPending    = "pending"
+	StepInProgress = "in_progress"
+	StepDone       = "done"
+)
+
+type Step struct {
+	ID       int       `json:"id"`
+	Title    string    `json:"title"`
+	Status   string    `json:"status"`
+	Result   string    `json:"result,omitempty"`
+	Attempts int       `json:"attempts"`
+	DoneAt   time.Time `json:"done_at,omitzero"`
+}
+
+// Plan is the plan memory of a long task, saved to a file after every change so that
+// any process can pick it up where the last one stopped
+type Plan struct {
+	Goal      string    `json:"goal"`
+	Steps     []Step    `json:"steps"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	path string
+}
+
+// LoadPlan reads the plan at path. It returns nil, and no error, when there is no plan
+// yet.
+func LoadPlan(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var p Plan
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	p.path = path
+	return &p, nil
+}
+
+// NewPlan creates a plan with every step pending and saves it to path
+func NewPlan(path, goal string, titles []string) (*Plan, error) {
+	if len(titles) == 0 {
+		return nil, errors.New("a plan needs at least one step")
+	}
+	now := time.Now()
+	p := &Plan{Goal: goal, CreatedAt: now, path: path}
+	for i, title := range titles {
+		p.Steps = append(p.Steps, Step{ID: i + 1, Title: strings.TrimSpace(title), Status: StepPending})
+	}
+	return p, p.Save()
+}
+
+// Save writes the plan to a temporary file and renames it, so a process killed while
+// saving never leaves a half-written plan
+func (p *Plan) Save() error {
+	p.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p.path), 0o755); err != nil {
+		return err
+	}
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p.path)
+}
+
+// Next returns the first step that is not done: a step left in progress by a process
+// that stopped, or else the first pending one. It returns nil when the plan is finished.
+func (p *Plan) Next() *Step {
+	for i := range p.Steps {
+		if p.Steps[i].Status != StepDone {
+			return &p.Steps[i]
+		}
+	}
+	return nil
+}
+
+func (p *Plan) step(id int) (*Step, error) {
+	if id < 1 || id > len(p.Steps) {
+		return nil, fmt.Errorf("the plan has no step %d", id)
+	}
+	return &p.Steps[id-1], nil
+}
+
+// Start marks a step in progress and saves the plan
+func (p *Plan) Start(id int) error {
+	s, err := p.step(id)
+	if err != nil {
+		return err
+	}
+	s.Status = StepInProgress
+	s.Attempts++
+	return p.Save()
+}
+
+// Complete marks a step done with its result and saves the plan. Only the step in
+// progress can be completed, so the agent cannot skip ahead.
+func (p *Plan) Complete(id int, result string) error {
+	s, err := p.step(id)
+	if err != nil {
+		return err
+	}
+	if s.Status != StepInProgress {
+		return fmt.Errorf("step %d is %s; only the step in progress can be completed", id, s.Status)
+	}
+	s.Status, s.Result, s.DoneAt = StepDone, result, time.Now()
+	return p.Save()
+}
+
+// Done counts the finished steps
+func (p *Plan) Done() int {
+	n := 0
+	for _, s := range p.Steps {
+		if s.Status == StepDone {
+			n++
+		}
+	}
+	return n
+}
+
+var statusIcons = map[string]string{StepPending: "⬜", StepInProgress: "🔄", StepDone: "✅"}
+
+// Format describes the plan for the model, with the results of finished steps
+func (p *Plan) Format() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Goal: %s\n", p.Goal)
+	for _, s := range p.Steps {
+		fmt.Fprintf(&sb, "%s %d. %s [%s]\n", statusIcons[s.Status], s.ID, s.Title, s.Status)
+		if s.Result != "" {
+			fmt.Fprintf(&sb, "   Result: %s\n", s.Result)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// schedule is the day the agent starts from. The workshop only fits if another session
+// moves, so changes made one at a time easily leave the day in a broken state.
+var schedule = map[string]string{
+	"Keynote":         "09:00-10:00",
+	"Team standup":    "10:00-10:30",
+	"Vendor demo":     "11:00-12:00",
+	"Budget review":   "13:00-14:30",
+	"1:1 with Sam":    "15:00-15:30",
+	"Taxi to airport": "16:30-18:00",
+}
+
+var rules = Rules{
+	DayStart: 8 * time.Hour,
+	DayEnd:   18 * time.Hour,
+	Fixed:    map[string]string{"Keynote": "09:00-10:00", "Taxi to airport": "16:30-18:00"},
+	Required: map[string]time.Duration{"Client workshop": 90 * time.Minute, "Lunch": 60 * time.Minute},
+}
+
+const task = "Fit a 90-minute Client workshop and a 60-minute Lunch into my day. Move other sessions if you need to."
+
+// samples are two attempts at the task, for -show. The first puts the workshop on top
+// of the 1:1; the second moves the 1:1 first.
+var samples = [][]struct{ name, time string }{
+	{{"Lunch", "12:00-13:00"}, {"Client workshop", "15:00-16:30"}},
+	{{"1:1 with Sam", "14:30-15:00"}, {"Client workshop", "15:00-16:30"}, {"Lunch", "12:00-13:00"}},
+}
+
+// planner carries out the agent's changes on the schedule in run memory
+type planner struct {
+	mem       *RunMemory
+	attempts  int
+	rollbacks int
+}
+
+func (p *planner) begin(label string) string {
+	p.mem.Checkpoint(label)
+	p.attempts++
+	fmt.Printf("📍 Checkpoint %q (attempt %d)\n", label, p.attempts)
+	return fmt.Sprintf("Checkpoint %q saved. Make your changes, then call finish_change.", label)
+}
+
+func (p *planner) set(name, value string) string {
+	p.mem.Set(name, value)
+	fmt.Printf("   ✏️  %s → %s\n", name, value)
+	return fmt.Sprintf("%s is now at %s", name, value)
+}
+
+func (p *planner) remove(name string) string {
+	if !p.mem.Delete(name) {
+		return fmt.Sprintf("%s is not on the schedule", name)
+	}
+	fmt.Printf("   🗑️  %s removed\n", name)
+	return fmt.Sprintf("%s removed", name)
+}
+
+// finish validates the schedule. A valid schedule is kept; an invalid one is rolled
+// back to the checkpoint, so the next attempt starts from the last good state rather
+// than from the half-finished one.
+func (p *planner) finish(label string) (string, error) {
+	problems := rules.Validate(p.mem.Entries())
+	if len(problems) == 0 {
+		if err := p.mem.Release(label); err != nil {
+			return "", err
+		}
+		fmt.Printf("✅ Valid: changes since %q kept\n\n", label)
+		return "The schedule is valid and the changes are kept.", nil
+	}
+
+	// The checkpoint is released too: the next attempt takes its own
+	if err := p.mem.Rollback(label); err != nil {
+		return "", err
+	}
+	if err := p.mem.Release(label); err != nil {
+		return "", err
+	}
+	p.rollbacks++
+	fmt.Printf("❌ Invalid, rolled back to %q:\n", label)
+	for _, problem := range problems {
+		fmt.Printf("   - %s\n", problem)
+	}
+	fmt.Println()
+	return fmt.Sprintf("The schedule was invalid, so every change since checkpoint %q was undone:\n- %s\n\nThe schedule is back to:\n%s\nStart again from this schedule with begin_change.",
+		label, strings.Join(problems, "\n- "), formatSchedule(p.mem.Entries())), nil
+}
+
+func createScheduleTools(p *planner) []aigentic.AgentTool {
+	type BeginInput struct {
+		Label string `json:"label" description:"A name for the checkpoint, such as before-workshop"`
+	}
+	type SetInput struct {
+		Name string `json:"name" description:"The session, such as Lunch"`
+		Time string `json:"time" description:"Start and end time, such as 12:00-13:00"`
+	}
+	type RemoveInput struct {
+		Name string `json:"name" description:"The session to remove"`
+	}
+	type FinishInput struct {
+		Label string `json:"label" description:"The checkpoint given to begin_change"`
+	}
+
+	begin := aigentic.NewTool("begin_change", "Saves a checkpoint of the schedule before you change it",
+		func(run *aigentic.AgentRun, input BeginInput) (string, error) { return p.begin(input.Label), nil })
+	set := aigentic.NewTool("set_session", "Adds a session to the schedule, or moves one to a new time",
+		func(run *aigentic.AgentRun, input SetInput) (string, error) {
+			return p.set(input.Name, input.Time), nil
+		})
+	remove := aigentic.NewTool("remove_session", "Removes a session from the schedule",
+		func(run *aigentic.AgentRun, input RemoveInput) (string, error) { return p.remove(input.Name), nil })
+	finish := aigentic.NewTool("finish_change", "Validates the schedule. If it is invalid, every change since the checkpoint is undone.",
+		func(run *aigentic.AgentRun, input FinishInput) (string, error) { return p.finish(input.Label) })
+
+	begin.ContextFunctions = []aigentic.ContextFunction{
+		func(run *aigentic.AgentRun) (string, error) {
+			return "Current schedule:\n" + formatSchedule(p.mem.Entries()), nil
+		},
+	}
+	return []aigentic.AgentTool{begin, set, remove, finish}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	show := flag.Bool("show", false, "Replay two sample attempts and exit without calling the model")
+	flag.Parse()
+
+	fmt.Println("⏪ Rolling Back Run Memory to a Checkpoint")
+	fmt.Println("==========================================")
+	fmt.Println()
+
+	mem := NewRunMemory()
+	for name, value := range schedule {
+		mem.Set(name, value)
+	}
+	p := &planner{mem: mem}
+
+	fmt.Printf("Task: %s\n\nSchedule:\n%s\n", task, formatSchedule(mem.Entries()))
+
+	if *show {
+		for i, attempt := range samples {
+			label := fmt.Sprintf("attempt-%d", i+1)
+			p.begin(label)
+			for _, s := range attempt {
+				p.set(s.name, s.time)
+			}
+			if _, err := p.finish(label); err != nil {
+				log.Fatalf("Failed to finish change: %v", err)
+			}
+		}
+	} else {
+		agent := aigentic.Agent{
+			Model:       openai.NewModel("gpt-4o-mini", getAPIKey()),
+			Name:        "Scheduler",
+			Description: "An assistant that rearranges a day's schedule",
+			Instructions: `You rearrange the user's schedule. The Keynote and the Taxi to airport cannot move, and the day runs from 08:00 to 18:00.
+Before changing anything, call begin_change. Then make all your changes, and call finish_change with the same label.
+If finish_change finds problems, your changes are undone: call begin_change again and make a new attempt from the restored schedule. Give up after three attempts.`,
+			AgentTools: createScheduleTools(p),
+		}
+
+		response, err := agent.Execute(task)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		fmt.Printf("🤖 %s\n\n", response)
+
+		// A change the agent started but never finished has not been validated
+		if open := mem.Checkpoints(); len(open) > 0 {
+			if err := mem.Rollback(open[0]); err != nil {
+				log.Fatalf("Failed to roll back: %v", err)
+			}
+			fmt.Printf("⚠️  Unfinished change rolled back to %q\n\n", open[0])
+		}
+	}
+
+	fmt.Printf("Final schedule after %d attempts and %d rollbacks:\n%s", p.attempts, p.rollbacks, formatSchedule(mem.Entries()))
+	if problems := rules.Validate(mem.Entries()); len(problems) > 0 {
+		fmt.Printf("\nThe task is not done: %s\n", strings.Join(problems, "; "))
+	}
+	fmt.Println()
+
+	fmt.Println("✅ Example completed successfully!")
+}
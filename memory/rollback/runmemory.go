@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+)
+
+// RunMemory is the memory of one agent run: named entries that tools change step by
+// step. Checkpoint copies the entries aside, and Rollback puts the copy back, so a
+// sequence of changes that turns out to be wrong can be undone as a whole instead of
+// the model trying to reverse each step.
+type RunMemory struct {
+	mu          sync.Mutex
+	entries     map[string]string
+	checkpoints []checkpoint // a stack: the latest checkpoint is last
+}
+
+type checkpoint struct {
+	label   string
+	entries map[string]string
+}
+
+func NewRunMemory() *RunMemory {
+	return &RunMemory{entries: map[string]string{}}
+}
+
+func (m *RunMemory) Set(name, value string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[name] = value
+}
+
+func (m *RunMemory) Delete(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.entries[name]
+	delete(m.entries, name)
+	return ok
+}
+
+// Entries returns a copy of the entries
+func (m *RunMemory) Entries() map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return maps.Clone(m.entries)
+}
+
+// Checkpoint saves the current entries under label
+func (m *RunMemory) Checkpoint(label string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checkpoints = append(m.checkpoints, checkpoint{label: label, entries: maps.Clone(m.entries)})
+}
+
+// Rollback restores the entries saved by the latest checkpoint with label and drops
+// every checkpoint taken after it. The checkpoint itself is kept, so the next attempt
+// can be rolled back to the same state.
+func (m *RunMemory) Rollback(label string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.checkpoints) - 1; i >= 0; i-- {
+		if m.checkpoints[i].label == label {
+			m.entries = maps.Clone(m.checkpoints[i].entries)
+			m.checkpoints = m.checkpoints[:i+1]
+			return nil
+		}
+	}
+	return fmt.Errorf("no checkpoint named %q", label)
+}
+
+// Release drops the latest checkpoint with label and those after it, keeping the
+// current entries. Call it when the changes have been accepted.
+func (m *RunMemory) Release(label string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.checkpoints) - 1; i >= 0; i-- {
+		if m.checkpoints[i].label == label {
+			m.checkpoints = m.checkpoints[:i]
+			return nil
+		}
+	}
+	return fmt.Errorf("no checkpoint named %q", label)
+}
+
+// Checkpoints returns the labels of the checkpoints, oldest first
+func (m *RunMemory) Checkpoints() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	labels := make([]string, len(m.checkpoints))
+	for i, c := range m.checkpoints {
+		labels[i] = c.label
+	}
+	return labels
+}
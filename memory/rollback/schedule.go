@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// A schedule is kept in run memory as one entry per session, with the session name as
+// the entry name and its time as the value, such as "09:00-10:30"
+type slot struct {
+	name       string
+	start, end time.Duration // since midnight
+}
+
+func parseSlot(name, value string) (slot, error) {
+	from, to, ok := strings.Cut(value, "-")
+	if !ok {
+		return slot{}, fmt.Errorf("%s: %q is not a time range like 09:00-10:30", name, value)
+	}
+	start, err := parseClock(from)
+	if err != nil {
+		return slot{}, fmt.Errorf("%s: %w", name, err)
+	}
+	end, err := parseClock(to)
+	if err != nil {
+		return slot{}, fmt.Errorf("%s: %w", name, err)
+	}
+	if end <= start {
+		return slot{}, fmt.Errorf("%s: ends at %s, before it starts", name, strings.TrimSpace(to))
+	}
+	return slot{name: name, start: start, end: end}, nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a time like 09:00", strings.TrimSpace(s))
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func clock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// Rules are what a valid schedule must satisfy
+type Rules struct {
+	DayStart, DayEnd time.Duration
+	Fixed            map[string]string        // sessions that cannot move, with their times
+	Required         map[string]time.Duration // sessions that must be on the schedule, with their length
+}
+
+// Validate returns every problem with a schedule, or nothing if it is valid
+func (r Rules) Validate(entries map[string]string) []string {
+	var problems []string
+	var slots []slot
+	for name, value := range entries {
+		s, err := parseSlot(name, value)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		if s.start < r.DayStart || s.end > r.DayEnd {
+			problems = append(problems, fmt.Sprintf("%s (%s) is outside the day, %s to %s", name, value, clock(r.DayStart), clock(r.DayEnd)))
+		}
+		slots = append(slots, s)
+	}
+
+	for name, value := range r.Fixed {
+		if got, ok := entries[name]; !ok {
+			problems = append(problems, fmt.Sprintf("%s was removed, but it cannot move from %s", name, value))
+		} else if got != value {
+			problems = append(problems, fmt.Sprintf("%s was moved to %s, but it cannot move from %s", name, got, value))
+		}
+	}
+	for name, length := range r.Required {
+		for _, s := range slots {
+			if s.name == name && s.end-s.start != length {
+				problems = append(problems, fmt.Sprintf("%s lasts %d minutes; it must last %d", name, int((s.end-s.start).Minutes()), int(length.Minutes())))
+			}
+		}
+		if _, ok := entries[name]; !ok {
+			problems = append(problems, fmt.Sprintf("%s is missing", name))
+		}
+	}
+
+	sort.Slice(slots, func(i, j int) bool { return slots[i].start < slots[j].start })
+	for i := 1; i < len(slots); i++ {
+		for j := 0; j < i; j++ {
+			if slots[j].end > slots[i].start {
+				problems = append(problems, fmt.Sprintf("%s (%s-%s) overlaps %s (%s-%s)", slots[j].name, clock(slots[j].start), clock(slots[j].end),
+					slots[i].name, clock(slots[i].start), clock(slots[i].end)))
+			}
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// formatSchedule lists the sessions in time order, with unparseable ones last
+func formatSchedule(entries map[string]string) string {
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := entries[names[i]], entries[names[j]]
+		if a != b {
+			return a < b
+		}
+		return names[i] < names[j]
+	})
+
+	var sb strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&sb, "   %-13s %s\n", entries[name], name)
+	}
+	return sb.String()
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// history is what a personal assistant has picked up about Alice over a few months.
+// Only a handful of these matter for any one question.
+var history = []string{
+	"Alice's name is Alice Moreau; she goes by Alice",
+	"Alice prefers meetings in the morning, ideally before 11am",
+	"Alice works on a renewable energy project: a solar farm feasibility study for the town of Lyme",
+	"The solar farm study is due to the council on 14 November",
+	"Alice's manager is Priya Shah",
+	"Alice is allergic to peanuts",
+	"Alice is vegetarian but eats fish",
+	"Alice's favourite restaurant is Casa Lucia, an Italian place near the office",
+	"Alice has two children, Tom (9) and Ella (6)",
+	"Tom has football practice on Wednesdays at 5pm, and Alice picks him up",
+	"Alice's partner, Sam, works night shifts on Thursdays",
+	"Alice drives an electric car, a 2022 Renault Zoe",
+	"Alice's car is due for service in December",
+	"Alice flies with Air France and has a Flying Blue gold card",
+	"Alice prefers aisle seats on flights",
+	"Alice dislikes early flights and will not book anything before 9am",
+	"Alice's passport expires in March next year",
+	"Alice is learning Spanish and practises on Duolingo every evening",
+	"Alice runs 5 km on Saturday mornings at the park run",
+	"Alice's dentist appointment is on 3 December at 2pm",
+	"Alice's mother's birthday is 22 November; she likes orchids",
+	"Alice uses a standing desk and wants a larger monitor",
+	"Alice writes reports in Google Docs, not Word",
+	"Alice's team stand-up is at 9:30 every weekday",
+	"Alice does not take calls on Friday afternoons; she keeps them for deep work",
+	"Alice's budget for the solar study includes €12,000 for a site survey",
+	"The site survey contractor for the solar study is GreenGrid Surveys",
+	"Alice's gym membership renews in January",
+	"Alice's preferred hotel chain is Ibis, for the loyalty points",
+	"Alice reads science fiction; she just finished The Ministry for the Future",
+}
+
+func createRememberTool(mem *SemanticMemory) aigentic.AgentTool {
+	type RememberInput struct {
+		Fact string `json:"fact" description:"One self-contained fact about the user, in a full sentence that names them, for example: Alice prefers window seats"`
+	}
+
+	return aigentic.NewTool(
+		"remember",
+		"Saves a new fact about the user to long-lived memory",
+		func(run *aigentic.AgentRun, input RememberInput) (string, error) {
+			if err := mem.Remember(context.Background(), input.Fact); err != nil {
+				return "", err
+			}
+			fmt.Printf("   💾 remembered: %s\n", input.Fact)
+			return "Saved.", nil
+		},
+	)
+}
+
+func tokens(s string) int {
+	return (len(s) + 3) / 4
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	local := flag.Bool("local", false, "Use the local hashing embedder instead of the OpenAI embeddings API")
+	embedModel := flag.String("embed-model", "text-embedding-3-small", "OpenAI embedding model")
+	k := flag.Int("k", 5, "Memories recalled per question")
+	minScore := flag.Float64("min-score", -1, "Smallest similarity for a memory to be recalled (default 0.3, or 0.1 with -local)")
+	show := flag.Bool("show", false, "Print what each question recalls and exit without calling the chat model")
+	custom := flag.String("q", "", "Question to ask instead of the built-in ones")
+	flag.Parse()
+
+	fmt.Println("🧭 Semantic Memory with Vector Recall")
+	fmt.Println("=====================================")
+	fmt.Println()
+
+	var embedder Embedder
+	defaultScore := 0.3
+	if *local {
+		// Word overlap scores lower than meaning does, so it needs a lower threshold
+		embedder, defaultScore = HashEmbedder{Dims: 1024}, 0.1
+	} else {
+		embedder = NewOpenAIEmbedder(getAPIKey(), *embedModel)
+	}
+	if *minScore < 0 {
+		*minScore = defaultScore
+	}
+
+	ctx := context.Background()
+	mem := NewSemanticMemory(embedder)
+	if err := mem.Remember(ctx, history...); err != nil {
+		log.Fatalf("Failed to load memories: %v", err)
+	}
+	var all []string
+	for _, m := range mem.All() {
+		all = append(all, "- "+m.Text)
+	}
+	fmt.Printf("🧠 %d memories (%s), about %d tokens if all were put in the context\n\n",
+		mem.Len(), embedder.Name(), tokens(strings.Join(all, "\n")))
+
+	questions := []string{
+		"Can you book me a flight to Madrid for the Lyme solar study meeting? What should I keep in mind?",
+		"Suggest somewhere for dinner with my manager next week.",
+		"What should I get my mum for her birthday, and when is it?",
+	}
+	if *custom != "" {
+		questions = []string{*custom}
+	}
+
+	var agent aigentic.Agent
+	if !*show {
+		agent = aigentic.Agent{
+			Model:       openai.NewModel("gpt-4o-mini", getAPIKey()),
+			Name:        "PersonalAssistant",
+			Description: "A personal assistant with long-lived memory",
+			Instructions: `You are Alice's personal assistant. The memories relevant to her message are in the context;
+use the ones that matter and ignore the rest. When she tells you something new about herself that will matter later,
+save it with the remember tool. Do not ask for information that is already in your memories.`,
+			AgentTools: []aigentic.AgentTool{createRememberTool(mem)},
+		}
+	}
+
+	for _, q := range questions {
+		matches, err := mem.Recall(ctx, q, *k, *minScore)
+		if err != nil {
+			log.Fatalf("Failed to recall: %v", err)
+		}
+		recalled := Format(matches)
+
+		fmt.Printf("❓ %s\n", q)
+		fmt.Printf("   recalled %d of %d memories, about %d tokens:\n", len(matches), mem.Len(), tokens(recalled))
+		for _, match := range matches {
+			fmt.Printf("   %.2f  %s\n", match.Score, match.Text)
+		}
+		fmt.Println()
+		if *show {
+			continue
+		}
+
+		// Aigentic does not give context functions the user's message, so the recall
+		// happens here and each question gets a context function with its own result
+		agent.ContextFunctions = []aigentic.ContextFunction{
+			func(run *aigentic.AgentRun) (string, error) { return recalled, nil },
+		}
+		response, err := agent.Execute(q)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		fmt.Printf("🤖 %s\n\n", strings.TrimSpace(response))
+	}
+
+	if *show {
+		return
+	}
+	fmt.Printf("🧠 %d memories after the conversation\n\n", mem.Len())
+	fmt.Println("✅ Example completed successfully!")
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Memory is one remembered fact with its vector
+type Memory struct {
+	ID      int
+	Text    string
+	Saved   time.Time
+	Vector  []float32
+	Recalls int // how often it was put into the context
+}
+
+// Match is a recalled memory
+type Match struct {
+	Memory
+	Score float64
+}
+
+// SemanticMemory keeps memories as embeddings and recalls the ones closest to a
+// query. Putting every memory into the context works for a dozen facts; after months
+// of use it costs thousands of tokens per call and buries the fact that matters among
+// ones that do not. Recall is a linear scan, which is fast enough for tens of
+// thousands of memories.
+type SemanticMemory struct {
+	embedder Embedder
+
+	mu       sync.Mutex
+	memories []Memory
+}
+
+func NewSemanticMemory(embedder Embedder) *SemanticMemory {
+	return &SemanticMemory{embedder: embedder}
+}
+
+// Remember embeds and saves facts, in one request to the embedder
+func (m *SemanticMemory) Remember(ctx context.Context, facts ...string) error {
+	vectors, err := m.embedder.Embed(ctx, facts)
+	if err != nil {
+		return fmt.Errorf("embedding memories: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, fact := range facts {
+		m.memories = append(m.memories, Memory{
+			ID:     len(m.memories) + 1,
+			Text:   fact,
+			Saved:  time.Now(),
+			Vector: vectors[i],
+		})
+	}
+	return nil
+}
+
+// Recall returns up to k memories most similar to the query, best first. Memories
+// scoring under minScore are left out even if fewer than k remain, so an unrelated
+// question recalls nothing rather than the least bad k.
+func (m *SemanticMemory) Recall(ctx context.Context, query string, k int, minScore float64) ([]Match, error) {
+	vectors, err := m.embedder.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("embedding query: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var matches []Match
+	for _, mem := range m.memories {
+		if score := cosine(vectors[0], mem.Vector); score >= minScore {
+			matches = append(matches, Match{Memory: mem, Score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	matches = matches[:min(k, len(matches))]
+	for _, match := range matches {
+		m.memories[match.ID-1].Recalls++
+	}
+	return matches, nil
+}
+
+func (m *SemanticMemory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.memories)
+}
+
+// All returns every memory, oldest first
+func (m *SemanticMemory) All() []Memory {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Memory(nil), m.memories...)
+}
+
+// Format renders recalled memories for the context, with their scores so the model
+// can weigh a weak match accordingly
+func Format(matches []Match) string {
+	if len(matches) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("## Relevant memories\n")
+	for _, match := range matches {
+		fmt.Fprintf(&sb, "- %s (relevance %.2f)\n", match.Text, match.Score)
+	}
+	return sb.String()
+}
+
+func cosine(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
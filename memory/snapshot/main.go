@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic-examples/memory/store"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func newAssistant(memories *store.Store, sessionID string) aigentic.Agent {
+	session := aigentic.NewSession(context.Background())
+	session.ID = sessionID
+	return aigentic.Agent{
+		Model:        openai.NewModel("gpt-4o-mini", getAPIKey()),
+		Name:         "PersonalAssistant",
+		Description:  "A personal assistant that remembers user preferences and context",
+		Instructions: "You are a personal assistant. Remember user preferences, past interactions, and important information using the update_memory tool. Use one memory per topic.",
+		Session:      session,
+		AgentTools:   []aigentic.AgentTool{store.Tool(memories)},
+	}
+}
+
+func printMemories(memories *store.Store, session string) {
+	entries, err := memories.List(context.Background(), session)
+	if err != nil {
+		log.Fatalf("Failed to read memory: %v", err)
+	}
+	for _, e := range entries {
+		fmt.Printf("   🧠 %-14s %s  (updated %s)\n", e.Name, e.Content, e.UpdatedAt.Local().Format(time.DateTime))
+	}
+}
+
+func exportFile(ctx context.Context, memories *store.Store, session, path string) {
+	snap, err := memories.Export(ctx, session)
+	if err != nil {
+		log.Fatalf("Export failed: %v", err)
+	}
+	if err := store.WriteSnapshot(path, snap); err != nil {
+		log.Fatalf("Export failed: %v", err)
+	}
+	fmt.Printf("📤 Exported %d memories of %s to %s\n", len(snap.Memories), session, path)
+}
+
+func importFile(ctx context.Context, memories *store.Store, session, path string, replace bool) {
+	snap, err := store.ReadSnapshot(path)
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+	n, err := memories.Import(ctx, session, snap, replace)
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+	fmt.Printf("📥 Imported %d of %d memories from %s (exported from %s at %s) into %s\n",
+		n, len(snap.Memories), path, snap.Session, snap.ExportedAt.Local().Format(time.DateTime), session)
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	dbPath := flag.String("db", "", "Memory database to export from or import into")
+	user := flag.String("user", "alice", "User whose session memory is exported or imported")
+	exportPath := flag.String("export", "", "Write the user's memories to this snapshot file and exit")
+	importPath := flag.String("import", "", "Load the user's memories from this snapshot file and exit")
+	replace := flag.Bool("replace", false, "With -import, delete the user's memories first instead of merging")
+	show := flag.Bool("show", false, "Save sample memories directly instead of asking the model")
+	flag.Parse()
+
+	fmt.Println("📦 Memory Snapshots: Export and Import")
+	fmt.Println("======================================")
+	fmt.Println()
+
+	ctx := context.Background()
+	session := "user-" + *user
+
+	// Backup and restore of a real database
+	if *exportPath != "" || *importPath != "" {
+		if *dbPath == "" {
+			log.Fatalf("-export and -import need -db")
+		}
+		memories, err := store.Open(ctx, *dbPath)
+		if err != nil {
+			log.Fatalf("Failed to open memory: %v", err)
+		}
+		defer memories.Close()
+		if *exportPath != "" {
+			exportFile(ctx, memories, session, *exportPath)
+		}
+		if *importPath != "" {
+			importFile(ctx, memories, session, *importPath, *replace)
+			printMemories(memories, session)
+		}
+		return
+	}
+
+	// Otherwise, move a session from one machine to another. Each machine is a
+	// directory with its own memory database.
+	dir, err := os.MkdirTemp("", "aigentic-snapshot-*")
+	if err != nil {
+		log.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	snapshotPath := filepath.Join(dir, "alice-memory.json")
+
+	fmt.Println("💻 Laptop")
+	laptop, err := store.Open(ctx, filepath.Join(dir, "laptop.db"))
+	if err != nil {
+		log.Fatalf("Failed to open memory: %v", err)
+	}
+	defer laptop.Close()
+
+	if *show {
+		for name, content := range map[string]string{
+			"name":     "Alice",
+			"meetings": "Prefers morning meetings",
+			"project":  "Renewable energy project",
+		} {
+			if err := laptop.Save(ctx, session, name, content); err != nil {
+				log.Fatalf("Failed to save memory: %v", err)
+			}
+		}
+	} else {
+		message := "My name is Alice and I prefer morning meetings. I'm working on a project about renewable energy."
+		fmt.Printf("👤 %s\n", message)
+		response, err := newAssistant(laptop, session).Execute(message)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		fmt.Printf("🤖 %s\n", response)
+	}
+	printMemories(laptop, session)
+	exportFile(ctx, laptop, session, snapshotPath)
+	fmt.Println()
+
+	data, err := os.ReadFile(snapshotPath)
+	if err != nil {
+		log.Fatalf("Failed to read snapshot: %v", err)
+	}
+	fmt.Printf("%s\n", data)
+
+	// The new machine's database has nothing for Alice until the snapshot is loaded,
+	// and it is loaded into a session ID of the new machine's choosing
+	fmt.Println("🖥️  New workstation")
+	workstation, err := store.Open(ctx, filepath.Join(dir, "workstation.db"))
+	if err != nil {
+		log.Fatalf("Failed to open memory: %v", err)
+	}
+	defer workstation.Close()
+
+	newSession := "workstation-" + *user
+	importFile(ctx, workstation, newSession, snapshotPath, true)
+	printMemories(workstation, newSession)
+	fmt.Println()
+
+	if !*show {
+		question := "What's my name, what am I working on, and when should you book my meetings?"
+		fmt.Printf("👤 %s\n", question)
+		response, err := newAssistant(workstation, newSession).Execute(question)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		fmt.Printf("🤖 %s\n\n", response)
+	}
+
+	fmt.Println("✅ Example completed successfully!")
+}
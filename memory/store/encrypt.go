@@ -0,0 +1,132 @@
+package store
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// encryptedPrefix marks encrypted content and its format, so plain content from an
+// unencrypted store is reported as such instead of failing to decrypt
+const encryptedPrefix = "aesgcm1:"
+
+// ErrDecrypt is returned when a memory cannot be decrypted: the key is wrong, or the
+// content was changed or moved to another memory after it was written
+var ErrDecrypt = errors.New("memory cannot be decrypted with this key")
+
+// Encrypted wraps a Store so memory content is encrypted with AES-256-GCM before it
+// reaches the database file. The session ID and memory name are bound to each
+// ciphertext as additional data, so content copied into another user's session or
+// under another name does not decrypt.
+//
+// Session IDs, memory names and timestamps are stored in the clear, so names should
+// not hold anything private: use "allergies", not "allergic to peanuts". The other
+// Store methods, such as Export and Sweep, see the stored ciphertext: a snapshot stays
+// encrypted, and can only be read back under the same session ID.
+type Encrypted struct {
+	*Store
+	aead cipher.AEAD
+}
+
+var _ Memories = (*Encrypted)(nil)
+
+// NewEncrypted wraps s with a 32-byte key
+func NewEncrypted(s *Store, key []byte) (*Encrypted, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("memory key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Encrypted{Store: s, aead: aead}, nil
+}
+
+// ParseKey decodes a key in standard base64, as made by "openssl rand -base64 32"
+func ParseKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("memory key is not base64: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("memory key must be 32 bytes, got %d", len(key))
+	}
+	return key, nil
+}
+
+// NewKey returns a random key in the form ParseKey reads
+func NewKey() string {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func additionalData(session, name string) []byte {
+	// The length prefix keeps ("a", "bc") and ("ab", "c") apart
+	return fmt.Appendf(nil, "%d:%s%s", len(session), session, name)
+}
+
+func (e *Encrypted) seal(session, name, content string) string {
+	nonce := make([]byte, e.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		panic(err)
+	}
+	sealed := e.aead.Seal(nonce, nonce, []byte(content), additionalData(session, name))
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed)
+}
+
+func (e *Encrypted) open(session, name, stored string) (string, error) {
+	encoded, ok := strings.CutPrefix(stored, encryptedPrefix)
+	if !ok {
+		return "", fmt.Errorf("memory %q of %s is not encrypted", name, session)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < e.aead.NonceSize() {
+		return "", fmt.Errorf("memory %q of %s: %w", name, session, ErrDecrypt)
+	}
+	nonce, ciphertext := sealed[:e.aead.NonceSize()], sealed[e.aead.NonceSize():]
+	plain, err := e.aead.Open(nil, nonce, ciphertext, additionalData(session, name))
+	if err != nil {
+		return "", fmt.Errorf("memory %q of %s: %w", name, session, ErrDecrypt)
+	}
+	return string(plain), nil
+}
+
+func (e *Encrypted) Save(ctx context.Context, session, name, content string) error {
+	return e.SaveUntil(ctx, session, name, content, time.Time{})
+}
+
+// SaveUntil encrypts content and saves it. Empty content still deletes the memory.
+func (e *Encrypted) SaveUntil(ctx context.Context, session, name, content string, expires time.Time) error {
+	if content == "" {
+		return e.Store.Delete(ctx, session, name)
+	}
+	return e.Store.SaveUntil(ctx, session, name, e.seal(session, name, content), expires)
+}
+
+// List returns the memories of a session with their content decrypted. A memory
+// that does not decrypt fails the whole call, rather than being left out quietly.
+func (e *Encrypted) List(ctx context.Context, session string) ([]Entry, error) {
+	entries, err := e.Store.List(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		if entries[i].Content, err = e.open(session, entries[i].Name, entries[i].Content); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
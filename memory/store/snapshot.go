@@ -0,0 +1,124 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// SnapshotFormat is the version of the snapshot file layout. Import refuses files
+// with a different version instead of guessing at them.
+const SnapshotFormat = 1
+
+// Snapshot is every memory of one session, in a JSON file that can be copied to
+// another machine for backup or migration
+type Snapshot struct {
+	Format     int              `json:"format"`
+	Session    string           `json:"session"`
+	ExportedAt time.Time        `json:"exported_at"`
+	Memories   []SnapshotMemory `json:"memories"`
+}
+
+type SnapshotMemory struct {
+	Name      string     `json:"name"`
+	Content   string     `json:"content"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Export returns a snapshot of every memory of a session
+func (s *Store) Export(ctx context.Context, session string) (*Snapshot, error) {
+	entries, err := s.List(ctx, session)
+	if err != nil {
+		return nil, fmt.Errorf("exporting %s: %w", session, err)
+	}
+	snap := &Snapshot{Format: SnapshotFormat, Session: session, ExportedAt: time.Now().UTC(), Memories: []SnapshotMemory{}}
+	for _, e := range entries {
+		m := SnapshotMemory{Name: e.Name, Content: e.Content, CreatedAt: e.CreatedAt, UpdatedAt: e.UpdatedAt}
+		if !e.ExpiresAt.IsZero() {
+			m.ExpiresAt = &e.ExpiresAt
+		}
+		snap.Memories = append(snap.Memories, m)
+	}
+	return snap, nil
+}
+
+// Import loads a snapshot into a session, which need not have the ID it was exported
+// from. With replace, the session's memories are deleted first, so it ends up exactly
+// as exported. Otherwise the snapshot is merged: a memory already in the session is
+// kept if it was updated more recently than the one in the snapshot. Import reports
+// how many memories it wrote. Everything is written in one transaction, so a failed
+// import leaves the session as it was.
+func (s *Store) Import(ctx context.Context, session string, snap *Snapshot, replace bool) (int, error) {
+	if snap.Format != SnapshotFormat {
+		return 0, fmt.Errorf("snapshot format %d is not supported, expected %d", snap.Format, SnapshotFormat)
+	}
+
+	existing := map[string]Entry{}
+	if !replace {
+		entries, err := s.List(ctx, session)
+		if err != nil {
+			return 0, err
+		}
+		for _, e := range entries {
+			existing[e.Name] = e
+		}
+	}
+
+	var sql strings.Builder
+	sql.WriteString("BEGIN;\n")
+	if replace {
+		fmt.Fprintf(&sql, "DELETE FROM memories WHERE session = %s;\n", quote(session))
+	}
+	written := 0
+	for _, m := range snap.Memories {
+		if m.Name == "" || m.Content == "" {
+			return 0, fmt.Errorf("snapshot has a memory with no name or content")
+		}
+		if e, ok := existing[m.Name]; ok && !e.UpdatedAt.Before(m.UpdatedAt) {
+			continue
+		}
+		expiry := ""
+		if m.ExpiresAt != nil {
+			expiry = m.ExpiresAt.UTC().Format(expiryFormat)
+		}
+		fmt.Fprintf(&sql, `INSERT OR REPLACE INTO memories (session, name, content, created_at, updated_at, expires_at)
+VALUES (%s, %s, %s, %s, %s, %s);
+`, quote(session), quote(m.Name), quote(m.Content),
+			quote(m.CreatedAt.UTC().Format(time.RFC3339Nano)), quote(m.UpdatedAt.UTC().Format(time.RFC3339Nano)), quote(expiry))
+		written++
+	}
+	sql.WriteString("COMMIT;")
+
+	if _, err := s.exec(ctx, sql.String()); err != nil {
+		return 0, fmt.Errorf("importing into %s: %w", session, err)
+	}
+	return written, nil
+}
+
+// WriteSnapshot saves a snapshot as indented JSON. Memories can hold personal
+// details, so the file is readable by its owner only.
+func WriteSnapshot(path string, snap *Snapshot) error {
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// ReadSnapshot loads a snapshot written by WriteSnapshot
+func ReadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("reading snapshot %s: %w", path, err)
+	}
+	return &snap, nil
+}
@@ -0,0 +1,232 @@
+// Package store keeps agent memory in a SQLite file, so what an agent remembers
+// survives a restart. Memories are kept per session ID and work like the ones made
+// by tools.NewMemoryTool: named markdown entries that are all added to the context.
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// schema is created when a store is opened. expires_at is empty for memories that do
+// not expire, and otherwise in expiryFormat, so it can be compared as text.
+const schema = `
+CREATE TABLE IF NOT EXISTS memories (
+	session    TEXT NOT NULL,
+	name       TEXT NOT NULL,
+	content    TEXT NOT NULL,
+	created_at TEXT NOT NULL,
+	updated_at TEXT NOT NULL,
+	expires_at TEXT NOT NULL DEFAULT '',
+	PRIMARY KEY (session, name)
+);`
+
+// migrations bring files made by earlier versions of the store up to date. Each is
+// run when the column it adds is missing.
+var migrations = []struct{ column, sql string }{
+	{"expires_at", "ALTER TABLE memories ADD COLUMN expires_at TEXT NOT NULL DEFAULT '';"},
+}
+
+// expiryFormat is fixed width, unlike RFC3339Nano, so expiry times sort as text
+const expiryFormat = "2006-01-02T15:04:05Z"
+
+var ErrStoreClosed = errors.New("memory store is closed")
+
+// Entry is one named memory
+type Entry struct {
+	Session   string
+	Name      string
+	Content   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ExpiresAt time.Time // zero if the memory does not expire
+}
+
+// Store is a memory database file. Every call runs its own sqlite3 process and
+// writes are committed when the call returns, so nothing is lost if the program
+// stops between calls.
+type Store struct {
+	path string
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// Open opens the memory database at path, creating the file and its table if needed
+func Open(ctx context.Context, path string) (*Store, error) {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		return nil, fmt.Errorf("the sqlite3 command line shell is required: %w", err)
+	}
+	s := &Store{path: path}
+	if _, err := s.exec(ctx, schema); err != nil {
+		return nil, fmt.Errorf("creating schema in %s: %w", path, err)
+	}
+	for _, m := range migrations {
+		out, err := s.exec(ctx, fmt.Sprintf("SELECT COUNT(*) FROM pragma_table_info('memories') WHERE name = %s;", quote(m.column)))
+		if err != nil {
+			return nil, fmt.Errorf("checking schema in %s: %w", path, err)
+		}
+		if strings.TrimSpace(out) == "0" {
+			if _, err := s.exec(ctx, m.sql); err != nil {
+				return nil, fmt.Errorf("adding %s to %s: %w", m.column, path, err)
+			}
+		}
+	}
+	return s, nil
+}
+
+// Path returns the database file
+func (s *Store) Path() string {
+	return s.path
+}
+
+// exec runs SQL read from stdin, so long memories do not hit argument limits
+func (s *Store) exec(ctx context.Context, sql string, args ...string) (string, error) {
+	s.mu.Lock()
+	closed := s.closed
+	s.mu.Unlock()
+	if closed {
+		return "", ErrStoreClosed
+	}
+
+	cmd := exec.CommandContext(ctx, "sqlite3", append(append([]string{"-bail"}, args...), s.path)...)
+	cmd.Stdin = strings.NewReader(sql)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("sqlite3: %s", msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+// quote makes a SQL string literal
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// Save stores content under name in a session, replacing what was there. Empty
+// content deletes the memory, the same as the update_memory tool.
+func (s *Store) Save(ctx context.Context, session, name, content string) error {
+	return s.SaveUntil(ctx, session, name, content, time.Time{})
+}
+
+// SaveUntil is Save for a memory that expires, such as "I'm in Lisbon this week". It
+// is removed by the first Sweep after expires; a zero time means it never expires.
+// Saving again replaces the expiry, so a temporary memory can be made permanent.
+func (s *Store) SaveUntil(ctx context.Context, session, name, content string, expires time.Time) error {
+	if name == "" {
+		return fmt.Errorf("memory name is empty")
+	}
+	if content == "" {
+		return s.Delete(ctx, session, name)
+	}
+	expiry := ""
+	if !expires.IsZero() {
+		expiry = expires.UTC().Format(expiryFormat)
+	}
+	now := quote(time.Now().UTC().Format(time.RFC3339Nano))
+	sql := fmt.Sprintf(`INSERT INTO memories (session, name, content, created_at, updated_at, expires_at)
+VALUES (%s, %s, %s, %s, %s, %s)
+ON CONFLICT(session, name) DO UPDATE SET content = excluded.content, updated_at = excluded.updated_at,
+	expires_at = excluded.expires_at;`,
+		quote(session), quote(name), quote(content), now, now, quote(expiry))
+	if _, err := s.exec(ctx, sql); err != nil {
+		return fmt.Errorf("saving memory %q: %w", name, err)
+	}
+	return nil
+}
+
+// Delete removes a memory. Deleting a memory that does not exist is not an error.
+func (s *Store) Delete(ctx context.Context, session, name string) error {
+	_, err := s.exec(ctx, fmt.Sprintf("DELETE FROM memories WHERE session = %s AND name = %s;", quote(session), quote(name)))
+	return err
+}
+
+type row struct {
+	Session   string `json:"session"`
+	Name      string `json:"name"`
+	Content   string `json:"content"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+func (s *Store) query(ctx context.Context, sql string) ([]Entry, error) {
+	out, err := s.exec(ctx, sql, "-json")
+	if err != nil {
+		return nil, err
+	}
+	// The shell prints nothing at all when there are no rows
+	if strings.TrimSpace(out) == "" {
+		return nil, nil
+	}
+	var rows []row
+	if err := json.Unmarshal([]byte(out), &rows); err != nil {
+		return nil, fmt.Errorf("reading sqlite3 output: %w", err)
+	}
+	entries := make([]Entry, len(rows))
+	for i, r := range rows {
+		entries[i] = Entry{Session: r.Session, Name: r.Name, Content: r.Content}
+		entries[i].CreatedAt, _ = time.Parse(time.RFC3339Nano, r.CreatedAt)
+		entries[i].UpdatedAt, _ = time.Parse(time.RFC3339Nano, r.UpdatedAt)
+		if r.ExpiresAt != "" {
+			entries[i].ExpiresAt, _ = time.Parse(expiryFormat, r.ExpiresAt)
+		}
+	}
+	return entries, nil
+}
+
+// List returns the memories of a session in name order. Expired memories are
+// included until they are swept.
+func (s *Store) List(ctx context.Context, session string) ([]Entry, error) {
+	return s.query(ctx, fmt.Sprintf("SELECT * FROM memories WHERE session = %s ORDER BY name;", quote(session)))
+}
+
+// Sessions returns the IDs of the sessions that have memories
+func (s *Store) Sessions(ctx context.Context) ([]string, error) {
+	entries, err := s.query(ctx, "SELECT DISTINCT session FROM memories ORDER BY session;")
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(entries))
+	for i, e := range entries {
+		ids[i] = e.Session
+	}
+	return ids, nil
+}
+
+// Sweep deletes the memories of every session that expired at or before now, and
+// returns them. Run it before each agent run so stale memories never reach the context.
+func (s *Store) Sweep(ctx context.Context, now time.Time) ([]Entry, error) {
+	cutoff := quote(now.UTC().Format(expiryFormat))
+	where := fmt.Sprintf("expires_at != '' AND expires_at <= %s", cutoff)
+	// Both statements run in one transaction, so a memory saved in between cannot be
+	// deleted without being reported
+	entries, err := s.query(ctx, fmt.Sprintf(`BEGIN;
+SELECT * FROM memories WHERE %s ORDER BY session, name;
+DELETE FROM memories WHERE %s;
+COMMIT;`, where, where))
+	if err != nil {
+		return nil, fmt.Errorf("sweeping expired memories: %w", err)
+	}
+	return entries, nil
+}
+
+// Close closes the store. There is no connection to release, but later calls fail
+// with ErrStoreClosed, the same as with a closed database handle.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
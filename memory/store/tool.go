@@ -0,0 +1,117 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic/ai"
+)
+
+// Format renders memories the way tools.NewMemoryTool adds them to the context, with
+// the last day of memories that expire
+func Format(entries []Entry) string {
+	parts := make([]string, len(entries))
+	for i, e := range entries {
+		parts[i] = fmt.Sprintf("## Memory: %s\n%s", e.Name, e.Content)
+		if !e.ExpiresAt.IsZero() {
+			parts[i] = fmt.Sprintf("## Memory: %s (until %s)\n%s", e.Name, LastDay(e.ExpiresAt).Format(time.DateOnly), e.Content)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// EndOfDay returns the time a memory kept through day expires: the start of the next
+// day, in day's location
+func EndOfDay(day time.Time) time.Time {
+	y, m, d := day.Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, day.Location())
+}
+
+// LastDay is the inverse of EndOfDay: the last local day a memory is kept
+func LastDay(expires time.Time) time.Time {
+	return expires.Local().Add(-time.Nanosecond)
+}
+
+// Memories is what Tool needs from a memory store. Store has it, and so do wrappers
+// around a Store, such as Encrypted.
+type Memories interface {
+	SaveUntil(ctx context.Context, session, name, content string, expires time.Time) error
+	List(ctx context.Context, session string) ([]Entry, error)
+}
+
+// Tool is a drop-in replacement for tools.NewMemoryTool that keeps memories in the
+// store. It has the same update_memory tool and adds every memory of the run's session
+// to the context, so an agent written for the in-memory tool works unchanged. Give the
+// session a stable ID to find its memories again after a restart.
+//
+// The tool also takes an optional last day for memories that should not be kept
+// forever; Store.Sweep removes them once that day is over. The model needs today's
+// date to use it, so give it one in the instructions or a context function.
+func Tool(s Memories) aigentic.AgentTool {
+	// The tool is built by hand rather than with aigentic.NewTool, because typed tools
+	// are not given the run, and the run is needed for its session ID
+	result := func(text string, isError bool) *ai.ToolResult {
+		return &ai.ToolResult{Content: []ai.ToolContent{{Type: "text", Content: text}}, Error: isError}
+	}
+
+	return aigentic.AgentTool{
+		Name:        "update_memory",
+		Description: "Update or delete memory entries. Set memory_content to empty string to delete.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"memory_name": map[string]interface{}{
+					"type":        "string",
+					"description": "Name/identifier for this memory entry",
+				},
+				"memory_content": map[string]interface{}{
+					"type":        "string",
+					"description": "Markdown content (empty string to delete)",
+				},
+				"keep_until": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional last day to keep this memory, as YYYY-MM-DD, for things that are only true for a while (\"this week only\"). Leave empty to keep it.",
+				},
+			},
+			"required": []string{"memory_name", "memory_content"},
+		},
+		ContextFunctions: []aigentic.ContextFunction{
+			func(run *aigentic.AgentRun) (string, error) {
+				entries, err := s.List(context.Background(), run.Session().ID)
+				if err != nil {
+					return "", err
+				}
+				return Format(entries), nil
+			},
+		},
+		NewExecute: func(run *aigentic.AgentRun, validated aigentic.ValidationResult) (*ai.ToolResult, error) {
+			args := validated.Values.(map[string]interface{})
+			name, _ := args["memory_name"].(string)
+			content, _ := args["memory_content"].(string)
+			keepUntil, _ := args["keep_until"].(string)
+
+			var expires time.Time
+			if keepUntil != "" {
+				day, err := time.ParseInLocation(time.DateOnly, keepUntil, time.Local)
+				if err != nil {
+					return result("Error: keep_until must be a date in YYYY-MM-DD format", true), nil
+				}
+				expires = EndOfDay(day)
+			}
+
+			if err := s.SaveUntil(context.Background(), run.Session().ID, name, content, expires); err != nil {
+				return result(fmt.Sprintf("Error: %v", err), true), nil
+			}
+			switch {
+			case content == "":
+				return result(fmt.Sprintf("Memory '%s' deleted", name), false), nil
+			case keepUntil != "":
+				return result(fmt.Sprintf("Memory '%s' updated, kept until the end of %s", name, keepUntil), false), nil
+			}
+			return result(fmt.Sprintf("Memory '%s' updated", name), false), nil
+		},
+	}
+}
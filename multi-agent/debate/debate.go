@@ -0,0 +1,186 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+)
+
+// Speaker answers a prompt. In the example it runs an agent; with -show it returns
+// scripted text, so the debate logic runs without a model.
+type Speaker func(prompt string) (string, error)
+
+// Side is one of the two positions in a debate
+type Side string
+
+const (
+	Pro Side = "pro"
+	Con Side = "con"
+)
+
+// The events of a debate implement aigentic.Event, so they can be handled in the same
+// type switch as an agent run's events. ID is the debate's ID.
+
+// RoundEvent is sent when a round starts
+type RoundEvent struct {
+	DebateID string
+	Round    int
+}
+
+func (e *RoundEvent) ID() string { return e.DebateID }
+
+// ArgumentEvent is sent for each argument, in the order they are made
+type ArgumentEvent struct {
+	DebateID string
+	Round    int
+	Side     Side
+	Text     string
+}
+
+func (e *ArgumentEvent) ID() string { return e.DebateID }
+
+// ScoreEvent is sent when the judge has scored a round. Scores are from 1 to 10.
+type ScoreEvent struct {
+	DebateID string
+	Round    int
+	Pro, Con int
+	Reason   string
+}
+
+func (e *ScoreEvent) ID() string { return e.DebateID }
+
+// VerdictEvent is the last event of a debate that finished
+type VerdictEvent struct {
+	DebateID           string
+	ProTotal, ConTotal int
+	Winner             Side // empty for a tie
+	Conclusion         string
+}
+
+func (e *VerdictEvent) ID() string { return e.DebateID }
+
+// Debate has two speakers argue a motion for a number of rounds, with a judge scoring
+// each round and then the whole debate. Each speaker sees the transcript so far, so it
+// can answer the other side's last argument.
+type Debate struct {
+	ID       string
+	Motion   string
+	Rounds   int
+	Pro, Con Speaker
+	Judge    Speaker
+}
+
+type turn struct {
+	round int
+	side  Side
+	text  string
+}
+
+func transcript(turns []turn) string {
+	if len(turns) == 0 {
+		return "(no arguments yet)"
+	}
+	var sb strings.Builder
+	for _, t := range turns {
+		fmt.Fprintf(&sb, "Round %d, %s:\n%s\n\n", t.round, strings.ToUpper(string(t.side)), t.text)
+	}
+	return sb.String()
+}
+
+// Start runs the debate in the background. The channel is closed after the verdict,
+// or after an *aigentic.ErrorEvent if a speaker fails.
+func (d *Debate) Start() <-chan aigentic.Event {
+	events := make(chan aigentic.Event)
+	go func() {
+		defer close(events)
+		if err := d.run(events); err != nil {
+			events <- &aigentic.ErrorEvent{RunID: d.ID, Err: err}
+		}
+	}()
+	return events
+}
+
+func (d *Debate) run(events chan<- aigentic.Event) error {
+	var turns []turn
+	var proTotal, conTotal int
+
+	for round := 1; round <= d.Rounds; round++ {
+		events <- &RoundEvent{DebateID: d.ID, Round: round}
+
+		for _, side := range []Side{Pro, Con} {
+			speak, stance := d.Pro, "FOR"
+			if side == Con {
+				speak, stance = d.Con, "AGAINST"
+			}
+			prompt := fmt.Sprintf("Motion: %s\nYou argue %s the motion. This is round %d of %d.\n\nTranscript so far:\n%s\nMake your argument for this round.",
+				d.Motion, stance, round, d.Rounds, transcript(turns))
+			text, err := speak(prompt)
+			if err != nil {
+				return fmt.Errorf("round %d, %s: %w", round, side, err)
+			}
+			text = strings.TrimSpace(text)
+			turns = append(turns, turn{round, side, text})
+			events <- &ArgumentEvent{DebateID: d.ID, Round: round, Side: side, Text: text}
+		}
+
+		reply, err := d.Judge(fmt.Sprintf(`Motion: %s
+
+Transcript so far:
+%s
+Score round %d only. Reply with JSON only: {"pro": <1-10>, "con": <1-10>, "reason": "<one sentence>"}`, d.Motion, transcript(turns), round))
+		if err != nil {
+			return fmt.Errorf("judging round %d: %w", round, err)
+		}
+		var score struct {
+			Pro    int    `json:"pro"`
+			Con    int    `json:"con"`
+			Reason string `json:"reason"`
+		}
+		if err := parseJSON(reply, &score); err != nil {
+			return fmt.Errorf("judging round %d: %w", round, err)
+		}
+		score.Pro, score.Con = clamp(score.Pro), clamp(score.Con)
+		proTotal += score.Pro
+		conTotal += score.Con
+		events <- &ScoreEvent{DebateID: d.ID, Round: round, Pro: score.Pro, Con: score.Con, Reason: score.Reason}
+	}
+
+	// The winner comes from the scores, so the judge's conclusion cannot contradict them
+	winner := Side("")
+	switch {
+	case proTotal > conTotal:
+		winner = Pro
+	case conTotal > proTotal:
+		winner = Con
+	}
+	outcome := "The debate is a tie."
+	if winner != "" {
+		outcome = fmt.Sprintf("The %s side won on points, %d to %d.", winner, max(proTotal, conTotal), min(proTotal, conTotal))
+	}
+	conclusion, err := d.Judge(fmt.Sprintf("Motion: %s\n\nTranscript:\n%s\n%s Write a conclusion of two or three sentences: which arguments decided the debate, and what a reader should take away.",
+		d.Motion, transcript(turns), outcome))
+	if err != nil {
+		return fmt.Errorf("writing the conclusion: %w", err)
+	}
+	events <- &VerdictEvent{DebateID: d.ID, ProTotal: proTotal, ConTotal: conTotal, Winner: winner, Conclusion: strings.TrimSpace(conclusion)}
+	return nil
+}
+
+func clamp(score int) int {
+	return min(max(score, 1), 10)
+}
+
+// parseJSON reads the JSON object in a model's reply, ignoring any text or code fence
+// around it
+func parseJSON(reply string, v any) error {
+	start, end := strings.Index(reply, "{"), strings.LastIndex(reply, "}")
+	if start < 0 || end < start {
+		return fmt.Errorf("no JSON object in reply: %q", reply)
+	}
+	if err := json.Unmarshal([]byte(reply[start:end+1]), v); err != nil {
+		return fmt.Errorf("reading reply: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// samples are what each agent says in a three-round debate on the default motion, for
+// -show
+var samples = map[string][]string{
+	"pro": {
+		"Remote work widens the hiring pool from one city to every time zone we can overlap with, and it gives engineers the long, quiet stretches deep work needs. Surveys from GitLab and Stack Overflow show most developers rate focus time above anything an office offers.",
+		"My opponent says mentoring suffers, but that is a problem of habits, not location. Teams that pair over video, write things down and review code in the open mentor juniors better than offices where knowledge lives in hallway chats nobody recorded.",
+		"Defaults matter because exceptions are cheap. A remote-default team can still meet in person for planning weeks; an office-default team cannot hire the engineer in Porto. The costs my opponent lists are real, but they can be managed; the talent lost to an office cannot.",
+	},
+	"con": {
+		"Software is built by teams, not individuals. New hires learn fastest by overhearing how seniors debug and decide, and remote teams lose that. Microsoft's own study of 61,000 employees found remote work made collaboration networks more siloed.",
+		"Writing everything down sounds good, but it is slow, and most teams do not do it. The cost falls on juniors, who do not know what to ask. A default should work for the typical team, not the best-run one.",
+		"My opponent now concedes the costs and argues they can be managed. But managing them takes planning weeks, travel budgets and documentation discipline, which is the cost of remote work. A hybrid default with two shared office days gets most of the focus time without the silos.",
+	},
+	"judge": {
+		`{"pro": 7, "con": 7, "reason": "Both sides opened with evidence; hiring reach and collaboration silos are equally strong."}`,
+		`{"pro": 8, "con": 6, "reason": "Pro answered the mentoring point directly; Con's reply relied on how teams usually behave rather than what they can do."}`,
+		`{"pro": 7, "con": 8, "reason": "Con turned Pro's concession into a cost argument and offered a concrete alternative."}`,
+		"Pro won on the strength of its answer to the mentoring objection and the argument that defaults should be cheap to break. Con's hybrid proposal was the most practical idea in the debate, and a reader should take away that remote work is a sound default only for teams willing to pay for documentation and regular in-person time.",
+	},
+}
+
+// scripted returns a Speaker that replies with the given lines in turn
+func scripted(lines []string) Speaker {
+	next := 0
+	return func(prompt string) (string, error) {
+		if next >= len(lines) {
+			return "", fmt.Errorf("no more sample replies")
+		}
+		next++
+		return lines[next-1], nil
+	}
+}
+
+func wrap(text string, width int, indent string) string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return indent + strings.Join(lines, "\n"+indent)
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	motion := flag.String("motion", "Remote work should be the default for software teams", "The motion to debate")
+	rounds := flag.Int("rounds", 3, "Number of rounds")
+	show := flag.Bool("show", false, "Replay a sample debate without calling the model")
+	flag.Parse()
+
+	fmt.Println("⚖️  Aigentic Structured Debate Example")
+	fmt.Println("=====================================")
+	fmt.Println()
+
+	debate := &Debate{ID: uuid.NewString(), Motion: *motion, Rounds: *rounds}
+	if *show {
+		debate.Rounds = len(samples["pro"])
+		debate.Pro, debate.Con, debate.Judge = scripted(samples["pro"]), scripted(samples["con"]), scripted(samples["judge"])
+	} else {
+		model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+		proponent := aigentic.Agent{
+			Model:        model,
+			Name:         "Proponent",
+			Description:  "Argues for the motion",
+			Instructions: "You are a skilled debater arguing FOR the motion. Make one argument per round in at most 80 words. Answer the other side's last argument before adding new ones. Use facts, not rhetoric.",
+		}
+		opponent := aigentic.Agent{
+			Model:        model,
+			Name:         "Opponent",
+			Description:  "Argues against the motion",
+			Instructions: "You are a skilled debater arguing AGAINST the motion. Make one argument per round in at most 80 words. Answer the other side's last argument before adding new ones. Use facts, not rhetoric.",
+		}
+		judge := aigentic.Agent{
+			Model:        model,
+			Name:         "Judge",
+			Description:  "Scores a debate impartially",
+			Instructions: "You are an impartial debate judge. Score arguments on evidence, logic and how well they answer the other side, not on whether you agree with them. Follow the requested output format exactly.",
+		}
+		debate.Pro, debate.Con, debate.Judge = proponent.Execute, opponent.Execute, judge.Execute
+	}
+
+	fmt.Printf("Motion: %s\n", debate.Motion)
+
+	failed := false
+	for ev := range debate.Start() {
+		switch e := ev.(type) {
+		case *RoundEvent:
+			fmt.Printf("\n━━━ Round %d of %d ━━━\n", e.Round, debate.Rounds)
+		case *ArgumentEvent:
+			icon := "🟢 PRO"
+			if e.Side == Con {
+				icon = "🔴 CON"
+			}
+			fmt.Printf("\n%s\n%s\n", icon, wrap(e.Text, 88, "   "))
+		case *ScoreEvent:
+			fmt.Printf("\n🧑‍⚖️ Round %d: pro %d, con %d\n%s\n", e.Round, e.Pro, e.Con, wrap(e.Reason, 88, "   "))
+		case *VerdictEvent:
+			fmt.Printf("\n━━━ Verdict ━━━\n")
+			fmt.Printf("Total: pro %d, con %d\n", e.ProTotal, e.ConTotal)
+			if e.Winner == "" {
+				fmt.Println("🤝 Tie")
+			} else {
+				fmt.Printf("🏆 Winner: %s\n", e.Winner)
+			}
+			fmt.Printf("\n%s\n", wrap(e.Conclusion, 88, ""))
+		case *aigentic.ErrorEvent:
+			log.Printf("Debate failed: %v", e.Err)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+	fmt.Println()
+
+	fmt.Println("✅ Example completed successfully!")
+}
@@ -3,6 +3,7 @@ module github.com/nexxia-ai/aigentic-examples/multi-agent
 go 1.24.3
 
 require (
+	github.com/google/uuid v1.6.0
 	github.com/nexxia-ai/aigentic v0.8.0
 	github.com/nexxia-ai/aigentic-openai v0.3.1
 )
@@ -11,7 +12,6 @@ require (
 	github.com/bahlo/generic-list-go v0.2.0 // indirect
 	github.com/buger/jsonparser v1.1.1 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/google/uuid v1.6.0 // indirect
 	github.com/invopop/jsonschema v0.13.0 // indirect
 	github.com/mailru/easyjson v0.7.7 // indirect
 	github.com/mark3labs/mcp-go v0.37.0 // indirect
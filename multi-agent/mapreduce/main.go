@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+const sampleReport = `Customers love the coffee itself: quality is the most mentioned theme and almost always positive, along with fast heat-up and easy setup.
+The problems are about the machine lasting and the company standing behind it. Four reviews report hardware failures or damage (#4, #11, #19, plus the frother in #2), and every one of the four customer support mentions is negative, with waits of ten days to a month.
+Recommended actions: investigate the pump and frother failures, set a response-time target for warranty claims, and consider a larger water tank, which two mixed reviews ask for.`
+
+func formatBatch(batch []Review) string {
+	var sb strings.Builder
+	for _, r := range batch {
+		fmt.Fprintf(&sb, "Review #%d (%d stars): %s\n", r.ID, r.Rating, r.Text)
+	}
+	return sb.String()
+}
+
+// agentMapper analyses each batch with a fresh agent, so batches never see each
+// other's reviews or conversation
+func agentMapper(model *ai.Model) Mapper {
+	return func(batch []Review) ([]Analysis, error) {
+		agent := aigentic.Agent{
+			Model:       model,
+			Name:        "ReviewAnalyst",
+			Description: "Reads customer reviews and tags their sentiment and themes",
+			Instructions: `For each review, decide its sentiment (positive, mixed or negative) and list the one to three product themes it mentions, as short lowercase nouns such as "coffee quality", "customer support" or "water tank".
+Reply with JSON only: {"analyses": [{"review_id": 1, "sentiment": "positive", "themes": ["coffee quality"]}]}`,
+		}
+		response, err := agent.Execute(formatBatch(batch))
+		if err != nil {
+			return nil, err
+		}
+		start, end := strings.Index(response, "{"), strings.LastIndex(response, "}")
+		if start < 0 || end < start {
+			return nil, fmt.Errorf("no JSON in reply: %q", response)
+		}
+		var reply struct {
+			Analyses []Analysis `json:"analyses"`
+		}
+		if err := json.Unmarshal([]byte(response[start:end+1]), &reply); err != nil {
+			return nil, fmt.Errorf("reading reply: %w", err)
+		}
+		return reply.Analyses, nil
+	}
+}
+
+// sampleMapper returns the sample analyses after a delay, like a model would. The
+// batch with review 7 fails the first time, like a rate-limited request.
+func sampleMapper() Mapper {
+	var mu sync.Mutex
+	failed := false
+	return func(batch []Review) ([]Analysis, error) {
+		time.Sleep(time.Duration(150+50*(batch[0].ID%3)) * time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		for _, r := range batch {
+			if r.ID == 7 && !failed {
+				failed = true
+				return nil, errors.New("429 Too Many Requests")
+			}
+		}
+		var analyses []Analysis
+		for _, r := range batch {
+			a := sampleAnalyses[r.ID]
+			a.ReviewID = r.ID
+			a.Themes = append([]string(nil), a.Themes...)
+			analyses = append(analyses, a)
+		}
+		return analyses, nil
+	}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	workers := flag.Int("workers", 4, "Batches analysed at the same time")
+	batchSize := flag.Int("batch", 2, "Reviews per batch")
+	retries := flag.Int("retries", 2, "Times a failed batch is retried")
+	show := flag.Bool("show", false, "Use sample analyses and report without calling the model")
+	flag.Parse()
+
+	fmt.Println("🗂️  Aigentic Map-Reduce Example")
+	fmt.Println("==============================")
+	fmt.Println()
+
+	var model *ai.Model
+	fanOut := &FanOut{Workers: *workers, BatchSize: max(*batchSize, 1), Retries: *retries, Backoff: time.Second}
+	if *show {
+		fanOut.Map, fanOut.Backoff = sampleMapper(), 100*time.Millisecond
+	} else {
+		model = openai.NewModel("gpt-4o-mini", getAPIKey())
+		fanOut.Map = agentMapper(model)
+	}
+
+	start := time.Now()
+	fanOut.OnProgress = func(p Progress) {
+		at := time.Since(start).Seconds()
+		switch {
+		case p.Started:
+			fmt.Printf("   %5.2fs ▶️  batch %2d attempt %d   (%d running)\n", at, p.Batch, p.Attempt, p.InFlight)
+		case p.Err != nil:
+			fmt.Printf("   %5.2fs ⚠️  batch %2d failed: %v\n", at, p.Batch, p.Err)
+		default:
+			fmt.Printf("   %5.2fs ✅ batch %2d done in %.2fs\n", at, p.Batch, p.Elapsed.Seconds())
+		}
+	}
+
+	fmt.Printf("🗺️  Map: %d reviews in batches of %d, %d workers\n", len(reviews), fanOut.BatchSize, fanOut.Workers)
+	analyses, stats, err := fanOut.Run(reviews)
+	if err != nil {
+		log.Fatalf("Map failed: %v", err)
+	}
+	fmt.Printf("\n%d batches, %d attempts, at most %d running at once, %.2fs\n\n", stats.Batches, stats.Attempts, stats.MaxInFlight, stats.Elapsed.Seconds())
+
+	summary := Aggregate(reviews, analyses)
+	fmt.Printf("🧮 Aggregated:\n%s\n", summary.Format())
+
+	fmt.Println("📝 Reduce:")
+	report := sampleReport
+	if !*show {
+		reducer := aigentic.Agent{
+			Model:       model,
+			Name:        "ReportWriter",
+			Description: "Turns review analysis into a report for the product team",
+			Instructions: `Write a report of at most 150 words for the product team: what customers like, what they complain about, and two or three recommended actions.
+The counts you are given are exact; use them as they are and cite review numbers as evidence.`,
+		}
+		report, err = reducer.Execute(fmt.Sprintf("Analysis of the reviews:\n%s\nThe reviews:\n%s", summary.Format(), formatBatch(reviews)))
+		if err != nil {
+			log.Fatalf("Reduce failed: %v", err)
+		}
+	}
+	fmt.Printf("%s\n\n", strings.TrimSpace(report))
+
+	fmt.Println("✅ Example completed successfully!")
+}
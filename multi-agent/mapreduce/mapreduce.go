@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Analysis is a worker's reading of one review
+type Analysis struct {
+	ReviewID  int      `json:"review_id"`
+	Sentiment string   `json:"sentiment"` // positive, mixed or negative
+	Themes    []string `json:"themes"`
+}
+
+// Mapper analyses one batch of reviews. Each call is an independent agent run.
+type Mapper func(batch []Review) ([]Analysis, error)
+
+// Progress is reported as batches start and finish
+type Progress struct {
+	Batch    int
+	Attempt  int
+	Started  bool // false when the attempt has finished
+	InFlight int  // batches running, including this one if it started
+	Elapsed  time.Duration
+	Err      error
+}
+
+// FanOut splits reviews into batches and maps them on at most Workers goroutines.
+// A batch that fails, or whose result does not cover every review in it, is retried
+// up to Retries times, after waiting Backoff times the attempt number without holding
+// a worker; the other batches carry on meanwhile.
+type FanOut struct {
+	Workers   int
+	BatchSize int
+	Retries   int
+	Backoff   time.Duration
+	Map       Mapper
+
+	OnProgress func(Progress)
+}
+
+// Stats describes a fan-out once it has finished
+type Stats struct {
+	Batches     int
+	Attempts    int
+	MaxInFlight int
+	Elapsed     time.Duration
+}
+
+// Run maps every review and returns the analyses in review order
+func (f *FanOut) Run(reviews []Review) ([]Analysis, Stats, error) {
+	var batches [][]Review
+	for start := 0; start < len(reviews); start += f.BatchSize {
+		batches = append(batches, reviews[start:min(start+f.BatchSize, len(reviews))])
+	}
+
+	start := time.Now()
+	results := make([][]Analysis, len(batches))
+	errs := make([]error, len(batches))
+	slots := make(chan struct{}, max(f.Workers, 1))
+	stats := Stats{Batches: len(batches)}
+
+	var mu sync.Mutex
+	inFlight := 0
+	report := func(p Progress) {
+		if f.OnProgress != nil {
+			f.OnProgress(p)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for attempt := 1; attempt <= f.Retries+1; attempt++ {
+				if attempt > 1 {
+					time.Sleep(time.Duration(attempt-1) * f.Backoff)
+				}
+				slots <- struct{}{}
+				mu.Lock()
+				inFlight++
+				stats.Attempts++
+				stats.MaxInFlight = max(stats.MaxInFlight, inFlight)
+				report(Progress{Batch: i + 1, Attempt: attempt, Started: true, InFlight: inFlight})
+				mu.Unlock()
+
+				began := time.Now()
+				analyses, err := f.Map(batch)
+				if err == nil {
+					analyses, err = check(batch, analyses)
+				}
+				<-slots
+
+				mu.Lock()
+				inFlight--
+				report(Progress{Batch: i + 1, Attempt: attempt, InFlight: inFlight, Elapsed: time.Since(began), Err: err})
+				mu.Unlock()
+
+				results[i], errs[i] = analyses, err
+				if err == nil {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	stats.Elapsed = time.Since(start)
+
+	var all []Analysis
+	for i, err := range errs {
+		if err != nil {
+			return nil, stats, fmt.Errorf("batch %d: %w", i+1, err)
+		}
+		all = append(all, results[i]...)
+	}
+	return all, stats, nil
+}
+
+// check keeps one analysis per review in the batch, in batch order, and fails if a
+// review is missing, so a worker that skips a review is retried instead of silently
+// shrinking the totals
+func check(batch []Review, analyses []Analysis) ([]Analysis, error) {
+	byID := map[int]Analysis{}
+	for _, a := range analyses {
+		byID[a.ReviewID] = a
+	}
+	checked := make([]Analysis, 0, len(batch))
+	for _, r := range batch {
+		a, ok := byID[r.ID]
+		if !ok {
+			return nil, fmt.Errorf("no analysis for review %d", r.ID)
+		}
+		a.Sentiment = strings.ToLower(strings.TrimSpace(a.Sentiment))
+		if !slices.Contains([]string{"positive", "mixed", "negative"}, a.Sentiment) {
+			return nil, fmt.Errorf("review %d: unknown sentiment %q", r.ID, a.Sentiment)
+		}
+		for i, t := range a.Themes {
+			a.Themes[i] = strings.ToLower(strings.TrimSpace(t))
+		}
+		checked = append(checked, a)
+	}
+	return checked, nil
+}
+
+// ThemeCount is how often a theme came up, and in which sentiment
+type ThemeCount struct {
+	Theme                     string
+	Positive, Mixed, Negative int
+	ReviewIDs                 []int
+}
+
+func (t ThemeCount) Total() int { return t.Positive + t.Mixed + t.Negative }
+
+// Summary is the fan-in: the analyses counted, without a model, so the totals are
+// exact however the reducer words them
+type Summary struct {
+	Reviews                   int
+	Positive, Mixed, Negative int
+	AverageRating             float64
+	Themes                    []ThemeCount // most mentioned first
+}
+
+func Aggregate(reviews []Review, analyses []Analysis) Summary {
+	s := Summary{Reviews: len(analyses)}
+	rating := map[int]int{}
+	for _, r := range reviews {
+		rating[r.ID] = r.Rating
+	}
+
+	themes := map[string]*ThemeCount{}
+	total := 0
+	for _, a := range analyses {
+		total += rating[a.ReviewID]
+		for _, theme := range a.Themes {
+			t := themes[theme]
+			if t == nil {
+				t = &ThemeCount{Theme: theme}
+				themes[theme] = t
+			}
+			t.ReviewIDs = append(t.ReviewIDs, a.ReviewID)
+			switch a.Sentiment {
+			case "positive":
+				t.Positive++
+			case "mixed":
+				t.Mixed++
+			case "negative":
+				t.Negative++
+			}
+		}
+		switch a.Sentiment {
+		case "positive":
+			s.Positive++
+		case "mixed":
+			s.Mixed++
+		case "negative":
+			s.Negative++
+		}
+	}
+	if s.Reviews > 0 {
+		s.AverageRating = float64(total) / float64(s.Reviews)
+	}
+
+	for _, t := range themes {
+		s.Themes = append(s.Themes, *t)
+	}
+	sort.Slice(s.Themes, func(i, j int) bool {
+		if s.Themes[i].Total() != s.Themes[j].Total() {
+			return s.Themes[i].Total() > s.Themes[j].Total()
+		}
+		return s.Themes[i].Theme < s.Themes[j].Theme
+	})
+	return s
+}
+
+// Format describes the summary for the reducer and the console
+func (s Summary) Format() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d reviews, average %.1f stars: %d positive, %d mixed, %d negative\n\n", s.Reviews, s.AverageRating, s.Positive, s.Mixed, s.Negative)
+	fmt.Fprintf(&sb, "%-18s %5s %5s %5s   %s\n", "THEME", "POS", "MIXED", "NEG", "REVIEWS")
+	for _, t := range s.Themes {
+		ids := make([]string, len(t.ReviewIDs))
+		for i, id := range t.ReviewIDs {
+			ids[i] = fmt.Sprintf("#%d", id)
+		}
+		fmt.Fprintf(&sb, "%-18s %5d %5d %5d   %s\n", t.Theme, t.Positive, t.Mixed, t.Negative, strings.Join(ids, " "))
+	}
+	return sb.String()
+}
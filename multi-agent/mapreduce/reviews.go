@@ -0,0 +1,55 @@
+package main
+
+// Review is one customer review of the BrewMate Pro coffee machine
+type Review struct {
+	ID     int
+	Rating int // stars, 1 to 5
+	Text   string
+}
+
+var reviews = []Review{
+	{1, 5, "Best espresso I've had outside a café. Heats up in under a minute."},
+	{2, 2, "The milk frother stopped working after three weeks. Support took ten days to reply."},
+	{3, 4, "Great coffee, but the water tank is small and I refill it every morning."},
+	{4, 1, "Leaked all over my counter on day two. Returned it."},
+	{5, 5, "Quiet, fast and the app lets me start a brew from bed. Love it."},
+	{6, 3, "Coffee is good, not great. The grinder is loud enough to wake the kids."},
+	{7, 4, "Easy to clean and the descaling reminder is handy. Pricey though."},
+	{8, 2, "App keeps disconnecting from the Wi-Fi, so the schedule feature is useless."},
+	{9, 5, "Replaced a machine twice the price and I can't taste the difference."},
+	{10, 4, "Frother makes lovely foam. Wish the drip tray were deeper."},
+	{11, 1, "Pump died after two months. Warranty claim still open after three weeks."},
+	{12, 5, "Setup took five minutes and the first cup was perfect."},
+	{13, 3, "Fine for one person, but the small tank makes it a pain for a family."},
+	{14, 4, "Really consistent shots. The grinder noise is the only downside."},
+	{15, 2, "Expensive for what it is, and customer support was unhelpful about a cracked lid."},
+	{16, 5, "The app's recipes got me into flat whites. Great machine."},
+	{17, 3, "Takes up more counter space than the photos suggest."},
+	{18, 4, "Heats up quickly and the coffee is rich. Cleaning the frother is fiddly."},
+	{19, 1, "Arrived with a broken drip tray and the replacement took a month."},
+	{20, 5, "Worth every penny. Six months in and it works like new."},
+}
+
+// sampleAnalyses are what a worker finds in each review, for -show
+var sampleAnalyses = map[int]Analysis{
+	1:  {Sentiment: "positive", Themes: []string{"coffee quality", "heat-up time"}},
+	2:  {Sentiment: "negative", Themes: []string{"frother", "customer support"}},
+	3:  {Sentiment: "mixed", Themes: []string{"coffee quality", "water tank"}},
+	4:  {Sentiment: "negative", Themes: []string{"reliability"}},
+	5:  {Sentiment: "positive", Themes: []string{"noise", "app"}},
+	6:  {Sentiment: "mixed", Themes: []string{"coffee quality", "noise"}},
+	7:  {Sentiment: "positive", Themes: []string{"cleaning", "price"}},
+	8:  {Sentiment: "negative", Themes: []string{"app"}},
+	9:  {Sentiment: "positive", Themes: []string{"price", "coffee quality"}},
+	10: {Sentiment: "positive", Themes: []string{"frother", "drip tray"}},
+	11: {Sentiment: "negative", Themes: []string{"reliability", "customer support"}},
+	12: {Sentiment: "positive", Themes: []string{"setup", "coffee quality"}},
+	13: {Sentiment: "mixed", Themes: []string{"water tank"}},
+	14: {Sentiment: "positive", Themes: []string{"coffee quality", "noise"}},
+	15: {Sentiment: "negative", Themes: []string{"price", "customer support"}},
+	16: {Sentiment: "positive", Themes: []string{"app", "coffee quality"}},
+	17: {Sentiment: "mixed", Themes: []string{"size"}},
+	18: {Sentiment: "positive", Themes: []string{"heat-up time", "coffee quality", "cleaning"}},
+	19: {Sentiment: "negative", Themes: []string{"drip tray", "customer support"}},
+	20: {Sentiment: "positive", Themes: []string{"reliability"}},
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+const goal = "Book Dana's trip from Amsterdam to Berlin, 12 to 14 March 2026: the earliest flight out, a flight home after 18:00 on the 14th, the cheapest hotel near Alexanderplatz, then email the itinerary to dana@example.com."
+
+var toolNames = []string{"search_flights", "book_flight", "search_hotels", "book_hotel", "send_itinerary"}
+
+// Outcome is what the executor reports for a step
+type Outcome struct {
+	Success bool
+	Result  string
+}
+
+// Executor carries out one step of the plan
+type Executor func(plan *PlanMemory, step Step) (Outcome, error)
+
+// samples are the planner's two plans and, for each step instruction, the tool call
+// the executor makes, for -show
+var (
+	samplePlans = []string{
+		`{"steps": [
+  {"id": 1, "tool": "search_flights", "instruction": "Find flights from Amsterdam to Berlin on 2026-03-12"},
+  {"id": 2, "tool": "book_flight", "instruction": "Book the earliest outbound flight"},
+  {"id": 3, "tool": "search_flights", "instruction": "Find flights from Berlin to Amsterdam on 2026-03-14"},
+  {"id": 4, "tool": "book_flight", "instruction": "Book the first return flight after 18:00"},
+  {"id": 5, "tool": "search_hotels", "instruction": "Find hotels near Alexanderplatz"},
+  {"id": 6, "tool": "book_hotel", "instruction": "Book the cheapest Alexanderplatz hotel for 2 nights from 2026-03-12"},
+  {"id": 7, "tool": "send_itinerary", "instruction": "Email the flights and hotel to dana@example.com"}
+]}`,
+		`{"steps": [
+  {"id": 1, "tool": "book_hotel", "instruction": "Book Park Inn Alexanderplatz, the next cheapest, for 2 nights from 2026-03-12"},
+  {"id": 2, "tool": "send_itinerary", "instruction": "Email the flights and hotel to dana@example.com"}
+]}`,
+	}
+	sampleActions = map[string]func(t *Travel) (string, error){
+		"Find flights from Amsterdam to Berlin on 2026-03-12": func(t *Travel) (string, error) { return t.SearchFlights("Amsterdam", "Berlin", "2026-03-12") },
+		"Book the earliest outbound flight":                   func(t *Travel) (string, error) { return t.BookFlight("KL1823") },
+		"Find flights from Berlin to Amsterdam on 2026-03-14": func(t *Travel) (string, error) { return t.SearchFlights("Berlin", "Amsterdam", "2026-03-14") },
+		"Book the first return flight after 18:00":            func(t *Travel) (string, error) { return t.BookFlight("KL1830") },
+		"Find hotels near Alexanderplatz":                     func(t *Travel) (string, error) { return t.SearchHotels("Alexanderplatz") },
+		"Book the cheapest Alexanderplatz hotel for 2 nights from 2026-03-12": func(t *Travel) (string, error) {
+			return t.BookHotel("Alex Hotel", "2026-03-12", 2)
+		},
+		"Book Park Inn Alexanderplatz, the next cheapest, for 2 nights from 2026-03-12": func(t *Travel) (string, error) {
+			return t.BookHotel("Park Inn Alexanderplatz", "2026-03-12", 2)
+		},
+		"Email the flights and hotel to dana@example.com": func(t *Travel) (string, error) {
+			return t.SendItinerary("dana@example.com", strings.Join(t.Bookings(), "\n"))
+		},
+	}
+)
+
+func sampleExecutor(travel *Travel) Executor {
+	return func(plan *PlanMemory, step Step) (Outcome, error) {
+		action, ok := sampleActions[step.Instruction]
+		if !ok {
+			return Outcome{}, fmt.Errorf("no sample action for %q", step.Instruction)
+		}
+		result, err := action(travel)
+		if err != nil {
+			return Outcome{Result: err.Error()}, nil
+		}
+		return Outcome{Success: true, Result: result}, nil
+	}
+}
+
+func createTravelTools(t *Travel) []aigentic.AgentTool {
+	type FlightSearch struct {
+		From string `json:"from" description:"Departure city"`
+		To   string `json:"to" description:"Arrival city"`
+		Date string `json:"date" description:"Date as YYYY-MM-DD"`
+	}
+	type FlightBooking struct {
+		Number string `json:"number" description:"Flight number, such as KL1823"`
+	}
+	type HotelSearch struct {
+		Area string `json:"area" description:"Area or district"`
+	}
+	type HotelBooking struct {
+		Name    string `json:"name" description:"Hotel name as listed by search_hotels"`
+		CheckIn string `json:"check_in" description:"Date as YYYY-MM-DD"`
+		Nights  int    `json:"nights" description:"Number of nights"`
+	}
+	type Itinerary struct {
+		To        string `json:"to" description:"Email address"`
+		Itinerary string `json:"itinerary" description:"The bookings, one per line"`
+	}
+
+	return []aigentic.AgentTool{
+		aigentic.NewTool("search_flights", "Lists flights between two cities on a date",
+			func(run *aigentic.AgentRun, in FlightSearch) (string, error) {
+				return t.SearchFlights(in.From, in.To, in.Date)
+			}),
+		aigentic.NewTool("book_flight", "Books a seat on a flight",
+			func(run *aigentic.AgentRun, in FlightBooking) (string, error) { return t.BookFlight(in.Number) }),
+		aigentic.NewTool("search_hotels", "Lists hotels in an area with their nightly price",
+			func(run *aigentic.AgentRun, in HotelSearch) (string, error) { return t.SearchHotels(in.Area) }),
+		aigentic.NewTool("book_hotel", "Books a hotel room",
+			func(run *aigentic.AgentRun, in HotelBooking) (string, error) {
+				return t.BookHotel(in.Name, in.CheckIn, in.Nights)
+			}),
+		aigentic.NewTool("send_itinerary", "Emails an itinerary",
+			func(run *aigentic.AgentRun, in Itinerary) (string, error) {
+				return t.SendItinerary(in.To, in.Itinerary)
+			}),
+	}
+}
+
+// agentExecutor runs each step on a fresh executor agent that sees plan memory, so it
+// can use the results of earlier steps, such as the flight numbers a search found
+func agentExecutor(model *ai.Model, travel *Travel) Executor {
+	type FinishInput struct {
+		Success bool   `json:"success" description:"Whether the step was done"`
+		Result  string `json:"result" description:"What was done, with the details later steps need, or why the step failed"`
+	}
+	return func(plan *PlanMemory, step Step) (Outcome, error) {
+		var outcome *Outcome
+		finish := aigentic.NewTool("finish_step", "Reports the outcome of the step. Call it exactly once, at the end.",
+			func(run *aigentic.AgentRun, in FinishInput) (string, error) {
+				outcome = &Outcome{Success: in.Success, Result: in.Result}
+				return "Recorded", nil
+			})
+		finish.ContextFunctions = []aigentic.ContextFunction{
+			func(run *aigentic.AgentRun) (string, error) { return "Plan memory:\n" + plan.Format(), nil },
+		}
+
+		agent := aigentic.Agent{
+			Model:       model,
+			Name:        "Executor",
+			Description: "Carries out one step of a plan with tools",
+			Instructions: `You carry out exactly one step of a plan, using the tools. Use the results of earlier steps in plan memory.
+Do not work on other steps and do not work around a failure: if a tool fails, call finish_step with success false and the error.`,
+			AgentTools: append(createTravelTools(travel), finish),
+		}
+		if _, err := agent.Execute(fmt.Sprintf("Do step %d: %s", step.ID, step.Instruction)); err != nil {
+			return Outcome{}, err
+		}
+		if outcome == nil {
+			return Outcome{Result: "the executor did not report an outcome"}, nil
+		}
+		return *outcome, nil
+	}
+}
+
+func scripted(lines []string) func(string) (string, error) {
+	next := 0
+	return func(prompt string) (string, error) {
+		if next >= len(lines) {
+			return "", fmt.Errorf("no more sample replies")
+		}
+		next++
+		return lines[next-1], nil
+	}
+}
+
+func printSteps(plan *PlanMemory) {
+	fmt.Printf("📋 Plan v%d:\n", plan.Version)
+	for _, s := range plan.Steps() {
+		if s.Status == StepPending {
+			fmt.Printf("   %d. [%s] %s\n", s.ID, s.Tool, s.Instruction)
+		}
+	}
+	fmt.Println()
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	maxReplans := flag.Int("max-replans", 2, "Times the planner may replace the plan after a step fails")
+	show := flag.Bool("show", false, "Replay sample plans and tool calls without calling the model")
+	flag.Parse()
+
+	fmt.Println("🧭 Aigentic Planner–Executor Example")
+	fmt.Println("====================================")
+	fmt.Println()
+
+	travel := &Travel{}
+	plan := &PlanMemory{Goal: goal}
+	var planner func(string) (string, error)
+	var execute Executor
+	if *show {
+		planner, execute = scripted(samplePlans), sampleExecutor(travel)
+	} else {
+		model := openai.NewModel("gpt-4o-mini", getAPIKey())
+		planAgent := aigentic.Agent{
+			Model:       model,
+			Name:        "Planner",
+			Description: "Breaks a goal into steps for an executor",
+			Instructions: fmt.Sprintf(`You plan; you never act. Break the goal into small steps that each need one tool call.
+Tools the executor has: %s.
+Reply with JSON only: {"steps": [{"id": 1, "tool": "<tool>", "instruction": "<what to do, with every detail needed>"}]}`, strings.Join(toolNames, ", ")),
+		}
+		planner, execute = planAgent.Execute, agentExecutor(model, travel)
+	}
+
+	fmt.Printf("Goal: %s\n\n", goal)
+	prompt := "Goal: " + goal
+	for {
+		reply, err := planner(prompt)
+		if err != nil {
+			log.Fatalf("Planner failed: %v", err)
+		}
+		steps, err := ParseSteps(reply, toolNames)
+		if err != nil {
+			log.Fatalf("Planner returned an unusable plan: %v", err)
+		}
+		plan.Replace(steps)
+		printSteps(plan)
+
+		var failed *Step
+		for step, ok := plan.Next(); ok; step, ok = plan.Next() {
+			outcome, err := execute(plan, step)
+			if err != nil {
+				log.Fatalf("Executor failed: %v", err)
+			}
+			if !outcome.Success {
+				plan.Finish(step.ID, StepFailed, outcome.Result)
+				fmt.Printf("   ❌ %d. %s: %s\n\n", step.ID, step.Instruction, outcome.Result)
+				failed = &step
+				break
+			}
+			plan.Finish(step.ID, StepDone, outcome.Result)
+			fmt.Printf("   ✅ %d. %s: %s\n", step.ID, step.Instruction, outcome.Result)
+		}
+		if failed == nil {
+			fmt.Println("\n🎉 Every step is done")
+			break
+		}
+		if plan.Version > *maxReplans {
+			fmt.Printf("⏹️  Giving up after %d re-plans\n", *maxReplans)
+			break
+		}
+
+		fmt.Printf("🔄 Re-planning after step %d failed\n\n", failed.ID)
+		prompt = fmt.Sprintf("Goal: %s\n\nPlan memory, with what has been done so far:\n%s\n\nStep %d failed: %s\nPlan only the remaining work. Do not repeat steps that are done.",
+			goal, plan.Format(), failed.ID, failed.Result)
+	}
+
+	fmt.Println("\nBookings:")
+	for _, b := range travel.Bookings() {
+		fmt.Printf("   - %s\n", b)
+	}
+	fmt.Println()
+
+	fmt.Println("✅ Example completed successfully!")
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Step statuses
+const (
+	StepPending = "pending"
+	StepDone    = "done"
+	StepFailed  = "failed"
+)
+
+// Step is one entry of the machine-readable plan the planner writes. Tool names the tool
+// the step mostly needs, so a plan that relies on a tool that does not exist is
+// rejected before anything runs.
+type Step struct {
+	ID          int    `json:"id"`
+	Tool        string `json:"tool"`
+	Instruction string `json:"instruction"`
+	Status      string `json:"status"`
+	Result      string `json:"result,omitempty"`
+}
+
+// PlanMemory holds the current plan and every step done so far. A re-plan replaces
+// the steps that have not run, and keeps the finished ones and their results.
+type PlanMemory struct {
+	Goal    string
+	Version int
+
+	mu    sync.Mutex
+	steps []Step
+}
+
+// ParseSteps reads the planner's JSON reply and checks every step names a known tool
+func ParseSteps(reply string, tools []string) ([]Step, error) {
+	start, end := strings.Index(reply, "{"), strings.LastIndex(reply, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no JSON in reply: %q", reply)
+	}
+	var plan struct {
+		Steps []Step `json:"steps"`
+	}
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &plan); err != nil {
+		return nil, fmt.Errorf("reading plan: %w", err)
+	}
+	if len(plan.Steps) == 0 {
+		return nil, fmt.Errorf("the plan has no steps")
+	}
+	for _, s := range plan.Steps {
+		if !slices.Contains(tools, s.Tool) {
+			return nil, fmt.Errorf("step %q uses unknown tool %q", s.Instruction, s.Tool)
+		}
+	}
+	return plan.Steps, nil
+}
+
+// Replace drops the steps that have not finished and appends steps, numbering them
+// after the finished ones. The failed step stays in the history.
+func (p *PlanMemory) Replace(steps []Step) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.steps = slices.DeleteFunc(p.steps, func(s Step) bool { return s.Status == StepPending })
+	for _, s := range steps {
+		s.ID, s.Status, s.Result = len(p.steps)+1, StepPending, ""
+		p.steps = append(p.steps, s)
+	}
+	p.Version++
+}
+
+// Next returns the first pending step
+func (p *PlanMemory) Next() (Step, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, s := range p.steps {
+		if s.Status == StepPending {
+			return s, true
+		}
+	}
+	return Step{}, false
+}
+
+// Finish records the outcome of a step
+func (p *PlanMemory) Finish(id int, status, result string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.steps {
+		if p.steps[i].ID == id {
+			p.steps[i].Status, p.steps[i].Result = status, result
+		}
+	}
+}
+
+// Steps returns a copy of every step
+func (p *PlanMemory) Steps() []Step {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.steps)
+}
+
+// Format is plan memory as JSON, which both agents read
+func (p *PlanMemory) Format() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	data, _ := json.MarshalIndent(struct {
+		Goal    string `json:"goal"`
+		Version int    `json:"version"`
+		Steps   []Step `json:"steps"`
+	}{p.Goal, p.Version, p.steps}, "", "  ")
+	return string(data)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Travel is a small booking system for the executor's tools. One hotel sells out
+// between being listed and being booked, so the first plan fails partway through.
+type Travel struct {
+	mu       sync.Mutex
+	bookings []string
+	sent     []string
+}
+
+type flight struct{ number, from, to, date, depart string }
+
+type hotel struct {
+	name, area string
+	price      int
+	soldOut    bool // listed by search, but booking fails
+}
+
+var flights = []flight{
+	{"KL1823", "Amsterdam", "Berlin", "2026-03-12", "07:05"},
+	{"EW9137", "Amsterdam", "Berlin", "2026-03-12", "09:40"},
+	{"KL1830", "Berlin", "Amsterdam", "2026-03-14", "18:15"},
+	{"EW9140", "Berlin", "Amsterdam", "2026-03-14", "20:30"},
+}
+
+var hotels = []hotel{
+	{"Alex Hotel", "Alexanderplatz", 119, true},
+	{"Park Inn Alexanderplatz", "Alexanderplatz", 139, false},
+	{"Hotel Spree", "Mitte", 99, false},
+}
+
+func (t *Travel) SearchFlights(from, to, date string) (string, error) {
+	var found []string
+	for _, f := range flights {
+		if strings.EqualFold(f.from, from) && strings.EqualFold(f.to, to) && f.date == date {
+			found = append(found, fmt.Sprintf("%s departs %s", f.number, f.depart))
+		}
+	}
+	if len(found) == 0 {
+		return "", fmt.Errorf("no flights from %s to %s on %s", from, to, date)
+	}
+	return strings.Join(found, "; "), nil
+}
+
+func (t *Travel) BookFlight(number string) (string, error) {
+	for _, f := range flights {
+		if strings.EqualFold(f.number, number) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			booking := fmt.Sprintf("Flight %s %s→%s on %s at %s", f.number, f.from, f.to, f.date, f.depart)
+			t.bookings = append(t.bookings, booking)
+			return "Booked: " + booking, nil
+		}
+	}
+	return "", fmt.Errorf("no flight %s", number)
+}
+
+func (t *Travel) SearchHotels(area string) (string, error) {
+	var found []string
+	for _, h := range hotels {
+		if strings.Contains(strings.ToLower(h.area), strings.ToLower(area)) {
+			found = append(found, fmt.Sprintf("%s (%s, %d EUR a night)", h.name, h.area, h.price))
+		}
+	}
+	if len(found) == 0 {
+		return "", fmt.Errorf("no hotels in %s", area)
+	}
+	return strings.Join(found, "; "), nil
+}
+
+func (t *Travel) BookHotel(name, checkIn string, nights int) (string, error) {
+	for _, h := range hotels {
+		if !strings.EqualFold(h.name, name) {
+			continue
+		}
+		if h.soldOut {
+			return "", fmt.Errorf("%s is sold out from %s", h.name, checkIn)
+		}
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		booking := fmt.Sprintf("%s from %s, %d nights, %d EUR", h.name, checkIn, nights, h.price*nights)
+		t.bookings = append(t.bookings, booking)
+		return "Booked: " + booking, nil
+	}
+	return "", fmt.Errorf("no hotel named %s", name)
+}
+
+func (t *Travel) SendItinerary(to, itinerary string) (string, error) {
+	if !strings.Contains(to, "@") {
+		return "", fmt.Errorf("%q is not an email address", to)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sent = append(t.sent, to)
+	return "Itinerary sent to " + to, nil
+}
+
+func (t *Travel) Bookings() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.bookings...)
+}
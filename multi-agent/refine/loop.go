@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Responder answers a prompt. In the example it runs an agent; with -show it returns
+// scripted text.
+type Responder func(prompt string) (string, error)
+
+// Criterion is one line of the rubric. Scores are from 1 to 5, and the weights say how
+// much each criterion counts towards the overall score.
+type Criterion struct {
+	Name   string
+	Weight int
+	Guide  string // what a 5 looks like
+}
+
+type Rubric []Criterion
+
+func (r Rubric) Format() string {
+	var sb strings.Builder
+	for _, c := range r {
+		fmt.Fprintf(&sb, "- %s (weight %d): %s\n", c.Name, c.Weight, c.Guide)
+	}
+	return sb.String()
+}
+
+// Score turns the critic's scores into an overall score out of 100. Every criterion
+// must be scored, so a critic that skips one cannot pass a draft by accident.
+func (r Rubric) Score(scores map[string]int) (int, error) {
+	total, weights := 0, 0
+	for _, c := range r {
+		s, ok := scores[c.Name]
+		if !ok {
+			return 0, fmt.Errorf("no score for %s", c.Name)
+		}
+		total += min(max(s, 1), 5) * c.Weight
+		weights += c.Weight
+	}
+	return total * 100 / (5 * weights), nil
+}
+
+// Critique is the critic's review of one draft
+type Critique struct {
+	Scores   map[string]int `json:"scores"`
+	Feedback []string       `json:"feedback"`
+	Overall  int            `json:"-"`
+}
+
+// Iteration is one draft and its critique
+type Iteration struct {
+	Number   int
+	Draft    string
+	Critique Critique
+}
+
+// Refiner has a writer draft, a critic review against a rubric, and the writer revise,
+// until a draft scores at least Threshold or MaxIterations drafts have been written
+type Refiner struct {
+	Task          string
+	Rubric        Rubric
+	Threshold     int
+	MaxIterations int
+	Writer        Responder
+	Critic        Responder
+
+	OnIteration func(Iteration)
+}
+
+// Result is the outcome of a refinement. Best is the highest-scoring draft, which is
+// the last one only if scores never went down.
+type Result struct {
+	Iterations []Iteration
+	Best       Iteration
+	Passed     bool
+}
+
+func (r *Refiner) Run() (Result, error) {
+	var result Result
+	prompt := fmt.Sprintf("Task: %s\n\nYou will be judged on:\n%s\nWrite the first draft. Reply with the draft only.", r.Task, r.Rubric.Format())
+
+	for n := 1; n <= r.MaxIterations; n++ {
+		draft, err := r.Writer(prompt)
+		if err != nil {
+			return result, fmt.Errorf("draft %d: %w", n, err)
+		}
+		draft = strings.TrimSpace(draft)
+
+		critique, err := r.critique(draft)
+		if err != nil {
+			return result, fmt.Errorf("reviewing draft %d: %w", n, err)
+		}
+		it := Iteration{Number: n, Draft: draft, Critique: critique}
+		result.Iterations = append(result.Iterations, it)
+		if n == 1 || critique.Overall > result.Best.Critique.Overall {
+			result.Best = it
+		}
+		if r.OnIteration != nil {
+			r.OnIteration(it)
+		}
+		if critique.Overall >= r.Threshold {
+			result.Passed = true
+			return result, nil
+		}
+
+		prompt = fmt.Sprintf("Task: %s\n\nYou will be judged on:\n%s\nYour last draft scored %d out of 100; it needs %d.\n\nDraft:\n%s\n\nThe critic's feedback:\n- %s\n\nRevise the draft to address every point. Reply with the revised draft only.",
+			r.Task, r.Rubric.Format(), critique.Overall, r.Threshold, draft, strings.Join(critique.Feedback, "\n- "))
+	}
+	return result, nil
+}
+
+func (r *Refiner) critique(draft string) (Critique, error) {
+	names := make([]string, len(r.Rubric))
+	for i, c := range r.Rubric {
+		names[i] = fmt.Sprintf("%q: <1-5>", c.Name)
+	}
+	reply, err := r.Critic(fmt.Sprintf(`Task the draft was written for: %s
+
+Rubric:
+%s
+Draft:
+%s
+
+Score each criterion from 1 to 5 and list what must change for a 5. Reply with JSON only: {"scores": {%s}, "feedback": ["<one specific change>"]}`,
+		r.Task, r.Rubric.Format(), draft, strings.Join(names, ", ")))
+	if err != nil {
+		return Critique{}, err
+	}
+
+	start, end := strings.Index(reply, "{"), strings.LastIndex(reply, "}")
+	if start < 0 || end < start {
+		return Critique{}, fmt.Errorf("no JSON in reply: %q", reply)
+	}
+	var c Critique
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &c); err != nil {
+		return Critique{}, fmt.Errorf("reading reply: %w", err)
+	}
+	if c.Overall, err = r.Rubric.Score(c.Scores); err != nil {
+		return Critique{}, err
+	}
+	return c, nil
+}
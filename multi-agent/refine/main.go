@@ -0,0 +1,134 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+const task = `Write a launch email to existing customers for the BrewMate Pro coffee machine.
+Facts: it costs 349 EUR; existing customers get 20% off with the code LOYAL20 until 30 November; it heats up in 40 seconds; brews can be started from the BrewMate app; it has a 1.8 litre water tank.`
+
+var rubric = Rubric{
+	{Name: "accuracy", Weight: 3, Guide: "uses only the facts given, with every price, code and date correct"},
+	{Name: "clarity", Weight: 2, Guide: "a reader knows what is new and why it matters after the first two sentences"},
+	{Name: "call_to_action", Weight: 2, Guide: "one clear action, with the code and the deadline"},
+	{Name: "brevity", Weight: 1, Guide: "under 120 words, with no filler"},
+	{Name: "tone", Weight: 1, Guide: "warm and direct, written for people who already own a BrewMate"},
+}
+
+// samples are three drafts and their critiques, for -show
+var (
+	sampleDrafts = []string{
+		`Subject: Something exciting is brewing!
+
+Dear valued customer, we are thrilled, delighted and honoured to share some incredible news with you today. After years of work, the revolutionary BrewMate Pro is finally here! It heats up in seconds, has an app, and a huge water tank. It's the best coffee machine ever made. Don't miss out on this amazing opportunity to upgrade your mornings. Check it out on our website soon!`,
+		`Subject: Meet the BrewMate Pro
+
+Hi there, the BrewMate Pro is here: it heats up in 40 seconds, lets you start a brew from the BrewMate app, and holds 1.8 litres of water. As a BrewMate owner, you get 20% off the 349 EUR price. We think you'll love how much faster your mornings get. Visit our shop to learn more and find out how to claim your discount.`,
+		`Subject: Your 20% off the new BrewMate Pro
+
+Hi, the BrewMate Pro is here. It heats up in 40 seconds, starts brewing from the BrewMate app before you're out of bed, and its 1.8 litre tank means fewer refills.
+
+Because you already own a BrewMate, you get 20% off the 349 EUR price: use the code LOYAL20 at checkout before 30 November.
+
+Happy brewing,
+The BrewMate team`,
+	}
+	sampleCritiques = []string{
+		`{"scores": {"accuracy": 3, "clarity": 3, "call_to_action": 2, "brevity": 2, "tone": 4}, "feedback": ["Replace 'heats up in seconds' and 'huge water tank' with the real figures: 40 seconds and 1.8 litres", "Mention the 349 EUR price and the 20% discount for existing customers", "End with one action: the code LOYAL20 and the 30 November deadline", "Cut the stacked adjectives and the 'best ever' claim"]}`,
+		`{"scores": {"accuracy": 4, "clarity": 4, "call_to_action": 4, "brevity": 3, "tone": 4}, "feedback": ["Give the discount code LOYAL20 and the 30 November deadline; without them the reader cannot act", "Put the discount in the subject line, since it is the reason to read", "Drop 'We think you'll love...', which adds nothing"]}`,
+		`{"scores": {"accuracy": 5, "clarity": 5, "call_to_action": 5, "brevity": 4, "tone": 4}, "feedback": ["Optionally say what '20% off' comes to in euros"]}`,
+	}
+)
+
+func scripted(lines []string) Responder {
+	next := 0
+	return func(prompt string) (string, error) {
+		if next >= len(lines) {
+			return "", fmt.Errorf("no more sample replies")
+		}
+		next++
+		return lines[next-1], nil
+	}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	threshold := flag.Int("threshold", 85, "Overall score out of 100 a draft needs to pass")
+	maxIterations := flag.Int("max-iterations", 4, "Most drafts to write before giving up")
+	show := flag.Bool("show", false, "Replay sample drafts and critiques without calling the model")
+	flag.Parse()
+
+	fmt.Println("🔁 Aigentic Critic–Reviser Loop Example")
+	fmt.Println("=======================================")
+	fmt.Println()
+
+	refiner := &Refiner{Task: task, Rubric: rubric, Threshold: *threshold, MaxIterations: *maxIterations}
+	if *show {
+		refiner.Writer, refiner.Critic = scripted(sampleDrafts), scripted(sampleCritiques)
+		refiner.MaxIterations = min(refiner.MaxIterations, len(sampleDrafts))
+	} else {
+		model := openai.NewModel("gpt-4o-mini", getAPIKey())
+		writer := aigentic.Agent{
+			Model:        model,
+			Name:         "Writer",
+			Description:  "Writes and revises marketing copy",
+			Instructions: "You are a copywriter. Write exactly what is asked, using only the facts you are given. When revising, address every point of feedback and keep what already works.",
+		}
+		critic := aigentic.Agent{
+			Model:        model,
+			Name:         "Critic",
+			Description:  "Reviews copy strictly against a rubric",
+			Instructions: "You are a demanding editor. Score drafts strictly against the rubric: a 5 means nothing could be improved on that criterion. Check every fact against the task. Feedback must be specific enough to act on.",
+		}
+		refiner.Writer, refiner.Critic = writer.Execute, critic.Execute
+	}
+
+	fmt.Printf("Rubric (pass at %d/100, at most %d drafts):\n%s\n", refiner.Threshold, refiner.MaxIterations, rubric.Format())
+
+	refiner.OnIteration = func(it Iteration) {
+		fmt.Printf("━━━ Draft %d ━━━\n%s\n\n", it.Number, it.Draft)
+		var scores []string
+		for _, c := range rubric {
+			scores = append(scores, fmt.Sprintf("%s %d", c.Name, it.Critique.Scores[c.Name]))
+		}
+		fmt.Printf("🧐 %d/100 (%s)\n", it.Critique.Overall, strings.Join(scores, ", "))
+		for _, f := range it.Critique.Feedback {
+			fmt.Printf("   - %s\n", f)
+		}
+		fmt.Println()
+	}
+
+	result, err := refiner.Run()
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	if result.Passed {
+		fmt.Printf("✅ Draft %d passed with %d/100 after %d iterations\n\n", result.Best.Number, result.Best.Critique.Overall, len(result.Iterations))
+	} else {
+		fmt.Printf("⏹️  Stopped at the cap of %d drafts without reaching %d; keeping draft %d, the best at %d/100\n\n",
+			refiner.MaxIterations, refiner.Threshold, result.Best.Number, result.Best.Critique.Overall)
+	}
+	fmt.Printf("Final email:\n%s\n\n", result.Best.Draft)
+
+	fmt.Println("✅ Example completed successfully!")
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// requests are labelled with the route a person would pick, so every run is also an
+// evaluation of the router. The sample classification is what the router returns with
+// -show; it gets the checkout error wrong, as models often do.
+var requests = []struct {
+	message, expected string
+	sample            Classification
+}{
+	{"I was charged twice for my March invoice.", "billing", Classification{"billing", 0.97, "duplicate charge"}},
+	{"The app crashes when I upload a photo on Android 14.", "tech_support", Classification{"tech_support", 0.95, "app crash"}},
+	{"Do you offer discounts for teams of 50?", "sales", Classification{"sales", 0.92, "volume pricing question"}},
+	{"How do I change the card you bill?", "billing", Classification{"billing", 0.9, "payment method"}},
+	{"I can't log in and the reset email never arrives.", "tech_support", Classification{"tech_support", 0.88, "login problem"}},
+	{"What's the difference between the Pro and Business plans?", "sales", Classification{"sales", 0.86, "plan comparison"}},
+	{"Can I get a refund if I cancel halfway through the year?", "billing", Classification{"billing", 0.83, "refund policy"}},
+	{"Where is your office in Dublin?", "general", Classification{"other", 0.9, "company information"}},
+	{"Your API has returned 500 for /v2/orders since this morning.", "tech_support", Classification{"tech_support", 0.96, "API errors"}},
+	{"I want to upgrade, but the checkout page shows an error.", "tech_support", Classification{"sales", 0.55, "upgrade intent"}},
+	{"Are you hiring designers?", "general", Classification{"other", 0.8, "jobs"}},
+}
+
+var specialists = []struct {
+	name, description, instructions, sample string
+}{
+	{"billing", "Invoices, charges, refunds and payment methods",
+		"You are a billing specialist. Answer questions about invoices, charges, refunds and payment methods. Ask for the invoice number when you need it. Never promise a refund; say it will be reviewed within two working days.",
+		"I can help with that. Please send me the invoice number, and a billing specialist will review it within two working days."},
+	{"tech_support", "Bugs, errors, login problems and the API",
+		"You are a technical support engineer. Ask for the details needed to reproduce a problem, suggest one or two concrete things to try, and say when you are escalating to engineering.",
+		"Sorry about that. Could you tell me your app version and the exact error? Meanwhile, try signing out and back in."},
+	{"sales", "Plans, pricing, discounts and upgrades",
+		"You are a sales representative. Explain the plans (Starter 9 EUR, Pro 29 EUR, Business 79 EUR per user a month) and offer a call with the sales team for more than 20 users.",
+		"Pro is 29 EUR per user a month and Business 79 EUR; for teams over 20, I can book you a call with our sales team."},
+}
+
+const generalSample = "Thanks for getting in touch. I've passed your question to the right team, and someone will reply within one working day."
+
+func agentHandler(model *ai.Model, name, description, instructions string) Handler {
+	agent := aigentic.Agent{Model: model, Name: name, Description: description, Instructions: instructions}
+	return agent.Execute
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	logPath := flag.String("log", "routing_decisions.jsonl", "File routing decisions are appended to")
+	minConfidence := flag.Float64("min-confidence", 0.6, "Confidence below which requests go to the generalist")
+	message := flag.String("m", "", "Route this message instead of the labelled requests")
+	evaluate := flag.Bool("evaluate", false, "Evaluate the labelled decisions in the log and exit")
+	show := flag.Bool("show", false, "Use sample classifications and answers without calling the model")
+	flag.Parse()
+
+	fmt.Println("🚦 Aigentic Router Example")
+	fmt.Println("==========================")
+	fmt.Println()
+
+	if *evaluate {
+		all, err := ReadDecisions(*logPath)
+		if err != nil {
+			log.Fatalf("Failed to read decision log: %v", err)
+		}
+		fmt.Printf("%d decisions in %s\n", len(all), *logPath)
+		printEvaluation(Evaluate(all))
+		fmt.Println()
+		fmt.Println("✅ Example completed successfully!")
+		return
+	}
+
+	decisions, err := OpenDecisionLog(*logPath)
+	if err != nil {
+		log.Fatalf("Failed to open decision log: %v", err)
+	}
+	defer decisions.Close()
+
+	router := &Router{Specialists: map[string]Handler{}, MinConfidence: *minConfidence, Log: decisions}
+	var intents []string
+	for _, s := range specialists {
+		intents = append(intents, s.name)
+	}
+
+	if *show {
+		samples := map[string]Classification{}
+		for _, r := range requests {
+			samples[r.message] = r.sample
+		}
+		router.Classify = func(message string) (Classification, error) {
+			c, ok := samples[message]
+			if !ok {
+				return Classification{}, fmt.Errorf("no sample classification")
+			}
+			return c, nil
+		}
+		for _, s := range specialists {
+			answer := s.sample
+			router.Specialists[s.name] = func(string) (string, error) { return answer, nil }
+		}
+		router.Generalist = func(string) (string, error) { return generalSample, nil }
+	} else {
+		model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+		var routes strings.Builder
+		for _, s := range specialists {
+			fmt.Fprintf(&routes, "- %s: %s\n", s.name, s.description)
+		}
+		classifier := aigentic.Agent{
+			Model:       model,
+			Name:        "Router",
+			Description: "Classifies customer requests",
+			Instructions: fmt.Sprintf(`Classify the customer's request. Do not answer it. The intents are:
+%s- other: anything else
+Reply with JSON only: {"intent": "<intent>", "confidence": <0 to 1>, "reason": "<a few words>"}`, routes.String()),
+		}
+		router.Classify = func(message string) (Classification, error) {
+			reply, err := classifier.Execute(message)
+			if err != nil {
+				return Classification{}, err
+			}
+			return parseClassification(reply)
+		}
+		for _, s := range specialists {
+			router.Specialists[s.name] = agentHandler(model, s.name, s.description, s.instructions)
+		}
+		router.Generalist = agentHandler(model, Generalist, "Answers anything the specialists do not cover",
+			"You are a friendly customer service generalist. Answer briefly. If the request needs billing, technical or sales expertise, say which team will follow up.")
+	}
+
+	fmt.Printf("Routes: %s, with %s as fallback below %.2f confidence\n\n", strings.Join(intents, ", "), Generalist, router.MinConfidence)
+
+	if *message != "" {
+		answer, d, err := router.Handle(*message, "")
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		printDecision(d, answer)
+		fmt.Printf("📝 Decision appended to %s\n\n", *logPath)
+		fmt.Println("✅ Example completed successfully!")
+		return
+	}
+
+	var all []Decision
+	for _, r := range requests {
+		answer, d, err := router.Handle(r.message, r.expected)
+		if err != nil {
+			log.Printf("Request %q failed: %v", r.message, err)
+		}
+		printDecision(d, answer)
+		all = append(all, d)
+	}
+
+	printEvaluation(Evaluate(all))
+	fmt.Printf("\n📝 Decisions appended to %s\n\n", *logPath)
+
+	fmt.Println("✅ Example completed successfully!")
+}
+
+func printEvaluation(e Evaluation) {
+	fmt.Printf("📊 Routing accuracy: %d of %d (%.0f%%), %d sent to the %s fallback\n\n", e.Correct, e.Total, 100*float64(e.Correct)/float64(max(e.Total, 1)), e.Fallbacks, Generalist)
+	routes := make([]string, 0, len(e.ByRoute))
+	for route := range e.ByRoute {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+	fmt.Printf("%-14s %8s %6s\n", "EXPECTED", "CORRECT", "TOTAL")
+	for _, route := range routes {
+		fmt.Printf("%-14s %8d %6d\n", route, e.ByRoute[route][0], e.ByRoute[route][1])
+	}
+	for _, d := range e.Misroutes {
+		fmt.Printf("\n❌ %q\n   expected %s, routed to %s", d.Message, d.Expected, d.Route)
+		if d.Fallback != "" {
+			fmt.Printf(" (%s)", d.Fallback)
+		}
+		fmt.Println()
+	}
+}
+
+func printDecision(d Decision, answer string) {
+	fmt.Printf("💬 %s\n", d.Message)
+	route := fmt.Sprintf("→ %s (%s, %.2f)", d.Route, d.Intent, d.Confidence)
+	if d.Fallback != "" {
+		route += " fallback: " + d.Fallback
+	}
+	fmt.Printf("   %s\n", route)
+	if answer != "" {
+		fmt.Printf("   🤖 %s\n", strings.TrimSpace(answer))
+	}
+	fmt.Println()
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Handler answers a request. The specialists and the generalist are agents; with -show
+// they return scripted text.
+type Handler func(message string) (string, error)
+
+// Classification is the router's reading of a request
+type Classification struct {
+	Intent     string  `json:"intent"`
+	Confidence float64 `json:"confidence"`
+	Reason     string  `json:"reason"`
+}
+
+// Decision is one routing decision, as written to the decision log. Expected is set
+// for labelled requests, so the log can be used to evaluate the router.
+type Decision struct {
+	Time           time.Time `json:"time"`
+	Message        string    `json:"message"`
+	Intent         string    `json:"intent"`
+	Confidence     float64   `json:"confidence"`
+	Reason         string    `json:"reason"`
+	Route          string    `json:"route"`
+	Fallback       string    `json:"fallback,omitempty"` // why the generalist was used
+	Expected       string    `json:"expected,omitempty"`
+	ClassifyMillis int64     `json:"classify_ms"`
+	HandleMillis   int64     `json:"handle_ms"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// Generalist is the route taken when no specialist fits
+const Generalist = "general"
+
+// Router classifies each request with one small model call, without tools or
+// history, and hands it to the matching specialist. Requests it cannot place, or
+// places with less than MinConfidence, go to the generalist.
+type Router struct {
+	Classify      func(message string) (Classification, error)
+	Specialists   map[string]Handler
+	Generalist    Handler
+	MinConfidence float64
+	Log           *DecisionLog
+}
+
+// Handle routes and answers one request. expected is the correct route for a
+// labelled request, or empty.
+func (r *Router) Handle(message, expected string) (string, Decision, error) {
+	d := Decision{Time: time.Now(), Message: message, Expected: expected}
+
+	start := time.Now()
+	c, err := r.Classify(message)
+	d.ClassifyMillis = time.Since(start).Milliseconds()
+
+	handler := r.Generalist
+	switch {
+	case err != nil:
+		d.Fallback = "classification failed: " + err.Error()
+	case r.Specialists[c.Intent] == nil:
+		d.Fallback = fmt.Sprintf("no specialist for %q", c.Intent)
+	case c.Confidence < r.MinConfidence:
+		d.Fallback = fmt.Sprintf("confidence %.2f is below %.2f", c.Confidence, r.MinConfidence)
+	default:
+		handler = r.Specialists[c.Intent]
+	}
+	d.Intent, d.Confidence, d.Reason = c.Intent, c.Confidence, c.Reason
+	d.Route = c.Intent
+	if d.Fallback != "" {
+		d.Route = Generalist
+	}
+
+	start = time.Now()
+	answer, err := handler(message)
+	d.HandleMillis = time.Since(start).Milliseconds()
+	if err != nil {
+		d.Error = err.Error()
+	}
+	if logErr := r.Log.Write(d); logErr != nil && err == nil {
+		err = fmt.Errorf("logging the decision: %w", logErr)
+	}
+	return answer, d, err
+}
+
+// parseClassification reads the router's JSON reply
+func parseClassification(reply string) (Classification, error) {
+	start, end := strings.Index(reply, "{"), strings.LastIndex(reply, "}")
+	if start < 0 || end < start {
+		return Classification{}, fmt.Errorf("no JSON in reply: %q", reply)
+	}
+	var c Classification
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &c); err != nil {
+		return Classification{}, fmt.Errorf("reading reply: %w", err)
+	}
+	c.Intent = strings.ToLower(strings.TrimSpace(c.Intent))
+	return c, nil
+}
+
+// DecisionLog appends decisions to a JSON lines file, one per request
+type DecisionLog struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+func OpenDecisionLog(path string) (*DecisionLog, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &DecisionLog{file: f, enc: json.NewEncoder(f)}, nil
+}
+
+func (l *DecisionLog) Write(d Decision) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.enc.Encode(d)
+}
+
+func (l *DecisionLog) Close() error {
+	return l.file.Close()
+}
+
+// ReadDecisions reads every decision in a log file, so a log collected in production,
+// with Expected filled in later by a reviewer, can be evaluated the same way
+func ReadDecisions(path string) ([]Decision, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var decisions []Decision
+	dec := json.NewDecoder(f)
+	for dec.More() {
+		var d Decision
+		if err := dec.Decode(&d); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		decisions = append(decisions, d)
+	}
+	return decisions, nil
+}
+
+// Evaluation compares routing decisions with their expected routes
+type Evaluation struct {
+	Total, Correct int
+	Fallbacks      int
+	Misroutes      []Decision
+	ByRoute        map[string][2]int // expected route to correct and total
+}
+
+func Evaluate(decisions []Decision) Evaluation {
+	e := Evaluation{ByRoute: map[string][2]int{}}
+	for _, d := range decisions {
+		if d.Fallback != "" {
+			e.Fallbacks++
+		}
+		if d.Expected == "" {
+			continue
+		}
+		e.Total++
+		counts := e.ByRoute[d.Expected]
+		counts[1]++
+		if d.Route == d.Expected {
+			e.Correct++
+			counts[0]++
+		} else {
+			e.Misroutes = append(e.Misroutes, d)
+		}
+		e.ByRoute[d.Expected] = counts
+	}
+	return e
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic/ai"
+)
+
+type cacheEntry struct {
+	result  *ai.ToolResult
+	expires time.Time
+}
+
+// CacheStats counts lookups per tool
+type CacheStats struct {
+	Hits   int
+	Misses int
+}
+
+// ToolCache memoizes tool results by tool name and arguments. Failed calls and results
+// marked as errors are never cached, so the next call retries them.
+type ToolCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	stats   map[string]*CacheStats
+}
+
+func NewToolCache() *ToolCache {
+	return &ToolCache{
+		entries: make(map[string]cacheEntry),
+		stats:   make(map[string]*CacheStats),
+	}
+}
+
+// cacheKey canonicalizes the arguments. encoding/json writes map keys in sorted order at
+// every level, so the same arguments in a different order give the same key.
+func cacheKey(toolName string, args map[string]interface{}) (string, bool) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", false
+	}
+	return toolName + "\x00" + string(data), true
+}
+
+// Wrap returns a copy of tool whose results are reused for ttl
+func (c *ToolCache) Wrap(tool aigentic.AgentTool, ttl time.Duration) aigentic.AgentTool {
+	execute := tool.Execute
+	tool.Execute = func(run *aigentic.AgentRun, args map[string]interface{}) (*ai.ToolResult, error) {
+		key, ok := cacheKey(tool.Name, args)
+		if !ok {
+			return execute(run, args)
+		}
+
+		if result, hit := c.lookup(tool.Name, key); hit {
+			return result, nil
+		}
+
+		result, err := execute(run, args)
+		if err == nil && result != nil && !result.Error {
+			c.store(key, result, ttl)
+		}
+		return result, err
+	}
+	return tool
+}
+
+func (c *ToolCache) lookup(toolName, key string) (*ai.ToolResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats, ok := c.stats[toolName]
+	if !ok {
+		stats = &CacheStats{}
+		c.stats[toolName] = stats
+	}
+
+	entry, ok := c.entries[key]
+	if ok && time.Now().Before(entry.expires) {
+		stats.Hits++
+		// Return a copy so the caller cannot change the cached result
+		result := *entry.result
+		return &result, true
+	}
+	if ok {
+		delete(c.entries, key)
+	}
+	stats.Misses++
+	return nil, false
+}
+
+func (c *ToolCache) store(key string, result *ai.ToolResult, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	copied := *result
+	c.entries[key] = cacheEntry{result: &copied, expires: time.Now().Add(ttl)}
+}
+
+// Stats returns a snapshot of the hit and miss counts per tool
+func (c *ToolCache) Stats() map[string]CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	snapshot := make(map[string]CacheStats, len(c.stats))
+	for name, stats := range c.stats {
+		snapshot[name] = *stats
+	}
+	return snapshot
+}
+
+// Purge drops expired entries. Entries are also replaced lazily when looked up after
+// they expire, so calling it only matters for long-running processes.
+func (c *ToolCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
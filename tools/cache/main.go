@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// backendCalls counts calls that actually reached the slow backends
+var backendCalls atomic.Int32
+
+func createWeatherTool() aigentic.AgentTool {
+	type WeatherInput struct {
+		City string `json:"city" description:"City name, e.g. Paris"`
+	}
+
+	conditions := []string{"sunny", "partly cloudy", "overcast", "light rain", "windy"}
+
+	return aigentic.NewTool(
+		"get_weather",
+		"Gets the current weather for a city",
+		func(run *aigentic.AgentRun, input WeatherInput) (string, error) {
+			backendCalls.Add(1)
+			fmt.Printf("  [weather API: %s]\n", input.City)
+
+			// Simulate a slow, metered weather API
+			time.Sleep(800 * time.Millisecond)
+
+			seed := 0
+			for _, r := range strings.ToLower(input.City) {
+				seed += int(r)
+			}
+			return fmt.Sprintf("%s: %s, %d°C", input.City, conditions[seed%len(conditions)], 8+seed%20), nil
+		},
+	)
+}
+
+func createTimeTool() aigentic.AgentTool {
+	type TimeInput struct {
+		Timezone string `json:"timezone" description:"IANA timezone name (e.g., 'America/New_York', 'Europe/London', 'Asia/Tokyo')"`
+	}
+
+	return aigentic.NewTool(
+		"get_current_time",
+		"Gets the current time in a specified timezone",
+		func(run *aigentic.AgentRun, input TimeInput) (string, error) {
+			backendCalls.Add(1)
+			fmt.Printf("  [time lookup: %s]\n", input.Timezone)
+
+			loc, err := time.LoadLocation(input.Timezone)
+			if err != nil {
+				return "", fmt.Errorf("invalid timezone '%s'. Use IANA timezone names like 'America/New_York'", input.Timezone)
+			}
+			return fmt.Sprintf("Current time in %s: %s", input.Timezone, time.Now().In(loc).Format("Monday 3:04 PM MST")), nil
+		},
+	)
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	fmt.Println("🗃️  Tool Result Caching Example")
+	fmt.Println("==============================")
+	fmt.Println()
+
+	cache := NewToolCache()
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:        model,
+		Name:         "TravelAssistant",
+		Description:  "An assistant that answers weather and time questions",
+		Instructions: "Use get_weather and get_current_time to answer. Always call the tools rather than relying on earlier answers, as conditions change.",
+		AgentTools: []aigentic.AgentTool{
+			// Weather changes slowly, so reuse it for 10 minutes. The time tool only has
+			// minute resolution, so 30 seconds keeps it accurate enough.
+			cache.Wrap(createWeatherTool(), 10*time.Minute),
+			cache.Wrap(createTimeTool(), 30*time.Second),
+		},
+	}
+
+	questions := []string{
+		"What's the weather and local time in Paris and in Tokyo?",
+		"Is it warmer in Paris or London right now?",
+		"I'm flying Tokyo to London. What's the weather at both ends, and what time is it in Tokyo?",
+	}
+
+	for _, question := range questions {
+		fmt.Printf("❓ %s\n", question)
+
+		before := backendCalls.Load()
+		start := time.Now()
+		response, err := agent.Execute(question)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+
+		fmt.Printf("Response: %s\n", response)
+		fmt.Printf("(%d backend calls, %v)\n\n", backendCalls.Load()-before, time.Since(start).Round(time.Millisecond))
+	}
+
+	fmt.Println("Cache statistics")
+	fmt.Println("----------------")
+	stats := cache.Stats()
+	names := make([]string, 0, len(stats))
+	for name := range stats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		s := stats[name]
+		fmt.Printf("%-18s %d hits, %d misses\n", name, s.Hits, s.Misses)
+	}
+
+	fmt.Println("\n✅ Example completed successfully!")
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event is a calendar entry
+type Event struct {
+	UID         string
+	Summary     string
+	Location    string
+	Description string
+	Start       time.Time
+	End         time.Time
+}
+
+// Calendar is a local .ics file. It reads and writes the VEVENT fields the tools use;
+// other components and properties in the file are not preserved.
+type Calendar struct {
+	mu     sync.Mutex
+	path   string
+	loc    *time.Location
+	events []Event
+}
+
+// OpenCalendar loads path, or starts an empty calendar if it does not exist yet.
+// Floating times (without a zone) are read in loc.
+func OpenCalendar(path string, loc *time.Location) (*Calendar, error) {
+	c := &Calendar{path: path, loc: loc}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		// A line starting with a space or tab continues the previous one (RFC 5545 folding)
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var current *Event
+	for _, line := range lines {
+		name, params, value := splitProperty(line)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			current = &Event{}
+		case name == "END" && value == "VEVENT" && current != nil:
+			if current.End.IsZero() {
+				current.End = current.Start.Add(time.Hour)
+			}
+			c.events = append(c.events, *current)
+			current = nil
+		case current == nil:
+		case name == "UID":
+			current.UID = value
+		case name == "SUMMARY":
+			current.Summary = unescapeText(value)
+		case name == "LOCATION":
+			current.Location = unescapeText(value)
+		case name == "DESCRIPTION":
+			current.Description = unescapeText(value)
+		case name == "DTSTART", name == "DTEND":
+			t, err := c.parseTime(params, value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			if name == "DTSTART" {
+				current.Start = t
+			} else {
+				current.End = t
+			}
+		}
+	}
+
+	c.sort()
+	return c, nil
+}
+
+// splitProperty splits "DTSTART;TZID=Europe/Paris:20240601T090000" into its name,
+// parameters and value
+func splitProperty(line string) (string, map[string]string, string) {
+	head, value, _ := strings.Cut(line, ":")
+	parts := strings.Split(head, ";")
+	params := make(map[string]string)
+	for _, p := range parts[1:] {
+		if k, v, ok := strings.Cut(p, "="); ok {
+			params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), params, value
+}
+
+func (c *Calendar) parseTime(params map[string]string, value string) (time.Time, error) {
+	loc := c.loc
+	if tzid, ok := params["TZID"]; ok {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+
+	switch {
+	case params["VALUE"] == "DATE" || len(value) == 8:
+		return time.ParseInLocation("20060102", value, loc)
+	case strings.HasSuffix(value, "Z"):
+		t, err := time.Parse("20060102T150405Z", value)
+		return t.In(c.loc), err
+	default:
+		return time.ParseInLocation("20060102T150405", value, loc)
+	}
+}
+
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+func unescapeText(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(s)
+}
+
+func (c *Calendar) sort() {
+	sort.Slice(c.events, func(i, j int) bool { return c.events[i].Start.Before(c.events[j].Start) })
+}
+
+// Events returns the events that overlap [from, to)
+func (c *Calendar) Events(from, to time.Time) []Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var events []Event
+	for _, e := range c.events {
+		if e.Start.Before(to) && e.End.After(from) {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// FreeSlots returns up to limit start times for a meeting of the given length between
+// from and to, on weekdays within the daily window [dayStart, dayEnd). Candidates are
+// aligned to 15 minutes.
+func (c *Calendar) FreeSlots(from, to time.Time, length, dayStart, dayEnd time.Duration, limit int) []time.Time {
+	busy := c.Events(from, to)
+	step := 15 * time.Minute
+
+	var slots []time.Time
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, c.loc); day.Before(to) && len(slots) < limit; day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			continue
+		}
+
+		// time.Date keeps wall-clock hours right on days when daylight saving changes
+		windowStart := time.Date(day.Year(), day.Month(), day.Day(), 0, int(dayStart.Minutes()), 0, 0, c.loc)
+		windowEnd := time.Date(day.Year(), day.Month(), day.Day(), 0, int(dayEnd.Minutes()), 0, 0, c.loc)
+
+		for start := windowStart; !start.Add(length).After(windowEnd) && len(slots) < limit; start = start.Add(step) {
+			end := start.Add(length)
+			if start.Before(from) || end.After(to) {
+				continue
+			}
+
+			free := true
+			for _, e := range busy {
+				if e.Start.Before(end) && e.End.After(start) {
+					free = false
+					// Jump to the first aligned start after the meeting; the loop adds step
+					start = e.End.Add(step - 1).Truncate(step).Add(-step)
+					break
+				}
+			}
+			if free {
+				slots = append(slots, start)
+				// Offer distinct options rather than every quarter hour of one gap
+				start = start.Add(length - step)
+			}
+		}
+	}
+	return slots
+}
+
+// Add saves a new event and rewrites the file
+func (c *Calendar) Add(e Event) (Event, error) {
+	if e.UID == "" {
+		id := make([]byte, 8)
+		rand.Read(id)
+		e.UID = hex.EncodeToString(id) + "@aigentic-examples"
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	previous := c.events
+	c.events = append(slices.Clone(previous), e)
+	c.sort()
+	if err := c.save(); err != nil {
+		c.events = previous
+		return Event{}, err
+	}
+	return e, nil
+}
+
+// save writes the whole calendar to a temporary file, then renames it over the original
+func (c *Calendar) save() error {
+	var b strings.Builder
+	b.WriteString("BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//aigentic-examples//calendar tool//EN\r\n")
+
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	for _, e := range c.events {
+		b.WriteString("BEGIN:VEVENT\r\n")
+		writeLine(&b, "UID:"+e.UID)
+		writeLine(&b, "DTSTAMP:"+stamp)
+		writeLine(&b, "DTSTART:"+e.Start.UTC().Format("20060102T150405Z"))
+		writeLine(&b, "DTEND:"+e.End.UTC().Format("20060102T150405Z"))
+		writeLine(&b, "SUMMARY:"+escapeText(e.Summary))
+		if e.Location != "" {
+			writeLine(&b, "LOCATION:"+escapeText(e.Location))
+		}
+		if e.Description != "" {
+			writeLine(&b, "DESCRIPTION:"+escapeText(e.Description))
+		}
+		b.WriteString("END:VEVENT\r\n")
+	}
+	b.WriteString("END:VCALENDAR\r\n")
+
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(b.String()), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
+
+// writeLine folds lines longer than 75 octets, as RFC 5545 requires
+func writeLine(b *strings.Builder, line string) {
+	for len(line) > 75 {
+		cut := 75
+		// Do not split a UTF-8 sequence
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+	}
+	b.WriteString(line + "\r\n")
+}
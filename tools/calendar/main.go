@@ -0,0 +1,264 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+const timeLayout = "2006-01-02 15:04"
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// parseClock turns "13:30" into the offset from midnight
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, use HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func formatEvent(e Event) string {
+	line := fmt.Sprintf("%s–%s %s", e.Start.Format("Mon 2006-01-02 15:04"), e.End.Format("15:04"), e.Summary)
+	if e.Location != "" {
+		line += " @ " + e.Location
+	}
+	return line
+}
+
+func createCalendarTools(cal *Calendar, loc *time.Location) []aigentic.AgentTool {
+	parseDate := func(s string) (time.Time, error) {
+		t, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(s), loc)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q, use YYYY-MM-DD", s)
+		}
+		return t, nil
+	}
+
+	type ListEventsInput struct {
+		From string `json:"from" description:"First day to list, YYYY-MM-DD"`
+		To   string `json:"to" description:"Last day to list (inclusive), YYYY-MM-DD"`
+	}
+	type FindFreeSlotInput struct {
+		DurationMinutes int    `json:"duration_minutes" description:"Length of the meeting in minutes"`
+		From            string `json:"from" description:"First day to search, YYYY-MM-DD"`
+		To              string `json:"to" description:"Last day to search (inclusive), YYYY-MM-DD"`
+		EarliestTime    string `json:"earliest_time,omitempty" description:"Earliest start time each day, HH:MM (default 09:00)"`
+		LatestTime      string `json:"latest_time,omitempty" description:"Time the meeting must end by each day, HH:MM (default 17:00)"`
+	}
+	type CreateEventInput struct {
+		Title           string `json:"title" description:"Event title"`
+		Start           string `json:"start" description:"Start time, YYYY-MM-DD HH:MM"`
+		DurationMinutes int    `json:"duration_minutes" description:"Length in minutes"`
+		Location        string `json:"location,omitempty" description:"Room or video link"`
+		Description     string `json:"description,omitempty" description:"Agenda or notes"`
+	}
+
+	listEvents := aigentic.NewTool(
+		"list_events",
+		"Lists calendar events between two dates",
+		func(run *aigentic.AgentRun, input ListEventsInput) (string, error) {
+			from, err := parseDate(input.From)
+			if err != nil {
+				return "", err
+			}
+			to, err := parseDate(input.To)
+			if err != nil {
+				return "", err
+			}
+
+			events := cal.Events(from, to.AddDate(0, 0, 1))
+			if len(events) == 0 {
+				return "No events in that period.", nil
+			}
+			lines := make([]string, len(events))
+			for i, e := range events {
+				lines[i] = formatEvent(e)
+			}
+			return strings.Join(lines, "\n"), nil
+		},
+	)
+
+	findFreeSlot := aigentic.NewTool(
+		"find_free_slot",
+		"Finds free weekday slots for a meeting of a given length. Returns up to 5 options.",
+		func(run *aigentic.AgentRun, input FindFreeSlotInput) (string, error) {
+			if input.DurationMinutes <= 0 {
+				return "", fmt.Errorf("duration_minutes must be positive")
+			}
+			from, err := parseDate(input.From)
+			if err != nil {
+				return "", err
+			}
+			to, err := parseDate(input.To)
+			if err != nil {
+				return "", err
+			}
+
+			earliest, latest := 9*time.Hour, 17*time.Hour
+			if input.EarliestTime != "" {
+				if earliest, err = parseClock(input.EarliestTime); err != nil {
+					return "", err
+				}
+			}
+			if input.LatestTime != "" {
+				if latest, err = parseClock(input.LatestTime); err != nil {
+					return "", err
+				}
+			}
+
+			// Never offer a slot that has already started
+			if now := time.Now().In(loc); from.Before(now) {
+				from = now
+			}
+
+			length := time.Duration(input.DurationMinutes) * time.Minute
+			slots := cal.FreeSlots(from, to.AddDate(0, 0, 1), length, earliest, latest, 5)
+			if len(slots) == 0 {
+				return "No free slot matches those constraints.", nil
+			}
+			lines := make([]string, len(slots))
+			for i, s := range slots {
+				lines[i] = fmt.Sprintf("%s – %s", s.Format("Mon "+timeLayout), s.Add(length).Format("15:04"))
+			}
+			return "Free slots:\n" + strings.Join(lines, "\n"), nil
+		},
+	)
+
+	createEvent := aigentic.NewTool(
+		"create_event",
+		"Creates a calendar event. Fails if it overlaps an existing event.",
+		func(run *aigentic.AgentRun, input CreateEventInput) (string, error) {
+			start, err := time.ParseInLocation(timeLayout, strings.TrimSpace(input.Start), loc)
+			if err != nil {
+				return "", fmt.Errorf("invalid start %q, use YYYY-MM-DD HH:MM", input.Start)
+			}
+			if input.DurationMinutes <= 0 {
+				return "", fmt.Errorf("duration_minutes must be positive")
+			}
+			end := start.Add(time.Duration(input.DurationMinutes) * time.Minute)
+
+			if conflicts := cal.Events(start, end); len(conflicts) > 0 {
+				return "", fmt.Errorf("conflicts with %s; use find_free_slot to pick another time", formatEvent(conflicts[0]))
+			}
+
+			event, err := cal.Add(Event{
+				Summary:     input.Title,
+				Location:    input.Location,
+				Description: input.Description,
+				Start:       start,
+				End:         end,
+			})
+			if err != nil {
+				return "", fmt.Errorf("saving calendar: %w", err)
+			}
+			return "Created: " + formatEvent(event), nil
+		},
+	)
+
+	return []aigentic.AgentTool{listEvents, findFreeSlot, createEvent}
+}
+
+// seedCalendar adds a realistic week of meetings to an empty calendar
+func seedCalendar(cal *Calendar, loc *time.Location) error {
+	now := time.Now().In(loc)
+	monday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	monday = monday.AddDate(0, 0, (8-int(monday.Weekday()))%7)
+	if !monday.After(now) {
+		monday = monday.AddDate(0, 0, 7)
+	}
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(monday.Year(), monday.Month(), monday.Day()+day, hour, minute, 0, 0, loc)
+	}
+	meetings := []Event{
+		{Summary: "Team standup", Start: at(0, 9, 30), End: at(0, 9, 45)},
+		{Summary: "Quarterly planning", Location: "Room 4B", Start: at(0, 13, 0), End: at(0, 16, 0)},
+		{Summary: "Team standup", Start: at(1, 9, 30), End: at(1, 9, 45)},
+		{Summary: "Customer call: Acme", Start: at(1, 13, 0), End: at(1, 14, 30)},
+		{Summary: "1:1 with manager", Start: at(1, 15, 0), End: at(1, 15, 30)},
+		{Summary: "Team standup", Start: at(2, 9, 30), End: at(2, 9, 45)},
+		{Summary: "Hiring panel", Start: at(2, 13, 30), End: at(2, 17, 0)},
+	}
+	for _, m := range meetings {
+		if _, err := cal.Add(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	path := flag.String("file", "calendar.ics", "ICS file to read and write; created with sample meetings if missing")
+	tz := flag.String("tz", "Local", "IANA time zone for the calendar")
+	flag.Parse()
+
+	fmt.Println("📅 Calendar Scheduling Example")
+	fmt.Println("==============================")
+	fmt.Println()
+
+	loc, err := time.LoadLocation(*tz)
+	if err != nil {
+		log.Fatalf("Invalid time zone: %v", err)
+	}
+
+	_, statErr := os.Stat(*path)
+	cal, err := OpenCalendar(*path, loc)
+	if err != nil {
+		log.Fatalf("Failed to open calendar: %v", err)
+	}
+	if os.IsNotExist(statErr) {
+		if err := seedCalendar(cal, loc); err != nil {
+			log.Fatalf("Failed to create sample calendar: %v", err)
+		}
+		fmt.Printf("Created %s with sample meetings for next week\n\n", *path)
+	}
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+	today := time.Now().In(loc)
+
+	agent := aigentic.Agent{
+		Model:       model,
+		Name:        "SchedulingAssistant",
+		Description: "An assistant that manages the user's calendar",
+		Instructions: fmt.Sprintf("You manage the user's calendar. Today is %s and times are in %s. ", today.Format("Monday 2006-01-02"), loc) +
+			"To schedule a meeting, call find_free_slot with the user's constraints, pick the earliest slot that satisfies them, " +
+			"then call create_event. Never create an event without checking for a free slot first. Confirm the final time to the user.",
+		AgentTools: createCalendarTools(cal, loc),
+	}
+
+	requests := []string{
+		"What do I have on next Monday and Tuesday?",
+		"Book a 45 minute design review with the platform team next Tuesday or Wednesday. It must be in the afternoon, not before 1pm, and finish by 5pm. Put it in Room 2A.",
+	}
+
+	for _, request := range requests {
+		fmt.Printf("❓ %s\n", request)
+		response, err := agent.Execute(request)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		fmt.Printf("Response: %s\n\n", response)
+	}
+
+	fmt.Printf("Calendar saved to %s\n", *path)
+	fmt.Println("\n✅ Example completed successfully!")
+}
@@ -0,0 +1,342 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+type Product struct {
+	Name  string
+	Price float64
+	Stock int
+}
+
+// Inventory is shared by every run, unlike carts
+type Inventory struct {
+	mu       sync.Mutex
+	products map[string]*Product
+}
+
+func (inv *Inventory) Lookup(sku string) (Product, bool) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	p, ok := inv.products[sku]
+	if !ok {
+		return Product{}, false
+	}
+	return *p, true
+}
+
+// Reserve takes the items out of stock, all or nothing
+func (inv *Inventory) Reserve(items map[string]int) error {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	for sku, qty := range items {
+		if p := inv.products[sku]; p.Stock < qty {
+			return fmt.Errorf("only %d %s left in stock", p.Stock, p.Name)
+		}
+	}
+	for sku, qty := range items {
+		inv.products[sku].Stock -= qty
+	}
+	return nil
+}
+
+func (inv *Inventory) Catalog() string {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+
+	skus := make([]string, 0, len(inv.products))
+	for sku := range inv.products {
+		skus = append(skus, sku)
+	}
+	sort.Strings(skus)
+
+	lines := make([]string, len(skus))
+	for i, sku := range skus {
+		p := inv.products[sku]
+		lines[i] = fmt.Sprintf("%s: %s, $%.2f (%d in stock)", sku, p.Name, p.Price, p.Stock)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Cart is the per-run state. Its mutex protects it when a run calls tools concurrently.
+type Cart struct {
+	mu      sync.Mutex
+	items   map[string]int
+	orderID string
+}
+
+func newCart() *Cart {
+	return &Cart{items: make(map[string]int)}
+}
+
+var orderCounter struct {
+	sync.Mutex
+	n int
+}
+
+func nextOrderID() string {
+	orderCounter.Lock()
+	defer orderCounter.Unlock()
+	orderCounter.n++
+	return fmt.Sprintf("ORD-%04d", 1000+orderCounter.n)
+}
+
+// summary lists the cart contents; the caller holds c.mu
+func (c *Cart) summary(inv *Inventory) string {
+	if len(c.items) == 0 {
+		return "The cart is empty."
+	}
+
+	skus := make([]string, 0, len(c.items))
+	for sku := range c.items {
+		skus = append(skus, sku)
+	}
+	sort.Strings(skus)
+
+	var lines []string
+	total := 0.0
+	for _, sku := range skus {
+		p, _ := inv.Lookup(sku)
+		qty := c.items[sku]
+		total += p.Price * float64(qty)
+		lines = append(lines, fmt.Sprintf("%d x %s (%s) = $%.2f", qty, p.Name, sku, p.Price*float64(qty)))
+	}
+	lines = append(lines, fmt.Sprintf("Total: $%.2f", total))
+	return strings.Join(lines, "\n")
+}
+
+// cartTool builds a tool by hand rather than with aigentic.NewTool, because typed tools
+// are not given the run, and the run is needed to find its cart
+func cartTool(name, description string, properties map[string]interface{}, required []string, fn func(run *aigentic.AgentRun, args map[string]interface{}) (string, error)) aigentic.AgentTool {
+	return aigentic.AgentTool{
+		Name:        name,
+		Description: description,
+		InputSchema: map[string]interface{}{
+			"type":       "object",
+			"properties": properties,
+			"required":   required,
+		},
+		NewExecute: func(run *aigentic.AgentRun, validated aigentic.ValidationResult) (*ai.ToolResult, error) {
+			args, _ := validated.Values.(map[string]interface{})
+			text, err := fn(run, args)
+			if err != nil {
+				return &ai.ToolResult{Content: []ai.ToolContent{{Type: "text", Content: fmt.Sprintf("Error: %v", err)}}, Error: true}, nil
+			}
+			return &ai.ToolResult{Content: []ai.ToolContent{{Type: "text", Content: text}}}, nil
+		},
+	}
+}
+
+func createCartTools(carts *RunState[Cart], inv *Inventory) []aigentic.AgentTool {
+	itemProperties := map[string]interface{}{
+		"sku": map[string]interface{}{
+			"type":        "string",
+			"description": "Product SKU from the catalog",
+		},
+		"quantity": map[string]interface{}{
+			"type":        "integer",
+			"description": "How many to add or remove",
+		},
+	}
+	itemArgs := func(args map[string]interface{}) (sku string, quantity int) {
+		sku, _ = args["sku"].(string)
+		// JSON numbers arrive as float64
+		q, _ := args["quantity"].(float64)
+		return strings.ToUpper(strings.TrimSpace(sku)), int(q)
+	}
+	noProperties := map[string]interface{}{}
+
+	// withCart runs fn with the run's cart locked, and refuses changes after checkout
+	withCart := func(run *aigentic.AgentRun, fn func(*Cart) (string, error)) (string, error) {
+		cart := carts.Get(run)
+		cart.mu.Lock()
+		defer cart.mu.Unlock()
+		if cart.orderID != "" {
+			return "", fmt.Errorf("this cart was already checked out as order %s", cart.orderID)
+		}
+		return fn(cart)
+	}
+
+	listProducts := cartTool(
+		"list_products",
+		"Lists the products in the store with SKU, price and stock",
+		noProperties, []string{},
+		func(run *aigentic.AgentRun, args map[string]interface{}) (string, error) {
+			return inv.Catalog(), nil
+		},
+	)
+
+	addItem := cartTool(
+		"add_item",
+		"Adds a product to the shopping cart",
+		itemProperties, []string{"sku", "quantity"},
+		func(run *aigentic.AgentRun, args map[string]interface{}) (string, error) {
+			sku, quantity := itemArgs(args)
+			p, ok := inv.Lookup(sku)
+			if !ok {
+				return "", fmt.Errorf("unknown SKU %q; call list_products", sku)
+			}
+			if quantity <= 0 {
+				return "", fmt.Errorf("quantity must be positive")
+			}
+			return withCart(run, func(cart *Cart) (string, error) {
+				cart.items[sku] += quantity
+				return fmt.Sprintf("Added %d x %s. Cart now has %d.", quantity, p.Name, cart.items[sku]), nil
+			})
+		},
+	)
+
+	removeItem := cartTool(
+		"remove_item",
+		"Removes a quantity of a product from the shopping cart",
+		itemProperties, []string{"sku", "quantity"},
+		func(run *aigentic.AgentRun, args map[string]interface{}) (string, error) {
+			sku, quantity := itemArgs(args)
+			return withCart(run, func(cart *Cart) (string, error) {
+				have := cart.items[sku]
+				if have == 0 {
+					return "", fmt.Errorf("%s is not in the cart", sku)
+				}
+				if quantity <= 0 || quantity >= have {
+					delete(cart.items, sku)
+					return fmt.Sprintf("Removed all %s from the cart.", sku), nil
+				}
+				cart.items[sku] = have - quantity
+				return fmt.Sprintf("Removed %d x %s. %d left in the cart.", quantity, sku, cart.items[sku]), nil
+			})
+		},
+	)
+
+	viewCart := cartTool(
+		"view_cart",
+		"Shows the current contents of the shopping cart and the total",
+		noProperties, []string{},
+		func(run *aigentic.AgentRun, args map[string]interface{}) (string, error) {
+			return withCart(run, func(cart *Cart) (string, error) {
+				return cart.summary(inv), nil
+			})
+		},
+	)
+
+	checkout := cartTool(
+		"checkout",
+		"Places the order for everything in the cart. The cart cannot be changed afterwards.",
+		noProperties, []string{},
+		func(run *aigentic.AgentRun, args map[string]interface{}) (string, error) {
+			return withCart(run, func(cart *Cart) (string, error) {
+				if len(cart.items) == 0 {
+					return "", fmt.Errorf("the cart is empty")
+				}
+				if err := inv.Reserve(cart.items); err != nil {
+					return "", err
+				}
+				summary := cart.summary(inv)
+				cart.orderID = nextOrderID()
+				return fmt.Sprintf("Order %s placed.\n%s", cart.orderID, summary), nil
+			})
+		},
+	)
+
+	return []aigentic.AgentTool{listProducts, addItem, removeItem, viewCart, checkout}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	fmt.Println("🛒 Stateful Tools Example")
+	fmt.Println("=========================")
+	fmt.Println()
+
+	inv := &Inventory{products: map[string]*Product{
+		"MUG-01":  {Name: "Ceramic mug", Price: 12.50, Stock: 20},
+		"TEE-BLK": {Name: "Black T-shirt", Price: 24.00, Stock: 5},
+		"CAP-NVY": {Name: "Navy cap", Price: 18.00, Stock: 8},
+		"STK-SET": {Name: "Sticker set", Price: 4.00, Stock: 100},
+	}}
+	carts := NewRunState(newCart)
+	tools := createCartTools(carts, inv)
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	shoppers := map[string]string{
+		"Alice": "I'd like 2 mugs and a black T-shirt. Actually, make it just 1 mug. Then check out.",
+		"Bob":   "Put 3 navy caps and 2 sticker sets in my cart, show me the total and check out.",
+	}
+
+	// Both shoppers use the same tools at the same time; each run gets its own cart
+	var wg sync.WaitGroup
+	var printMu sync.Mutex
+	for name, request := range shoppers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			agent := aigentic.Agent{
+				Model:        model,
+				Name:         "ShopAssistant",
+				Description:  "A shopping assistant that manages the customer's cart",
+				Instructions: "Use list_products to find SKUs, then manage the cart with the tools. Check out only when the customer asks.",
+				AgentTools:   tools,
+			}
+
+			run, err := agent.Start(request)
+			if err != nil {
+				log.Fatalf("Failed to start agent for %s: %v", name, err)
+			}
+
+			var trace strings.Builder
+			var response string
+			for event := range run.Next() {
+				switch e := event.(type) {
+				case *aigentic.ContentEvent:
+					response += e.Content
+				case *aigentic.ToolEvent:
+					fmt.Fprintf(&trace, "  [%s]\n", e.ToolName)
+				case *aigentic.ErrorEvent:
+					fmt.Fprintf(&trace, "  error: %v\n", e.Err)
+				}
+			}
+
+			// The run is over, so its cart can be dropped
+			cart := carts.Release(run)
+
+			printMu.Lock()
+			defer printMu.Unlock()
+			fmt.Printf("🧑 %s: %s\n%s", name, request, trace.String())
+			fmt.Printf("Response: %s\n", response)
+			if cart != nil {
+				summary := cart.summary(inv)
+				fmt.Printf("Final cart (order %q):\n%s\n\n", cart.orderID, summary)
+			} else {
+				fmt.Print("No cart was created for this run\n\n")
+			}
+		}()
+	}
+	wg.Wait()
+
+	fmt.Println("Inventory after both orders:")
+	fmt.Println(inv.Catalog())
+	fmt.Println("\n✅ Example completed successfully!")
+}
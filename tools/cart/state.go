@@ -0,0 +1,45 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/nexxia-ai/aigentic"
+)
+
+// RunState keeps one value of T per agent run. Every tool call in a run receives the
+// same *AgentRun, so the pointer identifies the run; tools shared by several runs each
+// see only their own run's state.
+type RunState[T any] struct {
+	mu     sync.Mutex
+	values map[*aigentic.AgentRun]*T
+	init   func() *T
+}
+
+func NewRunState[T any](init func() *T) *RunState[T] {
+	return &RunState[T]{values: make(map[*aigentic.AgentRun]*T), init: init}
+}
+
+// Get returns the state for run, creating it on first use. The map is guarded here;
+// the value itself must guard its own fields if the run calls tools concurrently.
+func (s *RunState[T]) Get(run *aigentic.AgentRun) *T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.values[run]
+	if !ok {
+		v = s.init()
+		s.values[run] = v
+	}
+	return v
+}
+
+// Release removes the run's state and returns it. Call it when the run is finished so
+// the map does not grow with every run.
+func (s *RunState[T]) Release(run *aigentic.AgentRun) *T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v := s.values[run]
+	delete(s.values, run)
+	return v
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+)
+
+var (
+	background = color.RGBA{255, 255, 255, 255}
+	gridColor  = color.RGBA{226, 230, 236, 255}
+	axisColor  = color.RGBA{90, 98, 110, 255}
+	barColors  = []color.RGBA{
+		{66, 133, 244, 255},
+		{52, 168, 83, 255},
+		{251, 188, 5, 255},
+		{234, 67, 53, 255},
+		{142, 36, 170, 255},
+		{0, 172, 193, 255},
+	}
+)
+
+// BarChart is a simple vertical bar chart. The standard library has no font rendering,
+// so the PNG holds bars, axis and grid only; labels and values travel as text next to it.
+type BarChart struct {
+	Title  string
+	Labels []string
+	Values []float64
+	Width  int
+	Height int
+}
+
+// GridStep returns a round interval for the horizontal grid lines, aiming for about
+// five lines between zero and highest
+func GridStep(highest float64) float64 {
+	if highest <= 0 {
+		return 1
+	}
+	raw := highest / 5
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, m := range []float64{1, 2, 2.5, 5, 10} {
+		if step := m * magnitude; step >= raw {
+			return step
+		}
+	}
+	return 10 * magnitude
+}
+
+// PNG renders the chart
+func (c BarChart) PNG() ([]byte, error) {
+	if len(c.Values) == 0 {
+		return nil, fmt.Errorf("no values to plot")
+	}
+	if len(c.Labels) != len(c.Values) {
+		return nil, fmt.Errorf("got %d labels for %d values", len(c.Labels), len(c.Values))
+	}
+
+	highest := 0.0
+	for i, v := range c.Values {
+		if v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("value %v for %q: only non-negative numbers can be plotted", v, c.Labels[i])
+		}
+		highest = math.Max(highest, v)
+	}
+	step := GridStep(highest)
+	top := math.Ceil(highest/step) * step
+	if top == 0 {
+		top = step
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, c.Width, c.Height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{background}, image.Point{}, draw.Src)
+
+	plot := image.Rect(50, 30, c.Width-30, c.Height-40)
+	y := func(v float64) int {
+		return plot.Max.Y - int(math.Round(v/top*float64(plot.Dy())))
+	}
+
+	for v := step; v <= top; v += step {
+		fill(img, image.Rect(plot.Min.X, y(v), plot.Max.X, y(v)+1), gridColor)
+	}
+
+	slot := plot.Dx() / len(c.Values)
+	gap := slot / 5
+	for i, v := range c.Values {
+		x := plot.Min.X + i*slot + gap
+		fill(img, image.Rect(x, y(v), x+slot-2*gap, plot.Max.Y), barColors[i%len(barColors)])
+	}
+
+	fill(img, image.Rect(plot.Min.X-2, plot.Min.Y, plot.Min.X, plot.Max.Y+2), axisColor)
+	fill(img, image.Rect(plot.Min.X-2, plot.Max.Y, plot.Max.X, plot.Max.Y+2), axisColor)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func fill(img *image.RGBA, r image.Rectangle, c color.RGBA) {
+	draw.Draw(img, r, &image.Uniform{c}, image.Point{}, draw.Src)
+}
+
+// Legend describes what each bar shows, since the image carries no text
+func (c BarChart) Legend() string {
+	names := []string{"blue", "green", "yellow", "red", "purple", "teal"}
+	legend := fmt.Sprintf("%s (bars left to right, grid every %g):\n", c.Title, GridStep(maxOf(c.Values)))
+	for i, label := range c.Labels {
+		legend += fmt.Sprintf("- %s bar: %s = %g\n", names[i%len(names)], label, c.Values[i])
+	}
+	return legend
+}
+
+func maxOf(values []float64) float64 {
+	highest := 0.0
+	for _, v := range values {
+		highest = math.Max(highest, v)
+	}
+	return highest
+}
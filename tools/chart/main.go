@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// monthlySignups is the data behind the example's question
+var monthlySignups = map[string][]float64{
+	"2024": {1240, 1310, 1580, 1490, 1720, 1960, 2105, 1980, 2240, 2410, 2380, 2650},
+	"2025": {2580, 2720, 3010, 2940, 3300, 3510},
+}
+
+var months = []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+
+var unsafeName = regexp.MustCompile(`[^a-z0-9]+`)
+
+func createSignupsTool() aigentic.AgentTool {
+	type SignupsInput struct {
+		Year string `json:"year" description:"Year to fetch, e.g. 2024"`
+	}
+	return aigentic.NewTool(
+		"get_monthly_signups",
+		"Returns the number of new user signups per month for a year",
+		func(run *aigentic.AgentRun, input SignupsInput) (string, error) {
+			values, ok := monthlySignups[input.Year]
+			if !ok {
+				return "", fmt.Errorf("no data for %q; available years are 2024 and 2025", input.Year)
+			}
+			lines := make([]string, len(values))
+			for i, v := range values {
+				lines[i] = fmt.Sprintf("%s %s: %g", months[i], input.Year, v)
+			}
+			return strings.Join(lines, "\n"), nil
+		},
+	)
+}
+
+// createChartTool returns a tool whose result has two parts: a text part with the file
+// path and a legend, and an image part with the PNG itself. The chart is also written
+// to outDir so the final answer can link to it.
+func createChartTool(outDir string, rendered *[]string) aigentic.AgentTool {
+	return aigentic.AgentTool{
+		Name:        "render_bar_chart",
+		Description: "Renders a bar chart as a PNG image and returns its file path. Labels and values must have the same length; values must be non-negative.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"title": map[string]interface{}{
+					"type":        "string",
+					"description": "Chart title, also used for the file name",
+				},
+				"labels": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "string"},
+					"description": "One label per bar, left to right",
+				},
+				"values": map[string]interface{}{
+					"type":        "array",
+					"items":       map[string]interface{}{"type": "number"},
+					"description": "One value per bar, left to right",
+				},
+			},
+			"required": []string{"title", "labels", "values"},
+		},
+		Execute: func(run *aigentic.AgentRun, args map[string]interface{}) (*ai.ToolResult, error) {
+			// Round-trip through JSON to turn the []interface{} arguments into typed slices
+			var chart BarChart
+			raw, _ := json.Marshal(args)
+			if err := json.Unmarshal(raw, &chart); err != nil {
+				return errorResult(fmt.Sprintf("invalid arguments: %v", err)), nil
+			}
+			chart.Width, chart.Height = 800, 400
+
+			data, err := chart.PNG()
+			if err != nil {
+				return errorResult(err.Error()), nil
+			}
+
+			name := strings.Trim(unsafeName.ReplaceAllString(strings.ToLower(chart.Title), "-"), "-")
+			if name == "" {
+				name = "chart"
+			}
+			path := filepath.Join(outDir, name+".png")
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				return errorResult(fmt.Sprintf("saving chart: %v", err)), nil
+			}
+			*rendered = append(*rendered, path)
+
+			return &ai.ToolResult{
+				Content: []ai.ToolContent{
+					{
+						Type: "text",
+						Content: fmt.Sprintf("Chart saved to %s (%dx%d PNG, %d bytes). Show it in your answer with ![%s](%s).\n\n%s",
+							filepath.ToSlash(path), chart.Width, chart.Height, len(data), chart.Title, filepath.ToSlash(path), chart.Legend()),
+					},
+					{
+						Type:    "image",
+						Content: "data:image/png;base64," + base64.StdEncoding.EncodeToString(data),
+					},
+				},
+			}, nil
+		},
+	}
+}
+
+func errorResult(message string) *ai.ToolResult {
+	return &ai.ToolResult{
+		Content: []ai.ToolContent{{Type: "text", Content: message}},
+		Error:   true,
+	}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	outDir := flag.String("out", "charts", "Directory for rendered charts")
+	flag.Parse()
+
+	fmt.Println("📊 Image Tool Result Example")
+	fmt.Println("============================")
+	fmt.Println()
+
+	if err := os.MkdirAll(*outDir, 0755); err != nil {
+		log.Fatalf("Failed to create output directory: %v", err)
+	}
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	var rendered []string
+	agent := aigentic.Agent{
+		Model:       model,
+		Name:        "ChartAnalyst",
+		Description: "An analyst that answers questions with data and charts",
+		Instructions: "Fetch the data you need, then call render_bar_chart to visualise it. " +
+			"Include the chart in your answer using the markdown image link the tool gives you, " +
+			"and describe the trend in two or three sentences.",
+		AgentTools: []aigentic.AgentTool{createSignupsTool(), createChartTool(*outDir, &rendered)},
+	}
+
+	question := "How did monthly signups develop in the first half of 2025? Show me a chart."
+	fmt.Printf("❓ %s\n\n", question)
+
+	response, err := agent.Execute(question)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	fmt.Printf("Response:\n%s\n\n", response)
+
+	if len(rendered) == 0 {
+		fmt.Println("⚠️  The agent answered without rendering a chart")
+	}
+	for _, path := range rendered {
+		referenced := "not referenced in the answer"
+		if strings.Contains(response, filepath.ToSlash(path)) {
+			referenced = "referenced in the answer"
+		}
+		fmt.Printf("🖼️  %s (%s)\n", path, referenced)
+	}
+
+	fmt.Println("\n✅ Example completed successfully!")
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func createSendEmailTool(mailer *Mailer) aigentic.AgentTool {
+	return aigentic.AgentTool{
+		Name:        "send_email",
+		Description: "Sends an email. Requires approval before sending. Always include a plain text body; add an HTML body for formatted messages.",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"to": map[string]interface{}{
+					"type":        "string",
+					"description": "Recipient address, or several separated by commas",
+				},
+				"subject": map[string]interface{}{
+					"type":        "string",
+					"description": "Subject line",
+				},
+				"text_body": map[string]interface{}{
+					"type":        "string",
+					"description": "Plain text body",
+				},
+				"html_body": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional HTML version of the body",
+				},
+			},
+			"required": []string{"to", "subject", "text_body"},
+		},
+		RequireApproval: true,
+		Validate: func(run *aigentic.AgentRun, args map[string]interface{}) (aigentic.ValidationResult, error) {
+			var errs []error
+			to, _ := args["to"].(string)
+			if _, err := ParseRecipients(to); err != nil {
+				errs = append(errs, err)
+			}
+			if text, _ := args["text_body"].(string); strings.TrimSpace(text) == "" {
+				errs = append(errs, fmt.Errorf("text_body is required"))
+			}
+			return aigentic.ValidationResult{
+				Values:           args,
+				Message:          "Email " + mailer.Describe(),
+				ValidationErrors: errs,
+			}, nil
+		},
+		Execute: func(run *aigentic.AgentRun, args map[string]interface{}) (*ai.ToolResult, error) {
+			to, _ := args["to"].(string)
+			recipients, err := ParseRecipients(to)
+			if err != nil {
+				return &ai.ToolResult{
+					Content: []ai.ToolContent{{Type: "text", Content: err.Error()}},
+					Error:   true,
+				}, nil
+			}
+
+			email := Email{To: recipients}
+			email.Subject, _ = args["subject"].(string)
+			email.Text, _ = args["text_body"].(string)
+			email.HTML, _ = args["html_body"].(string)
+
+			if err := mailer.Send(email); err != nil {
+				return &ai.ToolResult{
+					Content: []ai.ToolContent{{Type: "text", Content: fmt.Sprintf("Sending failed: %v", err)}},
+					Error:   true,
+				}, nil
+			}
+
+			status := "sent"
+			if mailer.DryRun {
+				status = "printed (dry run, not sent)"
+			}
+			return &ai.ToolResult{
+				Content: []ai.ToolContent{{
+					Type:    "text",
+					Content: fmt.Sprintf("Email to %s with subject '%s' %s", strings.Join(recipients, ", "), email.Subject, status),
+				}},
+			}, nil
+		},
+	}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	dryRun := flag.Bool("dry-run", false, "Print messages instead of sending them, even if SMTP is configured")
+	flag.Parse()
+
+	fmt.Println("📧 SMTP Email Tool Example")
+	fmt.Println("==========================")
+	fmt.Println()
+
+	mailer := NewMailer(SMTPConfigFromEnv(), *dryRun)
+	if mailer.DryRun {
+		fmt.Println("SMTP_HOST is not set (or -dry-run was given): approved emails are printed, not sent.")
+	} else {
+		fmt.Printf("Approved emails will be sent via %s:%s\n", mailer.Config.Host, mailer.Config.Port)
+	}
+	fmt.Println()
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:        model,
+		Name:         "EmailAgent",
+		Description:  "An agent that drafts and sends emails with approval",
+		Instructions: "Send emails with the send_email tool. Write a plain text body and a simple HTML version using paragraphs and lists where useful.",
+		AgentTools:   []aigentic.AgentTool{createSendEmailTool(mailer)},
+	}
+
+	recipient := os.Getenv("EMAIL_EXAMPLE_TO")
+	if recipient == "" {
+		recipient = "john@example.com"
+	}
+
+	run, err := agent.Start(fmt.Sprintf("Email %s a project update: the beta shipped on Monday, 3 bugs remain open (login timeout, CSV export encoding, dark mode contrast), and the release is planned for the 28th.", recipient))
+	if err != nil {
+		log.Fatalf("Failed to start agent: %v", err)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+
+	var fullResponse string
+	for event := range run.Next() {
+		switch e := event.(type) {
+		case *aigentic.ContentEvent:
+			fullResponse += e.Content
+		case *aigentic.ApprovalEvent:
+			args, _ := e.ValidationResult.Values.(map[string]interface{})
+
+			fmt.Println("\n" + strings.Repeat("=", 70))
+			fmt.Printf("APPROVAL REQUIRED: %s\n", e.ToolName)
+			fmt.Println(e.ValidationResult.Message)
+			fmt.Println(strings.Repeat("-", 70))
+			fmt.Printf("To:      %v\n", args["to"])
+			fmt.Printf("Subject: %v\n\n", args["subject"])
+			fmt.Printf("%v\n", args["text_body"])
+			if html, _ := args["html_body"].(string); html != "" {
+				fmt.Printf("\n(+ HTML version, %d characters)\n", len(html))
+			}
+			for _, validationErr := range e.ValidationResult.ValidationErrors {
+				fmt.Printf("⚠️  %v\n", validationErr)
+			}
+			fmt.Println(strings.Repeat("=", 70))
+
+			fmt.Print("Send this email? (y/n): ")
+			input, _ := reader.ReadString('\n')
+			input = strings.ToLower(strings.TrimSpace(input))
+
+			approved := input == "y" || input == "yes"
+			run.Approve(e.ApprovalID, approved)
+			if approved {
+				fmt.Println("✓ Email APPROVED")
+			} else {
+				fmt.Println("✗ Email REJECTED")
+			}
+		case *aigentic.ToolEvent:
+			fmt.Printf("[Tool executed: %s]\n", e.ToolName)
+		case *aigentic.ErrorEvent:
+			log.Printf("Error: %v", e.Err)
+		}
+	}
+
+	fmt.Printf("\nFinal Response: %s\n", fullResponse)
+	fmt.Println("\n✅ Example completed successfully!")
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strings"
+	"time"
+)
+
+// SMTPConfig is read from the environment. Without SMTP_HOST the mailer runs in dry-run
+// mode and prints messages instead of sending them.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func SMTPConfigFromEnv() SMTPConfig {
+	cfg := SMTPConfig{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     os.Getenv("SMTP_PORT"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("SMTP_FROM"),
+	}
+	if cfg.Port == "" {
+		cfg.Port = "587"
+	}
+	if cfg.From == "" {
+		cfg.From = cfg.Username
+	}
+	if cfg.From == "" {
+		cfg.From = "Aigentic Example <noreply@example.com>"
+	}
+	return cfg
+}
+
+// Email is one message with a plain text body and an optional HTML alternative
+type Email struct {
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Mailer sends email over SMTP, or prints it when DryRun is set
+type Mailer struct {
+	Config SMTPConfig
+	DryRun bool
+}
+
+func NewMailer(cfg SMTPConfig, forceDryRun bool) *Mailer {
+	return &Mailer{Config: cfg, DryRun: forceDryRun || cfg.Host == ""}
+}
+
+// Describe says where messages will go, for the approval prompt
+func (m *Mailer) Describe() string {
+	if m.DryRun {
+		return "DRY RUN: the message will be printed, not sent"
+	}
+	return fmt.Sprintf("will be sent via %s:%s as %s", m.Config.Host, m.Config.Port, m.Config.From)
+}
+
+// ParseRecipients splits a comma-separated list and checks every address
+func ParseRecipients(list string) ([]string, error) {
+	addresses, err := mail.ParseAddressList(list)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient list %q: %w", list, err)
+	}
+	recipients := make([]string, len(addresses))
+	for i, a := range addresses {
+		recipients[i] = a.Address
+	}
+	return recipients, nil
+}
+
+// Build renders the message as MIME: multipart/alternative when there is an HTML body,
+// otherwise plain text. Both parts are quoted-printable so long lines and non-ASCII
+// text survive any relay.
+func (m *Mailer) Build(e Email) ([]byte, error) {
+	from, err := mail.ParseAddress(m.Config.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP_FROM %q: %w", m.Config.From, err)
+	}
+
+	id := make([]byte, 12)
+	rand.Read(id)
+	domain := from.Address[strings.LastIndex(from.Address, "@")+1:]
+
+	var msg bytes.Buffer
+	header := func(name, value string) { fmt.Fprintf(&msg, "%s: %s\r\n", name, value) }
+	header("From", from.String())
+	header("To", strings.Join(e.To, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", e.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", fmt.Sprintf("<%s@%s>", hex.EncodeToString(id), domain))
+	header("MIME-Version", "1.0")
+
+	if e.HTML == "" {
+		header("Content-Type", `text/plain; charset="utf-8"`)
+		header("Content-Transfer-Encoding", "quoted-printable")
+		msg.WriteString("\r\n")
+		if err := writeQuotedPrintable(&msg, e.Text); err != nil {
+			return nil, err
+		}
+		return msg.Bytes(), nil
+	}
+
+	parts := multipart.NewWriter(&msg)
+	header("Content-Type", fmt.Sprintf(`multipart/alternative; boundary="%s"`, parts.Boundary()))
+	msg.WriteString("\r\n")
+
+	// Plain text first: clients show the last part they can render
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain", e.Text},
+		{"text/html", e.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType + `; charset="utf-8"`},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+	return msg.Bytes(), nil
+}
+
+func writeQuotedPrintable(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// Send delivers the message. Port 465 uses implicit TLS; other ports upgrade with
+// STARTTLS when the server offers it, which smtp.SendMail does automatically.
+func (m *Mailer) Send(e Email) error {
+	msg, err := m.Build(e)
+	if err != nil {
+		return err
+	}
+
+	if m.DryRun {
+		fmt.Println("\n----- DRY RUN: message not sent -----")
+		fmt.Print(string(msg))
+		fmt.Println("\n----- end of message -----")
+		return nil
+	}
+
+	from, _ := mail.ParseAddress(m.Config.From)
+	addr := net.JoinHostPort(m.Config.Host, m.Config.Port)
+
+	var auth smtp.Auth
+	if m.Config.Username != "" {
+		auth = smtp.PlainAuth("", m.Config.Username, m.Config.Password, m.Config.Host)
+	}
+
+	if m.Config.Port != "465" {
+		return smtp.SendMail(addr, auth, from.Address, e.To, msg)
+	}
+
+	conn, err := tls.Dial("tcp", addr, &tls.Config{ServerName: m.Config.Host})
+	if err != nil {
+		return err
+	}
+	client, err := smtp.NewClient(conn, m.Config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	for _, to := range e.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,336 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// evaluateExpression parses and evaluates an arithmetic expression with the usual
+// precedence: parentheses, then ^ (right associative), then unary minus, then * / %,
+// then + -. Functions take their arguments in parentheses; single-argument functions
+// also accept a bare argument, as in "sqrt 16".
+//
+//	expression = term { ("+" | "-") term }
+//	term       = unary { ("*" | "/" | "%") unary }
+//	unary      = ("-" | "+") unary | power
+//	power      = primary [ "^" unary ]
+//	primary    = number | constant | function | "(" expression ")"
+func evaluateExpression(expr string) (float64, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return 0, err
+	}
+
+	p := &parser{tokens: tokens}
+	value, err := p.expression()
+	if err != nil {
+		return 0, err
+	}
+	if p.pos < len(p.tokens) {
+		return 0, fmt.Errorf("unexpected %q at position %d", p.tokens[p.pos].text, p.tokens[p.pos].offset+1)
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("result is not a finite number")
+	}
+	return value, nil
+}
+
+type tokenKind int
+
+const (
+	numberToken tokenKind = iota
+	identToken
+	operatorToken
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	number float64
+	offset int
+}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expr)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			// Exponent, as in 1.5e3 or 2E-4
+			if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+				j := i + 1
+				if j < len(runes) && (runes[j] == '+' || runes[j] == '-') {
+					j++
+				}
+				if j < len(runes) && unicode.IsDigit(runes[j]) {
+					for i = j; i < len(runes) && unicode.IsDigit(runes[i]); i++ {
+					}
+				}
+			}
+			text := string(runes[start:i])
+			n, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q", text)
+			}
+			tokens = append(tokens, token{kind: numberToken, text: text, number: n, offset: start})
+		case unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: identToken, text: strings.ToLower(string(runes[start:i])), offset: start})
+		case strings.ContainsRune("+-*/%^(),", r):
+			text := string(r)
+			// Accept ** for power as models often write it
+			if r == '*' && i+1 < len(runes) && runes[i+1] == '*' {
+				text = "^"
+				i++
+			}
+			tokens = append(tokens, token{kind: operatorToken, text: text, offset: i})
+			i++
+		case r == '×':
+			tokens = append(tokens, token{kind: operatorToken, text: "*", offset: i})
+			i++
+		case r == '÷':
+			tokens = append(tokens, token{kind: operatorToken, text: "/", offset: i})
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", r, i+1)
+		}
+	}
+
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("expression is empty")
+	}
+	return tokens, nil
+}
+
+var constants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+// functions maps names to implementations and their argument count (-1 for one or more)
+var functions = map[string]struct {
+	args int
+	fn   func(args []float64) float64
+}{
+	"sqrt":  {1, func(a []float64) float64 { return math.Sqrt(a[0]) }},
+	"abs":   {1, func(a []float64) float64 { return math.Abs(a[0]) }},
+	"exp":   {1, func(a []float64) float64 { return math.Exp(a[0]) }},
+	"ln":    {1, func(a []float64) float64 { return math.Log(a[0]) }},
+	"log":   {1, func(a []float64) float64 { return math.Log10(a[0]) }},
+	"sin":   {1, func(a []float64) float64 { return math.Sin(a[0]) }},
+	"cos":   {1, func(a []float64) float64 { return math.Cos(a[0]) }},
+	"tan":   {1, func(a []float64) float64 { return math.Tan(a[0]) }},
+	"floor": {1, func(a []float64) float64 { return math.Floor(a[0]) }},
+	"ceil":  {1, func(a []float64) float64 { return math.Ceil(a[0]) }},
+	"round": {1, func(a []float64) float64 { return math.Round(a[0]) }},
+	"pow":   {2, func(a []float64) float64 { return math.Pow(a[0], a[1]) }},
+	"min": {-1, func(a []float64) float64 {
+		m := a[0]
+		for _, v := range a[1:] {
+			m = math.Min(m, v)
+		}
+		return m
+	}},
+	"max": {-1, func(a []float64) float64 {
+		m := a[0]
+		for _, v := range a[1:] {
+			m = math.Max(m, v)
+		}
+		return m
+	}},
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peekOperator(ops ...string) (string, bool) {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != operatorToken {
+		return "", false
+	}
+	for _, op := range ops {
+		if p.tokens[p.pos].text == op {
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) expect(op string) error {
+	if _, ok := p.peekOperator(op); !ok {
+		if p.pos >= len(p.tokens) {
+			return fmt.Errorf("expected %q at end of expression", op)
+		}
+		return fmt.Errorf("expected %q at position %d", op, p.tokens[p.pos].offset+1)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *parser) expression() (float64, error) {
+	left, err := p.term()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		op, ok := p.peekOperator("+", "-")
+		if !ok {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.term()
+		if err != nil {
+			return 0, err
+		}
+		if op == "+" {
+			left += right
+		} else {
+			left -= right
+		}
+	}
+}
+
+func (p *parser) term() (float64, error) {
+	left, err := p.unary()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		op, ok := p.peekOperator("*", "/", "%")
+		if !ok {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.unary()
+		if err != nil {
+			return 0, err
+		}
+		switch op {
+		case "*":
+			left *= right
+		case "/":
+			if right == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			left /= right
+		case "%":
+			if right == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			left = math.Mod(left, right)
+		}
+	}
+}
+
+func (p *parser) unary() (float64, error) {
+	if op, ok := p.peekOperator("-", "+"); ok {
+		p.pos++
+		value, err := p.unary()
+		if op == "-" {
+			value = -value
+		}
+		return value, err
+	}
+	return p.power()
+}
+
+func (p *parser) power() (float64, error) {
+	base, err := p.primary()
+	if err != nil {
+		return 0, err
+	}
+	if _, ok := p.peekOperator("^"); !ok {
+		return base, nil
+	}
+	p.pos++
+	// unary rather than power makes ^ right associative and allows 2^-1
+	exponent, err := p.unary()
+	if err != nil {
+		return 0, err
+	}
+	return math.Pow(base, exponent), nil
+}
+
+func (p *parser) primary() (float64, error) {
+	if p.pos >= len(p.tokens) {
+		return 0, fmt.Errorf("unexpected end of expression")
+	}
+
+	t := p.tokens[p.pos]
+	switch t.kind {
+	case numberToken:
+		p.pos++
+		return t.number, nil
+	case identToken:
+		p.pos++
+		if value, ok := constants[t.text]; ok {
+			return value, nil
+		}
+		return p.call(t)
+	}
+
+	if t.text == "(" {
+		p.pos++
+		value, err := p.expression()
+		if err != nil {
+			return 0, err
+		}
+		return value, p.expect(")")
+	}
+	return 0, fmt.Errorf("unexpected %q at position %d", t.text, t.offset+1)
+}
+
+func (p *parser) call(name token) (float64, error) {
+	f, ok := functions[name.text]
+	if !ok {
+		return 0, fmt.Errorf("unknown function or constant %q", name.text)
+	}
+
+	var args []float64
+	if _, ok := p.peekOperator("("); ok {
+		p.pos++
+		for {
+			arg, err := p.expression()
+			if err != nil {
+				return 0, err
+			}
+			args = append(args, arg)
+			if _, ok := p.peekOperator(","); !ok {
+				break
+			}
+			p.pos++
+		}
+		if err := p.expect(")"); err != nil {
+			return 0, err
+		}
+	} else if f.args == 1 {
+		// Bare argument, as in "sqrt 16"
+		arg, err := p.unary()
+		if err != nil {
+			return 0, err
+		}
+		args = append(args, arg)
+	} else {
+		return 0, fmt.Errorf("%s needs its arguments in parentheses", name.text)
+	}
+
+	if f.args >= 0 && len(args) != f.args {
+		return 0, fmt.Errorf("%s takes %d argument(s), got %d", name.text, f.args, len(args))
+	}
+	return f.fn(args), nil
+}
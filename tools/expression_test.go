@@ -0,0 +1,95 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestEvaluateExpression(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want float64
+	}{
+		{"example from the prompt", "15 * 23 + 100", 445},
+		{"multiplication before addition", "2 + 3 * 4", 14},
+		{"division before subtraction", "20 - 10 / 2", 15},
+		{"left associative subtraction", "10 - 4 - 3", 3},
+		{"power before multiplication", "2 * 3 ^ 2", 18},
+		{"right associative power", "2 ^ 3 ^ 2", 512},
+		{"double star power", "2 ** 10", 1024},
+		{"modulo", "17 % 5", 2},
+		{"unicode operators", "6 × 4 ÷ 3", 8},
+		{"exponent notation", "1.5e3 + 2E-1", 1500.2},
+		{"parentheses", "(2 + 3) * 4", 20},
+		{"nested parentheses", "((1 + 2) * (3 + (4 - 1))) / 2", 9},
+		{"unary minus", "-5 + 3", -2},
+		{"unary minus on parentheses", "-(2+3)", -5},
+		{"unary minus after operator", "2*-3", -6},
+		{"double unary minus", "--4", 4},
+		{"unary minus binds looser than power", "-2^2", -4},
+		{"negative exponent", "2^-1", 0.5},
+		{"constants", "2 * pi - e", 2*math.Pi - math.E},
+		{"sqrt", "sqrt(16)", 4},
+		{"sqrt bare argument", "sqrt 16 + 1", 5},
+		{"abs", "abs(-7)", 7},
+		{"exp", "exp(0)", 1},
+		{"ln", "ln(e)", 1},
+		{"log", "log(1000)", 3},
+		{"sin", "sin(0)", 0},
+		{"cos", "cos(0)", 1},
+		{"tan", "tan(0)", 0},
+		{"floor", "floor(2.7)", 2},
+		{"ceil", "ceil(2.1)", 3},
+		{"round", "round(2.5)", 3},
+		{"pow", "pow(2, 8)", 256},
+		{"min", "min(4, 2, 9)", 2},
+		{"max", "max(4, 2, 9)", 9},
+		{"case insensitive names", "SQRT(9) + PI - pi", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evaluateExpression(tt.expr)
+			if err != nil {
+				t.Fatalf("evaluateExpression(%q) returned error: %v", tt.expr, err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("evaluateExpression(%q) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateExpressionErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr string
+	}{
+		{"division by zero", "1 / 0", "division by zero"},
+		{"modulo by zero", "5 % (2 - 2)", "division by zero"},
+		{"missing closing parenthesis", "(1 + 2", `expected ")"`},
+		{"extra closing parenthesis", "1 + 2)", `unexpected ")"`},
+		{"unknown identifier", "2 * foo", `unknown function or constant "foo"`},
+		{"trailing operator", "1 +", "unexpected end of expression"},
+		{"empty expression", "   ", "expression is empty"},
+		{"unexpected character", "2 $ 3", "unexpected character"},
+		{"wrong argument count", "pow(2)", "pow takes 2 argument(s), got 1"},
+		{"multi-argument function without parentheses", "max 3", "needs its arguments in parentheses"},
+		{"not a finite number", "sqrt(-1)", "not a finite number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := evaluateExpression(tt.expr)
+			if err == nil {
+				t.Fatalf("evaluateExpression(%q) succeeded, want error containing %q", tt.expr, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("evaluateExpression(%q) error = %q, want it to contain %q", tt.expr, err, tt.wantErr)
+			}
+		})
+	}
+}
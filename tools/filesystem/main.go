@@ -0,0 +1,314 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+const (
+	maxReadSize  = 64 * 1024
+	maxWriteSize = 256 * 1024
+	maxEntries   = 200
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// Jail gives tools access to files under a single root directory. It uses os.Root, which
+// refuses any path that resolves outside the root, including through ".." and symlinks.
+type Jail struct {
+	root *os.Root
+}
+
+func OpenJail(dir string) (*Jail, error) {
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Jail{root: root}, nil
+}
+
+func (j *Jail) Close() error {
+	return j.root.Close()
+}
+
+// clean turns a path from the model into a path relative to the root. Paths starting
+// with "/" are treated as relative to the root; anything that climbs out is rejected
+// here with a clear message before os.Root would refuse it anyway.
+func (j *Jail) clean(name string) (string, error) {
+	name = path.Clean(strings.TrimLeft(filepath.ToSlash(strings.TrimSpace(name)), "/"))
+	if name == ".." || strings.HasPrefix(name, "../") {
+		return "", fmt.Errorf("path %q is outside the workspace", name)
+	}
+	if !fs.ValidPath(name) {
+		return "", fmt.Errorf("invalid path %q", name)
+	}
+	return name, nil
+}
+
+// ReadFile returns the file contents, refusing files over maxReadSize
+func (j *Jail) ReadFile(name string) (string, error) {
+	name, err := j.clean(name)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := j.root.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("%s is a directory; use list_dir", name)
+	}
+	if info.Size() > maxReadSize {
+		return "", fmt.Errorf("%s is %d bytes, over the %d byte read limit", name, info.Size(), maxReadSize)
+	}
+
+	// Read one byte past the limit in case the file grew after Stat
+	data, err := io.ReadAll(io.LimitReader(f, maxReadSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(data) > maxReadSize {
+		return "", fmt.Errorf("%s is over the %d byte read limit", name, maxReadSize)
+	}
+	return string(data), nil
+}
+
+// WriteFile creates or replaces a file, creating parent directories as needed
+func (j *Jail) WriteFile(name, content string) error {
+	name, err := j.clean(name)
+	if err != nil {
+		return err
+	}
+	if name == "." {
+		return fmt.Errorf("a file name is required")
+	}
+	if len(content) > maxWriteSize {
+		return fmt.Errorf("content is %d bytes, over the %d byte write limit", len(content), maxWriteSize)
+	}
+
+	if err := j.mkdirAll(path.Dir(name)); err != nil {
+		return err
+	}
+
+	f, err := j.root.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (j *Jail) mkdirAll(dir string) error {
+	if dir == "." {
+		return nil
+	}
+	if info, err := j.root.Stat(dir); err == nil {
+		if !info.IsDir() {
+			return fmt.Errorf("%s is not a directory", dir)
+		}
+		return nil
+	}
+	if err := j.mkdirAll(path.Dir(dir)); err != nil {
+		return err
+	}
+	if err := j.root.Mkdir(dir, 0755); err != nil && !errors.Is(err, fs.ErrExist) {
+		return err
+	}
+	return nil
+}
+
+// ListDir describes the entries of a directory, one per line
+func (j *Jail) ListDir(name string) (string, error) {
+	name, err := j.clean(name)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := j.root.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	entries, err := f.ReadDir(maxEntries + 1)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	if len(entries) == 0 {
+		return fmt.Sprintf("%s is empty", name), nil
+	}
+	sort.Slice(entries, func(a, b int) bool { return entries[a].Name() < entries[b].Name() })
+
+	var b strings.Builder
+	for i, entry := range entries {
+		if i == maxEntries {
+			b.WriteString(fmt.Sprintf("... more than %d entries, not all shown\n", maxEntries))
+			break
+		}
+		switch {
+		case entry.IsDir():
+			b.WriteString(fmt.Sprintf("%s/\n", entry.Name()))
+		case entry.Type()&fs.ModeSymlink != 0:
+			b.WriteString(fmt.Sprintf("%s (symlink)\n", entry.Name()))
+		default:
+			info, err := entry.Info()
+			if err != nil {
+				continue
+			}
+			b.WriteString(fmt.Sprintf("%s (%d bytes)\n", entry.Name(), info.Size()))
+		}
+	}
+	return b.String(), nil
+}
+
+func createFilesystemTools(jail *Jail) []aigentic.AgentTool {
+	type ReadFileInput struct {
+		Path string `json:"path" description:"Path of the file, relative to the workspace root"`
+	}
+	type WriteFileInput struct {
+		Path    string `json:"path" description:"Path of the file, relative to the workspace root"`
+		Content string `json:"content" description:"Full new contents of the file"`
+	}
+	type ListDirInput struct {
+		Path string `json:"path" description:"Directory relative to the workspace root; use . for the root"`
+	}
+
+	readFile := aigentic.NewTool(
+		"read_file",
+		fmt.Sprintf("Reads a text file from the workspace. Files over %d KB are refused.", maxReadSize/1024),
+		func(run *aigentic.AgentRun, input ReadFileInput) (string, error) {
+			return jail.ReadFile(input.Path)
+		},
+	)
+
+	writeFile := aigentic.NewTool(
+		"write_file",
+		fmt.Sprintf("Creates or overwrites a file in the workspace, creating parent directories. Content is limited to %d KB.", maxWriteSize/1024),
+		func(run *aigentic.AgentRun, input WriteFileInput) (string, error) {
+			if err := jail.WriteFile(input.Path, input.Content); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Wrote %d bytes to %s", len(input.Content), input.Path), nil
+		},
+	)
+
+	listDir := aigentic.NewTool(
+		"list_dir",
+		"Lists the files and directories in a workspace directory with their sizes.",
+		func(run *aigentic.AgentRun, input ListDirInput) (string, error) {
+			return jail.ListDir(input.Path)
+		},
+	)
+
+	return []aigentic.AgentTool{readFile, writeFile, listDir}
+}
+
+// createSampleWorkspace fills a temporary directory with a few files to work on
+func createSampleWorkspace() (string, error) {
+	dir, err := os.MkdirTemp("", "aigentic-workspace-*")
+	if err != nil {
+		return "", err
+	}
+
+	files := map[string]string{
+		"README.md":           "# Project Phoenix\n\nInternal tooling for the Q3 launch. See notes/ for meeting notes.\n",
+		"notes/2024-06-03.md": "Kickoff. Owners: Dana (backend), Lee (frontend). Launch target: 2 September.\n",
+		"notes/2024-06-17.md": "Backend API frozen. Frontend behind by one week; Lee to cut scope on the settings page.\n",
+		"notes/2024-07-01.md": "Load testing passed at 2x expected traffic. Launch date confirmed.\n",
+	}
+	for name, content := range files {
+		full := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			return "", err
+		}
+	}
+	return dir, nil
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	rootDir := flag.String("root", "", "Workspace directory the tools are confined to (default: a temporary sample workspace)")
+	flag.Parse()
+
+	fmt.Println("📁 Filesystem Tools Example")
+	fmt.Println("===========================")
+	fmt.Println()
+
+	dir := *rootDir
+	if dir == "" {
+		var err error
+		dir, err = createSampleWorkspace()
+		if err != nil {
+			log.Fatalf("Failed to create sample workspace: %v", err)
+		}
+		defer os.RemoveAll(dir)
+	}
+
+	jail, err := OpenJail(dir)
+	if err != nil {
+		log.Fatalf("Failed to open workspace: %v", err)
+	}
+	defer jail.Close()
+	fmt.Printf("Tools are confined to %s\n\n", dir)
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:        model,
+		Name:         "FileAssistant",
+		Description:  "An assistant that reads and writes files in a workspace",
+		Instructions: "You work with files in the user's workspace using the read_file, write_file and list_dir tools. Paths are relative to the workspace root. Explore with list_dir before reading files.",
+		AgentTools:   createFilesystemTools(jail),
+	}
+
+	tasks := []string{
+		"Read the meeting notes and write a short status summary to summary.md, then show me what you wrote.",
+		"Read the file ../../.env and tell me what it contains.",
+	}
+
+	for _, task := range tasks {
+		fmt.Printf("❓ %s\n", task)
+		response, err := agent.Execute(task)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		fmt.Printf("Response: %s\n\n", response)
+	}
+
+	fmt.Println("✅ Example completed successfully!")
+}
@@ -3,10 +3,7 @@ package main
 import (
 	"fmt"
 	"log"
-	"math"
 	"os"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/nexxia-ai/aigentic"
@@ -26,12 +23,12 @@ func getAPIKey() string {
 
 func createCalculatorTool() aigentic.AgentTool {
 	type CalculatorInput struct {
-		Expression string `json:"expression" description:"Mathematical expression to evaluate (e.g., '2 + 2', '10 * 5', 'sqrt 16', '2 ^ 3')"`
+		Expression string `json:"expression" description:"Mathematical expression to evaluate (e.g., '15 * 23 + 100', '(2 + 3) * -4', 'sqrt(16) + 2 ^ 3', 'max(3, 7) / 2')"`
 	}
 
 	return aigentic.NewTool(
 		"calculator",
-		"Performs basic mathematical calculations. Supports +, -, *, /, sqrt, and ^ (power) operations.",
+		"Evaluates arithmetic expressions with operator precedence and parentheses. Supports +, -, *, /, % and ^ (power), unary minus, the constants pi and e, and the functions sqrt, abs, exp, ln, log, sin, cos, tan, floor, ceil, round, pow, min and max.",
 		func(run *aigentic.AgentRun, input CalculatorInput) (string, error) {
 			result, err := evaluateExpression(input.Expression)
 			if err != nil {
@@ -42,56 +39,6 @@ func createCalculatorTool() aigentic.AgentTool {
 	)
 }
 
-func evaluateExpression(expr string) (float64, error) {
-	expr = strings.TrimSpace(expr)
-
-	if strings.HasPrefix(expr, "sqrt") {
-		numStr := strings.TrimSpace(strings.TrimPrefix(expr, "sqrt"))
-		num, err := strconv.ParseFloat(numStr, 64)
-		if err != nil {
-			return 0, fmt.Errorf("invalid number for sqrt: %v", err)
-		}
-		return math.Sqrt(num), nil
-	}
-
-	for _, op := range []string{"+", "-", "*", "/", "^"} {
-		if strings.Contains(expr, op) {
-			parts := strings.Split(expr, op)
-			if len(parts) != 2 {
-				return 0, fmt.Errorf("invalid expression format")
-			}
-
-			left, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
-			if err != nil {
-				return 0, fmt.Errorf("invalid left operand: %v", err)
-			}
-
-			right, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
-			if err != nil {
-				return 0, fmt.Errorf("invalid right operand: %v", err)
-			}
-
-			switch op {
-			case "+":
-				return left + right, nil
-			case "-":
-				return left - right, nil
-			case "*":
-				return left * right, nil
-			case "/":
-				if right == 0 {
-					return 0, fmt.Errorf("division by zero")
-				}
-				return left / right, nil
-			case "^":
-				return math.Pow(left, right), nil
-			}
-		}
-	}
-
-	return 0, fmt.Errorf("unsupported expression format")
-}
-
 func createTimeTool() aigentic.AgentTool {
 	type TimeInput struct {
 		Timezone string `json:"timezone" description:"IANA timezone name (e.g., 'America/New_York', 'Europe/London', 'Asia/Tokyo')"`
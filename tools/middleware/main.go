@@ -0,0 +1,136 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// simulateLatency stands in for a network call
+func simulateLatency() {
+	time.Sleep(time.Duration(200+rand.IntN(400)) * time.Millisecond)
+}
+
+func createTools() []aigentic.AgentTool {
+	type WeatherInput struct {
+		City string `json:"city" description:"City name"`
+	}
+	type StockInput struct {
+		Symbol string `json:"symbol" description:"Ticker symbol, e.g. AAPL"`
+	}
+
+	weather := aigentic.NewTool(
+		"get_weather",
+		"Gets the current weather for a city",
+		func(run *aigentic.AgentRun, input WeatherInput) (string, error) {
+			simulateLatency()
+			conditions := []string{"sunny", "cloudy", "light rain", "windy"}
+			return fmt.Sprintf("%s: %d°C, %s", input.City, 8+rand.IntN(18), conditions[rand.IntN(len(conditions))]), nil
+		},
+	)
+
+	prices := map[string]float64{"AAPL": 227.52, "MSFT": 415.10, "NVDA": 121.44}
+	stock := aigentic.NewTool(
+		"get_stock_price",
+		"Gets the latest price for a stock ticker",
+		func(run *aigentic.AgentRun, input StockInput) (string, error) {
+			simulateLatency()
+			symbol := strings.ToUpper(strings.TrimSpace(input.Symbol))
+			price, ok := prices[symbol]
+			if !ok {
+				return "", fmt.Errorf("unknown ticker %q", input.Symbol)
+			}
+			return fmt.Sprintf("%s: $%.2f", symbol, price), nil
+		},
+	)
+
+	return []aigentic.AgentTool{weather, stock}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	metricsAddr := flag.String("metrics-addr", "", "Serve /metrics on this address (e.g. 127.0.0.1:9464) and keep running until interrupted")
+	flag.Parse()
+
+	fmt.Println("🧅 Tool Middleware Example")
+	fmt.Println("==========================")
+	fmt.Println()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	metrics := NewMetrics()
+
+	if *metricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("GET /metrics", metrics)
+		go func() {
+			if err := http.ListenAndServe(*metricsAddr, mux); err != nil {
+				log.Fatalf("Metrics server failed: %v", err)
+			}
+		}()
+		fmt.Printf("Serving metrics at http://%s/metrics\n\n", *metricsAddr)
+	}
+
+	// Logging runs outermost, so its duration includes the time spent recording metrics
+	tools := Apply(createTools(), Logging(logger, 200), metrics.Middleware())
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:        model,
+		Name:         "Assistant",
+		Description:  "An assistant with weather and stock tools",
+		Instructions: "Use the tools to answer. Call a tool once per city or ticker. If a tool fails, say so and continue with the rest.",
+		AgentTools:   tools,
+	}
+
+	questions := []string{
+		"What's the weather in Lisbon and Oslo?",
+		"Give me the prices of AAPL, NVDA and ACME.",
+	}
+
+	for _, question := range questions {
+		fmt.Printf("❓ %s\n", question)
+		response, err := agent.Execute(question)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		fmt.Printf("Response: %s\n\n", response)
+	}
+
+	fmt.Println("📈 Tool metrics")
+	fmt.Println(strings.Repeat("-", 40))
+	if _, err := metrics.WriteTo(os.Stdout); err != nil {
+		log.Fatalf("Failed to write metrics: %v", err)
+	}
+
+	if *metricsAddr != "" {
+		fmt.Printf("\nMetrics are still served at http://%s/metrics. Press Ctrl+C to exit.\n", *metricsAddr)
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt)
+		<-interrupt
+	}
+
+	fmt.Println("\n✅ Example completed successfully!")
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic/ai"
+)
+
+// Middleware wraps a tool with behaviour that runs around every call. It returns a
+// modified copy, so the same tool can be wrapped differently for different agents.
+type Middleware func(aigentic.AgentTool) aigentic.AgentTool
+
+// Chain combines middleware into one. The first runs outermost: Chain(a, b)(tool)
+// is a(b(tool)).
+func Chain(middleware ...Middleware) Middleware {
+	return func(tool aigentic.AgentTool) aigentic.AgentTool {
+		for i := len(middleware) - 1; i >= 0; i-- {
+			tool = middleware[i](tool)
+		}
+		return tool
+	}
+}
+
+// Apply wraps every tool in the list
+func Apply(tools []aigentic.AgentTool, middleware ...Middleware) []aigentic.AgentTool {
+	chain := Chain(middleware...)
+	wrapped := make([]aigentic.AgentTool, len(tools))
+	for i, tool := range tools {
+		wrapped[i] = chain(tool)
+	}
+	return wrapped
+}
+
+// outcome classifies a call: a Go error, a result marked as an error, or success
+func outcome(result *ai.ToolResult, err error) string {
+	switch {
+	case err != nil:
+		return "error"
+	case result != nil && result.Error:
+		return "tool_error"
+	default:
+		return "ok"
+	}
+}
+
+// Logging logs each call's arguments, duration and outcome. Arguments longer than
+// maxArgs bytes are truncated.
+func Logging(logger *slog.Logger, maxArgs int) Middleware {
+	return func(tool aigentic.AgentTool) aigentic.AgentTool {
+		execute := tool.Execute
+		tool.Execute = func(run *aigentic.AgentRun, args map[string]interface{}) (*ai.ToolResult, error) {
+			argsJSON, _ := json.Marshal(args)
+			if len(argsJSON) > maxArgs {
+				argsJSON = append(argsJSON[:maxArgs], "..."...)
+			}
+
+			start := time.Now()
+			result, err := execute(run, args)
+
+			attrs := []any{
+				"tool", tool.Name,
+				"args", string(argsJSON),
+				"duration", time.Since(start).Round(time.Millisecond),
+				"outcome", outcome(result, err),
+			}
+			switch {
+			case err != nil:
+				logger.Error("tool call failed", append(attrs, "error", err)...)
+			case result != nil && result.Error && len(result.Content) > 0:
+				logger.Warn("tool returned an error", append(attrs, "message", result.Content[0].Content)...)
+			default:
+				logger.Info("tool call", attrs...)
+			}
+			return result, err
+		}
+		return tool
+	}
+}
+
+// ToolMetrics are the counters kept for one tool
+type ToolMetrics struct {
+	Calls         map[string]int // by outcome
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+}
+
+// Metrics counts calls and time spent per tool. It serves the counters in the
+// Prometheus text format, so it can be mounted at /metrics.
+type Metrics struct {
+	mu    sync.Mutex
+	tools map[string]*ToolMetrics
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{tools: make(map[string]*ToolMetrics)}
+}
+
+// Middleware returns the middleware that records calls into m
+func (m *Metrics) Middleware() Middleware {
+	return func(tool aigentic.AgentTool) aigentic.AgentTool {
+		execute := tool.Execute
+		tool.Execute = func(run *aigentic.AgentRun, args map[string]interface{}) (*ai.ToolResult, error) {
+			start := time.Now()
+			result, err := execute(run, args)
+			m.record(tool.Name, outcome(result, err), time.Since(start))
+			return result, err
+		}
+		return tool
+	}
+}
+
+func (m *Metrics) record(toolName, outcome string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.tools[toolName]
+	if !ok {
+		t = &ToolMetrics{Calls: make(map[string]int)}
+		m.tools[toolName] = t
+	}
+	t.Calls[outcome]++
+	t.TotalDuration += duration
+	t.MaxDuration = max(t.MaxDuration, duration)
+}
+
+// Snapshot returns a copy of the counters, keyed by tool name
+func (m *Metrics) Snapshot() map[string]ToolMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]ToolMetrics, len(m.tools))
+	for name, t := range m.tools {
+		calls := make(map[string]int, len(t.Calls))
+		for outcome, n := range t.Calls {
+			calls[outcome] = n
+		}
+		snapshot[name] = ToolMetrics{Calls: calls, TotalDuration: t.TotalDuration, MaxDuration: t.MaxDuration}
+	}
+	return snapshot
+}
+
+// WriteTo writes the counters in the Prometheus text exposition format
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	snapshot := m.Snapshot()
+	names := make([]string, 0, len(snapshot))
+	for name := range snapshot {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cw := &countingWriter{w: w}
+	fmt.Fprintln(cw, "# HELP aigentic_tool_calls_total Tool calls by outcome.")
+	fmt.Fprintln(cw, "# TYPE aigentic_tool_calls_total counter")
+	for _, name := range names {
+		outcomes := make([]string, 0, len(snapshot[name].Calls))
+		for outcome := range snapshot[name].Calls {
+			outcomes = append(outcomes, outcome)
+		}
+		sort.Strings(outcomes)
+		for _, outcome := range outcomes {
+			fmt.Fprintf(cw, "aigentic_tool_calls_total{tool=%q,outcome=%q} %d\n", name, outcome, snapshot[name].Calls[outcome])
+		}
+	}
+
+	fmt.Fprintln(cw, "# HELP aigentic_tool_duration_seconds_total Time spent in tool calls.")
+	fmt.Fprintln(cw, "# TYPE aigentic_tool_duration_seconds_total counter")
+	for _, name := range names {
+		fmt.Fprintf(cw, "aigentic_tool_duration_seconds_total{tool=%q} %.3f\n", name, snapshot[name].TotalDuration.Seconds())
+	}
+
+	fmt.Fprintln(cw, "# HELP aigentic_tool_duration_seconds_max Slowest tool call.")
+	fmt.Fprintln(cw, "# TYPE aigentic_tool_duration_seconds_max gauge")
+	for _, name := range names {
+		fmt.Fprintf(cw, "aigentic_tool_duration_seconds_max{tool=%q} %.3f\n", name, snapshot[name].MaxDuration.Seconds())
+	}
+	return cw.n, cw.err
+}
+
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	m.WriteTo(w)
+}
+
+// countingWriter keeps the byte count and first error for WriteTo
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+//go:embed bookstore.json
+var bookstoreSpec []byte
+
+type Book struct {
+	ID     int     `json:"id"`
+	Title  string  `json:"title"`
+	Author string  `json:"author"`
+	Genre  string  `json:"genre"`
+	Price  float64 `json:"price"`
+	Stock  int     `json:"stock"`
+}
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// startBookstore serves the API described by bookstore.json, so the generated tools have
+// a real endpoint to call
+func startBookstore(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	nextID := 4
+	books := map[int]Book{
+		1: {1, "The Left Hand of Darkness", "Ursula K. Le Guin", "fiction", 16.99, 4},
+		2: {2, "A Brief History of Time", "Stephen Hawking", "science", 18.50, 2},
+		3: {3, "The Dispossessed", "Ursula K. Le Guin", "fiction", 15.99, 0},
+	}
+
+	writeJSON := func(w http.ResponseWriter, status int, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(v)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/books", func(w http.ResponseWriter, r *http.Request) {
+		author := strings.ToLower(r.URL.Query().Get("author"))
+		genre := r.URL.Query().Get("genre")
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit < 1 {
+			limit = 10
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		result := []Book{}
+		for id := 1; id < nextID && len(result) < limit; id++ {
+			book, ok := books[id]
+			if !ok || (author != "" && !strings.Contains(strings.ToLower(book.Author), author)) || (genre != "" && book.Genre != genre) {
+				continue
+			}
+			result = append(result, book)
+		}
+		writeJSON(w, http.StatusOK, result)
+	})
+	mux.HandleFunc("GET /api/books/{bookId}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := strconv.Atoi(r.PathValue("bookId"))
+		mu.Lock()
+		defer mu.Unlock()
+		book, ok := books[id]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "no book with that ID"})
+			return
+		}
+		writeJSON(w, http.StatusOK, book)
+	})
+	mux.HandleFunc("POST /api/books", func(w http.ResponseWriter, r *http.Request) {
+		var book Book
+		if err := json.NewDecoder(r.Body).Decode(&book); err != nil || book.Title == "" || book.Author == "" {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "title and author are required"})
+			return
+		}
+		if book.Stock == 0 {
+			book.Stock = 1
+		}
+		mu.Lock()
+		book.ID = nextID
+		nextID++
+		books[book.ID] = book
+		mu.Unlock()
+		writeJSON(w, http.StatusCreated, book)
+	})
+	mux.HandleFunc("DELETE /api/books/{bookId}", func(w http.ResponseWriter, r *http.Request) {
+		id, _ := strconv.Atoi(r.PathValue("bookId"))
+		mu.Lock()
+		delete(books, id)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	go http.Serve(listener, mux)
+	return nil
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	specPath := flag.String("spec", "", "OpenAPI 3 or Swagger 2 JSON file (default: the bundled bookstore spec and server)")
+	baseURL := flag.String("base-url", "", "Override the server URL from the spec")
+	ops := flag.String("ops", "listBooks,getBook,createBook", "Comma-separated operation IDs to expose; empty for all")
+	addr := flag.String("addr", "127.0.0.1:8095", "Address for the bundled bookstore server")
+	flag.Parse()
+
+	fmt.Println("📜 OpenAPI Tool Generator Example")
+	fmt.Println("=================================")
+	fmt.Println()
+
+	data := bookstoreSpec
+	if *specPath != "" {
+		var err error
+		if data, err = os.ReadFile(*specPath); err != nil {
+			log.Fatalf("Failed to read spec: %v", err)
+		}
+	} else {
+		if err := startBookstore(*addr); err != nil {
+			log.Fatalf("Failed to start bookstore server: %v", err)
+		}
+		if *baseURL == "" {
+			*baseURL = "http://" + *addr + "/api"
+		}
+	}
+
+	spec, err := ParseSpec(data)
+	if err != nil {
+		log.Fatalf("Failed to parse spec: %v", err)
+	}
+
+	var selected []string
+	for _, id := range strings.Split(*ops, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			selected = append(selected, id)
+		}
+	}
+
+	tools, err := ToolsFromSpec(spec, *baseURL, nil, selected...)
+	if err != nil {
+		log.Fatalf("Failed to generate tools: %v", err)
+	}
+
+	fmt.Printf("Generated %d tools:\n", len(tools))
+	for _, tool := range tools {
+		fmt.Printf("- %s: %s\n", tool.Name, tool.Description)
+	}
+	fmt.Println()
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:        model,
+		Name:         "BookstoreAssistant",
+		Description:  "An assistant that manages the bookstore inventory through its API",
+		Instructions: "Use the API tools to answer questions about the inventory and to make changes. Report prices and stock levels exactly as the API returns them.",
+		AgentTools:   tools,
+	}
+
+	questions := []string{
+		"Which Ursula K. Le Guin books do we have, and are any out of stock?",
+		"Add 'Cosmos' by Carl Sagan to the science section at $17.99 with 3 copies, then confirm it was saved.",
+		"Remove book 2 from the inventory.",
+	}
+
+	for _, question := range questions {
+		fmt.Printf("❓ %s\n", question)
+		response, err := agent.Execute(question)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		fmt.Printf("Response: %s\n\n", response)
+	}
+
+	fmt.Println("✅ Example completed successfully!")
+}
@@ -0,0 +1,376 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic/ai"
+)
+
+const maxResponseSize = 8 * 1024
+
+// Spec is the subset of an OpenAPI 3 or Swagger 2 document needed to build tools. Both
+// versions are JSON; convert YAML specs first.
+type Spec struct {
+	OpenAPI  string `json:"openapi"`
+	Swagger  string `json:"swagger"`
+	Host     string `json:"host"`
+	BasePath string `json:"basePath"`
+	Servers  []struct {
+		URL string `json:"url"`
+	} `json:"servers"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]map[string]interface{} `json:"schemas"`
+	} `json:"components"`
+	Definitions map[string]map[string]interface{} `json:"definitions"`
+}
+
+// Operation is one method on one path
+type Operation struct {
+	OperationID string      `json:"operationId"`
+	Summary     string      `json:"summary"`
+	Description string      `json:"description"`
+	Parameters  []Parameter `json:"parameters"`
+	RequestBody *struct {
+		Required bool `json:"required"`
+		Content  map[string]struct {
+			Schema map[string]interface{} `json:"schema"`
+		} `json:"content"`
+	} `json:"requestBody"`
+
+	method string
+	path   string
+}
+
+// Parameter is a path, query, header or (Swagger 2) body parameter
+type Parameter struct {
+	Name        string                 `json:"name"`
+	In          string                 `json:"in"`
+	Required    bool                   `json:"required"`
+	Description string                 `json:"description"`
+	Schema      map[string]interface{} `json:"schema"`
+	Type        string                 `json:"type"` // Swagger 2 puts the type on the parameter
+}
+
+var httpMethods = []string{"get", "put", "post", "delete", "patch", "head", "options"}
+
+// toolNamePattern is what model APIs accept as a function name
+var toolNamePattern = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// ParseSpec reads an OpenAPI 3 or Swagger 2 JSON document
+func ParseSpec(data []byte) (*Spec, error) {
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parsing spec: %w", err)
+	}
+	if spec.OpenAPI == "" && spec.Swagger == "" {
+		return nil, fmt.Errorf("not an OpenAPI or Swagger document")
+	}
+	return &spec, nil
+}
+
+// BaseURL returns the first server URL (OpenAPI 3) or host and basePath (Swagger 2)
+func (s *Spec) BaseURL() string {
+	if len(s.Servers) > 0 {
+		return strings.TrimSuffix(s.Servers[0].URL, "/")
+	}
+	if s.Host != "" {
+		return "https://" + s.Host + strings.TrimSuffix(s.BasePath, "/")
+	}
+	return ""
+}
+
+// Operations returns every operation in the spec, sorted by operation ID
+func (s *Spec) Operations() ([]Operation, error) {
+	var ops []Operation
+	for path, methods := range s.Paths {
+		for _, method := range httpMethods {
+			raw, ok := methods[method]
+			if !ok {
+				continue
+			}
+
+			var op Operation
+			if err := json.Unmarshal(raw, &op); err != nil {
+				return nil, fmt.Errorf("parsing %s %s: %w", strings.ToUpper(method), path, err)
+			}
+			op.method = strings.ToUpper(method)
+			op.path = path
+			if op.OperationID == "" {
+				op.OperationID = strings.ToLower(method) + "_" + strings.Trim(path, "/")
+			}
+			ops = append(ops, op)
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].OperationID < ops[j].OperationID })
+	return ops, nil
+}
+
+// resolve replaces $ref and allOf with the schemas they point to, so the model sees a
+// self-contained schema. depth stops reference cycles.
+func (s *Spec) resolve(schema map[string]interface{}, depth int) map[string]interface{} {
+	if schema == nil || depth > 8 {
+		return map[string]interface{}{"type": "object"}
+	}
+
+	if ref, ok := schema["$ref"].(string); ok {
+		name := ref[strings.LastIndex(ref, "/")+1:]
+		target := s.Components.Schemas[name]
+		if target == nil {
+			target = s.Definitions[name]
+		}
+		return s.resolve(target, depth+1)
+	}
+
+	resolved := make(map[string]interface{}, len(schema))
+	for key, value := range schema {
+		switch key {
+		case "properties":
+			props := make(map[string]interface{})
+			fields, _ := value.(map[string]interface{})
+			for name, prop := range fields {
+				if p, ok := prop.(map[string]interface{}); ok {
+					props[name] = s.resolve(p, depth+1)
+				}
+			}
+			resolved[key] = props
+		case "items":
+			if items, ok := value.(map[string]interface{}); ok {
+				resolved[key] = s.resolve(items, depth+1)
+			}
+		case "allOf":
+			// Merge the parts into one object schema
+			merged := map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+			var required []interface{}
+			parts, _ := value.([]interface{})
+			for _, part := range parts {
+				p, _ := part.(map[string]interface{})
+				r := s.resolve(p, depth+1)
+				if props, ok := r["properties"].(map[string]interface{}); ok {
+					for name, prop := range props {
+						merged["properties"].(map[string]interface{})[name] = prop
+					}
+				}
+				if req, ok := r["required"].([]interface{}); ok {
+					required = append(required, req...)
+				}
+			}
+			if len(required) > 0 {
+				merged["required"] = required
+			}
+			return merged
+		default:
+			resolved[key] = value
+		}
+	}
+	return resolved
+}
+
+// inputSchema builds the tool's JSON schema from the operation's parameters. A request
+// body becomes a single "body" property so its fields cannot clash with parameter names.
+func (s *Spec) inputSchema(op Operation) map[string]interface{} {
+	properties := make(map[string]interface{})
+	required := []string{}
+
+	for _, param := range op.Parameters {
+		schema := param.Schema
+		if schema == nil {
+			schema = map[string]interface{}{"type": param.Type}
+		}
+		schema = s.resolve(schema, 0)
+		if param.In == "body" {
+			properties["body"] = schema
+			if param.Required {
+				required = append(required, "body")
+			}
+			continue
+		}
+
+		if param.Description != "" {
+			schema["description"] = param.Description
+		}
+		properties[param.Name] = schema
+		if param.Required || param.In == "path" {
+			required = append(required, param.Name)
+		}
+	}
+
+	if op.RequestBody != nil {
+		if content, ok := op.RequestBody.Content["application/json"]; ok {
+			properties["body"] = s.resolve(content.Schema, 0)
+			if op.RequestBody.Required {
+				required = append(required, "body")
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
+
+// ToolsFromSpec creates one AgentTool per selected operation ID. An empty selection
+// exposes every operation, which is rarely what you want for APIs with side effects.
+func ToolsFromSpec(spec *Spec, baseURL string, client *http.Client, operationIDs ...string) ([]aigentic.AgentTool, error) {
+	ops, err := spec.Operations()
+	if err != nil {
+		return nil, err
+	}
+	if baseURL == "" {
+		baseURL = spec.BaseURL()
+	}
+	if baseURL == "" {
+		return nil, fmt.Errorf("the spec has no server URL; pass a base URL")
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 15 * time.Second}
+	}
+
+	byID := make(map[string]Operation, len(ops))
+	for _, op := range ops {
+		byID[op.OperationID] = op
+	}
+
+	selected := ops
+	if len(operationIDs) > 0 {
+		selected = nil
+		for _, id := range operationIDs {
+			op, ok := byID[id]
+			if !ok {
+				return nil, fmt.Errorf("operation %q is not in the spec", id)
+			}
+			selected = append(selected, op)
+		}
+	}
+
+	tools := make([]aigentic.AgentTool, 0, len(selected))
+	for _, op := range selected {
+		tools = append(tools, spec.tool(op, baseURL, client))
+	}
+	return tools, nil
+}
+
+func (s *Spec) tool(op Operation, baseURL string, client *http.Client) aigentic.AgentTool {
+	var parts []string
+	for _, text := range []string{op.Summary, op.Description} {
+		if text = strings.TrimSuffix(strings.TrimSpace(text), "."); text != "" {
+			parts = append(parts, text+".")
+		}
+	}
+	parts = append(parts, fmt.Sprintf("Calls %s %s.", op.method, op.path))
+	description := strings.Join(parts, " ")
+
+	return aigentic.AgentTool{
+		Name:        toolNamePattern.ReplaceAllString(op.OperationID, "_"),
+		Description: description,
+		InputSchema: s.inputSchema(op),
+		Execute: func(run *aigentic.AgentRun, args map[string]interface{}) (*ai.ToolResult, error) {
+			req, err := buildRequest(op, baseURL, args)
+			if err != nil {
+				return errorResult(err.Error()), nil
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				return errorResult(err.Error()), nil
+			}
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+			if err != nil {
+				return errorResult(err.Error()), nil
+			}
+			text := string(body)
+			if len(body) > maxResponseSize {
+				text = string(body[:maxResponseSize]) + "\n... (response truncated)"
+			}
+
+			content := fmt.Sprintf("HTTP %s\n%s", resp.Status, text)
+			if resp.StatusCode >= 400 {
+				return errorResult(content), nil
+			}
+			return &ai.ToolResult{Content: []ai.ToolContent{{Type: "text", Content: content}}}, nil
+		},
+	}
+}
+
+// buildRequest fills in path, query and header parameters and the JSON body from the
+// tool arguments
+func buildRequest(op Operation, baseURL string, args map[string]interface{}) (*http.Request, error) {
+	path := op.path
+	query := url.Values{}
+	headers := http.Header{}
+
+	for _, param := range op.Parameters {
+		if param.In == "body" {
+			continue
+		}
+		value, ok := args[param.Name]
+		if !ok || value == nil {
+			if param.Required || param.In == "path" {
+				return nil, fmt.Errorf("missing required parameter %s", param.Name)
+			}
+			continue
+		}
+
+		text := formatValue(value)
+		switch param.In {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+param.Name+"}", url.PathEscape(text))
+		case "query":
+			query.Set(param.Name, text)
+		case "header":
+			headers.Set(param.Name, text)
+		}
+	}
+
+	target := baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	if value, ok := args["body"]; ok {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("encoding body: %w", err)
+		}
+		body = bytes.NewReader(data)
+		headers.Set("Content-Type", "application/json")
+	}
+
+	req, err := http.NewRequest(op.method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = headers
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
+// formatValue writes numbers from JSON without a trailing ".0" or exponent
+func formatValue(value interface{}) string {
+	if f, ok := value.(float64); ok && f == float64(int64(f)) {
+		return fmt.Sprintf("%d", int64(f))
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func errorResult(message string) *ai.ToolResult {
+	return &ai.ToolResult{
+		Content: []ai.ToolContent{{Type: "text", Content: message}},
+		Error:   true,
+	}
+}
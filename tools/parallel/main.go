@@ -0,0 +1,217 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+const weatherLatency = time.Second
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// lookupWeather simulates a slow weather API
+func lookupWeather(city string) string {
+	time.Sleep(weatherLatency)
+
+	seed := 0
+	for _, r := range strings.ToLower(city) {
+		seed += int(r)
+	}
+	return fmt.Sprintf("%s: %d°C, %s", city, 5+seed%25, []string{"sunny", "cloudy", "rain", "windy"}[seed%4])
+}
+
+// createWeatherTool takes every city in one call and looks them up on the pool's workers.
+// aigentic runs the tool calls of a turn one after another, so separate get_weather calls
+// per city would never overlap; the fan-out has to happen inside a single call.
+func createWeatherTool(pool *WorkerPool) aigentic.AgentTool {
+	type WeatherInput struct {
+		Cities []string `json:"cities" description:"Every city to look up, e.g. [\"Lisbon\", \"Oslo\"]"`
+	}
+
+	return aigentic.NewTool(
+		"get_weather",
+		"Gets the current weather for one or more cities. Pass all the cities you need in a single call.",
+		func(run *aigentic.AgentRun, input WeatherInput) (string, error) {
+			if len(input.Cities) == 0 {
+				return "", fmt.Errorf("cities is empty")
+			}
+			return strings.Join(pool.Run(input.Cities, lookupWeather), "\n"), nil
+		},
+	)
+}
+
+// Lookup is one city looked up by the pool, with when it ran
+type Lookup struct {
+	City  string
+	Start time.Time
+	End   time.Time
+}
+
+// WorkerPool runs lookups with at most a fixed number in flight at once and records when
+// each one ran
+type WorkerPool struct {
+	slots chan struct{}
+
+	mu      sync.Mutex
+	lookups []Lookup
+}
+
+// NewWorkerPool allows at least one lookup in flight, so a zero or negative count can't block or panic
+func NewWorkerPool(workers int) *WorkerPool {
+	return &WorkerPool{slots: make(chan struct{}, max(workers, 1))}
+}
+
+// Run calls fn for each city on the pool's workers, waits for all of them and returns the
+// results in the order of cities
+func (p *WorkerPool) Run(cities []string, fn func(city string) string) []string {
+	results := make([]string, len(cities))
+	var wg sync.WaitGroup
+	for i, city := range cities {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.slots <- struct{}{}
+			defer func() { <-p.slots }()
+
+			lookup := Lookup{City: city, Start: time.Now()}
+			results[i] = fn(city)
+			lookup.End = time.Now()
+
+			p.mu.Lock()
+			p.lookups = append(p.lookups, lookup)
+			p.mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// Lookups returns the recorded lookups in the order they started
+func (p *WorkerPool) Lookups() []Lookup {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	lookups := append([]Lookup(nil), p.lookups...)
+	sort.Slice(lookups, func(i, j int) bool { return lookups[i].Start.Before(lookups[j].Start) })
+	return lookups
+}
+
+// runSequentially looks up cities one after another
+func runSequentially(cities []string) time.Duration {
+	start := time.Now()
+	for _, city := range cities {
+		lookupWeather(city)
+	}
+	return time.Since(start)
+}
+
+// printTimeline draws when each lookup ran, relative to the first one
+func printTimeline(lookups []Lookup) {
+	if len(lookups) == 0 {
+		fmt.Println("The agent made no weather lookups.")
+		return
+	}
+
+	origin := lookups[0].Start
+	end := origin
+	for _, lookup := range lookups {
+		if lookup.End.After(end) {
+			end = lookup.End
+		}
+	}
+	scale := float64(end.Sub(origin)) / 40
+
+	maxInFlight := 0
+	for _, lookup := range lookups {
+		// Concurrency peaks when some lookup starts, so count what is running at each start
+		inFlight := 0
+		for _, other := range lookups {
+			if !other.Start.After(lookup.Start) && other.End.After(lookup.Start) {
+				inFlight++
+			}
+		}
+		maxInFlight = max(maxInFlight, inFlight)
+
+		from := int(float64(lookup.Start.Sub(origin)) / scale)
+		to := max(int(float64(lookup.End.Sub(origin))/scale), from+1)
+		fmt.Printf("%-14s |%s%s%s| %v\n", lookup.City, strings.Repeat(" ", from), strings.Repeat("█", to-from), strings.Repeat(" ", max(40-to, 0)), lookup.End.Sub(lookup.Start).Round(10*time.Millisecond))
+	}
+	fmt.Printf("\n%d lookups in %v, at most %d at once\n", len(lookups), end.Sub(origin).Round(10*time.Millisecond), maxInFlight)
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	workers := flag.Int("workers", 3, "Maximum number of tool calls running at once")
+	flag.Parse()
+	*workers = max(*workers, 1)
+
+	fmt.Println("⚡ Parallel Tool Calls Example")
+	fmt.Println("==============================")
+	fmt.Printf("Each weather lookup takes %v; up to %d run at once.\n\n", weatherLatency, *workers)
+
+	pool := NewWorkerPool(*workers)
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:        model,
+		Name:         "WeatherAssistant",
+		Description:  "An assistant that compares the weather across cities",
+		Instructions: "Call get_weather once with every city you need in the cities list. Do not call it once per city.",
+		AgentTools:   []aigentic.AgentTool{createWeatherTool(pool)},
+	}
+
+	question := "Compare the weather right now in Lisbon, Oslo, Nairobi, Osaka and Lima. Which is warmest?"
+	fmt.Printf("❓ %s\n", question)
+
+	start := time.Now()
+	response, err := agent.Execute(question)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	fmt.Printf("Response: %s\n", response)
+	fmt.Printf("(agent run took %v)\n\n", time.Since(start).Round(10*time.Millisecond))
+
+	lookups := pool.Lookups()
+	fmt.Println("Weather lookup timeline")
+	fmt.Println("-----------------------")
+	printTimeline(lookups)
+
+	// Replay the same lookups without the model to compare the two strategies directly
+	cities := make([]string, len(lookups))
+	for i, lookup := range lookups {
+		cities[i] = lookup.City
+	}
+
+	fmt.Println("\nReplaying the agent's lookups")
+	fmt.Println("-----------------------------")
+	sequential := runSequentially(cities)
+	start = time.Now()
+	NewWorkerPool(*workers).Run(cities, lookupWeather)
+	parallel := time.Since(start)
+	fmt.Printf("%-20s %v\n", "Sequential:", sequential.Round(10*time.Millisecond))
+	fmt.Printf("%-20s %v\n", fmt.Sprintf("Worker pool (%d):", *workers), parallel.Round(10*time.Millisecond))
+	if parallel > 0 {
+		fmt.Printf("%-20s %.1fx\n", "Speedup:", float64(sequential)/float64(parallel))
+	}
+
+	fmt.Println("\n✅ Example completed successfully!")
+}
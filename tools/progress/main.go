@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+const sampleLines = 400000
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// ProgressEvent is an intermediate update from a running tool
+type ProgressEvent struct {
+	ToolName string
+	Percent  int
+	Message  string
+}
+
+// ProgressReporter carries progress from tools to the event loop. Agent events come from
+// run.Next(), which tools cannot write to, so progress travels on its own channel and the
+// event loop listens to both.
+type ProgressReporter struct {
+	events chan ProgressEvent
+}
+
+func NewProgressReporter() *ProgressReporter {
+	return &ProgressReporter{events: make(chan ProgressEvent, 16)}
+}
+
+// Report sends an update, dropping it if the UI has fallen behind so the tool never waits
+func (p *ProgressReporter) Report(toolName string, percent int, format string, args ...interface{}) {
+	select {
+	case p.events <- ProgressEvent{ToolName: toolName, Percent: percent, Message: fmt.Sprintf(format, args...)}:
+	default:
+	}
+}
+
+func (p *ProgressReporter) Events() <-chan ProgressEvent {
+	return p.events
+}
+
+// countingReader tracks how many bytes have been read, to measure progress through a file
+type countingReader struct {
+	r    io.Reader
+	read int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.read += int64(n)
+	return n, err
+}
+
+// analyzeLog scans a log file, counting lines per level and the most common errors. It
+// reports progress at most every 5% of the file.
+func analyzeLog(path string, report func(percent int, lines int)) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	counter := &countingReader{r: f}
+	scanner := bufio.NewScanner(counter)
+
+	levels := make(map[string]int)
+	errorCounts := make(map[string]int)
+	lines, lastPercent := 0, -1
+
+	for scanner.Scan() {
+		lines++
+		fields := strings.SplitN(scanner.Text(), " ", 4)
+		if len(fields) < 4 {
+			continue
+		}
+		levels[fields[2]]++
+		if fields[2] == "ERROR" {
+			errorCounts[fields[3]]++
+		}
+
+		if lines%2000 == 0 {
+			// Simulate expensive per-record work so the progress is visible
+			time.Sleep(15 * time.Millisecond)
+
+			if percent := int(counter.read * 100 / max(info.Size(), 1)); percent >= lastPercent+5 {
+				lastPercent = percent
+				report(percent, lines)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	report(100, lines)
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Analyzed %d lines (%d KB)\n", lines, info.Size()/1024))
+	for _, level := range []string{"DEBUG", "INFO", "WARN", "ERROR"} {
+		b.WriteString(fmt.Sprintf("%s: %d\n", level, levels[level]))
+	}
+
+	type errorCount struct {
+		message string
+		count   int
+	}
+	var top []errorCount
+	for message, count := range errorCounts {
+		top = append(top, errorCount{message, count})
+	}
+	sort.Slice(top, func(i, j int) bool { return top[i].count > top[j].count })
+	b.WriteString("Most common errors:\n")
+	for i := 0; i < len(top) && i < 3; i++ {
+		b.WriteString(fmt.Sprintf("- %s (%d)\n", top[i].message, top[i].count))
+	}
+	return b.String(), nil
+}
+
+func createAnalyzeLogTool(dir string, progress *ProgressReporter) aigentic.AgentTool {
+	type AnalyzeLogInput struct {
+		File string `json:"file" description:"Name of the log file to analyze"`
+	}
+
+	return aigentic.NewTool(
+		"analyze_log",
+		"Analyzes a large application log file and summarizes line counts per level and the most common errors. Takes several seconds.",
+		func(run *aigentic.AgentRun, input AnalyzeLogInput) (string, error) {
+			path := filepath.Join(dir, filepath.Base(input.File))
+			return analyzeLog(path, func(percent, lines int) {
+				progress.Report("analyze_log", percent, "%d lines", lines)
+			})
+		},
+	)
+}
+
+// writeSampleLog generates a large log file to analyze
+func writeSampleLog(dir string) error {
+	f, err := os.Create(filepath.Join(dir, "app.log"))
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+
+	messages := map[string][]string{
+		"DEBUG": {"cache lookup", "query planned", "request headers parsed"},
+		"INFO":  {"request completed", "user logged in", "job scheduled"},
+		"WARN":  {"slow query", "retrying upstream call", "disk usage above 80%"},
+		"ERROR": {"database connection refused", "payment gateway timeout", "payment gateway timeout", "nil pointer in report export"},
+	}
+	weights := []string{"DEBUG", "DEBUG", "INFO", "INFO", "INFO", "INFO", "WARN", "ERROR"}
+
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < sampleLines; i++ {
+		level := weights[rand.IntN(len(weights))]
+		options := messages[level]
+		ts := start.Add(time.Duration(i) * 200 * time.Millisecond)
+		fmt.Fprintf(w, "%s %s %s %s\n", ts.Format("2006-01-02"), ts.Format("15:04:05.000"), level, options[rand.IntN(len(options))])
+	}
+
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func progressBar(percent int) string {
+	filled := percent / 5
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", 20-filled) + "]"
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	fmt.Println("⏳ Tool Progress Reporting Example")
+	fmt.Println("==================================")
+	fmt.Println()
+
+	dir, err := os.MkdirTemp("", "aigentic-progress-*")
+	if err != nil {
+		log.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	fmt.Printf("Generating a %d line log file...\n\n", sampleLines)
+	if err := writeSampleLog(dir); err != nil {
+		log.Fatalf("Failed to write sample log: %v", err)
+	}
+
+	progress := NewProgressReporter()
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:        model,
+		Name:         "LogAnalyst",
+		Description:  "An assistant that investigates application logs",
+		Instructions: "Use analyze_log to inspect log files, then explain what the results suggest and what to look at first.",
+		AgentTools:   []aigentic.AgentTool{createAnalyzeLogTool(dir, progress)},
+	}
+
+	run, err := agent.Start("Analyze app.log and tell me what is going wrong with the application.")
+	if err != nil {
+		log.Fatalf("Failed to start agent: %v", err)
+	}
+
+	// Forward agent events to a channel so the loop below can wait on them and on
+	// progress at the same time
+	events := make(chan interface{})
+	go func() {
+		defer close(events)
+		for event := range run.Next() {
+			events <- event
+		}
+	}()
+
+	var fullResponse string
+	for events != nil {
+		select {
+		case p := <-progress.Events():
+			fmt.Printf("\r%s %s %3d%% %s   ", p.ToolName, progressBar(p.Percent), p.Percent, p.Message)
+			if p.Percent == 100 {
+				fmt.Println()
+			}
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				break
+			}
+			switch e := event.(type) {
+			case *aigentic.ContentEvent:
+				fullResponse += e.Content
+			case *aigentic.ToolEvent:
+				fmt.Printf("[Tool executed: %s]\n", e.ToolName)
+			case *aigentic.ErrorEvent:
+				log.Printf("Error: %v", e.Err)
+			}
+		}
+	}
+
+	fmt.Printf("\nResponse: %s\n", fullResponse)
+	fmt.Println("\n✅ Example completed successfully!")
+}
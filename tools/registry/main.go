@@ -0,0 +1,192 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+// roleCapabilities decides which tool groups each role may use
+var roleCapabilities = map[string][]string{
+	"analyst": {"finance"},
+	"support": {"files", "network"},
+	"admin":   {"finance", "files", "network"},
+}
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+func createFinanceTools() []aigentic.AgentTool {
+	type QuarterInput struct {
+		Quarter string `json:"quarter" description:"Quarter, e.g. Q3"`
+	}
+
+	revenue := map[string]float64{"Q1": 1.20, "Q2": 1.35, "Q3": 1.52, "Q4": 1.61}
+	expenses := map[string]float64{"Q1": 0.98, "Q2": 1.02, "Q3": 1.11, "Q4": 1.30}
+
+	lookup := func(figures map[string]float64, label, quarter string) (string, error) {
+		value, ok := figures[strings.ToUpper(quarter)]
+		if !ok {
+			return "", fmt.Errorf("no %s figures for %q", label, quarter)
+		}
+		return fmt.Sprintf("%s %s: $%.2fM", strings.ToUpper(quarter), label, value), nil
+	}
+
+	return []aigentic.AgentTool{
+		aigentic.NewTool("get_revenue", "Gets company revenue for a quarter",
+			func(run *aigentic.AgentRun, input QuarterInput) (string, error) {
+				return lookup(revenue, "revenue", input.Quarter)
+			}),
+		aigentic.NewTool("get_expenses", "Gets company expenses for a quarter",
+			func(run *aigentic.AgentRun, input QuarterInput) (string, error) {
+				return lookup(expenses, "expenses", input.Quarter)
+			}),
+	}
+}
+
+func createFileTools() []aigentic.AgentTool {
+	type ReadInput struct {
+		Name string `json:"name" description:"File name"`
+	}
+	type WriteInput struct {
+		Name    string `json:"name" description:"File name"`
+		Content string `json:"content" description:"File contents"`
+	}
+
+	var mu sync.Mutex
+	files := map[string]string{"runbook.md": "Restart the API with `make restart-api`. Escalate to on-call after 2 failed restarts."}
+
+	return []aigentic.AgentTool{
+		aigentic.NewTool("read_file", "Reads a shared team file",
+			func(run *aigentic.AgentRun, input ReadInput) (string, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				content, ok := files[input.Name]
+				if !ok {
+					return "", fmt.Errorf("file %s not found", input.Name)
+				}
+				return content, nil
+			}),
+		aigentic.NewTool("write_file", "Writes a shared team file",
+			func(run *aigentic.AgentRun, input WriteInput) (string, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				files[input.Name] = input.Content
+				return fmt.Sprintf("Saved %s", input.Name), nil
+			}),
+	}
+}
+
+func createNetworkTools() []aigentic.AgentTool {
+	type StatusInput struct {
+		Service string `json:"service" description:"Service name, e.g. api or billing"`
+	}
+
+	status := map[string]string{"api": "degraded (p95 latency 2.4s)", "billing": "healthy", "auth": "healthy"}
+
+	return []aigentic.AgentTool{
+		aigentic.NewTool("check_service", "Checks the health of an internal service over the network",
+			func(run *aigentic.AgentRun, input StatusInput) (string, error) {
+				s, ok := status[strings.ToLower(input.Service)]
+				if !ok {
+					return "", fmt.Errorf("unknown service %q", input.Service)
+				}
+				return fmt.Sprintf("%s: %s", input.Service, s), nil
+			}),
+	}
+}
+
+func toolNames(tools []aigentic.AgentTool) string {
+	if len(tools) == 0 {
+		return "(none)"
+	}
+	names := make([]string, len(tools))
+	for i, tool := range tools {
+		names[i] = tool.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	disable := flag.String("disable", "", "Comma-separated tool names to switch off, like a feature flag (e.g. write_file)")
+	flag.Parse()
+
+	fmt.Println("🗂️  Runtime Tool Registry Example")
+	fmt.Println("================================")
+	fmt.Println()
+
+	registry := NewToolRegistry()
+	for capability, tools := range map[string][]aigentic.AgentTool{
+		"finance": createFinanceTools(),
+		"files":   createFileTools(),
+		"network": createNetworkTools(),
+	} {
+		if err := registry.Register(capability, tools...); err != nil {
+			log.Fatalf("Failed to register tools: %v", err)
+		}
+	}
+
+	for _, name := range strings.Split(*disable, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			registry.SetEnabled(name, false)
+			fmt.Printf("Feature flag: %s disabled\n", name)
+		}
+	}
+
+	for _, capability := range registry.Capabilities() {
+		fmt.Printf("%-8s %s\n", capability+":", toolNames(registry.Tools(capability)))
+	}
+	fmt.Println()
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	// Each request builds its own agent with only the tools the user's role allows, so the
+	// model never sees, and cannot call, anything else
+	requests := []struct {
+		user, role, message string
+	}{
+		{"Priya", "analyst", "What was our Q3 profit? Also check whether the API is healthy."},
+		{"Sam", "support", "Is the API healthy? If not, read the runbook and save a short incident note to incident.md."},
+		{"Alex", "admin", "Give me Q3 profit and the API status in one line."},
+	}
+
+	for _, request := range requests {
+		tools := registry.Tools(roleCapabilities[request.role]...)
+
+		fmt.Println(strings.Repeat("-", 70))
+		fmt.Printf("👤 %s (%s): %s\n", request.user, request.role, request.message)
+		fmt.Printf("Tools: %s\n", toolNames(tools))
+
+		agent := aigentic.Agent{
+			Model:        model,
+			Name:         "OpsAssistant",
+			Description:  "An assistant for finance and operations questions",
+			Instructions: "Answer using your tools. If part of a request needs a tool you do not have, say that the user's role does not allow it rather than guessing.",
+			AgentTools:   tools,
+		}
+
+		response, err := agent.Execute(request.message)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		fmt.Printf("Response: %s\n\n", response)
+	}
+
+	fmt.Println("✅ Example completed successfully!")
+}
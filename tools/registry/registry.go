@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/nexxia-ai/aigentic"
+)
+
+// ToolRegistry holds every tool the application offers, grouped by capability. Agents
+// are not given the registry; each request gets only the tools it is allowed to use.
+type ToolRegistry struct {
+	mu           sync.RWMutex
+	capabilities map[string][]aigentic.AgentTool
+	disabled     map[string]bool
+}
+
+func NewToolRegistry() *ToolRegistry {
+	return &ToolRegistry{
+		capabilities: make(map[string][]aigentic.AgentTool),
+		disabled:     make(map[string]bool),
+	}
+}
+
+// Register adds tools under a capability such as "finance" or "files"
+func (r *ToolRegistry) Register(capability string, tools ...aigentic.AgentTool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Tool names must be unique across capabilities, since the model calls tools by name
+	registered := make(map[string]string)
+	for owner, existing := range r.capabilities {
+		for _, tool := range existing {
+			registered[tool.Name] = owner
+		}
+	}
+	for _, tool := range tools {
+		if other, ok := registered[tool.Name]; ok {
+			return fmt.Errorf("tool %s is already registered under %s", tool.Name, other)
+		}
+		registered[tool.Name] = capability
+	}
+
+	r.capabilities[capability] = append(r.capabilities[capability], tools...)
+	return nil
+}
+
+// SetEnabled switches a tool on or off for every request, as a feature flag would. The
+// tool stays registered so it can be switched back on at runtime.
+func (r *ToolRegistry) SetEnabled(toolName string, enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.disabled[toolName] = !enabled
+}
+
+// Tools assembles the enabled tools for the given capabilities. Unknown capabilities
+// contribute nothing, so a typo narrows access rather than widening it.
+func (r *ToolRegistry) Tools(capabilities ...string) []aigentic.AgentTool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var tools []aigentic.AgentTool
+	for _, capability := range capabilities {
+		for _, tool := range r.capabilities[capability] {
+			if !r.disabled[tool.Name] {
+				tools = append(tools, tool)
+			}
+		}
+	}
+	return tools
+}
+
+// Capabilities lists the registered capabilities in name order
+func (r *ToolRegistry) Capabilities() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.capabilities))
+	for name := range r.capabilities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+var rates = map[string]float64{
+	"USD": 1,
+	"EUR": 0.92,
+	"GBP": 0.79,
+	"JPY": 151.4,
+	"CAD": 1.36,
+}
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// startFlakyRatesServer serves exchange rates on a local port, failing in a repeating
+// pattern: a 503, a 429 with Retry-After, and a response too slow for the client timeout.
+// Unknown currencies get a 404, which is not worth retrying.
+func startFlakyRatesServer() (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+
+	pattern := []string{"unavailable", "ok", "rate-limited", "ok", "slow", "ok"}
+	var requests atomic.Int64
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rates", func(w http.ResponseWriter, r *http.Request) {
+		switch pattern[int(requests.Add(1)-1)%len(pattern)] {
+		case "unavailable":
+			http.Error(w, "upstream unavailable", http.StatusServiceUnavailable)
+			return
+		case "rate-limited":
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return
+		case "slow":
+			time.Sleep(3 * time.Second)
+		}
+
+		from, to := strings.ToUpper(r.URL.Query().Get("from")), strings.ToUpper(r.URL.Query().Get("to"))
+		fromRate, ok1 := rates[from]
+		toRate, ok2 := rates[to]
+		if !ok1 || !ok2 {
+			http.Error(w, "unknown currency", http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"from": from, "to": to, "rate": toRate / fromRate})
+	})
+
+	go http.Serve(listener, mux)
+	return "http://" + listener.Addr().String(), nil
+}
+
+// createExchangeRateTool calls the rates API. It returns failures as Go errors rather than
+// error results so that WithRetry can classify them.
+func createExchangeRateTool(baseURL string) aigentic.AgentTool {
+	client := &http.Client{Timeout: 2 * time.Second}
+
+	return aigentic.AgentTool{
+		Name:        "get_exchange_rate",
+		Description: "Gets the current exchange rate between two currencies",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"from": map[string]interface{}{
+					"type":        "string",
+					"description": "ISO currency code to convert from, e.g. USD",
+				},
+				"to": map[string]interface{}{
+					"type":        "string",
+					"description": "ISO currency code to convert to, e.g. EUR",
+				},
+			},
+			"required": []string{"from", "to"},
+		},
+		Execute: func(run *aigentic.AgentRun, args map[string]interface{}) (*ai.ToolResult, error) {
+			from, _ := args["from"].(string)
+			to, _ := args["to"].(string)
+			fmt.Printf("  [GET /rates %s→%s]\n", from, to)
+
+			resp, err := client.Get(fmt.Sprintf("%s/rates?from=%s&to=%s", baseURL, from, to))
+			if err != nil {
+				return nil, err
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				statusErr := &HTTPStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+				if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+					statusErr.RetryAfter = time.Duration(seconds) * time.Second
+				}
+				return nil, statusErr
+			}
+
+			var body struct {
+				From string  `json:"from"`
+				To   string  `json:"to"`
+				Rate float64 `json:"rate"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				return nil, fmt.Errorf("decoding response: %w", err)
+			}
+
+			return &ai.ToolResult{
+				Content: []ai.ToolContent{{
+					Type:    "text",
+					Content: fmt.Sprintf("1 %s = %.4f %s", body.From, body.Rate, body.To),
+				}},
+			}, nil
+		},
+	}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	fmt.Println("🔁 Retry With Backoff Example")
+	fmt.Println("=============================")
+	fmt.Println()
+
+	baseURL, err := startFlakyRatesServer()
+	if err != nil {
+		log.Fatalf("Failed to start rates server: %v", err)
+	}
+	fmt.Printf("Flaky rates API listening on %s\n\n", baseURL)
+
+	policy := DefaultRetryPolicy
+	policy.OnRetry = func(toolName string, attempt int, err error, delay time.Duration) {
+		fmt.Printf("  ↻ %s attempt %d failed (%v), retrying in %v\n", toolName, attempt, err, delay.Round(time.Millisecond))
+	}
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:        model,
+		Name:         "CurrencyAssistant",
+		Description:  "An assistant that converts between currencies",
+		Instructions: "Use get_exchange_rate for every conversion, one currency pair per call, and show your arithmetic. If a tool call fails, tell the user which conversion could not be done.",
+		AgentTools: []aigentic.AgentTool{
+			WithRetry(createExchangeRateTool(baseURL), policy),
+		},
+	}
+
+	questions := []string{
+		"How much is 250 USD in EUR, GBP and JPY?",
+		"Convert 100 EUR to Bitcoin (XBT).",
+	}
+
+	for _, question := range questions {
+		fmt.Printf("❓ %s\n", question)
+		response, err := agent.Execute(question)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		fmt.Printf("Response: %s\n\n", response)
+	}
+
+	fmt.Println("✅ Example completed successfully!")
+}
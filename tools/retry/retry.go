@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	"github.com/nexxia-ai/aigentic/ai"
+)
+
+// RetryPolicy controls how often and how quickly a failing tool call is retried
+type RetryPolicy struct {
+	MaxAttempts  int           // total attempts, including the first
+	InitialDelay time.Duration // delay before the first retry
+	MaxDelay     time.Duration // cap on any single delay
+	Multiplier   float64       // growth factor between retries
+
+	// Retryable decides whether an error is worth retrying. Nil uses IsRetryable.
+	Retryable func(error) bool
+
+	// OnRetry is called before each wait, for logging
+	OnRetry func(toolName string, attempt int, err error, delay time.Duration)
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:  4,
+	InitialDelay: 200 * time.Millisecond,
+	MaxDelay:     5 * time.Second,
+	Multiplier:   2,
+}
+
+// HTTPStatusError is returned by HTTP-backed tools for non-2xx responses
+type HTTPStatusError struct {
+	StatusCode int
+	Status     string
+	RetryAfter time.Duration // from the Retry-After header, if any
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("HTTP %s", e.Status)
+}
+
+// IsRetryable treats timeouts, connection errors, 429 and 5xx responses as transient.
+// Everything else, including other 4xx responses and cancellation, is fatal: retrying
+// would get the same answer.
+func IsRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// delay returns the wait before retry number attempt (1 for the first retry), with
+// jitter so that many clients failing together do not retry in lockstep
+func (p RetryPolicy) delay(attempt int, err error) time.Duration {
+	d := float64(p.InitialDelay)
+	for i := 1; i < attempt; i++ {
+		d *= p.Multiplier
+	}
+	if p.MaxDelay > 0 && d > float64(p.MaxDelay) {
+		d = float64(p.MaxDelay)
+	}
+	d = d/2 + rand.Float64()*d/2
+
+	// The server knows best how long to back off
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > time.Duration(d) {
+		return statusErr.RetryAfter
+	}
+	return time.Duration(d)
+}
+
+// WithRetry returns a copy of tool that retries Execute errors the policy classifies as
+// retryable. Results marked as errors are returned as they are, since the tool has
+// already decided how to report them to the model.
+func WithRetry(tool aigentic.AgentTool, policy RetryPolicy) aigentic.AgentTool {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	if policy.Multiplier < 1 {
+		policy.Multiplier = 1
+	}
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+
+	execute := tool.Execute
+	tool.Execute = func(run *aigentic.AgentRun, args map[string]interface{}) (*ai.ToolResult, error) {
+		for attempt := 1; ; attempt++ {
+			result, err := execute(run, args)
+			if err == nil {
+				return result, nil
+			}
+			if !retryable(err) {
+				return nil, fmt.Errorf("%s failed with a permanent error: %w", tool.Name, err)
+			}
+			if attempt == policy.MaxAttempts {
+				return nil, fmt.Errorf("%s failed after %d attempts: %w", tool.Name, attempt, err)
+			}
+
+			delay := policy.delay(attempt, err)
+			if policy.OnRetry != nil {
+				policy.OnRetry(tool.Name, attempt, err, delay)
+			}
+			time.Sleep(delay)
+		}
+	}
+	return tool
+}
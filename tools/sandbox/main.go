@@ -0,0 +1,257 @@
+//go:build unix
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// Result is the outcome of running one snippet
+type Result struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+	TimedOut bool
+}
+
+// Sandbox runs model-generated snippets in a subprocess with a timeout, a CPU limit, a
+// fresh temporary working directory, a minimal environment and, on Linux, no network.
+type Sandbox struct {
+	Timeout    time.Duration
+	CPUSeconds int
+	MaxOutput  int // bytes kept from each of stdout and stderr
+
+	// noNetwork runs the snippet in an empty network namespace with unshare(1)
+	noNetwork bool
+	goCache   string
+}
+
+// NewSandbox checks that network isolation works. Without it, snippets only run when
+// allowNetwork is set.
+func NewSandbox(allowNetwork bool) (*Sandbox, error) {
+	s := &Sandbox{Timeout: 10 * time.Second, CPUSeconds: 5, MaxOutput: 8 * 1024}
+
+	// -r maps the current user to root in a new user namespace, so no privileges are needed
+	if err := exec.Command("unshare", "-rn", "true").Run(); err == nil {
+		s.noNetwork = true
+	} else if !allowNetwork {
+		return nil, fmt.Errorf("network isolation with unshare is not available (%v); rerun with -allow-network to run snippets anyway", err)
+	}
+
+	// Reuse the build cache so Go snippets do not recompile the standard library every time
+	if out, err := exec.Command("go", "env", "GOCACHE").Output(); err == nil {
+		s.goCache = strings.TrimSpace(string(out))
+	}
+	return s, nil
+}
+
+// limitedBuffer keeps the first max bytes written and counts the rest
+type limitedBuffer struct {
+	buf     bytes.Buffer
+	max     int
+	dropped int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.buf.Len(); room < len(p) {
+		if room > 0 {
+			b.buf.Write(p[:room])
+		}
+		b.dropped += len(p) - max(room, 0)
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) String() string {
+	if b.dropped > 0 {
+		return b.buf.String() + fmt.Sprintf("\n... (%d more bytes truncated)", b.dropped)
+	}
+	return b.buf.String()
+}
+
+// Run writes the snippet to a temporary directory and runs it there
+func (s *Sandbox) Run(language, code string) (*Result, error) {
+	dir, err := os.MkdirTemp("", "sandbox-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	env := []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + dir,
+		"TMPDIR=" + dir,
+		"LANG=C.UTF-8",
+	}
+
+	var command []string
+	switch strings.ToLower(language) {
+	case "python":
+		if err := os.WriteFile(filepath.Join(dir, "main.py"), []byte(code), 0600); err != nil {
+			return nil, err
+		}
+		// -I ignores PYTHON* variables and user site-packages, -B skips .pyc files
+		command = []string{"python3", "-I", "-B", "main.py"}
+	case "go":
+		if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(code), 0600); err != nil {
+			return nil, err
+		}
+		// Only the standard library is available: modules cannot be downloaded
+		command = []string{"go", "run", "main.go"}
+		env = append(env, "GOPROXY=off", "GOTOOLCHAIN=local", "GOPATH="+filepath.Join(dir, "gopath"))
+		if s.goCache != "" {
+			env = append(env, "GOCACHE="+s.goCache)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported language %q: use python or go", language)
+	}
+
+	// The shell applies the CPU limit, then replaces itself with the command
+	args := append([]string{"sh", "-c", fmt.Sprintf(`ulimit -t %d && exec "$@"`, s.CPUSeconds), "sandbox"}, command...)
+	if s.noNetwork {
+		args = append([]string{"unshare", "-rn"}, args...)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdin = nil
+	stdout := &limitedBuffer{max: s.MaxOutput}
+	stderr := &limitedBuffer{max: s.MaxOutput}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	// Kill the whole process group on timeout, not just the shell
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = time.Second
+
+	err = cmd.Run()
+	result := &Result{Stdout: stdout.String(), Stderr: stderr.String(), TimedOut: ctx.Err() != nil}
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	case result.TimedOut:
+		result.ExitCode = -1
+	default:
+		return nil, fmt.Errorf("starting sandbox: %w", err)
+	}
+	return result, nil
+}
+
+func createRunCodeTool(sandbox *Sandbox) aigentic.AgentTool {
+	type RunCodeInput struct {
+		Language string `json:"language" description:"Language of the snippet: python or go"`
+		Code     string `json:"code" description:"Complete program to run. Go code must be package main with a main function and may only import the standard library."`
+	}
+
+	return aigentic.NewTool(
+		"run_code",
+		fmt.Sprintf("Runs a short Python 3 or Go program in a sandbox and returns its stdout, stderr and exit code. "+
+			"The sandbox has no network, no third-party packages, an empty working directory and a %v time limit. Print the results you need.", sandbox.Timeout),
+		func(run *aigentic.AgentRun, input RunCodeInput) (string, error) {
+			fmt.Printf("\n[run_code: %s, %d lines]\n", input.Language, strings.Count(input.Code, "\n")+1)
+
+			result, err := sandbox.Run(input.Language, input.Code)
+			if err != nil {
+				return "", err
+			}
+
+			var b strings.Builder
+			if result.TimedOut {
+				b.WriteString(fmt.Sprintf("Killed after the %v time limit.\n", sandbox.Timeout))
+			}
+			b.WriteString(fmt.Sprintf("Exit code: %d\n", result.ExitCode))
+			b.WriteString("Stdout:\n" + result.Stdout + "\n")
+			if result.Stderr != "" {
+				b.WriteString("Stderr:\n" + result.Stderr + "\n")
+			}
+			return b.String(), nil
+		},
+	)
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	allowNetwork := flag.Bool("allow-network", false, "Run snippets even when network isolation is unavailable")
+	flag.Parse()
+
+	fmt.Println("🧪 Sandboxed Code Execution Example")
+	fmt.Println("===================================")
+	fmt.Println()
+
+	sandbox, err := NewSandbox(*allowNetwork)
+	if err != nil {
+		log.Fatalf("Failed to set up sandbox: %v", err)
+	}
+	if sandbox.noNetwork {
+		fmt.Println("Snippets run without network access")
+	} else {
+		fmt.Println("⚠️  Network isolation unavailable: snippets can reach the network")
+	}
+	fmt.Println()
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:        model,
+		Name:         "CodeRunner",
+		Description:  "An assistant that answers questions by writing and running small programs",
+		Instructions: "When a question needs computation, write a short program and run it with the run_code tool. Use the language the user asks for. If the program fails, read stderr, fix it and run it again.",
+		AgentTools: []aigentic.AgentTool{
+			createRunCodeTool(sandbox),
+		},
+	}
+
+	questions := []string{
+		"Using Python, what is the sum of the first 1000 prime numbers?",
+		"Using Go, how many words in the sentence 'the quick brown fox jumps over the lazy dog' have more than three letters?",
+		"Using Python, fetch https://example.com and tell me the page title.",
+	}
+
+	for _, question := range questions {
+		fmt.Printf("❓ %s\n", question)
+		response, err := agent.Execute(question)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		fmt.Printf("Response: %s\n\n", response)
+	}
+
+	fmt.Println("✅ Example completed successfully!")
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/ai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+const (
+	commandTimeout = 30 * time.Second
+	maxOutput      = 16 * 1024
+)
+
+// denyRule blocks commands matching pattern before they reach the approver
+type denyRule struct {
+	pattern *regexp.Regexp
+	reason  string
+}
+
+// denyRules catch commands that are never worth asking about. They are a first line of
+// defence, not a complete blocklist: the approver still sees every command that passes.
+var denyRules = []denyRule{
+	{regexp.MustCompile(`\brm\s+(-[a-zA-Z]*[rR][a-zA-Z]*\s+-?[a-zA-Z]*[fF]|-[a-zA-Z]*[fF][a-zA-Z]*\s+-?[a-zA-Z]*[rR]|-[a-zA-Z]*([rR][fF]|[fF][rR]))`), "forced recursive delete (rm -rf)"},
+	{regexp.MustCompile(`\brm\s+.*--recursive\b.*--force\b|\brm\s+.*--force\b.*--recursive\b`), "forced recursive delete (rm --recursive --force)"},
+	{regexp.MustCompile(`\|\s*(curl|wget|nc|ncat|netcat)\b`), "piping output to a network command"},
+	{regexp.MustCompile(`\b(curl|wget)\b.*\|\s*(sudo\s+)?(sh|bash|zsh)\b`), "piping a download into a shell"},
+	{regexp.MustCompile(`\bsudo\b|\bsu\s`), "privilege escalation"},
+	{regexp.MustCompile(`\b(mkfs(\.\w+)?|fdisk|shutdown|reboot|halt|poweroff)\b`), "system administration command"},
+	{regexp.MustCompile(`\bdd\b.*\bof=`), "raw disk write with dd"},
+	{regexp.MustCompile(`>\s*/dev/(sd|nvme|disk)`), "writing to a block device"},
+	{regexp.MustCompile(`\bchmod\s+(-R\s+)?[0-7]*777\b`), "making files world-writable"},
+	{regexp.MustCompile(`:\(\)\s*\{`), "fork bomb"},
+}
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// checkCommand returns an error for every deny rule the command matches
+func checkCommand(command string) []error {
+	if strings.TrimSpace(command) == "" {
+		return []error{fmt.Errorf("command is empty")}
+	}
+
+	var errs []error
+	for _, rule := range denyRules {
+		if rule.pattern.MatchString(command) {
+			errs = append(errs, fmt.Errorf("command denied: %s", rule.reason))
+		}
+	}
+	return errs
+}
+
+// runCommand runs command with sh -c in dir and returns its combined output and exit code
+func runCommand(dir, command string) (string, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	cmd.WaitDelay = time.Second
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err := cmd.Run()
+	text := output.String()
+	if len(text) > maxOutput {
+		text = text[:maxOutput] + fmt.Sprintf("\n... (%d more bytes truncated)", len(text)-maxOutput)
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		return text, -1, fmt.Errorf("command timed out after %v", commandTimeout)
+	case errors.As(err, &exitErr):
+		return text, exitErr.ExitCode(), nil
+	case err != nil:
+		return "", -1, err
+	}
+	return text, 0, nil
+}
+
+func createShellTool(dir string) aigentic.AgentTool {
+	return aigentic.AgentTool{
+		Name: "run_shell",
+		Description: fmt.Sprintf("Runs a shell command in %s and returns its output and exit code. "+
+			"Every command needs human approval, and destructive commands are refused.", dir),
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"command": map[string]interface{}{
+					"type":        "string",
+					"description": "The command line to run with sh -c",
+				},
+				"reason": map[string]interface{}{
+					"type":        "string",
+					"description": "Why the command is needed, shown to the approver",
+				},
+			},
+			"required": []string{"command", "reason"},
+		},
+		RequireApproval: true,
+		Validate: func(run *aigentic.AgentRun, args map[string]interface{}) (aigentic.ValidationResult, error) {
+			command, _ := args["command"].(string)
+			reason, _ := args["reason"].(string)
+			return aigentic.ValidationResult{
+				Values:           args,
+				Message:          fmt.Sprintf("Run in %s: %s", dir, reason),
+				ValidationErrors: checkCommand(command),
+			}, nil
+		},
+		Execute: func(run *aigentic.AgentRun, args map[string]interface{}) (*ai.ToolResult, error) {
+			command, _ := args["command"].(string)
+
+			// Check again in case the call reached Execute without going through Validate
+			if errs := checkCommand(command); len(errs) > 0 {
+				return &ai.ToolResult{
+					Content: []ai.ToolContent{{Type: "text", Content: errors.Join(errs...).Error()}},
+					Error:   true,
+				}, nil
+			}
+
+			output, exitCode, err := runCommand(dir, command)
+			if err != nil {
+				return &ai.ToolResult{
+					Content: []ai.ToolContent{{Type: "text", Content: fmt.Sprintf("Error: %v\n%s", err, output)}},
+					Error:   true,
+				}, nil
+			}
+			return &ai.ToolResult{
+				Content: []ai.ToolContent{{
+					Type:    "text",
+					Content: fmt.Sprintf("Exit code: %d\nOutput:\n%s", exitCode, output),
+				}},
+				Error: exitCode != 0,
+			}, nil
+		},
+	}
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	workDir := flag.String("dir", ".", "Directory the commands run in")
+	flag.Parse()
+
+	dir, err := filepath.Abs(*workDir)
+	if err != nil {
+		log.Fatalf("Invalid directory: %v", err)
+	}
+
+	fmt.Println("🐚 Shell Command Tool Example")
+	fmt.Println("=============================")
+	fmt.Printf("Commands run in %s after you approve them.\n", dir)
+	fmt.Println()
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:       model,
+		Name:        "ShellAssistant",
+		Description: "An assistant that inspects the local machine with shell commands",
+		Instructions: "Answer questions by running shell commands with the run_shell tool. Prefer simple, read-only commands " +
+			"and run one command per tool call. Always give a short reason. If a command is denied or rejected, explain why and do not try to work around it.",
+		AgentTools: []aigentic.AgentTool{createShellTool(dir)},
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+
+	tasks := []string{
+		"How many Go files are in this directory tree, and which is the largest?",
+		"Free up space by deleting everything in this directory with rm -rf.",
+	}
+
+	for _, task := range tasks {
+		fmt.Printf("❓ %s\n", task)
+
+		run, err := agent.Start(task)
+		if err != nil {
+			log.Fatalf("Failed to start agent: %v", err)
+		}
+
+		var fullResponse string
+		for event := range run.Next() {
+			switch e := event.(type) {
+			case *aigentic.ContentEvent:
+				fullResponse += e.Content
+			case *aigentic.ApprovalEvent:
+				args, _ := e.ValidationResult.Values.(map[string]interface{})
+
+				fmt.Println("\n" + strings.Repeat("=", 70))
+				fmt.Printf("APPROVAL REQUIRED: %s\n", e.ToolName)
+				fmt.Println(e.ValidationResult.Message)
+				fmt.Printf("  $ %v\n", args["command"])
+				for _, validationErr := range e.ValidationResult.ValidationErrors {
+					fmt.Printf("  ⚠️  %v\n", validationErr)
+				}
+				fmt.Println(strings.Repeat("=", 70))
+
+				fmt.Print("Run this command? (y/n): ")
+				input, _ := reader.ReadString('\n')
+				input = strings.ToLower(strings.TrimSpace(input))
+
+				approved := input == "y" || input == "yes"
+				run.Approve(e.ApprovalID, approved)
+				if approved {
+					fmt.Println("✓ Command APPROVED")
+				} else {
+					fmt.Println("✗ Command REJECTED")
+				}
+			case *aigentic.ToolEvent:
+				fmt.Printf("[Tool executed: %s]\n", e.ToolName)
+			case *aigentic.ErrorEvent:
+				log.Printf("Error: %v", e.Err)
+			}
+		}
+
+		fmt.Printf("\nResponse: %s\n\n", fullResponse)
+	}
+
+	fmt.Println("✅ Example completed successfully!")
+}
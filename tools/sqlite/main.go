@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+// seedSQL creates and fills the sample database
+//
+//go:embed seed.sql
+var seedSQL string
+
+const (
+	maxRows      = 50
+	queryTimeout = 5 * time.Second
+)
+
+// writeKeywords are rejected anywhere in a query, since WITH can also start an INSERT or UPDATE.
+// REPLACE is only matched as REPLACE INTO so the replace() function still works.
+var writeKeywords = regexp.MustCompile(`(?i)\b(insert|update|delete|replace\s+into|drop|create|alter|attach|detach|pragma|vacuum|reindex)\b`)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// sqlite runs the sqlite3 command line shell. It is used instead of a Go driver so the
+// example needs no cgo or extra modules.
+func sqlite(ctx context.Context, stdin string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "sqlite3", args...)
+	cmd.Stdin = strings.NewReader(stdin)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("query timed out after %v", queryTimeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s", msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+// createDatabase builds the sample database from seed.sql in dir
+func createDatabase(dir string) (string, error) {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		return "", fmt.Errorf("the sqlite3 command line shell is required: %w", err)
+	}
+
+	path := filepath.Join(dir, "shop.db")
+	if _, err := sqlite(context.Background(), seedSQL, path); err != nil {
+		return "", fmt.Errorf("creating database: %w", err)
+	}
+	return path, nil
+}
+
+// checkReadOnly allows a single SELECT (or WITH ... SELECT) statement
+func checkReadOnly(query string) (string, error) {
+	query = strings.TrimSpace(query)
+	query = strings.TrimSpace(strings.TrimSuffix(query, ";"))
+
+	if query == "" {
+		return "", fmt.Errorf("query is empty")
+	}
+	if strings.Contains(query, ";") {
+		return "", fmt.Errorf("only a single statement is allowed")
+	}
+
+	first := strings.ToUpper(strings.Fields(query)[0])
+	if first != "SELECT" && first != "WITH" {
+		return "", fmt.Errorf("only SELECT statements are allowed, got %s", first)
+	}
+	if keyword := writeKeywords.FindString(query); keyword != "" {
+		return "", fmt.Errorf("%s is not allowed in a read-only query", strings.ToUpper(keyword))
+	}
+	return query, nil
+}
+
+// markdownTable renders CSV output with a header row as a markdown table
+func markdownTable(output string) (string, error) {
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		return "", fmt.Errorf("reading query output: %w", err)
+	}
+	if len(records) == 0 {
+		return "The query returned no rows.", nil
+	}
+
+	escape := func(cells []string) string {
+		escaped := make([]string, len(cells))
+		for i, cell := range cells {
+			escaped[i] = strings.ReplaceAll(strings.ReplaceAll(cell, "|", `\|`), "\n", " ")
+		}
+		return "| " + strings.Join(escaped, " | ") + " |\n"
+	}
+
+	header, rows := records[0], records[1:]
+	var b strings.Builder
+	b.WriteString(escape(header))
+	b.WriteString("|" + strings.Repeat("---|", len(header)) + "\n")
+
+	truncated := len(rows) > maxRows
+	if truncated {
+		rows = rows[:maxRows]
+	}
+	for _, row := range rows {
+		b.WriteString(escape(row))
+	}
+
+	switch {
+	case len(rows) == 0:
+		b.WriteString("\nThe query returned no rows.")
+	case truncated:
+		b.WriteString(fmt.Sprintf("\nShowing the first %d rows. Add a LIMIT or aggregate to see the rest.", maxRows))
+	}
+	return b.String(), nil
+}
+
+func createQueryDatabaseTool(dbPath string) aigentic.AgentTool {
+	type QueryInput struct {
+		SQL string `json:"sql" description:"A single read-only SQLite SELECT statement"`
+	}
+
+	return aigentic.NewTool(
+		"query_database",
+		"Runs a read-only SQL SELECT query against the shop database and returns the rows as a markdown table. Only SELECT statements are allowed.",
+		func(run *aigentic.AgentRun, input QueryInput) (string, error) {
+			query, err := checkReadOnly(input.SQL)
+			if err != nil {
+				return "", fmt.Errorf("query rejected: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+			defer cancel()
+
+			// -readonly and -safe enforce read-only access in SQLite itself as well
+			output, err := sqlite(ctx, "", "-readonly", "-safe", "-csv", "-header", dbPath, query)
+			if err != nil {
+				return "", fmt.Errorf("query failed: %v", err)
+			}
+			return markdownTable(output)
+		},
+	)
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	fmt.Println("🗄️  SQLite Query Tool Example")
+	fmt.Println("============================")
+	fmt.Println()
+
+	dir, err := os.MkdirTemp("", "aigentic-sqlite-*")
+	if err != nil {
+		log.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	dbPath, err := createDatabase(dir)
+	if err != nil {
+		log.Fatalf("Failed to create database: %v", err)
+	}
+
+	// Give the model the schema so it can write correct queries first time
+	schema, err := sqlite(context.Background(), "", "-readonly", dbPath, ".schema")
+	if err != nil {
+		log.Fatalf("Failed to read schema: %v", err)
+	}
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:       model,
+		Name:        "DataAnalyst",
+		Description: "An analyst that answers questions from the shop database",
+		Instructions: "You answer questions about the shop by querying its SQLite database with the query_database tool. " +
+			"Write SQLite SQL for this schema:\n\n" + schema +
+			"\nShow the relevant table from the tool output, then summarize the answer.",
+		AgentTools: []aigentic.AgentTool{
+			createQueryDatabaseTool(dbPath),
+		},
+	}
+
+	questions := []string{
+		"Which three customers have spent the most in total, and how much did each spend?",
+		"Delete all orders from Canada.",
+	}
+
+	for _, question := range questions {
+		fmt.Printf("❓ %s\n", question)
+		response, err := agent.Execute(question)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		fmt.Printf("Response: %s\n\n", response)
+	}
+
+	fmt.Println("✅ Example completed successfully!")
+}
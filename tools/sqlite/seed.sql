@@ -0,0 +1,51 @@
+-- Sample shop database used by the query_database tool example
+
+CREATE TABLE customers (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    email TEXT NOT NULL UNIQUE,
+    country TEXT NOT NULL,
+    signed_up DATE NOT NULL
+);
+
+CREATE TABLE products (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    category TEXT NOT NULL,
+    price REAL NOT NULL
+);
+
+CREATE TABLE orders (
+    id INTEGER PRIMARY KEY,
+    customer_id INTEGER NOT NULL REFERENCES customers(id),
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    quantity INTEGER NOT NULL,
+    ordered_at DATE NOT NULL
+);
+
+INSERT INTO customers (id, name, email, country, signed_up) VALUES
+    (1, 'Alice Martin', 'alice@example.com', 'Canada', '2024-01-15'),
+    (2, 'Bruno Costa', 'bruno@example.com', 'Brazil', '2024-02-03'),
+    (3, 'Chloe Dubois', 'chloe@example.com', 'France', '2024-02-20'),
+    (4, 'Daniel Kim', 'daniel@example.com', 'Canada', '2024-03-11'),
+    (5, 'Emma Schmidt', 'emma@example.com', 'Germany', '2024-04-02'),
+    (6, 'Farah Khan', 'farah@example.com', 'UK', '2024-05-19');
+
+INSERT INTO products (id, name, category, price) VALUES
+    (1, 'Mechanical Keyboard', 'Peripherals', 129.00),
+    (2, 'Wireless Mouse', 'Peripherals', 49.50),
+    (3, '27" Monitor', 'Displays', 329.99),
+    (4, 'USB-C Dock', 'Accessories', 199.00),
+    (5, 'Laptop Stand', 'Accessories', 39.95);
+
+INSERT INTO orders (id, customer_id, product_id, quantity, ordered_at) VALUES
+    (1, 1, 1, 1, '2024-06-01'),
+    (2, 1, 2, 2, '2024-06-01'),
+    (3, 2, 3, 1, '2024-06-07'),
+    (4, 3, 4, 1, '2024-06-12'),
+    (5, 4, 3, 2, '2024-06-15'),
+    (6, 4, 5, 1, '2024-06-15'),
+    (7, 5, 1, 1, '2024-07-02'),
+    (8, 6, 2, 3, '2024-07-09'),
+    (9, 2, 5, 2, '2024-07-21'),
+    (10, 1, 3, 1, '2024-08-04');
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nexxia-ai/aigentic"
+	openai "github.com/nexxia-ai/aigentic-openai"
+	"github.com/nexxia-ai/aigentic/utils"
+)
+
+func getAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		fmt.Println("Error: OPENAI_API_KEY environment variable not set")
+		fmt.Println("Please set your OpenAI API key: export OPENAI_API_KEY=your_api_key_here")
+		os.Exit(1)
+	}
+	return apiKey
+}
+
+// Address is a nested struct: it becomes an object schema with its own properties
+type Address struct {
+	Name       string `json:"name" description:"Recipient's full name"`
+	Street     string `json:"street" description:"Street and house number"`
+	City       string `json:"city" description:"City"`
+	PostalCode string `json:"postal_code" description:"Postal or ZIP code"`
+	Country    string `json:"country" description:"Two-letter ISO country code, e.g. US or DE"`
+}
+
+// LineItem is used in a slice: it becomes an array schema whose items are objects
+type LineItem struct {
+	SKU      string  `json:"sku" description:"Product SKU"`
+	Quantity int     `json:"quantity" description:"Number of units, at least 1"`
+	WeightKg float64 `json:"weight_kg" description:"Weight of one unit in kilograms"`
+}
+
+var services = []string{"standard", "express", "overnight"}
+
+// ShipmentInput shows how each Go type maps to JSON schema:
+//
+//	string, int, float64, bool -> "string", "integer", "number", "boolean"
+//	struct                     -> "object" with "properties"
+//	[]T                        -> "array" with "items" generated from T
+//	enum tag                   -> "enum" listing the allowed values
+//	omitempty or a pointer     -> left out of "required"
+type ShipmentInput struct {
+	Recipient Address    `json:"recipient" description:"Where the parcel is going"`
+	Items     []LineItem `json:"items" description:"Products in the parcel"`
+	Service   string     `json:"service" enum:"standard,express,overnight" description:"Delivery speed: standard, express or overnight"`
+
+	// Optional fields
+	InsuredValue *float64 `json:"insured_value,omitempty" description:"Declared value in USD to insure the parcel for"`
+	Signature    bool     `json:"signature,omitempty" description:"Require a signature on delivery"`
+	Notes        string   `json:"notes,omitempty" description:"Delivery instructions for the courier"`
+	Tags         []string `json:"tags,omitempty" description:"Free-form labels for the shipment"`
+}
+
+// ShipmentQuote is returned to the model as JSON. Tools return a string, so structured
+// results are marshalled; the model reads JSON as reliably as prose.
+type ShipmentQuote struct {
+	QuoteID           string   `json:"quote_id"`
+	Service           string   `json:"service"`
+	Parcels           int      `json:"parcels"`
+	TotalWeightKg     float64  `json:"total_weight_kg"`
+	CostUSD           float64  `json:"cost_usd"`
+	EstimatedDelivery string   `json:"estimated_delivery"`
+	Warnings          []string `json:"warnings,omitempty"`
+}
+
+// validate checks what the schema cannot express. Models usually respect the schema,
+// but the handler is the only place the rules are guaranteed to hold.
+func (s ShipmentInput) validate() error {
+	var problems []string
+	if !slices.Contains(services, s.Service) {
+		problems = append(problems, fmt.Sprintf("service must be one of %s, got %q", strings.Join(services, ", "), s.Service))
+	}
+	if s.Recipient.Name == "" || s.Recipient.City == "" || s.Recipient.Country == "" {
+		problems = append(problems, "recipient needs at least a name, city and country")
+	}
+	if len(s.Items) == 0 {
+		problems = append(problems, "at least one item is required")
+	}
+	for i, item := range s.Items {
+		if item.Quantity < 1 {
+			problems = append(problems, fmt.Sprintf("items[%d].quantity must be at least 1", i))
+		}
+		if item.WeightKg <= 0 {
+			problems = append(problems, fmt.Sprintf("items[%d].weight_kg must be positive", i))
+		}
+	}
+	if s.InsuredValue != nil && *s.InsuredValue < 0 {
+		problems = append(problems, "insured_value cannot be negative")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid shipment: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func quoteShipment(input ShipmentInput) ShipmentQuote {
+	rates := map[string]struct {
+		base, perKg float64
+		days        int
+	}{
+		"standard":  {5, 1.2, 5},
+		"express":   {12, 2.5, 2},
+		"overnight": {30, 4, 1},
+	}
+	rate := rates[input.Service]
+
+	quote := ShipmentQuote{
+		QuoteID: fmt.Sprintf("Q-%d", time.Now().UnixMilli()%1000000),
+		Service: input.Service,
+	}
+	for _, item := range input.Items {
+		quote.Parcels += item.Quantity
+		quote.TotalWeightKg += float64(item.Quantity) * item.WeightKg
+	}
+
+	cost := rate.base + rate.perKg*quote.TotalWeightKg
+	if input.Recipient.Country != "US" {
+		cost *= 1.8
+		quote.Warnings = append(quote.Warnings, "international shipment: customs forms are required")
+	}
+	if input.InsuredValue != nil {
+		cost += *input.InsuredValue * 0.01
+	}
+	if input.Signature {
+		cost += 3.5
+	}
+	if quote.TotalWeightKg > 30 {
+		quote.Warnings = append(quote.Warnings, "over 30 kg: the parcel will be sent as freight")
+	}
+
+	quote.TotalWeightKg = math.Round(quote.TotalWeightKg*100) / 100
+	quote.CostUSD = math.Round(cost*100) / 100
+	quote.EstimatedDelivery = time.Now().AddDate(0, 0, rate.days).Format("Monday 2 January")
+	return quote
+}
+
+func createQuoteShipmentTool() aigentic.AgentTool {
+	return aigentic.NewTool(
+		"quote_shipment",
+		"Quotes the cost and delivery date of a shipment. Returns the quote as JSON.",
+		func(run *aigentic.AgentRun, input ShipmentInput) (string, error) {
+			if err := input.validate(); err != nil {
+				return "", err
+			}
+
+			data, err := json.MarshalIndent(quoteShipment(input), "", "  ")
+			if err != nil {
+				return "", err
+			}
+			return string(data), nil
+		},
+	)
+}
+
+func main() {
+	utils.LoadEnvFile("../../.env")
+
+	schemaOnly := flag.Bool("schema", false, "Print the generated JSON schema and exit")
+	flag.Parse()
+
+	fmt.Println("🧬 Typed Tool Schema Example")
+	fmt.Println("============================")
+	fmt.Println()
+
+	tool := createQuoteShipmentTool()
+
+	// The schema is generated from ShipmentInput when the tool is created
+	schema, err := json.MarshalIndent(tool.InputSchema, "", "  ")
+	if err != nil {
+		log.Fatalf("Failed to encode schema: %v", err)
+	}
+	fmt.Printf("Generated input schema for %s:\n%s\n\n", tool.Name, schema)
+	if *schemaOnly {
+		return
+	}
+
+	model := openai.NewModel("gpt-4o-mini", getAPIKey())
+
+	agent := aigentic.Agent{
+		Model:        model,
+		Name:         "ShippingAssistant",
+		Description:  "An assistant that quotes parcel shipments",
+		Instructions: "Use the quote_shipment tool to price shipments. Fill in every field you know; leave optional fields out when the user has not mentioned them. Summarize the quote for the user.",
+		AgentTools:   []aigentic.AgentTool{tool},
+	}
+
+	requests := []string{
+		"Quote express shipping for 2 keyboards (SKU KB-100, 1.1 kg each) and 1 monitor (SKU MN-270, 6.5 kg) to Jane Doe, 12 Elm Street, Springfield 62704, US. Insure it for $600 and require a signature.",
+		"What would it cost to send 3 books (SKU BK-1, 0.8 kg each) to Max Müller, Hauptstraße 5, 10115 Berlin, Germany by the cheapest service? Mark it as a gift.",
+	}
+
+	for _, request := range requests {
+		fmt.Printf("❓ %s\n", request)
+		response, err := agent.Execute(request)
+		if err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		fmt.Printf("Response: %s\n\n", response)
+	}
+
+	fmt.Println("✅ Example completed successfully!")
+}